@@ -93,10 +93,10 @@ var _ = BeforeEach(func() {
 	retryableConn := &lock.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 	lockFactory = lock.NewLockFactory(retryableConn)
-	sqlDB = db.NewSQL(dbConn, bus, lockFactory)
+	sqlDB = db.NewSQL(dbConn, bus, lockFactory, nil)
 
-	teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory)
-	pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+	teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
+	pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory, nil)
 })
 
 var _ = AfterEach(func() {
@@ -64,7 +64,7 @@ var _ = Describe("Job Builds", func() {
 					Jobs: atc.JobConfigs{
 						{Name: "job-name"},
 					},
-				}, dbng.ConfigVersion(1), dbng.PipelineUnpaused)
+				}, dbng.ConfigVersion(1), dbng.PipelineUnpaused, "")
 				Expect(err).NotTo(HaveOccurred())
 			})
 
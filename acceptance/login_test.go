@@ -34,7 +34,7 @@ var _ = Describe("Logging In", func() {
 			Jobs: atc.JobConfigs{
 				{Name: "job-name"},
 			},
-		}, dbng.ConfigVersion(1), dbng.PipelineUnpaused)
+		}, dbng.ConfigVersion(1), dbng.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		atcCommand = NewATCCommand(atcBin, 1, postgresRunner.DataSourceName(), []string{}, BASIC_AUTH)
@@ -60,7 +60,7 @@ var _ = Describe("Navigation", func() {
 						Name: "job-1",
 					},
 				},
-			}, dbng.ConfigVersion(1), dbng.PipelineUnpaused)
+			}, dbng.ConfigVersion(1), dbng.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			_, _, err = defaultTeam.SavePipeline("pipeline-2", atc.Config{
@@ -69,7 +69,7 @@ var _ = Describe("Navigation", func() {
 						Name: "job-2",
 					},
 				},
-			}, dbng.ConfigVersion(1), dbng.PipelineUnpaused)
+			}, dbng.ConfigVersion(1), dbng.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 		})
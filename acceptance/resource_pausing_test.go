@@ -39,7 +39,7 @@ var _ = Describe("Resource Pausing", func() {
 			Resources: atc.ResourceConfigs{
 				{Name: "resource-name"},
 			},
-		}, dbng.ConfigVersion(1), dbng.PipelineUnpaused)
+		}, dbng.ConfigVersion(1), dbng.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		atcCommand = NewATCCommand(atcBin, 1, postgresRunner.DataSourceName(), []string{}, BASIC_AUTH)
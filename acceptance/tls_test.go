@@ -37,7 +37,7 @@ var _ = Describe("TLS", func() {
 		retryableConn := &lock.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := lock.NewLockFactory(retryableConn)
-		sqlDB = db.NewSQL(dbConn, bus, lockFactory)
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory, nil)
 	})
 
 	AfterEach(func() {
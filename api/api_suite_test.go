@@ -17,12 +17,14 @@ import (
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/dbng/dbngfakes"
 
+	"github.com/concourse/atc/api/auditserver/auditserverfakes"
 	"github.com/concourse/atc/api/buildserver/buildserverfakes"
 	"github.com/concourse/atc/api/containerserver/containerserverfakes"
 	"github.com/concourse/atc/api/jobserver/jobserverfakes"
 	"github.com/concourse/atc/api/pipes/pipesfakes"
 	"github.com/concourse/atc/api/resourceserver/resourceserverfakes"
 	"github.com/concourse/atc/api/teamserver/teamserverfakes"
+	"github.com/concourse/atc/audit/auditfakes"
 	"github.com/concourse/atc/auth/authfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
@@ -56,6 +58,8 @@ var (
 	dbWorkerLifecycle             *dbngfakes.FakeWorkerLifecycle
 	teamDB                        *dbfakes.FakeTeamDB
 	pipelinesDB                   *dbfakes.FakePipelinesDB
+	auditDB                       *auditserverfakes.FakeAuditDB
+	fakeAuditor                   *auditfakes.FakeAuditor
 	buildsDB                      *authfakes.FakeBuildsDB
 	buildServerDB                 *buildserverfakes.FakeBuildsDB
 	build                         *dbfakes.FakeBuild
@@ -112,6 +116,8 @@ var _ = BeforeEach(func() {
 	containerDB = new(containerserverfakes.FakeContainerDB)
 	pipeDB = new(pipesfakes.FakePipeDB)
 	pipelinesDB = new(dbfakes.FakePipelinesDB)
+	auditDB = new(auditserverfakes.FakeAuditDB)
+	fakeAuditor = new(auditfakes.FakeAuditor)
 	buildsDB = new(authfakes.FakeBuildsDB)
 
 	authValidator = new(authfakes.FakeValidator)
@@ -160,15 +166,18 @@ var _ = BeforeEach(func() {
 
 		externalURL,
 
-		wrappa.NewAPIAuthWrappa(
-			authValidator,
-			authValidator,
-			userContextReader,
-			checkPipelineAccessHandlerFactory,
-			checkBuildReadAccessHandlerFactory,
-			checkBuildWriteAccessHandlerFactory,
-			checkWorkerTeamAccessHandlerFactory,
-		),
+		wrappa.MultiWrappa{
+			wrappa.NewAPIAuthWrappa(
+				authValidator,
+				authValidator,
+				userContextReader,
+				checkPipelineAccessHandlerFactory,
+				checkBuildReadAccessHandlerFactory,
+				checkBuildWriteAccessHandlerFactory,
+				checkWorkerTeamAccessHandlerFactory,
+			),
+			wrappa.NewAPIAuditWrappa(fakeAuditor, userContextReader, new(authfakes.FakeAPITokenContextReader)),
+		},
 
 		fakeTokenGenerator,
 		providerFactory,
@@ -187,6 +196,7 @@ var _ = BeforeEach(func() {
 		containerDB,
 		pipeDB,
 		pipelinesDB,
+		auditDB,
 
 		peerAddr,
 		constructedEventHandler.Construct,
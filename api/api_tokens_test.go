@@ -0,0 +1,285 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("API Tokens API", func() {
+	var response *http.Response
+
+	BeforeEach(func() {
+		authValidator.IsAuthenticatedReturns(true)
+		userContextReader.GetTeamReturns("some-team", false, true)
+		userContextReader.GetRoleReturns(atc.TeamRoleOwner, true)
+	})
+
+	Describe("GET /api/v1/teams/:team_name/api-tokens", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/api-tokens")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when getting the tokens succeeds", func() {
+			BeforeEach(func() {
+				teamDB.GetAPITokensReturns([]db.SavedAPIToken{
+					{
+						APIToken: db.APIToken{
+							Name:      "ci-bot",
+							Role:      atc.TeamRolePipelineOperator,
+							ExpiresAt: time.Unix(2000, 0),
+						},
+						CreatedAt:  time.Unix(1000, 0),
+						LastUsedAt: time.Unix(1500, 0),
+					},
+					{
+						APIToken: db.APIToken{
+							Name: "deployer",
+							Role: atc.TeamRoleMember,
+						},
+						CreatedAt: time.Unix(1000, 0),
+					},
+				}, nil)
+			})
+
+			It("gets the tokens of the requested team", func() {
+				Expect(teamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("some-team"))
+			})
+
+			It("returns 200 OK with the tokens, without their values", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"name": "ci-bot",
+						"role": "pipeline-operator",
+						"created_at": 1000,
+						"expires_at": 2000,
+						"last_used_at": 1500
+					},
+					{
+						"name": "deployer",
+						"role": "member",
+						"created_at": 1000
+					}
+				]`))
+			})
+		})
+
+		Context("when getting the tokens fails", func() {
+			BeforeEach(func() {
+				teamDB.GetAPITokensReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when the requester is not an owner", func() {
+			BeforeEach(func() {
+				userContextReader.GetRoleReturns(atc.TeamRoleMember, true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when the requester belongs to another team", func() {
+			BeforeEach(func() {
+				userContextReader.GetTeamReturns("some-other-team", false, true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/api-tokens", func() {
+		var token atc.APIToken
+
+		BeforeEach(func() {
+			token = atc.APIToken{
+				Name: "ci-bot",
+				Role: atc.TeamRoleViewer,
+			}
+
+			teamDB.CreateAPITokenStub = func(token db.APIToken, tokenHash string) (db.SavedAPIToken, error) {
+				return db.SavedAPIToken{
+					APIToken:  token,
+					CreatedAt: time.Unix(1000, 0),
+				}, nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			payload, err := json.Marshal(token)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Post(
+				server.URL+"/api/v1/teams/some-team/api-tokens",
+				"application/json",
+				bytes.NewBuffer(payload),
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns 201 Created with the token's value", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+			var created atc.APIToken
+			err := json.NewDecoder(response.Body).Decode(&created)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(created.Name).To(Equal("ci-bot"))
+			Expect(created.Role).To(Equal(atc.TeamRoleViewer))
+			Expect(created.CreatedAt).To(Equal(int64(1000)))
+			Expect(created.Token).NotTo(BeEmpty())
+		})
+
+		It("stores only the hash of the token's value", func() {
+			var created atc.APIToken
+			err := json.NewDecoder(response.Body).Decode(&created)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(teamDB.CreateAPITokenCallCount()).To(Equal(1))
+			savedToken, tokenHash := teamDB.CreateAPITokenArgsForCall(0)
+			Expect(savedToken.Name).To(Equal("ci-bot"))
+			Expect(savedToken.ExpiresAt).To(BeZero())
+			Expect(tokenHash).To(Equal(auth.HashAPIToken(created.Token)))
+		})
+
+		Context("when no role is given", func() {
+			BeforeEach(func() {
+				token.Role = ""
+			})
+
+			It("grants member", func() {
+				savedToken, _ := teamDB.CreateAPITokenArgsForCall(0)
+				Expect(savedToken.Role).To(Equal(atc.TeamRoleMember))
+			})
+		})
+
+		Context("when an expiry is given", func() {
+			BeforeEach(func() {
+				token.ExpiresAt = time.Now().Add(time.Hour).Unix()
+			})
+
+			It("saves it", func() {
+				savedToken, _ := teamDB.CreateAPITokenArgsForCall(0)
+				Expect(savedToken.ExpiresAt.Unix()).To(Equal(token.ExpiresAt))
+			})
+		})
+
+		Context("when the expiry has already passed", func() {
+			BeforeEach(func() {
+				token.ExpiresAt = time.Now().Add(-time.Hour).Unix()
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(teamDB.CreateAPITokenCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when no name is given", func() {
+			BeforeEach(func() {
+				token.Name = ""
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when the role is unknown", func() {
+			BeforeEach(func() {
+				token.Role = "overlord"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when the team already has a token with the name", func() {
+			BeforeEach(func() {
+				teamDB.CreateAPITokenStub = nil
+				teamDB.CreateAPITokenReturns(db.SavedAPIToken{}, db.ErrAPITokenAlreadyExists)
+			})
+
+			It("returns 409", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusConflict))
+			})
+		})
+
+		Context("when creating the token fails", func() {
+			BeforeEach(func() {
+				teamDB.CreateAPITokenStub = nil
+				teamDB.CreateAPITokenReturns(db.SavedAPIToken{}, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/api-tokens/:api_token_name", func() {
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/api-tokens/ci-bot", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the token exists", func() {
+			BeforeEach(func() {
+				teamDB.DeleteAPITokenReturns(true, nil)
+			})
+
+			It("deletes it and returns 204", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				Expect(teamDB.DeleteAPITokenArgsForCall(0)).To(Equal("ci-bot"))
+			})
+		})
+
+		Context("when the token does not exist", func() {
+			BeforeEach(func() {
+				teamDB.DeleteAPITokenReturns(false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when deleting the token fails", func() {
+			BeforeEach(func() {
+				teamDB.DeleteAPITokenReturns(false, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+})
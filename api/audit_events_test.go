@@ -0,0 +1,155 @@
+package api_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit Events API", func() {
+	Describe("GET /api/v1/audit-events", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/audit-events" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("main", true, true)
+			})
+
+			Context("when getting the events succeeds", func() {
+				BeforeEach(func() {
+					auditDB.GetAuditEventsReturns([]db.AuditEvent{
+						{
+							ID:           2,
+							Time:         time.Unix(200, 0),
+							Actor:        "some-team",
+							RemoteAddr:   "1.2.3.4:5678",
+							APITokenName: "some-token",
+							APITokenRole: atc.TeamRolePipelineOperator,
+							Route:        "PausePipeline",
+							TeamName:     "some-team",
+							Params:       map[string]string{"pipeline_name": "some-pipeline"},
+							Status:       200,
+						},
+						{
+							ID:     1,
+							Time:   time.Unix(100, 0),
+							Route:  "SetLogLevel",
+							Status: 401,
+						},
+					}, db.Pagination{}, nil)
+				})
+
+				It("returns 200 OK with the events", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 2,
+							"time": 200,
+							"actor": "some-team",
+							"remote_addr": "1.2.3.4:5678",
+							"api_token_name": "some-token",
+							"api_token_role": "pipeline-operator",
+							"route": "PausePipeline",
+							"team_name": "some-team",
+							"params": {"pipeline_name": "some-pipeline"},
+							"status": 200
+						},
+						{
+							"id": 1,
+							"time": 100,
+							"route": "SetLogLevel",
+							"status": 401
+						}
+					]`))
+				})
+
+				It("gets the first page by default", func() {
+					Expect(auditDB.GetAuditEventsArgsForCall(0)).To(Equal(db.Page{Limit: 100}))
+				})
+			})
+
+			Context("when a page is requested", func() {
+				BeforeEach(func() {
+					query = "?since=10&limit=2"
+				})
+
+				It("gets the page", func() {
+					Expect(auditDB.GetAuditEventsArgsForCall(0)).To(Equal(db.Page{Since: 10, Limit: 2}))
+				})
+			})
+
+			Context("when next/previous pages are available", func() {
+				BeforeEach(func() {
+					auditDB.GetAuditEventsReturns([]db.AuditEvent{}, db.Pagination{
+						Previous: &db.Page{Until: 4, Limit: 2},
+						Next:     &db.Page{Since: 3, Limit: 2},
+					}, nil)
+				})
+
+				It("returns Link headers per rfc5988", func() {
+					Expect(response.Header["Link"]).To(ConsistOf([]string{
+						fmt.Sprintf(`<%s/api/v1/audit-events?until=4&limit=2>; rel="previous"`, externalURL),
+						fmt.Sprintf(`<%s/api/v1/audit-events?since=3&limit=2>; rel="next"`, externalURL),
+					}))
+				})
+			})
+
+			Context("when getting the events fails", func() {
+				BeforeEach(func() {
+					auditDB.GetAuditEventsReturns(nil, db.Pagination{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authenticated as a non-admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
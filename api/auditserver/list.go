@@ -0,0 +1,60 @@
+package auditserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-audit-events")
+
+	until, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryUntil))
+	since, _ := strconv.Atoi(r.FormValue(atc.PaginationQuerySince))
+
+	limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+	if limit == 0 {
+		limit = atc.PaginationAPIDefaultLimit
+	}
+
+	events, pagination, err := s.db.GetAuditEvents(db.Page{Until: until, Since: since, Limit: limit})
+	if err != nil {
+		logger.Error("failed-to-get-audit-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if pagination.Next != nil {
+		s.addLink(w, atc.PaginationQuerySince, pagination.Next.Since, pagination.Next.Limit, atc.LinkRelNext)
+	}
+
+	if pagination.Previous != nil {
+		s.addLink(w, atc.PaginationQueryUntil, pagination.Previous.Until, pagination.Previous.Limit, atc.LinkRelPrevious)
+	}
+
+	presentedEvents := make([]atc.AuditEvent, len(events))
+	for i, event := range events {
+		presentedEvents[i] = present.AuditEvent(event)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(presentedEvents)
+}
+
+func (s *Server) addLink(w http.ResponseWriter, query string, id int, limit int, rel string) {
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s/api/v1/audit-events?%s=%d&%s=%d>; rel="%s"`,
+		s.externalURL,
+		query,
+		id,
+		atc.PaginationQueryLimit,
+		limit,
+		rel,
+	))
+}
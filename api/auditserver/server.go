@@ -0,0 +1,30 @@
+package auditserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+//go:generate counterfeiter . AuditDB
+
+type AuditDB interface {
+	GetAuditEvents(page db.Page) ([]db.AuditEvent, db.Pagination, error)
+}
+
+type Server struct {
+	logger      lager.Logger
+	externalURL string
+	db          AuditDB
+}
+
+func NewServer(
+	logger lager.Logger,
+	externalURL string,
+	db AuditDB,
+) *Server {
+	return &Server{
+		logger:      logger,
+		externalURL: externalURL,
+		db:          db,
+	}
+}
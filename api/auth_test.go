@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -24,6 +25,9 @@ var _ = Describe("Auth API", func() {
 				Team: db.Team{
 					Name:  "some-team",
 					Admin: true,
+					Roles: map[string]atc.TeamRole{
+						"basic": atc.TeamRoleMember,
+					},
 				},
 			}
 
@@ -69,11 +73,41 @@ var _ = Describe("Auth API", func() {
 
 						Expect(body).To(MatchJSON(`{"type":"some type","value":"some value"}`))
 
-						expiration, teamName, isAdmin := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						expiration, teamName, isAdmin, _ := fakeTokenGenerator.GenerateTokenArgsForCall(0)
 						Expect(expiration).To(BeTemporally("~", time.Now().Add(24*time.Hour), time.Minute))
 						Expect(teamName).To(Equal(savedTeam.Name))
 						Expect(isAdmin).To(Equal(savedTeam.Admin))
 					})
+
+					It("grants the role mapped for basic auth", func() {
+						_, _, _, role := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						Expect(role).To(Equal(atc.TeamRoleMember))
+					})
+
+					Context("when the team has ldap auth configured", func() {
+						BeforeEach(func() {
+							savedTeam.LDAPAuth = &db.LDAPAuth{Host: "ldap.example.com:389"}
+							savedTeam.Roles["ldap"] = atc.TeamRolePipelineOperator
+							teamDB.GetTeamReturns(savedTeam, true, nil)
+						})
+
+						It("grants the role mapped for ldap", func() {
+							_, _, _, role := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+							Expect(role).To(Equal(atc.TeamRolePipelineOperator))
+						})
+					})
+
+					Context("when renewing a token for the team", func() {
+						BeforeEach(func() {
+							userContextReader.GetTeamReturns("some-team", true, true)
+							userContextReader.GetRoleReturns(atc.TeamRoleViewer, true)
+						})
+
+						It("keeps the token's role", func() {
+							_, _, _, role := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+							Expect(role).To(Equal(atc.TeamRoleViewer))
+						})
+					})
 				})
 
 				Context("when generating the token fails", func() {
@@ -143,6 +177,9 @@ var _ = Describe("Auth API", func() {
 							ClientSecret: "client-secret",
 							DisplayName:  "custom secure auth",
 						},
+						LDAPAuth: &db.LDAPAuth{
+							Host: "ldap.example.com:389",
+						},
 					},
 				}
 
@@ -196,6 +233,11 @@ var _ = Describe("Auth API", func() {
 						"type": "basic",
 						"display_name": "Basic Auth",
 						"auth_url": "https://example.com/teams/some-team/login"
+					},
+					{
+						"type": "ldap",
+						"display_name": "LDAP",
+						"auth_url": "https://example.com/teams/some-team/login"
 					}
 				]`))
 			})
@@ -8,6 +8,8 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/ldap"
 )
 
 const CookieName = "ATC-Authorization"
@@ -33,7 +35,18 @@ func (s *Server) GetAuthToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tokenType, tokenValue, err := s.tokenGenerator.GenerateToken(time.Now().Add(s.expire), team.Name, team.Admin)
+	// a token being renewed keeps its role, otherwise the user logged in with
+	// basic auth or, failing that, LDAP
+	role := team.RoleFor(auth.BasicAuthProviderName)
+	if team.LDAPAuth != nil && (team.BasicAuth == nil || !auth.NewBasicAuthValidator(team).IsAuthenticated(r)) {
+		role = team.RoleFor(ldap.ProviderName)
+	}
+
+	if authTeam, found := auth.GetTeam(r); found && authTeam.IsAuthorized(team.Name) {
+		role = authTeam.Role()
+	}
+
+	tokenType, tokenValue, err := s.tokenGenerator.GenerateToken(time.Now().Add(s.expire), team.Name, team.Admin, role)
 	if err != nil {
 		logger.Error("generate-token", err)
 		w.WriteHeader(http.StatusInternalServerError)
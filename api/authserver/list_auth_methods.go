@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/genericoauth"
 	"github.com/concourse/atc/auth/github"
+	"github.com/concourse/atc/auth/ldap"
 	"github.com/concourse/atc/auth/uaa"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/web"
@@ -51,11 +52,11 @@ type byTypeAndName []atc.AuthMethod
 func (ms byTypeAndName) Len() int          { return len(ms) }
 func (ms byTypeAndName) Swap(i int, j int) { ms[i], ms[j] = ms[j], ms[i] }
 func (ms byTypeAndName) Less(i int, j int) bool {
-	if ms[i].Type == atc.AuthTypeBasic && ms[j].Type == atc.AuthTypeOAuth {
+	if ms[i].Type != atc.AuthTypeOAuth && ms[j].Type == atc.AuthTypeOAuth {
 		return false
 	}
 
-	if ms[i].Type == atc.AuthTypeOAuth && ms[j].Type == atc.AuthTypeBasic {
+	if ms[i].Type == atc.AuthTypeOAuth && ms[j].Type != atc.AuthTypeOAuth {
 		return true
 	}
 
@@ -132,5 +133,21 @@ func (s *Server) authMethods(team db.SavedTeam) ([]atc.AuthMethod, error) {
 		})
 	}
 
+	if team.LDAPAuth != nil {
+		path, err := web.Routes.CreatePathForRoute(
+			web.TeamLogIn,
+			rata.Params{"team_name": team.Name},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		methods = append(methods, atc.AuthMethod{
+			Type:        atc.AuthTypeLDAP,
+			DisplayName: ldap.DisplayName,
+			AuthURL:     s.externalURL + path,
+		})
+	}
+
 	return methods, nil
 }
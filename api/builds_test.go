@@ -1148,6 +1148,111 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("POST /api/v1/builds/:build_id/resume", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			req, err := http.NewRequest("POST", server.URL+"/api/v1/builds/128/resume", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the build can be found", func() {
+				BeforeEach(func() {
+					build.TeamNameReturns("some-team")
+					buildsDB.GetBuildByIDReturns(build, true, nil)
+				})
+
+				Context("when accessing same team's build", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("some-team", true, true)
+					})
+
+					It("resumes the build", func() {
+						Expect(build.ResumeFromBreakpointCallCount()).To(Equal(1))
+					})
+
+					Context("when the build is paused at a breakpoint", func() {
+						BeforeEach(func() {
+							build.ResumeFromBreakpointReturns(true, nil)
+						})
+
+						It("returns 204", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+						})
+					})
+
+					Context("when the build is not paused", func() {
+						BeforeEach(func() {
+							build.ResumeFromBreakpointReturns(false, nil)
+						})
+
+						It("returns 409", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						})
+					})
+
+					Context("when resuming fails", func() {
+						BeforeEach(func() {
+							build.ResumeFromBreakpointReturns(false, errors.New("oh no!"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when accessing other team's build", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("some-other-team", true, true)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+
+					It("does not resume the build", func() {
+						Expect(build.ResumeFromBreakpointCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the build can not be found", func() {
+				BeforeEach(func() {
+					buildsDB.GetBuildByIDReturns(nil, false, nil)
+				})
+
+				It("returns Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not resume the build", func() {
+				Expect(build.ResumeFromBreakpointCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/preparation", func() {
 		var response *http.Response
 
@@ -1166,12 +1271,24 @@ var _ = Describe("Builds API", func() {
 					PausedPipeline:   db.BuildPreparationStatusNotBlocking,
 					PausedJob:        db.BuildPreparationStatusNotBlocking,
 					MaxRunningBuilds: db.BuildPreparationStatusBlocking,
+					TriggerWindow:    db.BuildPreparationStatusBlocking,
 					Inputs: map[string]db.BuildPreparationStatus{
 						"foo": db.BuildPreparationStatusUnknown,
 						"bar": db.BuildPreparationStatusBlocking,
 					},
 					InputsSatisfied:     db.BuildPreparationStatusBlocking,
 					MissingInputReasons: db.MissingInputReasons{"some-input": "some-reason"},
+					InputsExplanation: &atc.InputsExplanation{
+						Resolved: false,
+						Inputs: []atc.InputExplanation{
+							{
+								Name:                     "some-input",
+								Resource:                 "some-resource",
+								PinnedVersion:            atc.Version{"some": "version"},
+								PinnedVersionUnavailable: true,
+							},
+						},
+					},
 				}
 				buildsDB.GetBuildByIDReturns(build, true, nil)
 				build.JobNameReturns("job1")
@@ -1273,6 +1390,7 @@ var _ = Describe("Builds API", func() {
 					"paused_pipeline": "not_blocking",
 					"paused_job": "not_blocking",
 					"max_running_builds": "blocking",
+					"trigger_window": "blocking",
 					"inputs": {
 						"foo": "unknown",
 						"bar": "blocking"
@@ -1280,6 +1398,18 @@ var _ = Describe("Builds API", func() {
 					"inputs_satisfied": "blocking",
 					"missing_input_reasons": {
 						"some-input": "some-reason"
+					},
+					"inputs_explanation": {
+						"resolved": false,
+						"inputs": [
+							{
+								"name": "some-input",
+								"resource": "some-resource",
+								"candidates": 0,
+								"pinned_version": {"some": "version"},
+								"pinned_version_unavailable": true
+							}
+						]
 					}
 				}`))
 				})
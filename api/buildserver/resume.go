@@ -0,0 +1,32 @@
+package buildserver
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc/db"
+
+	"code.cloudfoundry.org/lager"
+)
+
+func (s *Server) ResumeBuild(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rLog := s.logger.Session("resume", lager.Data{
+			"build": build.ID(),
+		})
+
+		resumed, err := build.ResumeFromBreakpoint()
+		if err != nil {
+			rLog.Error("failed-to-resume-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !resumed {
+			rLog.Info("build-not-paused")
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
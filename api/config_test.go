@@ -8,8 +8,11 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
+	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/dbng/dbngfakes"
 	"github.com/onsi/gomega/gbytes"
@@ -317,11 +320,12 @@ var _ = Describe("Config API", func() {
 						It("saves it", func() {
 							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+							name, savedConfig, id, pipelineState, author := dbTeam.SavePipelineArgsForCall(0)
 							Expect(name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(dbng.ConfigVersion(42)))
 							Expect(pipelineState).To(Equal(dbng.PipelineNoChange))
+							Expect(author).To(Equal("a-team"))
 						})
 
 						Context("and saving it fails", func() {
@@ -393,7 +397,7 @@ var _ = Describe("Config API", func() {
 						It("saves it", func() {
 							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+							name, savedConfig, id, pipelineState, _ := dbTeam.SavePipelineArgsForCall(0)
 							Expect(name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(dbng.ConfigVersion(42)))
@@ -403,7 +407,7 @@ var _ = Describe("Config API", func() {
 						It("does not give the DB a map of empty interfaces to empty interfaces", func() {
 							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-							_, savedConfig, _, _ := dbTeam.SavePipelineArgsForCall(0)
+							_, savedConfig, _, _, _ := dbTeam.SavePipelineArgsForCall(0)
 							Expect(savedConfig).To(Equal(pipelineConfig))
 
 							_, err := json.Marshal(pipelineConfig)
@@ -441,7 +445,7 @@ jobs:
 							It("saves it", func() {
 								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+								name, savedConfig, id, pipelineState, _ := dbTeam.SavePipelineArgsForCall(0)
 								Expect(name).To(Equal("a-pipeline"))
 								Expect(savedConfig).To(Equal(atc.Config{
 									Resources: []atc.ResourceConfig{
@@ -578,13 +582,22 @@ jobs:
 							It("saves it", func() {
 								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+								name, savedConfig, id, pipelineState, _ := dbTeam.SavePipelineArgsForCall(0)
 								Expect(name).To(Equal("a-pipeline"))
 								Expect(savedConfig).To(Equal(pipelineConfig))
 								Expect(id).To(Equal(dbng.ConfigVersion(42)))
 								Expect(pipelineState).To(Equal(expectedDBValue))
 							})
 
+							It("is audited without consuming the request body", func() {
+								Expect(fakeAuditor.RecordCallCount()).To(Equal(1))
+
+								event := fakeAuditor.RecordArgsForCall(0)
+								Expect(event.Route).To(Equal(atc.SaveConfig))
+								Expect(event.TeamName).To(Equal("a-team"))
+								Expect(event.Params).To(Equal(map[string]string{"pipeline_name": "a-pipeline"}))
+							})
+
 							Context("when it's the first time the pipeline has been created", func() {
 								BeforeEach(func() {
 									returnedPipeline := new(dbngfakes.FakePipeline)
@@ -914,4 +927,282 @@ jobs:
 			})
 		})
 	})
+
+	Describe("config history", func() {
+		var fakePipeline *dbngfakes.FakePipeline
+		var pipelineDB *dbfakes.FakePipelineDB
+
+		var response *http.Response
+
+		BeforeEach(func() {
+			pipelineDB = new(dbfakes.FakePipelineDB)
+			pipelineDB.GetPipelineNameReturns("a-pipeline")
+			pipelineDBFactory.BuildReturns(pipelineDB)
+			teamDB.GetPipelineByNameReturns(db.SavedPipeline{}, true, nil)
+
+			fakePipeline = new(dbngfakes.FakePipeline)
+			dbPipelineFactory.GetPipelineByIDReturns(fakePipeline)
+
+			fakePipeline.ConfigAtVersionStub = func(version dbng.ConfigVersion) (atc.Config, bool, error) {
+				switch version {
+				case 1:
+					return atc.Config{}, true, nil
+				case 2:
+					return pipelineConfig, true, nil
+				default:
+					return atc.Config{}, false, nil
+				}
+			}
+		})
+
+		Describe("GET /api/v1/teams/:team_name/pipelines/:name/config/versions", func() {
+			JustBeforeEach(func() {
+				var err error
+				response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/config/versions")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("a-team", true, true)
+				})
+
+				Context("when getting the versions succeeds", func() {
+					BeforeEach(func() {
+						fakePipeline.ConfigVersionsReturns([]dbng.PipelineConfigVersion{
+							{Version: 2, Author: "a-team", CreatedAt: time.Unix(200, 0)},
+							{Version: 1, CreatedAt: time.Unix(100, 0)},
+						}, nil)
+					})
+
+					It("returns 200 with the versions", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+							{"version": 2, "author": "a-team", "created_at": 200},
+							{"version": 1, "created_at": 100}
+						]`))
+					})
+				})
+
+				Context("when getting the versions fails", func() {
+					BeforeEach(func() {
+						fakePipeline.ConfigVersionsReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when not authenticated", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+		})
+
+		Describe("GET /api/v1/teams/:team_name/pipelines/:name/config/versions/:config_version", func() {
+			var version string
+
+			BeforeEach(func() {
+				version = "2"
+			})
+
+			JustBeforeEach(func() {
+				var err error
+				response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/config/versions/" + version)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("a-team", true, true)
+				})
+
+				It("returns 200 with the config at that version", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get(atc.ConfigVersionHeader)).To(Equal("2"))
+
+					var configResponse atc.ConfigResponse
+					err := json.NewDecoder(response.Body).Decode(&configResponse)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(configResponse.Config).To(Equal(&pipelineConfig))
+
+					Expect(fakePipeline.ConfigAtVersionArgsForCall(0)).To(Equal(dbng.ConfigVersion(2)))
+				})
+
+				Context("when the version does not exist", func() {
+					BeforeEach(func() {
+						version = "3"
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when the version is malformed", func() {
+					BeforeEach(func() {
+						version = "nope"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+		})
+
+		Describe("GET /api/v1/teams/:team_name/pipelines/:name/config/diff", func() {
+			var query string
+
+			BeforeEach(func() {
+				query = "?from=1&to=2"
+			})
+
+			JustBeforeEach(func() {
+				var err error
+				response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/config/diff" + query)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("a-team", true, true)
+				})
+
+				It("returns 200 with the diff", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+						"groups": {"added": ["some-group"]},
+						"resources": {"added": ["some-resource"]},
+						"resource_types": {"added": ["custom-resource"]},
+						"jobs": {"added": ["some-job"]}
+					}`))
+				})
+
+				Context("when a version does not exist", func() {
+					BeforeEach(func() {
+						query = "?from=1&to=3"
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when a version is missing", func() {
+					BeforeEach(func() {
+						query = "?from=1"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+		})
+
+		Describe("PUT /api/v1/teams/:team_name/pipelines/:name/config/versions/:config_version/rollback", func() {
+			var request *http.Request
+
+			BeforeEach(func() {
+				var err error
+				request, err = http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/config/versions/2/rollback", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				request.Header.Set(atc.ConfigVersionHeader, "42")
+			})
+
+			JustBeforeEach(func() {
+				var err error
+				response, err = client.Do(request)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("a-team", true, true)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("saves the config at that version as the newest", func() {
+					Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("a-team"))
+
+					Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+					name, savedConfig, from, pausedState, author := dbTeam.SavePipelineArgsForCall(0)
+					Expect(name).To(Equal("a-pipeline"))
+					Expect(savedConfig).To(Equal(pipelineConfig))
+					Expect(from).To(Equal(dbng.ConfigVersion(42)))
+					Expect(pausedState).To(Equal(dbng.PipelineNoChange))
+					Expect(author).To(Equal("a-team"))
+				})
+
+				Context("when the config has changed since", func() {
+					BeforeEach(func() {
+						dbTeam.SavePipelineReturns(nil, false, dbng.ErrConfigComparisonFailed)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+				})
+
+				Context("when saving fails", func() {
+					BeforeEach(func() {
+						dbTeam.SavePipelineReturns(nil, false, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when the version does not exist", func() {
+					BeforeEach(func() {
+						request.URL.Path = "/api/v1/teams/a-team/pipelines/a-pipeline/config/versions/3/rollback"
+					})
+
+					It("returns 404 without saving", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the current config version is not given", func() {
+					BeforeEach(func() {
+						request.Header.Del(atc.ConfigVersionHeader)
+					})
+
+					It("returns 400 without saving", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when not authenticated", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(false)
+				})
+
+				It("returns 401 without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,56 @@
+package configserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/dbng"
+)
+
+func (s *Server) DiffConfigVersions(_ db.PipelineDB, dbPipeline dbng.Pipeline) http.Handler {
+	logger := s.logger.Session("diff-config-versions")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fromVersion, err := strconv.Atoi(r.FormValue("from"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		toVersion, err := strconv.Atoi(r.FormValue("to"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		from, found, err := dbPipeline.ConfigAtVersion(dbng.ConfigVersion(fromVersion))
+		if err != nil {
+			logger.Error("failed-to-get-from-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		to, found, err := dbPipeline.ConfigAtVersion(dbng.ConfigVersion(toVersion))
+		if err != nil {
+			logger.Error("failed-to-get-to-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(atc.DiffConfigs(from, to))
+	})
+}
@@ -0,0 +1,74 @@
+package configserver
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/dbng"
+)
+
+// RollbackConfig saves an earlier config of the pipeline as its newest
+// version. Like SaveConfig, the current config version must be given so that
+// concurrent changes are not clobbered.
+func (s *Server) RollbackConfig(pipelineDB db.PipelineDB, dbPipeline dbng.Pipeline) http.Handler {
+	logger := s.logger.Session("rollback-config")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var from dbng.ConfigVersion
+		_, err := fmt.Sscanf(r.Header.Get(atc.ConfigVersionHeader), "%d", &from)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "config version is missing or malformed")
+			return
+		}
+
+		version, err := strconv.Atoi(r.FormValue(":config_version"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		config, found, err := dbPipeline.ConfigAtVersion(dbng.ConfigVersion(version))
+		if err != nil {
+			logger.Error("failed-to-get-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		team, found, err := s.teamFactory.FindTeam(r.FormValue(":team_name"))
+		if err != nil {
+			logger.Error("failed-to-find-team", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _, err = team.SavePipeline(pipelineDB.GetPipelineName(), config, from, dbng.PipelineNoChange, configAuthor(r))
+		if err != nil {
+			if err == dbng.ErrConfigComparisonFailed {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+
+			logger.Error("failed-to-save-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		logger.Info("rolled-back", lager.Data{"version": version})
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
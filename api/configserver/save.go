@@ -1,7 +1,6 @@
 package configserver
 
 import (
-	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,8 +12,8 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/dbng"
-	"github.com/mitchellh/mapstructure"
 	"github.com/tedsuo/rata"
 	"gopkg.in/yaml.v2"
 )
@@ -23,26 +22,9 @@ var (
 	ErrStatusUnsupportedMediaType = errors.New("content-type is not supported")
 	ErrCannotParseContentType     = errors.New("content-type header could not be parsed")
 	ErrMalformedRequestPayload    = errors.New("data in body could not be decoded")
-	ErrFailedToConstructDecoder   = errors.New("decoder could not be constructed")
-	ErrCouldNotDecode             = errors.New("data could not be decoded into config structure")
 	ErrInvalidPausedValue         = errors.New("invalid paused value")
 )
 
-type ExtraKeysError struct {
-	extraKeys []string
-}
-
-func (eke ExtraKeysError) Error() string {
-	msg := &bytes.Buffer{}
-
-	fmt.Fprintln(msg, "unknown/extra keys:")
-	for _, unusedKey := range eke.extraKeys {
-		fmt.Fprintf(msg, "  - %s\n", unusedKey)
-	}
-
-	return msg.String()
-}
-
 type SaveConfigResponse struct {
 	Errors   []string      `json:"errors,omitempty"`
 	Warnings []atc.Warning `json:"warnings,omitempty"`
@@ -77,11 +59,11 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 
 		s.handleBadRequest(w, []string{"malformed config"}, session)
 		return
-	case ErrFailedToConstructDecoder:
+	case atc.ErrFailedToConstructDecoder:
 		session.Error("failed-to-construct-decoder", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
-	case ErrCouldNotDecode:
+	case atc.ErrCouldNotDecode:
 		session.Error("could-not-decode", err)
 		s.handleBadRequest(w, []string{"failed to decode config"}, session)
 		return
@@ -91,7 +73,7 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	default:
 		if err != nil {
-			if eke, ok := err.(ExtraKeysError); ok {
+			if eke, ok := err.(atc.ExtraKeysError); ok {
 				s.handleBadRequest(w, []string{eke.Error()}, session)
 			} else {
 				session.Error("unexpected-error", err)
@@ -102,7 +84,9 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	warnings, errorMessages := config.Validate()
+	pipelineName := rata.Param(r, "pipeline_name")
+
+	warnings, errorMessages := config.ValidateForPipeline(pipelineName)
 	if len(errorMessages) > 0 {
 		session.Error("ignoring-invalid-config", err)
 		s.handleBadRequest(w, errorMessages, session)
@@ -111,7 +95,6 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 
 	session.Info("saving")
 
-	pipelineName := rata.Param(r, "pipeline_name")
 	teamName := rata.Param(r, "team_name")
 
 	team, found, err := s.teamFactory.FindTeam(teamName)
@@ -127,7 +110,7 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, created, err := team.SavePipeline(pipelineName, config, version, pausedState)
+	_, created, err := team.SavePipeline(pipelineName, config, version, pausedState, configAuthor(r))
 	if err != nil {
 		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -146,6 +129,20 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	s.writeSaveConfigResponse(w, SaveConfigResponse{Warnings: warnings}, session)
 }
 
+// configAuthor names whoever is saving a config, to be recorded in the
+// pipeline's config history.
+func configAuthor(r *http.Request) string {
+	if auth.IsSystem(r) {
+		return "system"
+	}
+
+	if team, found := auth.GetTeam(r); found {
+		return team.Name()
+	}
+
+	return ""
+}
+
 func (s *Server) handleBadRequest(w http.ResponseWriter, errorMessages []string, session lager.Logger) {
 	w.WriteHeader(http.StatusBadRequest)
 	s.writeSaveConfigResponse(w, SaveConfigResponse{
@@ -239,29 +236,9 @@ func saveConfigRequestUnmarshaler(r *http.Request) (atc.Config, dbng.PipelinePau
 		return atc.Config{}, dbng.PipelineNoChange, err
 	}
 
-	var config atc.Config
-	var md mapstructure.Metadata
-	msConfig := &mapstructure.DecoderConfig{
-		Metadata:         &md,
-		Result:           &config,
-		WeaklyTypedInput: true,
-		DecodeHook: mapstructure.ComposeDecodeHookFunc(
-			atc.SanitizeDecodeHook,
-			atc.VersionConfigDecodeHook,
-		),
-	}
-
-	decoder, err := mapstructure.NewDecoder(msConfig)
+	config, err := atc.DecodeConfig(configStructure)
 	if err != nil {
-		return atc.Config{}, dbng.PipelineNoChange, ErrFailedToConstructDecoder
-	}
-
-	if err := decoder.Decode(configStructure); err != nil {
-		return atc.Config{}, dbng.PipelineNoChange, ErrCouldNotDecode
-	}
-
-	if len(md.Unused) != 0 {
-		return atc.Config{}, dbng.PipelineNoChange, ExtraKeysError{extraKeys: md.Unused}
+		return atc.Config{}, dbng.PipelineNoChange, err
 	}
 
 	return config, pausedState, nil
@@ -0,0 +1,210 @@
+package configserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+)
+
+func (s *Server) SavePipelineTemplate(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("save-pipeline-template")
+
+	var template interface{}
+	_, err := requestToConfig(r.Header.Get("Content-Type"), r.Body, &template)
+	if err == ErrStatusUnsupportedMediaType {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+
+	if err == nil {
+		template, err = atc.SanitizeTemplate(template)
+	}
+
+	if err != nil {
+		s.handleBadRequest(w, []string{"malformed template"}, session)
+		return
+	}
+
+	team, found, err := s.teamFactory.FindTeam(r.FormValue(":team_name"))
+	if err != nil {
+		session.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	instances, err := team.FindPipelineTemplateInstances(r.FormValue(":template_name"))
+	if err != nil {
+		session.Error("failed-to-find-instances", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	errorMessages := validateTemplateInstances(template, instances)
+	if len(errorMessages) > 0 {
+		s.handleBadRequest(w, errorMessages, session)
+		return
+	}
+
+	savedTemplate, err := team.SavePipelineTemplate(r.FormValue(":template_name"), template)
+	if err != nil {
+		session.Error("failed-to-save-template", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(atc.PipelineTemplate{
+		Name:     savedTemplate.Name,
+		TeamName: r.FormValue(":team_name"),
+		Version:  savedTemplate.Version,
+		Config:   savedTemplate.Config,
+	})
+}
+
+// validateTemplateInstances renders the template with the vars of each of its
+// instances, returning the errors which would stop them from being
+// re-rendered.
+func validateTemplateInstances(template interface{}, instances []dbng.PipelineInstance) []string {
+	errorMessages := []string{}
+
+	for _, instance := range instances {
+		config, err := atc.RenderTemplate(template, instance.Vars)
+		if err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("instance '%s': %s", instance.PipelineName, err))
+			continue
+		}
+
+		_, instanceErrors := config.ValidateForPipeline(instance.PipelineName)
+		for _, message := range instanceErrors {
+			errorMessages = append(errorMessages, fmt.Sprintf("instance '%s': %s", instance.PipelineName, message))
+		}
+	}
+
+	return errorMessages
+}
+
+func (s *Server) GetPipelineTemplate(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("get-pipeline-template")
+
+	team, found, err := s.teamFactory.FindTeam(r.FormValue(":team_name"))
+	if err != nil {
+		session.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	template, found, err := team.FindPipelineTemplate(r.FormValue(":template_name"))
+	if err != nil {
+		session.Error("failed-to-find-template", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(atc.PipelineTemplate{
+		Name:     template.Name,
+		TeamName: r.FormValue(":team_name"),
+		Version:  template.Version,
+		Config:   template.Config,
+	})
+}
+
+// SavePipelineInstance renders a template with the vars in the request body
+// and saves the result as a pipeline which will be re-rendered whenever the
+// template changes. Like SaveConfig, the current config version must be
+// given, or 0 if the pipeline does not yet exist.
+func (s *Server) SavePipelineInstance(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("save-pipeline-instance")
+
+	var from dbng.ConfigVersion
+	_, err := fmt.Sscanf(r.Header.Get(atc.ConfigVersionHeader), "%d", &from)
+	if err != nil {
+		s.handleBadRequest(w, []string{"config version is missing or malformed"}, session)
+		return
+	}
+
+	var vars atc.InstanceVars
+	err = json.NewDecoder(r.Body).Decode(&vars)
+	if err != nil {
+		s.handleBadRequest(w, []string{"malformed instance vars"}, session)
+		return
+	}
+
+	team, found, err := s.teamFactory.FindTeam(r.FormValue(":team_name"))
+	if err != nil {
+		session.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	template, found, err := team.FindPipelineTemplate(r.FormValue(":template_name"))
+	if err != nil {
+		session.Error("failed-to-find-template", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	config, err := atc.RenderTemplate(template.Config, vars)
+	if err != nil {
+		s.handleBadRequest(w, []string{err.Error()}, session)
+		return
+	}
+
+	warnings, errorMessages := config.ValidateForPipeline(r.FormValue(":pipeline_name"))
+	if len(errorMessages) > 0 {
+		s.handleBadRequest(w, errorMessages, session)
+		return
+	}
+
+	_, created, err := team.SavePipelineInstance(r.FormValue(":pipeline_name"), template, vars, config, from, configAuthor(r))
+	if err != nil {
+		if err == dbng.ErrConfigComparisonFailed {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		session.Error("failed-to-save-instance", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if created {
+		w.WriteHeader(http.StatusCreated)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	s.writeSaveConfigResponse(w, SaveConfigResponse{Warnings: warnings}, session)
+}
@@ -0,0 +1,65 @@
+package configserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/dbng"
+)
+
+func (s *Server) ListConfigVersions(_ db.PipelineDB, dbPipeline dbng.Pipeline) http.Handler {
+	logger := s.logger.Session("list-config-versions")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		versions, err := dbPipeline.ConfigVersions()
+		if err != nil {
+			logger.Error("failed-to-get-config-versions", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presentedVersions := make([]atc.PipelineConfigVersion, len(versions))
+		for i, version := range versions {
+			presentedVersions[i] = present.PipelineConfigVersion(version)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(presentedVersions)
+	})
+}
+
+func (s *Server) GetConfigVersion(_ db.PipelineDB, dbPipeline dbng.Pipeline) http.Handler {
+	logger := s.logger.Session("get-config-version")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		version, err := strconv.Atoi(r.FormValue(":config_version"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		config, found, err := dbPipeline.ConfigAtVersion(dbng.ConfigVersion(version))
+		if err != nil {
+			logger.Error("failed-to-get-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(atc.ConfigVersionHeader, fmt.Sprintf("%d", version))
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(atc.ConfigResponse{
+			Config: &config,
+		})
+	})
+}
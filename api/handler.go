@@ -9,6 +9,7 @@ import (
 	"github.com/tedsuo/rata"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/auditserver"
 	"github.com/concourse/atc/api/authserver"
 	"github.com/concourse/atc/api/buildserver"
 	"github.com/concourse/atc/api/cliserver"
@@ -57,6 +58,7 @@ func NewHandler(
 	containerDB containerserver.ContainerDB,
 	pipeDB pipes.PipeDB,
 	pipelinesDB db.PipelinesDB,
+	auditDB auditserver.AuditDB,
 
 	peerURL string,
 	eventHandlerFactory buildserver.EventHandlerFactory,
@@ -128,18 +130,29 @@ func NewHandler(
 
 	infoServer := infoserver.NewServer(logger, version)
 
+	auditServer := auditserver.NewServer(logger, externalURL, auditDB)
+
 	handlers := map[string]http.Handler{
 		atc.ListAuthMethods: http.HandlerFunc(authServer.ListAuthMethods),
 		atc.GetAuthToken:    http.HandlerFunc(authServer.GetAuthToken),
 
-		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
-		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
+		atc.GetConfig:          http.HandlerFunc(configServer.GetConfig),
+		atc.SaveConfig:         http.HandlerFunc(configServer.SaveConfig),
+		atc.ListConfigVersions: pipelineHandlerFactory.HandlerFor(configServer.ListConfigVersions),
+		atc.GetConfigVersion:   pipelineHandlerFactory.HandlerFor(configServer.GetConfigVersion),
+		atc.DiffConfigVersions: pipelineHandlerFactory.HandlerFor(configServer.DiffConfigVersions),
+		atc.RollbackConfig:     pipelineHandlerFactory.HandlerFor(configServer.RollbackConfig),
+
+		atc.SavePipelineTemplate: http.HandlerFunc(configServer.SavePipelineTemplate),
+		atc.GetPipelineTemplate:  http.HandlerFunc(configServer.GetPipelineTemplate),
+		atc.SavePipelineInstance: http.HandlerFunc(configServer.SavePipelineInstance),
 
 		atc.GetBuild:            buildHandlerFactory.HandlerFor(buildServer.GetBuild),
 		atc.ListBuilds:          http.HandlerFunc(buildServer.ListBuilds),
 		atc.CreateBuild:         teamHandlerFactory.HandlerFor(buildServer.CreateBuild),
 		atc.BuildResources:      buildHandlerFactory.HandlerFor(buildServer.BuildResources),
 		atc.AbortBuild:          buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
+		atc.ResumeBuild:         buildHandlerFactory.HandlerFor(buildServer.ResumeBuild),
 		atc.GetBuildPlan:        buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
 		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
 		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
@@ -150,6 +163,7 @@ func NewHandler(
 		atc.ListJobInputs:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
 		atc.GetJobBuild:    pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
 		atc.CreateJobBuild: pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
+		atc.RerunBuild:     pipelineHandlerFactory.HandlerFor(jobServer.RerunBuild),
 		atc.PauseJob:       pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
 		atc.UnpauseJob:     pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
 		atc.JobBadge:       pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
@@ -173,9 +187,13 @@ func NewHandler(
 		atc.UnpauseResource: pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResource),
 		atc.CheckResource:   pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
 
+		atc.CheckResourceWebHook: pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceWebHook),
+
 		atc.ListResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersions),
 		atc.EnableResourceVersion:         pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
 		atc.DisableResourceVersion:        pipelineHandlerFactory.HandlerFor(versionServer.DisableResourceVersion),
+		atc.PinResourceVersion:            pipelineHandlerFactory.HandlerFor(versionServer.PinResourceVersion),
+		atc.UnpinResourceVersion:          pipelineHandlerFactory.HandlerFor(versionServer.UnpinResourceVersion),
 		atc.ListBuildsWithVersionAsInput:  pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsInput),
 		atc.ListBuildsWithVersionAsOutput: pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsOutput),
 
@@ -207,6 +225,16 @@ func NewHandler(
 		atc.ListTeams:   http.HandlerFunc(teamServer.ListTeams),
 		atc.SetTeam:     http.HandlerFunc(teamServer.SetTeam),
 		atc.DestroyTeam: http.HandlerFunc(teamServer.DestroyTeam),
+
+		atc.ListAPITokens:  http.HandlerFunc(teamServer.ListAPITokens),
+		atc.CreateAPIToken: http.HandlerFunc(teamServer.CreateAPIToken),
+		atc.RevokeAPIToken: http.HandlerFunc(teamServer.RevokeAPIToken),
+
+		atc.ListNotificationSubscriptions:  http.HandlerFunc(teamServer.ListNotificationSubscriptions),
+		atc.CreateNotificationSubscription: http.HandlerFunc(teamServer.CreateNotificationSubscription),
+		atc.DeleteNotificationSubscription: http.HandlerFunc(teamServer.DeleteNotificationSubscription),
+
+		atc.ListAuditEvents: http.HandlerFunc(auditServer.ListAuditEvents),
 	}
 
 	return rata.NewRouter(atc.Routes, wrapper.Wrap(handlers))
@@ -165,7 +165,7 @@ var _ = Describe("Jobs API", func() {
 										Get:      "some-name",
 										Resource: "some-other-input",
 										Params:   atc.Params{"secret": "params"},
-										Passed:   []string{"a", "b"},
+										Passed:   atc.PassedConfigs{{Job: "a"}, {Job: "b"}},
 										Trigger:  true,
 									},
 									{
@@ -336,7 +336,7 @@ var _ = Describe("Jobs API", func() {
 									Get:      "some-name",
 									Resource: "some-other-input",
 									Params:   atc.Params{"secret": "params"},
-									Passed:   []string{"a", "b"},
+									Passed:   atc.PassedConfigs{{Job: "a"}, {Job: "b"}},
 									Trigger:  true,
 								},
 								{
@@ -1313,13 +1313,200 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", func() {
+		var response *http.Response
+
+		var fakeScheduler *schedulerfakes.FakeBuildScheduler
+
+		BeforeEach(func() {
+			fakeScheduler = new(schedulerfakes.FakeBuildScheduler)
+			fakeSchedulerFactory.BuildSchedulerReturns(fakeScheduler)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/3", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			var originalBuild *dbfakes.FakeBuild
+
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", true, true)
+
+				pipelineDB.ConfigReturns(atc.Config{
+					Jobs: []atc.JobConfig{
+						{
+							Name: "some-job",
+							Plan: atc.PlanSequence{{Get: "some-input"}},
+						},
+					},
+
+					Resources: atc.ResourceConfigs{
+						{Name: "some-input", Type: "some-type"},
+					},
+				})
+
+				originalBuild = new(dbfakes.FakeBuild)
+				originalBuild.IDReturns(41)
+				originalBuild.NameReturns("3")
+				originalBuild.IsScheduledReturns(true)
+				pipelineDB.GetJobBuildReturns(originalBuild, true, nil)
+			})
+
+			Context("when rerunning the build succeeds", func() {
+				BeforeEach(func() {
+					build := new(dbfakes.FakeBuild)
+					build.IDReturns(42)
+					build.NameReturns("4")
+					build.JobNameReturns("some-job")
+					build.PipelineNameReturns("a-pipeline")
+					build.TeamNameReturns("some-team")
+					build.StatusReturns(db.StatusPending)
+					build.RerunOfReturns(41)
+					build.RerunOfNameReturns("3")
+					fakeScheduler.RerunBuildReturns(build, nil, nil)
+				})
+
+				It("looks up the original build", func() {
+					jobName, buildName := pipelineDB.GetJobBuildArgsForCall(0)
+					Expect(jobName).To(Equal("some-job"))
+					Expect(buildName).To(Equal("3"))
+				})
+
+				It("reruns it using the current config", func() {
+					Expect(fakeScheduler.RerunBuildCallCount()).To(Equal(1))
+
+					_, build, job, resources, resourceTypes := fakeScheduler.RerunBuildArgsForCall(0)
+					Expect(build).To(Equal(originalBuild))
+					Expect(job.Name).To(Equal("some-job"))
+					Expect(resources).To(Equal(atc.ResourceConfigs{
+						{Name: "some-input", Type: "some-type"},
+					}))
+					Expect(resourceTypes).To(Equal(versionedResourceTypes))
+				})
+
+				It("returns 200 OK with the build, linked to the original build", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"id": 42,
+						"name": "4",
+						"job_name": "some-job",
+						"status": "pending",
+						"url": "/teams/some-team/pipelines/a-pipeline/jobs/some-job/builds/4",
+						"api_url": "/api/v1/builds/42",
+						"pipeline_name": "a-pipeline",
+						"team_name": "some-team",
+						"rerun_of": {
+							"id": 41,
+							"name": "3"
+						}
+					}`))
+				})
+			})
+
+			Context("when rerunning the build fails", func() {
+				BeforeEach(func() {
+					fakeScheduler.RerunBuildReturns(nil, nil, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the original build has not been scheduled", func() {
+				BeforeEach(func() {
+					originalBuild.IsScheduledReturns(false)
+				})
+
+				It("returns 409 without rerunning it", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					Expect(fakeScheduler.RerunBuildCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the original build does not exist", func() {
+				BeforeEach(func() {
+					pipelineDB.GetJobBuildReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the original build fails", func() {
+				BeforeEach(func() {
+					pipelineDB.GetJobBuildReturns(nil, false, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when manual triggering is disabled", func() {
+				BeforeEach(func() {
+					pipelineDB.ConfigReturns(atc.Config{
+						Jobs: []atc.JobConfig{
+							{Name: "some-job", DisableManualTrigger: true},
+						},
+					})
+				})
+
+				It("returns 409 without rerunning the build", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					Expect(fakeScheduler.RerunBuildCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the job is not present in the config", func() {
+				BeforeEach(func() {
+					pipelineDB.ConfigReturns(atc.Config{
+						Jobs: []atc.JobConfig{
+							{Name: "other-job"},
+						},
+					})
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", func() {
 		var response *http.Response
+		var queryParams string
+
+		BeforeEach(func() {
+			queryParams = ""
+		})
 
 		JustBeforeEach(func() {
 			var err error
 
-			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/inputs")
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/inputs" + queryParams)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -1346,13 +1533,13 @@ var _ = Describe("Jobs API", func() {
 							{
 								Get:      "some-input",
 								Resource: "some-resource",
-								Passed:   []string{"job-a", "job-b"},
+								Passed:   atc.PassedConfigs{{Job: "job-a"}, {Job: "job-b"}},
 								Params:   atc.Params{"some": "params"},
 							},
 							{
 								Get:      "some-other-input",
 								Resource: "some-other-resource",
-								Passed:   []string{"job-c", "job-d"},
+								Passed:   atc.PassedConfigs{{Job: "job-c"}, {Job: "job-d"}},
 								Params:   atc.Params{"some": "other-params"},
 								Tags:     []string{"some-tag"},
 							},
@@ -1464,6 +1651,87 @@ var _ = Describe("Jobs API", func() {
 						It("returns 404", func() {
 							Expect(response.StatusCode).To(Equal(http.StatusNotFound))
 						})
+
+						Context("when asked to explain", func() {
+							BeforeEach(func() {
+								queryParams = "?explain=true"
+							})
+
+							Context("when the inputs can be explained", func() {
+								BeforeEach(func() {
+									pipelineDB.ExplainInputsReturns(atc.InputsExplanation{
+										Resolved: false,
+										Inputs: []atc.InputExplanation{
+											{
+												Name:       "some-input",
+												Resource:   "some-resource",
+												Candidates: 0,
+												Passed: []atc.PassedExplanation{
+													{
+														Job:                "job-a",
+														EliminatedVersions: []atc.Version{{"some": "version"}},
+														EliminatedCount:    1,
+													},
+												},
+												SkippedDisabledVersions: []atc.Version{{"some": "disabled-version"}},
+											},
+										},
+										Conflicts: []atc.PassedConflict{
+											{Job: "job-c", Inputs: []string{"some-input", "some-other-input"}},
+										},
+									}, true, nil)
+								})
+
+								It("returns 200 OK", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+								})
+
+								It("explained the inputs of the job", func() {
+									Expect(pipelineDB.ExplainInputsCallCount()).To(Equal(1))
+									Expect(pipelineDB.ExplainInputsArgsForCall(0)).To(Equal("some-job"))
+								})
+
+								It("returns the explanation with no inputs", func() {
+									body, err := ioutil.ReadAll(response.Body)
+									Expect(err).NotTo(HaveOccurred())
+
+									Expect(body).To(MatchJSON(`{
+										"inputs": [],
+										"explanation": {
+											"resolved": false,
+											"inputs": [
+												{
+													"name": "some-input",
+													"resource": "some-resource",
+													"candidates": 0,
+													"passed": [
+														{
+															"job": "job-a",
+															"eliminated_versions": [{"some": "version"}],
+															"eliminated_count": 1
+														}
+													],
+													"skipped_disabled_versions": [{"some": "disabled-version"}]
+												}
+											],
+											"conflicts": [
+												{"job": "job-c", "inputs": ["some-input", "some-other-input"]}
+											]
+										}
+									}`))
+								})
+							})
+
+							Context("when explaining the inputs fails", func() {
+								BeforeEach(func() {
+									pipelineDB.ExplainInputsReturns(atc.InputsExplanation{}, false, errors.New("oh no!"))
+								})
+
+								It("returns 500", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+								})
+							})
+						})
 					})
 
 					Context("when the input versions for the job can not be determined", func() {
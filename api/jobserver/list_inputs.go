@@ -39,7 +39,9 @@ func (s *Server) ListJobInputs(pipelineDB db.PipelineDB, dbPipeline dbng.Pipelin
 			return
 		}
 
-		if !found {
+		explain := r.FormValue("explain") == "true"
+
+		if !found && !explain {
 			w.WriteHeader(http.StatusNotFound)
 			return
 		}
@@ -60,6 +62,21 @@ func (s *Server) ListJobInputs(pipelineDB db.PipelineDB, dbPipeline dbng.Pipelin
 			presentedBuildInputs[i] = present.BuildInput(input, config, resource.Source)
 		}
 
-		json.NewEncoder(w).Encode(presentedBuildInputs)
+		if !explain {
+			json.NewEncoder(w).Encode(presentedBuildInputs)
+			return
+		}
+
+		explanation, _, err := pipelineDB.ExplainInputs(jobName)
+		if err != nil {
+			logger.Error("failed-to-explain-inputs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(atc.JobInputsExplanation{
+			Inputs:      presentedBuildInputs,
+			Explanation: explanation,
+		})
 	})
 }
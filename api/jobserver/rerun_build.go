@@ -0,0 +1,73 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/dbng"
+)
+
+func (s *Server) RerunBuild(pipelineDB db.PipelineDB, dbPipeline dbng.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("rerun-build")
+
+		jobName := r.FormValue(":job_name")
+		buildName := r.FormValue(":build_name")
+
+		config := pipelineDB.Config()
+
+		job, found := config.Jobs.Lookup(jobName)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if job.DisableManualTrigger {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		originalBuild, found, err := pipelineDB.GetJobBuild(jobName, buildName)
+		if err != nil {
+			logger.Error("failed-to-get-job-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// the inputs of a build are only determined once it is scheduled
+		if !originalBuild.IsScheduled() {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintf(w, "build %s has not been scheduled", buildName)
+			return
+		}
+
+		scheduler := s.schedulerFactory.BuildScheduler(pipelineDB, dbPipeline, s.externalURL)
+
+		resourceTypes, err := dbPipeline.ResourceTypes()
+		if err != nil {
+			logger.Error("failed-to-get-resource-types", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		versionedResourceTypes := deserializeVersionedResourceTypes(resourceTypes)
+
+		build, _, err := scheduler.RerunBuild(logger, originalBuild, job, config.Resources, versionedResourceTypes)
+		if err != nil {
+			logger.Error("failed-to-rerun", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "failed to rerun: %s", err)
+			return
+		}
+
+		json.NewEncoder(w).Encode(present.Build(build))
+	})
+}
@@ -89,6 +89,17 @@ var _ = Describe("Log Level API", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
 					})
 				})
+
+				Context("when the user is not an owner of the team", func() {
+					BeforeEach(func() {
+						userContextReader.GetRoleReturns(atc.TeamRoleViewer, true)
+						logLevelPayload = string(atc.LogLevelDebug)
+					})
+
+					It("returns 403 Forbidden", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
 			})
 
 			Context("is not admin", func() {
@@ -0,0 +1,267 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Notification Subscriptions API", func() {
+	var response *http.Response
+
+	BeforeEach(func() {
+		authValidator.IsAuthenticatedReturns(true)
+		userContextReader.GetTeamReturns("some-team", false, true)
+		userContextReader.GetRoleReturns(atc.TeamRoleOwner, true)
+	})
+
+	Describe("GET /api/v1/teams/:team_name/notifications", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/notifications")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when getting the subscriptions succeeds", func() {
+			BeforeEach(func() {
+				teamDB.GetNotificationSubscriptionsReturns([]db.SavedNotificationSubscription{
+					{
+						ID: 1,
+						NotificationSubscription: db.NotificationSubscription{
+							URL:          "https://example.com/hook",
+							Secret:       "some-secret",
+							Events:       []atc.BuildStatus{atc.StatusFailed, atc.StatusErrored},
+							PipelineName: "some-pipeline",
+							JobName:      "some-job",
+						},
+					},
+					{
+						ID: 2,
+						NotificationSubscription: db.NotificationSubscription{
+							URL:    "https://example.com/other-hook",
+							Secret: "some-other-secret",
+							Events: []atc.BuildStatus{atc.StatusStarted},
+						},
+					},
+				}, nil)
+			})
+
+			It("gets the subscriptions of the requested team", func() {
+				Expect(teamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("some-team"))
+			})
+
+			It("returns 200 OK with the subscriptions, without their secrets", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"id": 1,
+						"url": "https://example.com/hook",
+						"events": ["failed", "errored"],
+						"pipeline_name": "some-pipeline",
+						"job_name": "some-job"
+					},
+					{
+						"id": 2,
+						"url": "https://example.com/other-hook",
+						"events": ["started"]
+					}
+				]`))
+			})
+		})
+
+		Context("when getting the subscriptions fails", func() {
+			BeforeEach(func() {
+				teamDB.GetNotificationSubscriptionsReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when the requester is not an owner", func() {
+			BeforeEach(func() {
+				userContextReader.GetRoleReturns(atc.TeamRoleMember, true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/notifications", func() {
+		var subscription atc.NotificationSubscription
+
+		BeforeEach(func() {
+			subscription = atc.NotificationSubscription{
+				URL:          "https://example.com/hook",
+				Events:       []atc.BuildStatus{atc.StatusFailed},
+				PipelineName: "some-pipeline",
+			}
+
+			teamDB.CreateNotificationSubscriptionStub = func(subscription db.NotificationSubscription) (db.SavedNotificationSubscription, error) {
+				return db.SavedNotificationSubscription{
+					ID:                       1,
+					NotificationSubscription: subscription,
+				}, nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			payload, err := json.Marshal(subscription)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Post(
+				server.URL+"/api/v1/teams/some-team/notifications",
+				"application/json",
+				bytes.NewBuffer(payload),
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("saves the subscription with a generated secret", func() {
+			Expect(teamDB.CreateNotificationSubscriptionCallCount()).To(Equal(1))
+
+			savedSubscription := teamDB.CreateNotificationSubscriptionArgsForCall(0)
+			Expect(savedSubscription.URL).To(Equal("https://example.com/hook"))
+			Expect(savedSubscription.Events).To(Equal([]atc.BuildStatus{atc.StatusFailed}))
+			Expect(savedSubscription.PipelineName).To(Equal("some-pipeline"))
+			Expect(savedSubscription.Secret).NotTo(BeEmpty())
+		})
+
+		It("returns 201 Created with the secret", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+			var created atc.NotificationSubscription
+			err := json.NewDecoder(response.Body).Decode(&created)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(created.ID).To(Equal(1))
+			Expect(created.URL).To(Equal("https://example.com/hook"))
+			Expect(created.Secret).To(Equal(teamDB.CreateNotificationSubscriptionArgsForCall(0).Secret))
+		})
+
+		Context("when the URL is not absolute", func() {
+			BeforeEach(func() {
+				subscription.URL = "/hook"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(teamDB.CreateNotificationSubscriptionCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the URL is not http", func() {
+			BeforeEach(func() {
+				subscription.URL = "ftp://example.com/hook"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when no events are given", func() {
+			BeforeEach(func() {
+				subscription.Events = nil
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when an event is not a notifiable status", func() {
+			BeforeEach(func() {
+				subscription.Events = []atc.BuildStatus{atc.StatusPending}
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when creating the subscription fails", func() {
+			BeforeEach(func() {
+				teamDB.CreateNotificationSubscriptionStub = nil
+				teamDB.CreateNotificationSubscriptionReturns(db.SavedNotificationSubscription{}, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/notifications/:subscription_id", func() {
+		var subscriptionID string
+
+		BeforeEach(func() {
+			subscriptionID = "1"
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/notifications/"+subscriptionID, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the subscription exists", func() {
+			BeforeEach(func() {
+				teamDB.DeleteNotificationSubscriptionReturns(true, nil)
+			})
+
+			It("deletes it and returns 204", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				Expect(teamDB.DeleteNotificationSubscriptionArgsForCall(0)).To(Equal(1))
+			})
+		})
+
+		Context("when the subscription does not exist", func() {
+			BeforeEach(func() {
+				teamDB.DeleteNotificationSubscriptionReturns(false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when the id is not a number", func() {
+			BeforeEach(func() {
+				subscriptionID = "nope"
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				Expect(teamDB.DeleteNotificationSubscriptionCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when deleting the subscription fails", func() {
+			BeforeEach(func() {
+				teamDB.DeleteNotificationSubscriptionReturns(false, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+})
@@ -0,0 +1,351 @@
+package api_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pipeline Templates API", func() {
+	var template dbng.PipelineTemplate
+
+	BeforeEach(func() {
+		template = dbng.PipelineTemplate{
+			ID:   1,
+			Name: "some-template",
+			Config: map[string]interface{}{
+				"jobs": []interface{}{
+					map[string]interface{}{
+						"name": "build-((branch))",
+						"plan": []interface{}{
+							map[string]interface{}{"task": "build", "file": "((branch))/build.yml"},
+						},
+					},
+				},
+			},
+			Version: 2,
+		}
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipeline-templates/:template_name", func() {
+		var request *http.Request
+		var response *http.Response
+
+		BeforeEach(func() {
+			var err error
+			request, err = http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipeline-templates/some-template", bytes.NewBufferString(`
+jobs:
+- name: build-((branch))
+`))
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Header.Set("Content-Type", "application/x-yaml")
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", true, true)
+
+				dbTeam.SavePipelineTemplateReturns(dbng.PipelineTemplate{
+					ID:   1,
+					Name: "some-template",
+					Config: map[string]interface{}{
+						"jobs": []interface{}{
+							map[string]interface{}{"name": "build-((branch))"},
+						},
+					},
+					Version: 3,
+				}, nil)
+			})
+
+			It("saves the template as JSON-encodable data", func() {
+				Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("a-team"))
+
+				name, config := dbTeam.SavePipelineTemplateArgsForCall(0)
+				Expect(name).To(Equal("some-template"))
+				Expect(config).To(Equal(map[string]interface{}{
+					"jobs": []interface{}{
+						map[string]interface{}{"name": "build-((branch))"},
+					},
+				}))
+			})
+
+			It("returns 200 with the saved template", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+					"name": "some-template",
+					"team_name": "a-team",
+					"version": 3,
+					"config": {"jobs": [{"name": "build-((branch))"}]}
+				}`))
+			})
+
+			Context("when the template is malformed", func() {
+				BeforeEach(func() {
+					request.Body = ioutil.NopCloser(bytes.NewBufferString("{"))
+					request.Header.Set("Content-Type", "application/json")
+				})
+
+				It("returns 400 without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeam.SavePipelineTemplateCallCount()).To(BeZero())
+				})
+			})
+
+			It("checks the template against the template's instances", func() {
+				Expect(dbTeam.FindPipelineTemplateInstancesCallCount()).To(Equal(1))
+				Expect(dbTeam.FindPipelineTemplateInstancesArgsForCall(0)).To(Equal("some-template"))
+			})
+
+			Context("when the template renders valid configs for its instances", func() {
+				BeforeEach(func() {
+					dbTeam.FindPipelineTemplateInstancesReturns([]dbng.PipelineInstance{
+						{PipelineName: "build-master", Vars: atc.InstanceVars{"branch": "master"}},
+					}, nil)
+				})
+
+				It("saves the template", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(dbTeam.SavePipelineTemplateCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the template would render an invalid config for an instance", func() {
+				BeforeEach(func() {
+					request.Body = ioutil.NopCloser(bytes.NewBufferString(`
+jobs:
+- name: build-((branch))
+  plan:
+  - get: ((branch))-repo
+`))
+
+					dbTeam.FindPipelineTemplateInstancesReturns([]dbng.PipelineInstance{
+						{PipelineName: "build-master", Vars: atc.InstanceVars{"branch": "master"}},
+					}, nil)
+				})
+
+				It("returns 400 with the instance's errors without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("instance 'build-master': "))
+					Expect(dbTeam.SavePipelineTemplateCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when finding the template's instances fails", func() {
+				BeforeEach(func() {
+					dbTeam.FindPipelineTemplateInstancesReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500 without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					Expect(dbTeam.SavePipelineTemplateCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when saving fails", func() {
+				BeforeEach(func() {
+					dbTeam.SavePipelineTemplateReturns(dbng.PipelineTemplate{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 without saving", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(dbTeam.SavePipelineTemplateCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipeline-templates/:template_name", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipeline-templates/some-template")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", true, true)
+			})
+
+			Context("when the template exists", func() {
+				BeforeEach(func() {
+					dbTeam.FindPipelineTemplateReturns(template, true, nil)
+				})
+
+				It("returns 200 with the template", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(dbTeam.FindPipelineTemplateArgsForCall(0)).To(Equal("some-template"))
+
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+						"name": "some-template",
+						"team_name": "a-team",
+						"version": 2,
+						"config": {
+							"jobs": [{
+								"name": "build-((branch))",
+								"plan": [{"task": "build", "file": "((branch))/build.yml"}]
+							}]
+						}
+					}`))
+				})
+			})
+
+			Context("when the template does not exist", func() {
+				BeforeEach(func() {
+					dbTeam.FindPipelineTemplateReturns(dbng.PipelineTemplate{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipeline-templates/:template_name/instances/:pipeline_name", func() {
+		var request *http.Request
+		var response *http.Response
+
+		BeforeEach(func() {
+			var err error
+			request, err = http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipeline-templates/some-template/instances/build-master", bytes.NewBufferString(`{"branch":"master"}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Header.Set(atc.ConfigVersionHeader, "42")
+
+			dbTeam.FindPipelineTemplateReturns(template, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", true, true)
+			})
+
+			It("saves the rendered config as an instance of the template", func() {
+				Expect(dbTeam.SavePipelineInstanceCallCount()).To(Equal(1))
+
+				name, savedTemplate, vars, config, from, author := dbTeam.SavePipelineInstanceArgsForCall(0)
+				Expect(name).To(Equal("build-master"))
+				Expect(savedTemplate).To(Equal(template))
+				Expect(vars).To(Equal(atc.InstanceVars{"branch": "master"}))
+				Expect(config.Jobs).To(Equal(atc.JobConfigs{
+					{
+						Name: "build-master",
+						Plan: atc.PlanSequence{{Task: "build", TaskConfigPath: "master/build.yml"}},
+					},
+				}))
+				Expect(from).To(Equal(dbng.ConfigVersion(42)))
+				Expect(author).To(Equal("a-team"))
+			})
+
+			Context("when the instance is created", func() {
+				BeforeEach(func() {
+					dbTeam.SavePipelineInstanceReturns(nil, true, nil)
+				})
+
+				It("returns 201", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+				})
+			})
+
+			Context("when the instance is updated", func() {
+				BeforeEach(func() {
+					dbTeam.SavePipelineInstanceReturns(nil, false, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when a var is not given", func() {
+				BeforeEach(func() {
+					request.Body = ioutil.NopCloser(bytes.NewBufferString(`{}`))
+				})
+
+				It("leaves its placeholders to be filled in from the credential manager", func() {
+					Expect(dbTeam.SavePipelineInstanceCallCount()).To(Equal(1))
+
+					_, _, _, config, _, _ := dbTeam.SavePipelineInstanceArgsForCall(0)
+					Expect(config.Jobs[0].Name).To(Equal("build-((branch))"))
+				})
+			})
+
+			Context("when the template does not exist", func() {
+				BeforeEach(func() {
+					dbTeam.FindPipelineTemplateReturns(dbng.PipelineTemplate{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the config version is not given", func() {
+				BeforeEach(func() {
+					request.Header.Del(atc.ConfigVersionHeader)
+				})
+
+				It("returns 400 without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeam.SavePipelineInstanceCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the config has changed since", func() {
+				BeforeEach(func() {
+					dbTeam.SavePipelineInstanceReturns(nil, false, dbng.ErrConfigComparisonFailed)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 without saving", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(dbTeam.SavePipelineInstanceCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -283,6 +283,67 @@ var _ = Describe("Pipelines API", func() {
 					}]`))
 			})
 
+			Context("when some pipelines are instances of a template", func() {
+				BeforeEach(func() {
+					teamDB.GetPipelinesReturns([]db.SavedPipeline{
+						{
+							ID:              1,
+							TeamName:        "main",
+							TemplateName:    "release",
+							TemplateVersion: 1,
+							InstanceVars:    atc.InstanceVars{"version": "1.0"},
+							Pipeline:        db.Pipeline{Name: "release-1.0"},
+						},
+						{
+							ID:       2,
+							TeamName: "main",
+							Pipeline: db.Pipeline{Name: "other-pipeline"},
+						},
+						{
+							ID:              3,
+							TeamName:        "main",
+							TemplateName:    "release",
+							TemplateVersion: 1,
+							InstanceVars:    atc.InstanceVars{"version": "2.0"},
+							Pipeline:        db.Pipeline{Name: "release-2.0"},
+						},
+					}, nil)
+				})
+
+				It("groups the instances together", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"name": "release-1.0",
+							"url": "/teams/main/pipelines/release-1.0",
+							"paused": false,
+							"public": false,
+							"team_name": "main",
+							"template_name": "release",
+							"instance_vars": {"version": "1.0"}
+						},
+						{
+							"name": "release-2.0",
+							"url": "/teams/main/pipelines/release-2.0",
+							"paused": false,
+							"public": false,
+							"team_name": "main",
+							"template_name": "release",
+							"instance_vars": {"version": "2.0"}
+						},
+						{
+							"name": "other-pipeline",
+							"url": "/teams/main/pipelines/other-pipeline",
+							"paused": false,
+							"public": false,
+							"team_name": "main"
+						}
+					]`))
+				})
+			})
+
 			Context("when the call to get active pipelines fails", func() {
 				BeforeEach(func() {
 					teamDB.GetPipelinesReturns(nil, errors.New("disaster"))
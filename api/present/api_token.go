@@ -0,0 +1,24 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func APIToken(savedToken db.SavedAPIToken) atc.APIToken {
+	token := atc.APIToken{
+		Name:      savedToken.Name,
+		Role:      savedToken.Role,
+		CreatedAt: savedToken.CreatedAt.Unix(),
+	}
+
+	if !savedToken.ExpiresAt.IsZero() {
+		token.ExpiresAt = savedToken.ExpiresAt.Unix()
+	}
+
+	if !savedToken.LastUsedAt.IsZero() {
+		token.LastUsedAt = savedToken.LastUsedAt.Unix()
+	}
+
+	return token
+}
@@ -0,0 +1,21 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func AuditEvent(event db.AuditEvent) atc.AuditEvent {
+	return atc.AuditEvent{
+		ID:           event.ID,
+		Time:         event.Time.Unix(),
+		Actor:        event.Actor,
+		RemoteAddr:   event.RemoteAddr,
+		APITokenName: event.APITokenName,
+		APITokenRole: event.APITokenRole,
+		Route:        event.Route,
+		TeamName:     event.TeamName,
+		Params:       event.Params,
+		Status:       event.Status,
+	}
+}
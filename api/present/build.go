@@ -66,5 +66,16 @@ func Build(build db.Build) atc.Build {
 		atcBuild.ReapTime = build.ReapTime().Unix()
 	}
 
+	if build.RerunOf() != 0 {
+		atcBuild.RerunOf = &atc.RerunOfBuild{
+			ID:   build.RerunOf(),
+			Name: build.RerunOfName(),
+		}
+	}
+
+	if build.Breakpoint() != "" {
+		atcBuild.Breakpoint = string(build.Breakpoint())
+	}
+
 	return atcBuild
 }
@@ -17,8 +17,10 @@ func BuildPreparation(preparation db.BuildPreparation) atc.BuildPreparation {
 		PausedPipeline:      atc.BuildPreparationStatus(preparation.PausedPipeline),
 		PausedJob:           atc.BuildPreparationStatus(preparation.PausedJob),
 		MaxRunningBuilds:    atc.BuildPreparationStatus(preparation.MaxRunningBuilds),
+		TriggerWindow:       atc.BuildPreparationStatus(preparation.TriggerWindow),
 		Inputs:              inputs,
 		InputsSatisfied:     atc.BuildPreparationStatus(preparation.InputsSatisfied),
 		MissingInputReasons: atc.MissingInputReasons(preparation.MissingInputReasons),
+		InputsExplanation:   preparation.InputsExplanation,
 	}
 }
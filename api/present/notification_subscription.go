@@ -0,0 +1,16 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func NotificationSubscription(savedSubscription db.SavedNotificationSubscription) atc.NotificationSubscription {
+	return atc.NotificationSubscription{
+		ID:           savedSubscription.ID,
+		URL:          savedSubscription.URL,
+		Events:       savedSubscription.Events,
+		PipelineName: savedSubscription.PipelineName,
+		JobName:      savedSubscription.JobName,
+	}
+}
@@ -24,5 +24,8 @@ func Pipeline(savedPipeline db.SavedPipeline) atc.Pipeline {
 		Paused:   savedPipeline.Paused,
 		Public:   savedPipeline.Public,
 		Groups:   savedPipeline.Config.Groups,
+
+		TemplateName: savedPipeline.TemplateName,
+		InstanceVars: savedPipeline.InstanceVars,
 	}
 }
@@ -0,0 +1,14 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+)
+
+func PipelineConfigVersion(version dbng.PipelineConfigVersion) atc.PipelineConfigVersion {
+	return atc.PipelineConfigVersion{
+		Version:   int(version.Version),
+		Author:    version.Author,
+		CreatedAt: version.CreatedAt.Unix(),
+	}
+}
@@ -5,11 +5,38 @@ import (
 	"github.com/concourse/atc/db"
 )
 
+// Pipelines presents the pipelines in order, except that instances of a
+// template are grouped together where the first of them appears.
 func Pipelines(savedPipelines []db.SavedPipeline) []atc.Pipeline {
-	pipelines := make([]atc.Pipeline, len(savedPipelines))
+	type templateKey struct {
+		teamName     string
+		templateName string
+	}
+
+	instances := map[templateKey][]db.SavedPipeline{}
+	for _, savedPipeline := range savedPipelines {
+		if savedPipeline.TemplateName == "" {
+			continue
+		}
+
+		key := templateKey{savedPipeline.TeamName, savedPipeline.TemplateName}
+		instances[key] = append(instances[key], savedPipeline)
+	}
+
+	pipelines := []atc.Pipeline{}
+
+	for _, savedPipeline := range savedPipelines {
+		if savedPipeline.TemplateName == "" {
+			pipelines = append(pipelines, Pipeline(savedPipeline))
+			continue
+		}
+
+		key := templateKey{savedPipeline.TeamName, savedPipeline.TemplateName}
+		for _, instance := range instances[key] {
+			pipelines = append(pipelines, Pipeline(instance))
+		}
 
-	for i := range savedPipelines {
-		pipelines[i] = Pipeline(savedPipelines[i])
+		delete(instances, key)
 	}
 
 	return pipelines
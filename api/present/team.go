@@ -5,6 +5,9 @@ import (
 	"github.com/concourse/atc/db"
 )
 
+// Team presents only the team's identity. Its auth config, which includes
+// secrets such as OAuth client secrets and the LDAP bind password, is never
+// presented.
 func Team(savedTeam db.SavedTeam) atc.Team {
 	return atc.Team{
 		ID:   savedTeam.ID,
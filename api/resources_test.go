@@ -365,6 +365,39 @@ var _ = Describe("Resources API", func() {
 							}`))
 				})
 			})
+
+			Context("when the resource is pinned to a version", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetResourceReturns(db.SavedResource{
+						ID:           1,
+						PipelineName: "a-pipeline",
+						Resource: db.Resource{
+							Name: "resource-1",
+						},
+						Config: atc.ResourceConfig{
+							Type: "type-1",
+						},
+						PinnedVersionID: 42,
+						PinnedVersion:   db.Version{"version": "v1"},
+						PinComment:      "v2 is broken",
+					}, true, nil)
+				})
+
+				It("returns the resource json with the pinned version", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`
+							{
+								"name": "resource-1",
+								"type": "type-1",
+								"groups": [],
+								"url": "/teams/a-team/pipelines/a-pipeline/resources/resource-1",
+								"pinned_version": {"version": "v1"},
+								"pin_comment": "v2 is broken"
+							}`))
+				})
+			})
 		})
 	})
 
@@ -704,4 +737,109 @@ var _ = Describe("Resources API", func() {
 			})
 		})
 	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", func() {
+		var fakeScanner *radarfakes.FakeScanner
+		var webhookToken string
+		var response *http.Response
+
+		BeforeEach(func() {
+			fakeScanner = new(radarfakes.FakeScanner)
+			fakeScannerFactory.NewResourceScannerReturns(fakeScanner)
+
+			webhookToken = "some-token"
+
+			fakePipelineDB.ConfigReturns(atc.Config{
+				Resources: atc.ResourceConfigs{
+					{
+						Name:         "resource-name",
+						Type:         "git",
+						WebhookToken: "some-token",
+					},
+				},
+			})
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check/webhook?webhook_token="+webhookToken, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("injects the proper pipelineDB", func() {
+				Expect(teamDB.GetPipelineByNameCallCount()).To(Equal(1))
+				pipelineName := teamDB.GetPipelineByNameArgsForCall(0)
+				Expect(pipelineName).To(Equal("a-pipeline"))
+				Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
+				actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
+				Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
+			})
+
+			Context("when the webhook token matches", func() {
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("scans the resource", func() {
+					Eventually(fakeScanner.ScanCallCount).Should(Equal(1))
+					_, actualResourceName := fakeScanner.ScanArgsForCall(0)
+					Expect(actualResourceName).To(Equal("resource-name"))
+				})
+			})
+
+			Context("when the webhook token does not match", func() {
+				BeforeEach(func() {
+					webhookToken = "wrong-token"
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+
+				It("does not scan the resource", func() {
+					Consistently(fakeScanner.ScanCallCount).Should(BeZero())
+				})
+			})
+
+			Context("when the resource has no webhook token configured", func() {
+				BeforeEach(func() {
+					fakePipelineDB.ConfigReturns(atc.Config{
+						Resources: atc.ResourceConfigs{
+							{
+								Name: "resource-name",
+								Type: "git",
+							},
+						},
+					})
+
+					webhookToken = ""
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+
+				It("does not scan the resource", func() {
+					Consistently(fakeScanner.ScanCallCount).Should(BeZero())
+				})
+			})
+
+			Context("when the resource does not exist", func() {
+				BeforeEach(func() {
+					fakePipelineDB.ConfigReturns(atc.Config{})
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,45 @@
+package resourceserver
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/dbng"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) CheckResourceWebHook(pipelineDB db.PipelineDB, dbPipeline dbng.Pipeline) http.Handler {
+	logger := s.logger.Session("check-resource-webhook")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+		webhookToken := r.URL.Query().Get("webhook_token")
+
+		resourceConfig, found := pipelineDB.Config().Resources.Lookup(resourceName)
+		if !found {
+			logger.Info("resource-not-found", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if resourceConfig.WebhookToken == "" || webhookToken == "" ||
+			subtle.ConstantTimeCompare([]byte(resourceConfig.WebhookToken), []byte(webhookToken)) != 1 {
+			logger.Info("invalid-webhook-token", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		scanner := s.scannerFactory.NewResourceScanner(pipelineDB, dbPipeline)
+
+		go func() {
+			err := scanner.Scan(logger, resourceName)
+			if err != nil {
+				logger.Error("failed-to-scan", err, lager.Data{"resource": resourceName})
+			}
+		}()
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,51 @@
+package versionserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/dbng"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) PinResourceVersion(pipelineDB db.PipelineDB, _ dbng.Pipeline) http.Handler {
+	logger := s.logger.Session("pin-resource-version")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+
+		versionID, err := strconv.Atoi(rata.Param(r, "resource_version_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		// the comment is optional, so an empty body is fine
+		var reqBody atc.PinVersionRequestBody
+		err = json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil && err != io.EOF {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		pinned, err := pipelineDB.PinResourceVersion(resourceName, versionID, reqBody.Comment)
+		if err != nil {
+			logger.Error("failed-to-pin-resource-version", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !pinned {
+			logger.Debug("resource-version-not-found", lager.Data{"resource": resourceName, "version": versionID})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
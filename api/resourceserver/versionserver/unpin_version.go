@@ -0,0 +1,32 @@
+package versionserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/dbng"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) UnpinResourceVersion(pipelineDB db.PipelineDB, _ dbng.Pipeline) http.Handler {
+	logger := s.logger.Session("unpin-resource-version")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+
+		found, err := pipelineDB.UnpinResourceVersion(resourceName)
+		if err != nil {
+			logger.Error("failed-to-unpin-resource-version", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("resource-not-found", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -45,6 +45,16 @@ func atcDBTeamEquality(atcTeam atc.Team, dbTeam db.Team) {
 			Expect(dbGitHubTeam.TeamName).To(Equal(atcGitHubTeam.TeamName))
 		}
 	}
+	if atcTeam.LDAPAuth == nil {
+		Expect(dbTeam.LDAPAuth).To(BeNil())
+	} else {
+		Expect(dbTeam.LDAPAuth).NotTo(BeNil())
+		Expect(dbTeam.LDAPAuth.Host).To(Equal(atcTeam.LDAPAuth.Host))
+		Expect(dbTeam.LDAPAuth.UserSearchBaseDN).To(Equal(atcTeam.LDAPAuth.UserSearchBaseDN))
+		Expect(dbTeam.LDAPAuth.UserSearchFilter).To(Equal(atcTeam.LDAPAuth.UserSearchFilter))
+		Expect(dbTeam.LDAPAuth.Groups).To(Equal(atcTeam.LDAPAuth.Groups))
+	}
+	Expect(dbTeam.Roles).To(Equal(atcTeam.Roles))
 }
 
 var _ = Describe("Teams API", func() {
@@ -345,6 +355,50 @@ var _ = Describe("Teams API", func() {
 					})
 				})
 
+				Describe("roles", func() {
+					Context("when passed a valid role for a provider", func() {
+						BeforeEach(func() {
+							team = atc.Team{
+								Roles: map[string]atc.TeamRole{
+									"basic": atc.TeamRolePipelineOperator,
+								},
+							}
+						})
+
+						It("responds with 201", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusCreated))
+						})
+					})
+
+					Context("when passed an unknown role", func() {
+						BeforeEach(func() {
+							team = atc.Team{
+								Roles: map[string]atc.TeamRole{
+									"basic": "overlord",
+								},
+							}
+						})
+
+						It("returns a 400 Bad Request", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+					})
+
+					Context("when passed a role for an unknown provider", func() {
+						BeforeEach(func() {
+							team = atc.Team{
+								Roles: map[string]atc.TeamRole{
+									"gitlab": atc.TeamRoleViewer,
+								},
+							}
+						})
+
+						It("returns a 400 Bad Request", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+					})
+				})
+
 				Describe("UAA authentication", func() {
 					Context("when passed a valid team with UAA Auth", func() {
 						BeforeEach(func() {
@@ -502,6 +556,139 @@ var _ = Describe("Teams API", func() {
 				})
 			})
 
+			Describe("LDAP Authentication", func() {
+				BeforeEach(func() {
+					team = atc.Team{
+						LDAPAuth: &atc.LDAPAuth{
+							Host:             "ldap.example.com:636",
+							UserSearchBaseDN: "ou=people,dc=example,dc=com",
+							UserSearchFilter: "(uid=%s)",
+							UseTLS:           true,
+						},
+					}
+				})
+
+				Context("when passed a valid team with LDAP Auth", func() {
+					It("responds with 201", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					})
+				})
+
+				Context("when passed a bind password", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.BindDN = "cn=concourse,dc=example,dc=com"
+						team.LDAPAuth.BindPassword = "some-bind-password"
+
+						teamServerDB.CreateTeamStub = func(submittedTeam db.Team) (db.SavedTeam, error) {
+							return db.SavedTeam{ID: 2, Team: submittedTeam}, nil
+						}
+					})
+
+					It("does not return it", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(body)).NotTo(ContainSubstring("some-bind-password"))
+					})
+				})
+
+				Context("UserSearchFilter without a %s", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.UserSearchFilter = "(uid=*)"
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("UserSearchFilter with other formatting verbs", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.UserSearchFilter = "(&(uid=%s)(cn=%d))"
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("GroupSearchFilter without a %s", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.Groups = []string{"devs"}
+						team.LDAPAuth.GroupSearchBaseDN = "ou=groups,dc=example,dc=com"
+						team.LDAPAuth.GroupSearchFilter = "(member=*)"
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("Host not filled in", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.Host = ""
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("UserSearchFilter not filled in", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.UserSearchFilter = ""
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("Groups given without a GroupSearchFilter", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.Groups = []string{"devs"}
+						team.LDAPAuth.GroupSearchBaseDN = "ou=groups,dc=example,dc=com"
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("both UseTLS and StartTLS", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.StartTLS = true
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when the CA cert is invalid", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.CACert = "bogus-cert-contents"
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when passed a role for ldap", func() {
+					BeforeEach(func() {
+						team.Roles = map[string]atc.TeamRole{
+							"ldap": atc.TeamRoleViewer,
+						}
+					})
+
+					It("responds with 201", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					})
+				})
+			})
+
 			Context("when there's a problem finding teams", func() {
 				BeforeEach(func() {
 					teamDB.GetTeamReturns(db.SavedTeam{}, false, errors.New("a dingo ate my baby!"))
@@ -669,6 +856,40 @@ var _ = Describe("Teams API", func() {
 						})
 					})
 
+					Context("when passed LDAP auth credentials", func() {
+						BeforeEach(func() {
+							team.LDAPAuth = &atc.LDAPAuth{
+								Host:             "ldap.example.com:389",
+								UserSearchBaseDN: "ou=people,dc=example,dc=com",
+								UserSearchFilter: "(uid=%s)",
+							}
+						})
+
+						It("updates the LDAP auth for that team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(teamDB.UpdateLDAPAuthCallCount()).To(Equal(1))
+
+							ldapAuth := teamDB.UpdateLDAPAuthArgsForCall(0)
+							Expect(ldapAuth.Host).To(Equal(team.LDAPAuth.Host))
+							Expect(ldapAuth.UserSearchBaseDN).To(Equal(team.LDAPAuth.UserSearchBaseDN))
+							Expect(ldapAuth.UserSearchFilter).To(Equal(team.LDAPAuth.UserSearchFilter))
+						})
+					})
+
+					Context("when passed roles", func() {
+						BeforeEach(func() {
+							team.Roles = map[string]atc.TeamRole{
+								"github": atc.TeamRoleViewer,
+							}
+						})
+
+						It("updates the roles for that team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(teamDB.UpdateRolesCallCount()).To(Equal(1))
+							Expect(teamDB.UpdateRolesArgsForCall(0)).To(Equal(team.Roles))
+						})
+					})
+
 				})
 			})
 
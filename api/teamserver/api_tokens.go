@@ -0,0 +1,113 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListAPITokens(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("list-api-tokens")
+
+	teamDB := s.teamDBFactory.GetTeamDB(r.FormValue(":team_name"))
+
+	savedTokens, err := teamDB.GetAPITokens()
+	if err != nil {
+		hLog.Error("failed-to-get-api-tokens", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presentedTokens := make([]atc.APIToken, len(savedTokens))
+	for i, savedToken := range savedTokens {
+		presentedTokens[i] = present.APIToken(savedToken)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(presentedTokens)
+}
+
+func (s *Server) CreateAPIToken(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("create-api-token")
+
+	var request atc.APIToken
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		hLog.Info("malformed-request", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	token := db.APIToken{
+		Name: request.Name,
+		Role: request.Role,
+	}
+
+	if token.Role == "" {
+		token.Role = atc.TeamRoleMember
+	}
+
+	if request.ExpiresAt != 0 {
+		token.ExpiresAt = time.Unix(request.ExpiresAt, 0)
+	}
+
+	if token.Name == "" || !token.Role.IsValid() || (!token.ExpiresAt.IsZero() && token.ExpiresAt.Before(time.Now())) {
+		hLog.Info("invalid-api-token", lager.Data{"name": token.Name, "role": token.Role})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	value, err := auth.GenerateAPIToken()
+	if err != nil {
+		hLog.Error("failed-to-generate-api-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	teamDB := s.teamDBFactory.GetTeamDB(r.FormValue(":team_name"))
+
+	savedToken, err := teamDB.CreateAPIToken(token, auth.HashAPIToken(value))
+	if err == db.ErrAPITokenAlreadyExists {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	if err != nil {
+		hLog.Error("failed-to-create-api-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presentedToken := present.APIToken(savedToken)
+	presentedToken.Token = value
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(presentedToken)
+}
+
+func (s *Server) RevokeAPIToken(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("revoke-api-token")
+
+	teamDB := s.teamDBFactory.GetTeamDB(r.FormValue(":team_name"))
+
+	deleted, err := teamDB.DeleteAPIToken(r.FormValue(":api_token_name"))
+	if err != nil {
+		hLog.Error("failed-to-delete-api-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !deleted {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,141 @@
+package teamserver
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+const notificationSecretBytes = 32
+
+func (s *Server) ListNotificationSubscriptions(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("list-notification-subscriptions")
+
+	teamDB := s.teamDBFactory.GetTeamDB(r.FormValue(":team_name"))
+
+	savedSubscriptions, err := teamDB.GetNotificationSubscriptions()
+	if err != nil {
+		hLog.Error("failed-to-get-notification-subscriptions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presentedSubscriptions := make([]atc.NotificationSubscription, len(savedSubscriptions))
+	for i, savedSubscription := range savedSubscriptions {
+		presentedSubscriptions[i] = present.NotificationSubscription(savedSubscription)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(presentedSubscriptions)
+}
+
+func (s *Server) CreateNotificationSubscription(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("create-notification-subscription")
+
+	var request atc.NotificationSubscription
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		hLog.Info("malformed-request", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if !validNotificationSubscription(request) {
+		hLog.Info("invalid-notification-subscription", lager.Data{"url": request.URL, "events": request.Events})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	secret, err := generateNotificationSecret()
+	if err != nil {
+		hLog.Error("failed-to-generate-secret", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	teamDB := s.teamDBFactory.GetTeamDB(r.FormValue(":team_name"))
+
+	savedSubscription, err := teamDB.CreateNotificationSubscription(db.NotificationSubscription{
+		URL:          request.URL,
+		Secret:       secret,
+		Events:       request.Events,
+		PipelineName: request.PipelineName,
+		JobName:      request.JobName,
+	})
+	if err != nil {
+		hLog.Error("failed-to-create-notification-subscription", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presentedSubscription := present.NotificationSubscription(savedSubscription)
+	presentedSubscription.Secret = savedSubscription.Secret
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(presentedSubscription)
+}
+
+func (s *Server) DeleteNotificationSubscription(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("delete-notification-subscription")
+
+	id, err := strconv.Atoi(r.FormValue(":subscription_id"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	teamDB := s.teamDBFactory.GetTeamDB(r.FormValue(":team_name"))
+
+	deleted, err := teamDB.DeleteNotificationSubscription(id)
+	if err != nil {
+		hLog.Error("failed-to-delete-notification-subscription", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !deleted {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func validNotificationSubscription(subscription atc.NotificationSubscription) bool {
+	webhookURL, err := url.Parse(subscription.URL)
+	if err != nil || webhookURL.Host == "" || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") {
+		return false
+	}
+
+	if len(subscription.Events) == 0 {
+		return false
+	}
+
+	for _, event := range subscription.Events {
+		if !atc.IsValidNotificationEvent(event) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func generateNotificationSecret() (string, error) {
+	secret := make([]byte, notificationSecretBytes)
+
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
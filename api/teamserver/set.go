@@ -5,10 +5,15 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/concourse/atc/api/present"
 	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/genericoauth"
+	"github.com/concourse/atc/auth/github"
+	"github.com/concourse/atc/auth/ldap"
+	"github.com/concourse/atc/auth/uaa"
 	"github.com/concourse/atc/db"
 )
 
@@ -102,6 +107,16 @@ func (s *Server) updateCredentials(team db.Team, teamDB db.TeamDB) error {
 		return err
 	}
 
+	_, err = teamDB.UpdateLDAPAuth(team.LDAPAuth)
+	if err != nil {
+		return err
+	}
+
+	_, err = teamDB.UpdateRoles(team.Roles)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -166,5 +181,68 @@ func (s *Server) validate(team db.Team) error {
 		}
 	}
 
+	if team.LDAPAuth != nil {
+		if team.LDAPAuth.Host == "" {
+			return errors.New("LDAP auth requires a Host")
+		}
+
+		if team.LDAPAuth.UserSearchBaseDN == "" || team.LDAPAuth.UserSearchFilter == "" {
+			return errors.New("LDAP auth requires a UserSearchBaseDN and UserSearchFilter")
+		}
+
+		if ldap.ValidateSearchFilter(team.LDAPAuth.UserSearchFilter) != nil {
+			return errors.New("LDAP auth requires a UserSearchFilter with exactly one %s")
+		}
+
+		if len(team.LDAPAuth.Groups) > 0 && (team.LDAPAuth.GroupSearchBaseDN == "" || team.LDAPAuth.GroupSearchFilter == "") {
+			return errors.New("LDAP auth requires a GroupSearchBaseDN and GroupSearchFilter when Groups are given")
+		}
+
+		if team.LDAPAuth.GroupSearchFilter != "" && ldap.ValidateSearchFilter(team.LDAPAuth.GroupSearchFilter) != nil {
+			return errors.New("LDAP auth requires a GroupSearchFilter with exactly one %s")
+		}
+
+		if team.LDAPAuth.UseTLS && team.LDAPAuth.StartTLS {
+			return errors.New("LDAP auth cannot use both TLS and StartTLS")
+		}
+
+		if team.LDAPAuth.CACert != "" {
+			block, _ := pem.Decode([]byte(team.LDAPAuth.CACert))
+			invalidCertErr := errors.New("LDAP certificate is invalid")
+
+			if block == nil {
+				return invalidCertErr
+			}
+
+			_, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return invalidCertErr
+			}
+		}
+	}
+
+	for providerName, role := range team.Roles {
+		if !isAuthProviderName(providerName) {
+			return fmt.Errorf("role given for unknown auth provider '%s'", providerName)
+		}
+
+		if !role.IsValid() {
+			return fmt.Errorf("unknown role '%s' given for auth provider '%s'", role, providerName)
+		}
+	}
+
 	return nil
 }
+
+func isAuthProviderName(name string) bool {
+	switch name {
+	case auth.BasicAuthProviderName,
+		github.ProviderName,
+		uaa.ProviderName,
+		genericoauth.ProviderName,
+		ldap.ProviderName:
+		return true
+	default:
+		return false
+	}
+}
@@ -1,8 +1,10 @@
 package api_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"time"
@@ -370,6 +372,179 @@ var _ = Describe("Versions API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", func() {
+		var response *http.Response
+		var requestBody io.Reader
+
+		BeforeEach(func() {
+			requestBody = nil
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/pin", requestBody)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", true, true)
+			})
+
+			It("injects the proper pipelineDB", func() {
+				Expect(teamDB.GetPipelineByNameCallCount()).To(Equal(1))
+				Expect(teamDB.GetPipelineByNameArgsForCall(0)).To(Equal("a-pipeline"))
+				Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
+				actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
+				Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
+			})
+
+			Context("when pinning the version succeeds", func() {
+				BeforeEach(func() {
+					pipelineDB.PinResourceVersionReturns(true, nil)
+				})
+
+				It("pinned the right version of the resource without a comment", func() {
+					Expect(pipelineDB.PinResourceVersionCallCount()).To(Equal(1))
+					resourceName, versionID, comment := pipelineDB.PinResourceVersionArgsForCall(0)
+					Expect(resourceName).To(Equal("resource-name"))
+					Expect(versionID).To(Equal(42))
+					Expect(comment).To(BeEmpty())
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				Context("with a comment", func() {
+					BeforeEach(func() {
+						requestBody = bytes.NewBufferString(`{"comment":"v43 is broken"}`)
+					})
+
+					It("pins the version with the comment", func() {
+						_, _, comment := pipelineDB.PinResourceVersionArgsForCall(0)
+						Expect(comment).To(Equal("v43 is broken"))
+					})
+				})
+			})
+
+			Context("when the request body is malformed", func() {
+				BeforeEach(func() {
+					requestBody = bytes.NewBufferString(`{`)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not pin the version", func() {
+					Expect(pipelineDB.PinResourceVersionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the version of the resource is not found", func() {
+				BeforeEach(func() {
+					pipelineDB.PinResourceVersionReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when pinning the version fails", func() {
+				BeforeEach(func() {
+					pipelineDB.PinResourceVersionReturns(false, errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/unpin", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", true, true)
+			})
+
+			Context("when unpinning the resource succeeds", func() {
+				BeforeEach(func() {
+					pipelineDB.UnpinResourceVersionReturns(true, nil)
+				})
+
+				It("unpinned the right resource", func() {
+					Expect(pipelineDB.UnpinResourceVersionCallCount()).To(Equal(1))
+					Expect(pipelineDB.UnpinResourceVersionArgsForCall(0)).To(Equal("resource-name"))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when the resource is not found", func() {
+				BeforeEach(func() {
+					pipelineDB.UnpinResourceVersionReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when unpinning the resource fails", func() {
+				BeforeEach(func() {
+					pipelineDB.UnpinResourceVersionReturns(false, errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/input_to", func() {
 		var response *http.Response
 		var stringVersionID string
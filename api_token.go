@@ -0,0 +1,21 @@
+package atc
+
+// APIToken is a long-lived token granting access to a team's API, e.g. for
+// automation. It is used as a bearer token in place of the JWT returned by
+// GetAuthToken.
+type APIToken struct {
+	Name string `json:"name"`
+
+	// Role defaults to member.
+	Role TeamRole `json:"role,omitempty"`
+
+	// Unix timestamps. ExpiresAt is 0 if the token never expires, and
+	// LastUsedAt is 0 if it has never been used.
+	CreatedAt  int64 `json:"created_at,omitempty"`
+	ExpiresAt  int64 `json:"expires_at,omitempty"`
+	LastUsedAt int64 `json:"last_used_at,omitempty"`
+
+	// Token is only returned when the token is created. It cannot be
+	// recovered afterwards.
+	Token string `json:"token,omitempty"`
+}
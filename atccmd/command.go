@@ -19,10 +19,13 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api"
 	"github.com/concourse/atc/api/buildserver"
+	"github.com/concourse/atc/audit"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/provider"
 	"github.com/concourse/atc/builds"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/archive"
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/db/migrations"
 	"github.com/concourse/atc/dbng"
@@ -32,10 +35,12 @@ import (
 	"github.com/concourse/atc/gcng"
 	"github.com/concourse/atc/lockrunner"
 	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/notifications"
 	"github.com/concourse/atc/pipelines"
 	"github.com/concourse/atc/radar"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/scheduler"
+	"github.com/concourse/atc/scheduler/buildqueue"
 	"github.com/concourse/atc/web"
 	"github.com/concourse/atc/web/publichandler"
 	"github.com/concourse/atc/web/robotstxt"
@@ -47,6 +52,8 @@ import (
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/jackc/pgx"
 	"github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/tedsuo/ifrit"
 	"github.com/tedsuo/ifrit/grouper"
 	"github.com/tedsuo/ifrit/http_server"
@@ -84,6 +91,11 @@ type ATCCommand struct {
 	OldResourceGracePeriod       time.Duration `long:"old-resource-grace-period" default:"5m" description:"How long to cache the result of a get step after a newer version of the resource is found."`
 	ResourceCacheCleanupInterval time.Duration `long:"resource-cache-cleanup-interval" default:"30s" description:"Interval on which to cleanup old caches of resources."`
 
+	ContainerPlacementStrategy string `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" description:"Method by which a worker is selected during container placement."`
+
+	DefaultTaskCPULimit    uint64 `long:"default-task-cpu-limit"    description:"Default CPU limit, in shares, for task containers which do not specify one. 0 means unlimited."`
+	DefaultTaskMemoryLimit uint64 `long:"default-task-memory-limit" description:"Default memory limit, in bytes, for task containers which do not specify one. 0 means unlimited."`
+
 	CLIArtifactsDir DirFlag `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
 	Developer struct {
@@ -98,6 +110,16 @@ type ATCCommand struct {
 		ResourceTypes   map[string]string `long:"resource"         description:"A resource type to advertise for the worker. Can be specified multiple times." value-name:"TYPE:IMAGE"`
 	} `group:"Static Worker (optional)" namespace:"worker"`
 
+	CredentialManagement struct {
+		CredentialsFile FileFlag `long:"credentials-file" description:"YAML file mapping 'TEAM/PIPELINE/VAR' or 'TEAM/VAR' to credential values."`
+
+		Vault struct {
+			URL        URLFlag `long:"url"          description:"Vault server address used to resolve ((var)) placeholders."`
+			Token      string  `long:"client-token" description:"Client token for accessing secrets within the Vault server."`
+			PathPrefix string  `long:"path-prefix"  default:"/concourse" description:"Path under which credentials are namespaced by team and pipeline."`
+		} `namespace:"vault"`
+	} `group:"Credential Management"`
+
 	Metrics struct {
 		HostName   string            `long:"metrics-host-name"   description:"Host string to attach to emitted metrics."`
 		Tags       []string          `long:"metrics-tag"         description:"Tag to attach to emitted metrics. Can be specified multiple times." value-name:"TAG"`
@@ -109,13 +131,36 @@ type ATCCommand struct {
 		RiemannHost          string `long:"riemann-host"                description:"Riemann server address to emit metrics to."`
 		RiemannPort          uint16 `long:"riemann-port" default:"5555" description:"Port of the Riemann server to emit metrics to."`
 		RiemannServicePrefix string `long:"riemann-service-prefix" default:"" description:"An optional prefix for emitted Riemann services"`
+
+		PrometheusBindIP   IPFlag `long:"prometheus-bind-ip"   default:"0.0.0.0" description:"IP address on which to listen for Prometheus scrapes of the /metrics endpoint."`
+		PrometheusBindPort uint16 `long:"prometheus-bind-port"                   description:"Port on which to listen for Prometheus scrapes of the /metrics endpoint."`
 	} `group:"Metrics & Diagnostics"`
 
 	LogDBQueries bool `long:"log-db-queries" description:"Log database queries."`
 
+	AuditLogFile string `long:"audit-log-file" description:"File to which audit events are appended as JSON lines, in addition to the database."`
+
 	GCInterval time.Duration `long:"gc-interval" default:"30s" description:"Interval on which to perform garbage collection."`
 
+	DefaultBuildLogsToRetain     int `long:"default-build-logs-to-retain"      description:"Number of build logs to retain for jobs which do not configure build log retention. 0 means all."`
+	DefaultDaysToRetainBuildLogs int `long:"default-days-to-retain-build-logs" description:"Number of days to retain build logs for jobs which do not configure build log retention. 0 means forever."`
+
+	BuildLogArchive struct {
+		Directory DirFlag `long:"directory" description:"Directory in which to archive build logs before they are reaped."`
+
+		S3 struct {
+			Endpoint        string `long:"endpoint"          description:"Endpoint of an S3-compatible store. Not needed for AWS."`
+			Region          string `long:"region"            default:"us-east-1" description:"Region of the bucket."`
+			Bucket          string `long:"bucket"            description:"Bucket in which to archive build logs before they are reaped."`
+			Prefix          string `long:"prefix"            description:"Prefix under which to store archived build logs."`
+			AccessKeyID     string `long:"access-key-id"     description:"Access key ID. If not specified, credentials are taken from the environment."`
+			SecretAccessKey string `long:"secret-access-key" description:"Secret access key."`
+		} `namespace:"s3"`
+	} `group:"Build Log Archival" namespace:"build-log-archive"`
+
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
+
+	MaxActiveBuilds int `long:"max-active-builds" description:"Maximum number of builds to run at once across the cluster. Once reached, pending builds start as others finish, in order of job priority, then favouring teams with fewer running builds. 0 means unlimited."`
 }
 
 func (cmd *ATCCommand) Execute(args []string) error {
@@ -137,8 +182,9 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 
 	go metric.PeriodicallyEmit(logger.Session("periodic-metrics"), 10*time.Second)
 
-	if cmd.Metrics.RiemannHost != "" {
-		cmd.configureMetrics(logger)
+	err = cmd.configureMetrics(logger)
+	if err != nil {
+		return nil, err
 	}
 
 	dbConn, dbngConn, err := cmd.constructDBConn(logger)
@@ -158,10 +204,15 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 	listener := pq.NewListener(cmd.PostgresDataSource, time.Second, time.Minute, nil)
 	bus := db.NewNotificationsBus(listener, dbConn)
 
-	sqlDB := db.NewSQL(dbConn, bus, lockFactory)
+	eventArchiver, err := cmd.constructEventArchiver()
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB := db.NewSQL(dbConn, bus, lockFactory, eventArchiver)
 	resourceFetcherFactory := resource.NewFetcherFactory(sqlDB, clock.NewClock())
 	resourceFactoryFactory := resource.NewResourceFactoryFactory()
-	pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+	pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory, eventArchiver)
 	dbBuildFactory := dbng.NewBuildFactory(dbngConn)
 	dbVolumeFactory := dbng.NewVolumeFactory(dbngConn)
 	dbContainerFactory := dbng.NewContainerFactory(dbngConn)
@@ -172,8 +223,15 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 	dbResourceCacheFactory := dbng.NewResourceCacheFactory(dbngConn, lockFactory)
 	dbResourceConfigFactory := dbng.NewResourceConfigFactory(dbngConn, lockFactory)
 	dbWorkerBaseResourceTypeFactory := dbng.NewWorkerBaseResourceTypeFactory(dbngConn)
+
+	containerPlacementStrategy, err := worker.NewContainerPlacementStrategy(cmd.ContainerPlacementStrategy)
+	if err != nil {
+		return nil, err
+	}
+
 	workerClient := cmd.constructWorkerPool(
 		logger,
+		containerPlacementStrategy,
 		sqlDB,
 		resourceFetcherFactory,
 		resourceFactoryFactory,
@@ -187,19 +245,24 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 
 	resourceFetcher := resourceFetcherFactory.FetcherFor(workerClient)
 	resourceFactory := resourceFactoryFactory.FactoryFor(workerClient)
-	teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
-	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, teamDBFactory)
+	teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, eventArchiver)
+	credentialManager := cmd.constructCredentialManager()
+
+	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, dbTeamFactory, teamDBFactory, credentialManager)
 
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
 		resourceFactory,
 		cmd.ResourceCheckingInterval,
 		engine,
+		credentialManager,
+		buildqueue.NewQueue(sqlDB, cmd.MaxActiveBuilds, clock.NewClock()),
 	)
 
 	radarScannerFactory := radar.NewScannerFactory(
 		resourceFactory,
 		cmd.ResourceCheckingInterval,
 		cmd.ExternalURL.String(),
+		credentialManager,
 	)
 
 	signingKey, err := cmd.loadOrGenerateSigningKey()
@@ -340,6 +403,7 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 				sqlDB,
 				pipelineDBFactory,
 				dbPipelineFactory,
+				dbTeamFactory,
 				radarSchedulerFactory,
 			),
 			Interval: 10 * time.Second,
@@ -412,6 +476,11 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 				sqlDB,
 				pipelineDBFactory,
 				500,
+				atc.BuildLogRetention{
+					Builds: cmd.DefaultBuildLogsToRetain,
+					Days:   cmd.DefaultDaysToRetainBuildLogs,
+				},
+				clock.NewClock(),
 			),
 			"build-reaper",
 			sqlDB,
@@ -447,6 +516,16 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		httpHandler,
 	)})
 
+	if cmd.Metrics.PrometheusBindPort != 0 {
+		prometheusMux := http.NewServeMux()
+		prometheusMux.Handle("/metrics", promhttp.Handler())
+
+		members = append(members, grouper.Member{"prometheus", http_server.New(
+			cmd.prometheusBindAddr(),
+			prometheusMux,
+		)})
+	}
+
 	return onReady(grouper.NewParallel(os.Interrupt, members), func() {
 		logData := lager.Data{
 			"http":  cmd.nonTLSBindAddr(),
@@ -457,6 +536,10 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 			logData["https"] = cmd.tlsBindAddr()
 		}
 
+		if cmd.Metrics.PrometheusBindPort != 0 {
+			logData["prometheus"] = cmd.prometheusBindAddr()
+		}
+
 		logger.Info("listening", logData)
 	}), nil
 }
@@ -539,6 +622,13 @@ func (cmd *ATCCommand) validate() error {
 		}
 	}
 
+	if cmd.CredentialManagement.CredentialsFile != "" && cmd.CredentialManagement.Vault.URL.URL() != nil {
+		errs = multierror.Append(
+			errs,
+			errors.New("must configure at most one credential manager"),
+		)
+	}
+
 	tlsFlagCount := 0
 	if cmd.TLSBindPort != 0 {
 		tlsFlagCount++
@@ -564,6 +654,13 @@ func (cmd *ATCCommand) validate() error {
 		)
 	}
 
+	if cmd.BuildLogArchive.Directory != "" && cmd.BuildLogArchive.S3.Bucket != "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify only one of --build-log-archive-directory and --build-log-archive-s3-bucket"),
+		)
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -591,6 +688,10 @@ func (cmd *ATCCommand) debugBindAddr() string {
 	return fmt.Sprintf("%s:%d", cmd.DebugBindIP, cmd.DebugBindPort)
 }
 
+func (cmd *ATCCommand) prometheusBindAddr() string {
+	return fmt.Sprintf("%s:%d", cmd.Metrics.PrometheusBindIP, cmd.Metrics.PrometheusBindPort)
+}
+
 func (cmd *ATCCommand) constructLogger() (lager.Logger, *lager.ReconfigurableSink) {
 	logger, reconfigurableSink := cmd.Logger.Logger("atc")
 
@@ -602,7 +703,29 @@ func (cmd *ATCCommand) constructLogger() (lager.Logger, *lager.ReconfigurableSin
 	return logger, reconfigurableSink
 }
 
-func (cmd *ATCCommand) configureMetrics(logger lager.Logger) {
+func (cmd *ATCCommand) configureMetrics(logger lager.Logger) error {
+	var emitters []metric.Emitter
+
+	if cmd.Metrics.RiemannHost != "" {
+		emitters = append(emitters, metric.NewRiemannEmitter(
+			fmt.Sprintf("%s:%d", cmd.Metrics.RiemannHost, cmd.Metrics.RiemannPort),
+			cmd.Metrics.RiemannServicePrefix,
+		))
+	}
+
+	if cmd.Metrics.PrometheusBindPort != 0 {
+		prometheusEmitter, err := metric.NewPrometheusEmitter(prometheus.DefaultRegisterer)
+		if err != nil {
+			return err
+		}
+
+		emitters = append(emitters, prometheusEmitter)
+	}
+
+	if len(emitters) == 0 {
+		return nil
+	}
+
 	host := cmd.Metrics.HostName
 	if host == "" {
 		host, _ = os.Hostname()
@@ -610,12 +733,54 @@ func (cmd *ATCCommand) configureMetrics(logger lager.Logger) {
 
 	metric.Initialize(
 		logger.Session("metrics"),
-		fmt.Sprintf("%s:%d", cmd.Metrics.RiemannHost, cmd.Metrics.RiemannPort),
 		host,
 		cmd.Metrics.Tags,
 		cmd.Metrics.Attributes,
-		cmd.Metrics.RiemannServicePrefix,
+		emitters,
 	)
+
+	return nil
+}
+
+func (cmd *ATCCommand) constructCredentialManager() creds.CredentialManager {
+	if cmd.CredentialManagement.CredentialsFile != "" {
+		return creds.NewFileManager(string(cmd.CredentialManagement.CredentialsFile))
+	}
+
+	if cmd.CredentialManagement.Vault.URL.URL() != nil {
+		return creds.NewVaultManager(
+			cmd.CredentialManagement.Vault.URL.String(),
+			cmd.CredentialManagement.Vault.Token,
+			cmd.CredentialManagement.Vault.PathPrefix,
+			&http.Client{Timeout: time.Minute},
+		)
+	}
+
+	return nil
+}
+
+func (cmd *ATCCommand) constructEventArchiver() (archive.Archiver, error) {
+	if cmd.BuildLogArchive.Directory != "" {
+		return archive.NewArchiver(archive.NewLocalBackend(string(cmd.BuildLogArchive.Directory))), nil
+	}
+
+	if cmd.BuildLogArchive.S3.Bucket != "" {
+		backend, err := archive.NewS3Backend(archive.S3Config{
+			Endpoint:        cmd.BuildLogArchive.S3.Endpoint,
+			Region:          cmd.BuildLogArchive.S3.Region,
+			Bucket:          cmd.BuildLogArchive.S3.Bucket,
+			Prefix:          cmd.BuildLogArchive.S3.Prefix,
+			AccessKeyID:     cmd.BuildLogArchive.S3.AccessKeyID,
+			SecretAccessKey: cmd.BuildLogArchive.S3.SecretAccessKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure build log archive: %s", err)
+		}
+
+		return archive.NewArchiver(backend), nil
+	}
+
+	return nil, nil
 }
 
 func (cmd *ATCCommand) constructDBConn(logger lager.Logger) (db.Conn, dbng.Conn, error) {
@@ -665,6 +830,7 @@ func (cmd *ATCCommand) constructLockConn() (*lock.RetryableConn, error) {
 
 func (cmd *ATCCommand) constructWorkerPool(
 	logger lager.Logger,
+	containerPlacementStrategy worker.ContainerPlacementStrategy,
 	sqlDB *db.SQLDB,
 	resourceFetcherFactory resource.FetcherFactory,
 	resourceFactoryFactory resource.ResourceFactoryFactory,
@@ -694,6 +860,7 @@ func (cmd *ATCCommand) constructWorkerPool(
 			dbTeamFactory,
 			dbWorkerFactory,
 		),
+		containerPlacementStrategy,
 	)
 }
 
@@ -817,25 +984,40 @@ func (cmd *ATCCommand) constructEngine(
 	resourceFetcher resource.Fetcher,
 	resourceFactory resource.ResourceFactory,
 	dbResourceCacheFactory dbng.ResourceCacheFactory,
+	dbTeamFactory dbng.TeamFactory,
 	teamDBFactory db.TeamDBFactory,
+	credentialManager creds.CredentialManager,
 ) engine.Engine {
 	gardenFactory := exec.NewGardenFactory(
 		workerClient,
 		resourceFetcher,
 		resourceFactory,
 		dbResourceCacheFactory,
+		dbTeamFactory,
+		atc.ContainerLimits{
+			CPU:    cmd.DefaultTaskCPULimit,
+			Memory: cmd.DefaultTaskMemoryLimit,
+		},
+	)
+
+	notifier := notifications.NewNotifier(
+		teamDBFactory,
+		&http.Client{Timeout: 30 * time.Second},
+		clock.NewClock(),
+		cmd.ExternalURL.String(),
 	)
 
 	execV2Engine := engine.NewExecEngine(
 		gardenFactory,
-		engine.NewBuildDelegateFactory(),
+		engine.NewBuildDelegateFactory(notifier),
 		teamDBFactory,
+		credentialManager,
 		cmd.ExternalURL.String(),
 	)
 
 	execV1Engine := engine.NewExecV1DummyEngine()
 
-	return engine.NewDBEngine(engine.Engines{execV2Engine, execV1Engine})
+	return engine.NewDBEngine(engine.Engines{execV2Engine, execV1Engine}, notifier)
 }
 
 func (cmd *ATCCommand) constructHTTPHandler(
@@ -845,7 +1027,9 @@ func (cmd *ATCCommand) constructHTTPHandler(
 	oauthHandler http.Handler,
 ) http.Handler {
 	webMux := http.NewServeMux()
-	webMux.Handle("/api/v1/", apiHandler)
+	// look up the API token a request bears once, however many times it is
+	// validated and read
+	webMux.Handle("/api/v1/", auth.APITokenLookupHandler{Handler: apiHandler})
 	webMux.Handle("/auth/", oauthHandler)
 	webMux.Handle("/public/", publicHandler)
 	webMux.Handle("/robots.txt", robotstxt.Handler{})
@@ -879,11 +1063,19 @@ func (cmd *ATCCommand) constructAPIHandler(
 	radarSchedulerFactory pipelines.RadarSchedulerFactory,
 	radarScannerFactory radar.ScannerFactory,
 ) (http.Handler, error) {
-	authValidator := auth.JWTValidator{
-		PublicKey: &signingKey.PublicKey,
-	}
+	authValidator := auth.NewValidatorBasket(
+		auth.JWTValidator{PublicKey: &signingKey.PublicKey},
+		auth.APITokenValidator{Logger: logger.Session("api-token-validator"), DB: sqlDB},
+	)
 
-	getTokenValidator := auth.NewTeamAuthValidator(teamDBFactory, authValidator)
+	apiTokenReader := auth.APITokenReader{DB: sqlDB}
+
+	userContextReader := auth.NewUserContextReaderBasket(
+		auth.JWTReader{PublicKey: &signingKey.PublicKey},
+		apiTokenReader,
+	)
+
+	getTokenValidator := auth.NewTeamAuthValidator(logger, teamDBFactory, authValidator)
 
 	checkPipelineAccessHandlerFactory := auth.NewCheckPipelineAccessHandlerFactory(
 		pipelineDBFactory,
@@ -896,17 +1088,30 @@ func (cmd *ATCCommand) constructAPIHandler(
 
 	checkWorkerTeamAccessHandlerFactory := auth.NewCheckWorkerTeamAccessHandlerFactory(dbWorkerFactory)
 
+	auditSinks := []audit.Sink{audit.NewDBSink(sqlDB)}
+	if cmd.AuditLogFile != "" {
+		fileSink, err := audit.NewFileSink(cmd.AuditLogFile)
+		if err != nil {
+			return nil, err
+		}
+
+		auditSinks = append(auditSinks, fileSink)
+	}
+
+	auditor := audit.NewAuditor(logger.Session("audit"), auditSinks...)
+
 	apiWrapper := wrappa.MultiWrappa{
 		wrappa.NewAPIMetricsWrappa(logger),
 		wrappa.NewAPIAuthWrappa(
 			authValidator,
 			getTokenValidator,
-			auth.JWTReader{PublicKey: &signingKey.PublicKey},
+			userContextReader,
 			checkPipelineAccessHandlerFactory,
 			checkBuildReadAccessHandlerFactory,
 			checkBuildWriteAccessHandlerFactory,
 			checkWorkerTeamAccessHandlerFactory,
 		),
+		wrappa.NewAPIAuditWrappa(auditor, userContextReader, apiTokenReader),
 		wrappa.NewConcourseVersionWrappa(Version),
 	}
 
@@ -932,6 +1137,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 		sqlDB, // containerserver.ContainerDB
 		sqlDB, // pipes.PipeDB
 		sqlDB, // db.PipelinesDB
+		sqlDB, // auditserver.AuditDB
 
 		cmd.PeerURL.String(),
 		buildserver.NewEventHandler,
@@ -976,6 +1182,7 @@ func (cmd *ATCCommand) constructPipelineSyncer(
 	sqlDB *db.SQLDB,
 	pipelineDBFactory db.PipelineDBFactory,
 	dbPipelineFactory dbng.PipelineFactory,
+	dbTeamFactory dbng.TeamFactory,
 	radarSchedulerFactory pipelines.RadarSchedulerFactory,
 ) *pipelines.Syncer {
 	return pipelines.NewSyncer(
@@ -983,6 +1190,7 @@ func (cmd *ATCCommand) constructPipelineSyncer(
 		sqlDB,
 		pipelineDBFactory,
 		dbPipelineFactory,
+		dbTeamFactory,
 		func(pipelineDB db.PipelineDB, dbPipeline dbng.Pipeline) ifrit.Runner {
 			return grouper.NewParallel(os.Interrupt, grouper.Members{
 				{
@@ -0,0 +1,95 @@
+// This file was generated by counterfeiter
+package auditfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/audit"
+	"github.com/concourse/atc/db"
+)
+
+type FakeAuditDB struct {
+	SaveAuditEventStub        func(event db.AuditEvent) error
+	saveAuditEventMutex       sync.RWMutex
+	saveAuditEventArgsForCall []struct {
+		event db.AuditEvent
+	}
+	saveAuditEventReturns struct {
+		result1 error
+	}
+	saveAuditEventReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAuditDB) SaveAuditEvent(event db.AuditEvent) error {
+	fake.saveAuditEventMutex.Lock()
+	ret, specificReturn := fake.saveAuditEventReturnsOnCall[len(fake.saveAuditEventArgsForCall)]
+	fake.saveAuditEventArgsForCall = append(fake.saveAuditEventArgsForCall, struct {
+		event db.AuditEvent
+	}{event})
+	fake.recordInvocation("SaveAuditEvent", []interface{}{event})
+	fake.saveAuditEventMutex.Unlock()
+	if fake.SaveAuditEventStub != nil {
+		return fake.SaveAuditEventStub(event)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveAuditEventReturns.result1
+}
+
+func (fake *FakeAuditDB) SaveAuditEventCallCount() int {
+	fake.saveAuditEventMutex.RLock()
+	defer fake.saveAuditEventMutex.RUnlock()
+	return len(fake.saveAuditEventArgsForCall)
+}
+
+func (fake *FakeAuditDB) SaveAuditEventArgsForCall(i int) db.AuditEvent {
+	fake.saveAuditEventMutex.RLock()
+	defer fake.saveAuditEventMutex.RUnlock()
+	return fake.saveAuditEventArgsForCall[i].event
+}
+
+func (fake *FakeAuditDB) SaveAuditEventReturns(result1 error) {
+	fake.SaveAuditEventStub = nil
+	fake.saveAuditEventReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAuditDB) SaveAuditEventReturnsOnCall(i int, result1 error) {
+	fake.SaveAuditEventStub = nil
+	if fake.saveAuditEventReturnsOnCall == nil {
+		fake.saveAuditEventReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveAuditEventReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAuditDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.saveAuditEventMutex.RLock()
+	defer fake.saveAuditEventMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeAuditDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ audit.AuditDB = new(FakeAuditDB)
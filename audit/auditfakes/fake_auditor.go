@@ -0,0 +1,65 @@
+// This file was generated by counterfeiter
+package auditfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/audit"
+)
+
+type FakeAuditor struct {
+	RecordStub        func(atc.AuditEvent)
+	recordMutex       sync.RWMutex
+	recordArgsForCall []struct {
+		arg1 atc.AuditEvent
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAuditor) Record(arg1 atc.AuditEvent) {
+	fake.recordMutex.Lock()
+	fake.recordArgsForCall = append(fake.recordArgsForCall, struct {
+		arg1 atc.AuditEvent
+	}{arg1})
+	fake.recordInvocation("Record", []interface{}{arg1})
+	fake.recordMutex.Unlock()
+	if fake.RecordStub != nil {
+		fake.RecordStub(arg1)
+	}
+}
+
+func (fake *FakeAuditor) RecordCallCount() int {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	return len(fake.recordArgsForCall)
+}
+
+func (fake *FakeAuditor) RecordArgsForCall(i int) atc.AuditEvent {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	return fake.recordArgsForCall[i].arg1
+}
+
+func (fake *FakeAuditor) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeAuditor) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ audit.Auditor = new(FakeAuditor)
@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+)
+
+//go:generate counterfeiter . Auditor
+
+// Auditor records audit events to each of its sinks. Failing to record an
+// event does not fail the request being audited, and is only logged.
+type Auditor interface {
+	Record(atc.AuditEvent)
+}
+
+//go:generate counterfeiter . Sink
+
+type Sink interface {
+	Record(atc.AuditEvent) error
+}
+
+func NewAuditor(logger lager.Logger, sinks ...Sink) Auditor {
+	return &auditor{
+		logger: logger,
+		sinks:  sinks,
+	}
+}
+
+type auditor struct {
+	logger lager.Logger
+	sinks  []Sink
+}
+
+func (auditor *auditor) Record(event atc.AuditEvent) {
+	for _, sink := range auditor.sinks {
+		err := sink.Record(event)
+		if err != nil {
+			auditor.logger.Error("failed-to-record-audit-event", err, lager.Data{
+				"route": event.Route,
+			})
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package audit_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/audit"
+	"github.com/concourse/atc/audit/auditfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Auditor", func() {
+	var (
+		sinkA *auditfakes.FakeSink
+		sinkB *auditfakes.FakeSink
+
+		auditor audit.Auditor
+		event   atc.AuditEvent
+	)
+
+	BeforeEach(func() {
+		sinkA = new(auditfakes.FakeSink)
+		sinkB = new(auditfakes.FakeSink)
+
+		auditor = audit.NewAuditor(lagertest.NewTestLogger("test"), sinkA, sinkB)
+
+		event = atc.AuditEvent{
+			Time:   1234,
+			Actor:  "some-team",
+			Route:  atc.PausePipeline,
+			Status: 200,
+		}
+	})
+
+	It("records the event to every sink", func() {
+		auditor.Record(event)
+
+		Expect(sinkA.RecordCallCount()).To(Equal(1))
+		Expect(sinkA.RecordArgsForCall(0)).To(Equal(event))
+
+		Expect(sinkB.RecordCallCount()).To(Equal(1))
+		Expect(sinkB.RecordArgsForCall(0)).To(Equal(event))
+	})
+
+	Context("when a sink fails", func() {
+		BeforeEach(func() {
+			sinkA.RecordReturns(errors.New("nope"))
+		})
+
+		It("still records the event to the other sinks", func() {
+			auditor.Record(event)
+
+			Expect(sinkB.RecordCallCount()).To(Equal(1))
+		})
+	})
+})
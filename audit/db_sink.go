@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+//go:generate counterfeiter . AuditDB
+
+type AuditDB interface {
+	SaveAuditEvent(event db.AuditEvent) error
+}
+
+// NewDBSink constructs a Sink which saves events to the database, from which
+// they are served by the ListAuditEvents route.
+func NewDBSink(auditDB AuditDB) Sink {
+	return dbSink{
+		db: auditDB,
+	}
+}
+
+type dbSink struct {
+	db AuditDB
+}
+
+func (sink dbSink) Record(event atc.AuditEvent) error {
+	return sink.db.SaveAuditEvent(db.AuditEvent{
+		Time:         time.Unix(event.Time, 0),
+		Actor:        event.Actor,
+		RemoteAddr:   event.RemoteAddr,
+		APITokenName: event.APITokenName,
+		APITokenRole: event.APITokenRole,
+		Route:        event.Route,
+		TeamName:     event.TeamName,
+		Params:       event.Params,
+		Status:       event.Status,
+	})
+}
@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/concourse/atc"
+)
+
+// NewFileSink constructs a Sink which appends each event to the file at the
+// given path as a line of JSON, creating it if necessary.
+func NewFileSink(path string) (Sink, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileSink{
+		file: file,
+	}, nil
+}
+
+type fileSink struct {
+	fileL sync.Mutex
+	file  *os.File
+}
+
+func (sink *fileSink) Record(event atc.AuditEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	sink.fileL.Lock()
+	defer sink.fileL.Unlock()
+
+	_, err = sink.file.Write(append(line, '\n'))
+	return err
+}
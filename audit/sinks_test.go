@@ -0,0 +1,126 @@
+package audit_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/audit"
+	"github.com/concourse/atc/audit/auditfakes"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sinks", func() {
+	var event atc.AuditEvent
+
+	BeforeEach(func() {
+		event = atc.AuditEvent{
+			Time:         1234,
+			Actor:        "some-team",
+			RemoteAddr:   "1.2.3.4:5678",
+			APITokenName: "some-token",
+			APITokenRole: atc.TeamRolePipelineOperator,
+			Route:        atc.PausePipeline,
+			TeamName:     "some-team",
+			Params:       map[string]string{"pipeline_name": "some-pipeline"},
+			Status:       200,
+		}
+	})
+
+	Describe("DBSink", func() {
+		It("saves the event", func() {
+			fakeDB := new(auditfakes.FakeAuditDB)
+
+			err := audit.NewDBSink(fakeDB).Record(event)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeDB.SaveAuditEventCallCount()).To(Equal(1))
+			Expect(fakeDB.SaveAuditEventArgsForCall(0)).To(Equal(db.AuditEvent{
+				Time:         time.Unix(1234, 0),
+				Actor:        "some-team",
+				RemoteAddr:   "1.2.3.4:5678",
+				APITokenName: "some-token",
+				APITokenRole: atc.TeamRolePipelineOperator,
+				Route:        atc.PausePipeline,
+				TeamName:     "some-team",
+				Params:       map[string]string{"pipeline_name": "some-pipeline"},
+				Status:       200,
+			}))
+		})
+	})
+
+	Describe("FileSink", func() {
+		var (
+			tmpdir string
+			path   string
+		)
+
+		BeforeEach(func() {
+			var err error
+			tmpdir, err = ioutil.TempDir("", "audit")
+			Expect(err).NotTo(HaveOccurred())
+
+			path = filepath.Join(tmpdir, "audit.log")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tmpdir)
+		})
+
+		readEvents := func() []atc.AuditEvent {
+			file, err := os.Open(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer file.Close()
+
+			events := []atc.AuditEvent{}
+
+			scanner := bufio.NewScanner(file)
+			for scanner.Scan() {
+				var event atc.AuditEvent
+				err := json.Unmarshal(scanner.Bytes(), &event)
+				Expect(err).NotTo(HaveOccurred())
+
+				events = append(events, event)
+			}
+
+			return events
+		}
+
+		It("writes each event as a line of JSON", func() {
+			sink, err := audit.NewFileSink(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sink.Record(event)).To(Succeed())
+			Expect(sink.Record(event)).To(Succeed())
+
+			Expect(readEvents()).To(Equal([]atc.AuditEvent{event, event}))
+		})
+
+		It("appends to an existing file", func() {
+			sink, err := audit.NewFileSink(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sink.Record(event)).To(Succeed())
+
+			sink, err = audit.NewFileSink(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sink.Record(event)).To(Succeed())
+
+			Expect(readEvents()).To(HaveLen(2))
+		})
+
+		Context("when the file cannot be opened", func() {
+			It("returns an error", func() {
+				_, err := audit.NewFileSink(filepath.Join(tmpdir, "missing", "audit.log"))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -0,0 +1,26 @@
+package atc
+
+// AuditEvent records a request which may have changed something.
+type AuditEvent struct {
+	ID   int   `json:"id"`
+	Time int64 `json:"time"`
+
+	// Actor is the name of the team the requester was authenticated as, or
+	// "system". It is empty if the requester was not authenticated.
+	Actor      string `json:"actor,omitempty"`
+	RemoteAddr string `json:"remote_addr,omitempty"`
+
+	// APITokenName and APITokenRole identify the API token the requester
+	// authenticated with, if any.
+	APITokenName string   `json:"api_token_name,omitempty"`
+	APITokenRole TeamRole `json:"api_token_role,omitempty"`
+
+	// Route is the name of the route in Routes, and Params the route's
+	// parameters identifying its target, e.g. "pipeline_name".
+	Route    string            `json:"route"`
+	TeamName string            `json:"team_name,omitempty"`
+	Params   map[string]string `json:"params,omitempty"`
+
+	// Status is the HTTP status code of the response.
+	Status int `json:"status"`
+}
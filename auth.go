@@ -5,6 +5,7 @@ type AuthType string
 const (
 	AuthTypeBasic AuthType = "basic"
 	AuthTypeOAuth AuthType = "oauth"
+	AuthTypeLDAP  AuthType = "ldap"
 )
 
 type AuthMethod struct {
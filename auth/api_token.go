@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+const apiTokenBytes = 32
+
+//go:generate counterfeiter . APITokenDB
+
+type APITokenDB interface {
+	FindAPIToken(tokenHash string) (db.SavedAPIToken, bool, error)
+	UpdateAPITokenLastUsed(id int) error
+}
+
+// GenerateAPIToken returns a new random API token. Only its hash, as returned
+// by HashAPIToken, should be stored.
+func GenerateAPIToken() (string, error) {
+	token := make([]byte, apiTokenBytes)
+
+	_, err := rand.Read(token)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(token), nil
+}
+
+// HashAPIToken returns the hash by which an API token is stored and looked
+// up. The token is random, so it needs no salt or stretching.
+func HashAPIToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// APITokenValidator accepts requests bearing an unexpired API token, and
+// records that the token was used. Failing to record the use is only logged.
+type APITokenValidator struct {
+	Logger lager.Logger
+	DB     APITokenDB
+}
+
+func (validator APITokenValidator) IsAuthenticated(r *http.Request) bool {
+	token, found := findAPIToken(r, validator.DB)
+	if !found {
+		return false
+	}
+
+	err := validator.DB.UpdateAPITokenLastUsed(token.ID)
+	if err != nil {
+		validator.Logger.Error("failed-to-update-api-token-last-used", err, lager.Data{
+			"token": token.ID,
+		})
+	}
+
+	return true
+}
+
+//go:generate counterfeiter . APITokenContextReader
+
+// APITokenContextReader reads the API token a request bears, for recording
+// which token made the request.
+type APITokenContextReader interface {
+	GetAPIToken(r *http.Request) (db.SavedAPIToken, bool)
+}
+
+// APITokenReader reads the team and role of the API token a request bears.
+type APITokenReader struct {
+	DB APITokenDB
+}
+
+func (reader APITokenReader) GetAPIToken(r *http.Request) (db.SavedAPIToken, bool) {
+	return findAPIToken(r, reader.DB)
+}
+
+func (reader APITokenReader) GetTeam(r *http.Request) (string, bool, bool) {
+	token, found := findAPIToken(r, reader.DB)
+	if !found {
+		return "", false, false
+	}
+
+	return token.TeamName, token.TeamAdmin, true
+}
+
+func (reader APITokenReader) GetSystem(r *http.Request) (bool, bool) {
+	return false, false
+}
+
+func (reader APITokenReader) GetRole(r *http.Request) (atc.TeamRole, bool) {
+	token, found := findAPIToken(r, reader.DB)
+	if !found {
+		return "", false
+	}
+
+	return token.Role, true
+}
+
+var apiTokenLookupKey = "apiTokenLookup"
+
+type apiTokenLookup struct {
+	once  sync.Once
+	token db.SavedAPIToken
+	found bool
+}
+
+// APITokenLookupHandler caches the API token a request bears on the
+// request's context, so that the validator and readers look it up once
+// rather than on each of their calls.
+type APITokenLookupHandler struct {
+	Handler http.Handler
+}
+
+func (handler APITokenLookupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := context.WithValue(r.Context(), apiTokenLookupKey, &apiTokenLookup{})
+	handler.Handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+func findAPIToken(r *http.Request, apiTokenDB APITokenDB) (db.SavedAPIToken, bool) {
+	lookup, cached := r.Context().Value(apiTokenLookupKey).(*apiTokenLookup)
+	if !cached {
+		return lookUpAPIToken(r, apiTokenDB)
+	}
+
+	lookup.once.Do(func() {
+		lookup.token, lookup.found = lookUpAPIToken(r, apiTokenDB)
+	})
+
+	return lookup.token, lookup.found
+}
+
+func lookUpAPIToken(r *http.Request, apiTokenDB APITokenDB) (db.SavedAPIToken, bool) {
+	ah := r.Header.Get("Authorization")
+	if len(ah) <= 7 || strings.ToUpper(ah[0:7]) != "BEARER " {
+		return db.SavedAPIToken{}, false
+	}
+
+	token, found, err := apiTokenDB.FindAPIToken(HashAPIToken(ah[7:]))
+	if err != nil || !found {
+		return db.SavedAPIToken{}, false
+	}
+
+	return token, true
+}
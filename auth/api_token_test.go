@@ -0,0 +1,225 @@
+package auth_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("API tokens", func() {
+	var (
+		fakeDB  *authfakes.FakeAPITokenDB
+		request *http.Request
+	)
+
+	BeforeEach(func() {
+		fakeDB = new(authfakes.FakeAPITokenDB)
+
+		var err error
+		request, err = http.NewRequest("GET", "http://example.com", nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("GenerateAPIToken", func() {
+		It("generates a different token each time", func() {
+			tokenA, err := auth.GenerateAPIToken()
+			Expect(err).NotTo(HaveOccurred())
+
+			tokenB, err := auth.GenerateAPIToken()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tokenA).To(HaveLen(64))
+			Expect(tokenA).NotTo(Equal(tokenB))
+		})
+	})
+
+	Describe("HashAPIToken", func() {
+		It("hashes consistently without exposing the token", func() {
+			Expect(auth.HashAPIToken("some-token")).To(Equal(auth.HashAPIToken("some-token")))
+			Expect(auth.HashAPIToken("some-token")).NotTo(ContainSubstring("some-token"))
+			Expect(auth.HashAPIToken("some-token")).NotTo(Equal(auth.HashAPIToken("some-other-token")))
+		})
+	})
+
+	Describe("APITokenValidator", func() {
+		var isAuthenticated bool
+
+		JustBeforeEach(func() {
+			isAuthenticated = auth.APITokenValidator{
+				Logger: lagertest.NewTestLogger("test"),
+				DB:     fakeDB,
+			}.IsAuthenticated(request)
+		})
+
+		Context("when the request bears a known token", func() {
+			BeforeEach(func() {
+				request.Header.Set("Authorization", "Bearer some-token")
+				fakeDB.FindAPITokenReturns(db.SavedAPIToken{ID: 42}, true, nil)
+			})
+
+			It("looks the token up by its hash", func() {
+				Expect(fakeDB.FindAPITokenArgsForCall(0)).To(Equal(auth.HashAPIToken("some-token")))
+			})
+
+			It("returns true", func() {
+				Expect(isAuthenticated).To(BeTrue())
+			})
+
+			It("records that the token was used", func() {
+				Expect(fakeDB.UpdateAPITokenLastUsedCallCount()).To(Equal(1))
+				Expect(fakeDB.UpdateAPITokenLastUsedArgsForCall(0)).To(Equal(42))
+			})
+
+			Context("when recording the use fails", func() {
+				BeforeEach(func() {
+					fakeDB.UpdateAPITokenLastUsedReturns(errors.New("nope"))
+				})
+
+				It("still returns true", func() {
+					Expect(isAuthenticated).To(BeTrue())
+				})
+			})
+		})
+
+		Context("when the request bears an unknown token", func() {
+			BeforeEach(func() {
+				request.Header.Set("Authorization", "Bearer some-token")
+				fakeDB.FindAPITokenReturns(db.SavedAPIToken{}, false, nil)
+			})
+
+			It("returns false", func() {
+				Expect(isAuthenticated).To(BeFalse())
+			})
+		})
+
+		Context("when looking up the token fails", func() {
+			BeforeEach(func() {
+				request.Header.Set("Authorization", "Bearer some-token")
+				fakeDB.FindAPITokenReturns(db.SavedAPIToken{}, false, errors.New("nope"))
+			})
+
+			It("returns false", func() {
+				Expect(isAuthenticated).To(BeFalse())
+			})
+		})
+
+		Context("when the request bears no token", func() {
+			BeforeEach(func() {
+				request.Header.Set("Authorization", "Basic "+b64("username:password"))
+			})
+
+			It("returns false without looking anything up", func() {
+				Expect(isAuthenticated).To(BeFalse())
+				Expect(fakeDB.FindAPITokenCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("APITokenReader", func() {
+		var reader auth.APITokenReader
+
+		BeforeEach(func() {
+			reader = auth.APITokenReader{DB: fakeDB}
+		})
+
+		Context("when the request bears a known token", func() {
+			BeforeEach(func() {
+				request.Header.Set("Authorization", "Bearer some-token")
+				fakeDB.FindAPITokenReturns(db.SavedAPIToken{
+					APIToken: db.APIToken{
+						Name: "ci-bot",
+						Role: atc.TeamRolePipelineOperator,
+					},
+					TeamName:  "some-team",
+					TeamAdmin: true,
+				}, true, nil)
+			})
+
+			It("returns the token's team", func() {
+				teamName, isAdmin, found := reader.GetTeam(request)
+				Expect(found).To(BeTrue())
+				Expect(teamName).To(Equal("some-team"))
+				Expect(isAdmin).To(BeTrue())
+			})
+
+			It("returns the token's role", func() {
+				role, found := reader.GetRole(request)
+				Expect(found).To(BeTrue())
+				Expect(role).To(Equal(atc.TeamRolePipelineOperator))
+			})
+
+			It("returns the token itself", func() {
+				token, found := reader.GetAPIToken(request)
+				Expect(found).To(BeTrue())
+				Expect(token.Name).To(Equal("ci-bot"))
+				Expect(token.Role).To(Equal(atc.TeamRolePipelineOperator))
+			})
+
+			It("is never the system", func() {
+				_, found := reader.GetSystem(request)
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the request bears an unknown token", func() {
+			BeforeEach(func() {
+				request.Header.Set("Authorization", "Bearer some-token")
+			})
+
+			It("finds nothing", func() {
+				_, _, found := reader.GetTeam(request)
+				Expect(found).To(BeFalse())
+
+				_, found = reader.GetRole(request)
+				Expect(found).To(BeFalse())
+
+				_, found = reader.GetAPIToken(request)
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("APITokenLookupHandler", func() {
+		BeforeEach(func() {
+			request.Header.Set("Authorization", "Bearer some-token")
+			fakeDB.FindAPITokenReturns(db.SavedAPIToken{ID: 42, TeamName: "some-team"}, true, nil)
+		})
+
+		It("looks the token up once per request", func() {
+			validator := auth.APITokenValidator{
+				Logger: lagertest.NewTestLogger("test"),
+				DB:     fakeDB,
+			}
+
+			reader := auth.APITokenReader{DB: fakeDB}
+
+			handler := auth.APITokenLookupHandler{
+				Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					Expect(validator.IsAuthenticated(r)).To(BeTrue())
+
+					teamName, _, found := reader.GetTeam(r)
+					Expect(found).To(BeTrue())
+					Expect(teamName).To(Equal("some-team"))
+
+					_, found = reader.GetRole(r)
+					Expect(found).To(BeTrue())
+				}),
+			}
+
+			handler.ServeHTTP(httptest.NewRecorder(), request)
+			Expect(fakeDB.FindAPITokenCallCount()).To(Equal(1))
+
+			handler.ServeHTTP(httptest.NewRecorder(), request)
+			Expect(fakeDB.FindAPITokenCallCount()).To(Equal(2))
+		})
+	})
+})
@@ -0,0 +1,101 @@
+// This file was generated by counterfeiter
+package authfakes
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+type FakeAPITokenContextReader struct {
+	GetAPITokenStub        func(r *http.Request) (db.SavedAPIToken, bool)
+	getAPITokenMutex       sync.RWMutex
+	getAPITokenArgsForCall []struct {
+		r *http.Request
+	}
+	getAPITokenReturns struct {
+		result1 db.SavedAPIToken
+		result2 bool
+	}
+	getAPITokenReturnsOnCall map[int]struct {
+		result1 db.SavedAPIToken
+		result2 bool
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAPITokenContextReader) GetAPIToken(r *http.Request) (db.SavedAPIToken, bool) {
+	fake.getAPITokenMutex.Lock()
+	ret, specificReturn := fake.getAPITokenReturnsOnCall[len(fake.getAPITokenArgsForCall)]
+	fake.getAPITokenArgsForCall = append(fake.getAPITokenArgsForCall, struct {
+		r *http.Request
+	}{r})
+	fake.recordInvocation("GetAPIToken", []interface{}{r})
+	fake.getAPITokenMutex.Unlock()
+	if fake.GetAPITokenStub != nil {
+		return fake.GetAPITokenStub(r)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getAPITokenReturns.result1, fake.getAPITokenReturns.result2
+}
+
+func (fake *FakeAPITokenContextReader) GetAPITokenCallCount() int {
+	fake.getAPITokenMutex.RLock()
+	defer fake.getAPITokenMutex.RUnlock()
+	return len(fake.getAPITokenArgsForCall)
+}
+
+func (fake *FakeAPITokenContextReader) GetAPITokenArgsForCall(i int) *http.Request {
+	fake.getAPITokenMutex.RLock()
+	defer fake.getAPITokenMutex.RUnlock()
+	return fake.getAPITokenArgsForCall[i].r
+}
+
+func (fake *FakeAPITokenContextReader) GetAPITokenReturns(result1 db.SavedAPIToken, result2 bool) {
+	fake.GetAPITokenStub = nil
+	fake.getAPITokenReturns = struct {
+		result1 db.SavedAPIToken
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeAPITokenContextReader) GetAPITokenReturnsOnCall(i int, result1 db.SavedAPIToken, result2 bool) {
+	fake.GetAPITokenStub = nil
+	if fake.getAPITokenReturnsOnCall == nil {
+		fake.getAPITokenReturnsOnCall = make(map[int]struct {
+			result1 db.SavedAPIToken
+			result2 bool
+		})
+	}
+	fake.getAPITokenReturnsOnCall[i] = struct {
+		result1 db.SavedAPIToken
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeAPITokenContextReader) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getAPITokenMutex.RLock()
+	defer fake.getAPITokenMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeAPITokenContextReader) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ auth.APITokenContextReader = new(FakeAPITokenContextReader)
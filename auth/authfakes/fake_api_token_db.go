@@ -0,0 +1,166 @@
+// This file was generated by counterfeiter
+package authfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+type FakeAPITokenDB struct {
+	FindAPITokenStub        func(tokenHash string) (db.SavedAPIToken, bool, error)
+	findAPITokenMutex       sync.RWMutex
+	findAPITokenArgsForCall []struct {
+		tokenHash string
+	}
+	findAPITokenReturns struct {
+		result1 db.SavedAPIToken
+		result2 bool
+		result3 error
+	}
+	findAPITokenReturnsOnCall map[int]struct {
+		result1 db.SavedAPIToken
+		result2 bool
+		result3 error
+	}
+	UpdateAPITokenLastUsedStub        func(id int) error
+	updateAPITokenLastUsedMutex       sync.RWMutex
+	updateAPITokenLastUsedArgsForCall []struct {
+		id int
+	}
+	updateAPITokenLastUsedReturns struct {
+		result1 error
+	}
+	updateAPITokenLastUsedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAPITokenDB) FindAPIToken(tokenHash string) (db.SavedAPIToken, bool, error) {
+	fake.findAPITokenMutex.Lock()
+	ret, specificReturn := fake.findAPITokenReturnsOnCall[len(fake.findAPITokenArgsForCall)]
+	fake.findAPITokenArgsForCall = append(fake.findAPITokenArgsForCall, struct {
+		tokenHash string
+	}{tokenHash})
+	fake.recordInvocation("FindAPIToken", []interface{}{tokenHash})
+	fake.findAPITokenMutex.Unlock()
+	if fake.FindAPITokenStub != nil {
+		return fake.FindAPITokenStub(tokenHash)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.findAPITokenReturns.result1, fake.findAPITokenReturns.result2, fake.findAPITokenReturns.result3
+}
+
+func (fake *FakeAPITokenDB) FindAPITokenCallCount() int {
+	fake.findAPITokenMutex.RLock()
+	defer fake.findAPITokenMutex.RUnlock()
+	return len(fake.findAPITokenArgsForCall)
+}
+
+func (fake *FakeAPITokenDB) FindAPITokenArgsForCall(i int) string {
+	fake.findAPITokenMutex.RLock()
+	defer fake.findAPITokenMutex.RUnlock()
+	return fake.findAPITokenArgsForCall[i].tokenHash
+}
+
+func (fake *FakeAPITokenDB) FindAPITokenReturns(result1 db.SavedAPIToken, result2 bool, result3 error) {
+	fake.FindAPITokenStub = nil
+	fake.findAPITokenReturns = struct {
+		result1 db.SavedAPIToken
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeAPITokenDB) FindAPITokenReturnsOnCall(i int, result1 db.SavedAPIToken, result2 bool, result3 error) {
+	fake.FindAPITokenStub = nil
+	if fake.findAPITokenReturnsOnCall == nil {
+		fake.findAPITokenReturnsOnCall = make(map[int]struct {
+			result1 db.SavedAPIToken
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findAPITokenReturnsOnCall[i] = struct {
+		result1 db.SavedAPIToken
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeAPITokenDB) UpdateAPITokenLastUsed(id int) error {
+	fake.updateAPITokenLastUsedMutex.Lock()
+	ret, specificReturn := fake.updateAPITokenLastUsedReturnsOnCall[len(fake.updateAPITokenLastUsedArgsForCall)]
+	fake.updateAPITokenLastUsedArgsForCall = append(fake.updateAPITokenLastUsedArgsForCall, struct {
+		id int
+	}{id})
+	fake.recordInvocation("UpdateAPITokenLastUsed", []interface{}{id})
+	fake.updateAPITokenLastUsedMutex.Unlock()
+	if fake.UpdateAPITokenLastUsedStub != nil {
+		return fake.UpdateAPITokenLastUsedStub(id)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateAPITokenLastUsedReturns.result1
+}
+
+func (fake *FakeAPITokenDB) UpdateAPITokenLastUsedCallCount() int {
+	fake.updateAPITokenLastUsedMutex.RLock()
+	defer fake.updateAPITokenLastUsedMutex.RUnlock()
+	return len(fake.updateAPITokenLastUsedArgsForCall)
+}
+
+func (fake *FakeAPITokenDB) UpdateAPITokenLastUsedArgsForCall(i int) int {
+	fake.updateAPITokenLastUsedMutex.RLock()
+	defer fake.updateAPITokenLastUsedMutex.RUnlock()
+	return fake.updateAPITokenLastUsedArgsForCall[i].id
+}
+
+func (fake *FakeAPITokenDB) UpdateAPITokenLastUsedReturns(result1 error) {
+	fake.UpdateAPITokenLastUsedStub = nil
+	fake.updateAPITokenLastUsedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPITokenDB) UpdateAPITokenLastUsedReturnsOnCall(i int, result1 error) {
+	fake.UpdateAPITokenLastUsedStub = nil
+	if fake.updateAPITokenLastUsedReturnsOnCall == nil {
+		fake.updateAPITokenLastUsedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateAPITokenLastUsedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPITokenDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.findAPITokenMutex.RLock()
+	defer fake.findAPITokenMutex.RUnlock()
+	fake.updateAPITokenLastUsedMutex.RLock()
+	defer fake.updateAPITokenLastUsedMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeAPITokenDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ auth.APITokenDB = new(FakeAPITokenDB)
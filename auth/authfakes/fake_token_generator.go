@@ -5,16 +5,18 @@ import (
 	"sync"
 	"time"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 )
 
 type FakeTokenGenerator struct {
-	GenerateTokenStub        func(expiration time.Time, teamName string, isAdmin bool) (auth.TokenType, auth.TokenValue, error)
+	GenerateTokenStub        func(expiration time.Time, teamName string, isAdmin bool, role atc.TeamRole) (auth.TokenType, auth.TokenValue, error)
 	generateTokenMutex       sync.RWMutex
 	generateTokenArgsForCall []struct {
 		expiration time.Time
 		teamName   string
 		isAdmin    bool
+		role       atc.TeamRole
 	}
 	generateTokenReturns struct {
 		result1 auth.TokenType
@@ -30,18 +32,19 @@ type FakeTokenGenerator struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeTokenGenerator) GenerateToken(expiration time.Time, teamName string, isAdmin bool) (auth.TokenType, auth.TokenValue, error) {
+func (fake *FakeTokenGenerator) GenerateToken(expiration time.Time, teamName string, isAdmin bool, role atc.TeamRole) (auth.TokenType, auth.TokenValue, error) {
 	fake.generateTokenMutex.Lock()
 	ret, specificReturn := fake.generateTokenReturnsOnCall[len(fake.generateTokenArgsForCall)]
 	fake.generateTokenArgsForCall = append(fake.generateTokenArgsForCall, struct {
 		expiration time.Time
 		teamName   string
 		isAdmin    bool
-	}{expiration, teamName, isAdmin})
-	fake.recordInvocation("GenerateToken", []interface{}{expiration, teamName, isAdmin})
+		role       atc.TeamRole
+	}{expiration, teamName, isAdmin, role})
+	fake.recordInvocation("GenerateToken", []interface{}{expiration, teamName, isAdmin, role})
 	fake.generateTokenMutex.Unlock()
 	if fake.GenerateTokenStub != nil {
-		return fake.GenerateTokenStub(expiration, teamName, isAdmin)
+		return fake.GenerateTokenStub(expiration, teamName, isAdmin, role)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -55,10 +58,10 @@ func (fake *FakeTokenGenerator) GenerateTokenCallCount() int {
 	return len(fake.generateTokenArgsForCall)
 }
 
-func (fake *FakeTokenGenerator) GenerateTokenArgsForCall(i int) (time.Time, string, bool) {
+func (fake *FakeTokenGenerator) GenerateTokenArgsForCall(i int) (time.Time, string, bool, atc.TeamRole) {
 	fake.generateTokenMutex.RLock()
 	defer fake.generateTokenMutex.RUnlock()
-	return fake.generateTokenArgsForCall[i].expiration, fake.generateTokenArgsForCall[i].teamName, fake.generateTokenArgsForCall[i].isAdmin
+	return fake.generateTokenArgsForCall[i].expiration, fake.generateTokenArgsForCall[i].teamName, fake.generateTokenArgsForCall[i].isAdmin, fake.generateTokenArgsForCall[i].role
 }
 
 func (fake *FakeTokenGenerator) GenerateTokenReturns(result1 auth.TokenType, result2 auth.TokenValue, result3 error) {
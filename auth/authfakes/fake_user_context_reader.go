@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"sync"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 )
 
@@ -37,6 +38,19 @@ type FakeUserContextReader struct {
 		result1 bool
 		result2 bool
 	}
+	GetRoleStub        func(r *http.Request) (atc.TeamRole, bool)
+	getRoleMutex       sync.RWMutex
+	getRoleArgsForCall []struct {
+		r *http.Request
+	}
+	getRoleReturns struct {
+		result1 atc.TeamRole
+		result2 bool
+	}
+	getRoleReturnsOnCall map[int]struct {
+		result1 atc.TeamRole
+		result2 bool
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -146,6 +160,57 @@ func (fake *FakeUserContextReader) GetSystemReturnsOnCall(i int, result1 bool, r
 	}{result1, result2}
 }
 
+func (fake *FakeUserContextReader) GetRole(r *http.Request) (atc.TeamRole, bool) {
+	fake.getRoleMutex.Lock()
+	ret, specificReturn := fake.getRoleReturnsOnCall[len(fake.getRoleArgsForCall)]
+	fake.getRoleArgsForCall = append(fake.getRoleArgsForCall, struct {
+		r *http.Request
+	}{r})
+	fake.recordInvocation("GetRole", []interface{}{r})
+	fake.getRoleMutex.Unlock()
+	if fake.GetRoleStub != nil {
+		return fake.GetRoleStub(r)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getRoleReturns.result1, fake.getRoleReturns.result2
+}
+
+func (fake *FakeUserContextReader) GetRoleCallCount() int {
+	fake.getRoleMutex.RLock()
+	defer fake.getRoleMutex.RUnlock()
+	return len(fake.getRoleArgsForCall)
+}
+
+func (fake *FakeUserContextReader) GetRoleArgsForCall(i int) *http.Request {
+	fake.getRoleMutex.RLock()
+	defer fake.getRoleMutex.RUnlock()
+	return fake.getRoleArgsForCall[i].r
+}
+
+func (fake *FakeUserContextReader) GetRoleReturns(result1 atc.TeamRole, result2 bool) {
+	fake.GetRoleStub = nil
+	fake.getRoleReturns = struct {
+		result1 atc.TeamRole
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeUserContextReader) GetRoleReturnsOnCall(i int, result1 atc.TeamRole, result2 bool) {
+	fake.GetRoleStub = nil
+	if fake.getRoleReturnsOnCall == nil {
+		fake.getRoleReturnsOnCall = make(map[int]struct {
+			result1 atc.TeamRole
+			result2 bool
+		})
+	}
+	fake.getRoleReturnsOnCall[i] = struct {
+		result1 atc.TeamRole
+		result2 bool
+	}{result1, result2}
+}
+
 func (fake *FakeUserContextReader) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -153,6 +218,8 @@ func (fake *FakeUserContextReader) Invocations() map[string][][]interface{} {
 	defer fake.getTeamMutex.RUnlock()
 	fake.getSystemMutex.RLock()
 	defer fake.getSystemMutex.RUnlock()
+	fake.getRoleMutex.RLock()
+	defer fake.getRoleMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -8,6 +8,10 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// BasicAuthProviderName is the name under which a team's role for users
+// logging in with basic auth is mapped.
+const BasicAuthProviderName = "basic"
+
 type basicAuthValidator struct {
 	team db.SavedTeam
 }
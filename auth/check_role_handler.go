@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+)
+
+type checkRoleHandler struct {
+	handler  http.Handler
+	role     atc.TeamRole
+	rejector Rejector
+}
+
+// CheckRoleHandler rejects requests from users whose role within their team
+// does not permit the given role. Requests without a team, e.g. those which
+// are unauthenticated or come from the system, are left to the handler.
+func CheckRoleHandler(
+	handler http.Handler,
+	role atc.TeamRole,
+	rejector Rejector,
+) http.Handler {
+	return checkRoleHandler{
+		handler:  handler,
+		role:     role,
+		rejector: rejector,
+	}
+}
+
+func (h checkRoleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	authTeam, found := GetTeam(r)
+	if found && !authTeam.Role().Permits(h.role) {
+		h.rejector.Forbidden(w, r)
+		return
+	}
+
+	h.handler.ServeHTTP(w, r)
+}
@@ -0,0 +1,128 @@
+package auth_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckRoleHandler", func() {
+	var (
+		fakeValidator         *authfakes.FakeValidator
+		fakeUserContextReader *authfakes.FakeUserContextReader
+		fakeRejector          *authfakes.FakeRejector
+
+		server *httptest.Server
+		client *http.Client
+	)
+
+	simpleHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buffer := bytes.NewBufferString("simple ")
+
+		io.Copy(w, buffer)
+		io.Copy(w, r.Body)
+	})
+
+	BeforeEach(func() {
+		fakeValidator = new(authfakes.FakeValidator)
+		fakeUserContextReader = new(authfakes.FakeUserContextReader)
+		fakeRejector = new(authfakes.FakeRejector)
+
+		fakeRejector.ForbiddenStub = func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "still nope", http.StatusForbidden)
+		}
+
+		server = httptest.NewServer(auth.WrapHandler(
+			auth.CheckRoleHandler(
+				simpleHandler,
+				atc.TeamRolePipelineOperator,
+				fakeRejector,
+			),
+			fakeValidator,
+			fakeUserContextReader,
+		))
+
+		client = &http.Client{
+			Transport: &http.Transport{},
+		}
+	})
+
+	Context("when a request is made", func() {
+		var request *http.Request
+		var response *http.Response
+
+		BeforeEach(func() {
+			var err error
+
+			request, err = http.NewRequest("GET", server.URL, bytes.NewBufferString("hello"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the request has a team", func() {
+			BeforeEach(func() {
+				fakeValidator.IsAuthenticatedReturns(true)
+				fakeUserContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			Context("when the role permits the required role", func() {
+				BeforeEach(func() {
+					fakeUserContextReader.GetRoleReturns(atc.TeamRoleMember, true)
+				})
+
+				It("proxies to the handler", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					responseBody, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(responseBody)).To(Equal("simple hello"))
+				})
+			})
+
+			Context("when the role does not permit the required role", func() {
+				BeforeEach(func() {
+					fakeUserContextReader.GetRoleReturns(atc.TeamRoleViewer, true)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when the token has no role", func() {
+				BeforeEach(func() {
+					fakeUserContextReader.GetRoleReturns("", false)
+				})
+
+				It("treats the user as an owner", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+		})
+
+		Context("when the request has no team", func() {
+			BeforeEach(func() {
+				fakeUserContextReader.GetTeamReturns("", false, false)
+			})
+
+			It("proxies to the handler", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(fakeRejector.ForbiddenCallCount()).To(BeZero())
+			})
+		})
+	})
+})
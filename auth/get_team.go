@@ -1,16 +1,22 @@
 package auth
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+)
 
 type Team interface {
 	Name() string
 	IsAdmin() bool
 	IsAuthorized(teamName string) bool
+	Role() atc.TeamRole
 }
 
 type team struct {
 	name    string
 	isAdmin bool
+	role    atc.TeamRole
 }
 
 func (t *team) Name() string {
@@ -25,6 +31,10 @@ func (t *team) IsAuthorized(teamName string) bool {
 	return t.name == teamName
 }
 
+func (t *team) Role() atc.TeamRole {
+	return t.role
+}
+
 func GetTeam(r *http.Request) (Team, bool) {
 	teamName, namePresent := r.Context().Value(teamNameKey).(string)
 	isAdmin, adminPresent := r.Context().Value(isAdminKey).(bool)
@@ -33,8 +43,16 @@ func GetTeam(r *http.Request) (Team, bool) {
 		return nil, false
 	}
 
+	// tokens issued before roles were introduced carry no role, and were
+	// only ever issued to owners
+	role, rolePresent := r.Context().Value(roleKey).(atc.TeamRole)
+	if !rolePresent {
+		role = atc.TeamRoleOwner
+	}
+
 	return &team{
 		name:    teamName,
 		isAdmin: isAdmin,
+		role:    role,
 	}, true
 }
@@ -4,6 +4,7 @@ import (
 	"crypto/rsa"
 	"net/http"
 
+	"github.com/concourse/atc"
 	jwt "github.com/dgrijalva/jwt-go"
 )
 
@@ -45,3 +46,23 @@ func (jr JWTReader) GetSystem(r *http.Request) (bool, bool) {
 
 	return isSystemInterface.(bool), true
 }
+
+func (jr JWTReader) GetRole(r *http.Request) (atc.TeamRole, bool) {
+	token, err := getJWT(r, jr.PublicKey)
+	if err != nil {
+		return "", false
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	roleInterface, roleOK := claims[roleClaimKey]
+	if !roleOK {
+		return "", false
+	}
+
+	role, ok := roleInterface.(string)
+	if !ok {
+		return "", false
+	}
+
+	return atc.TeamRole(role), true
+}
@@ -0,0 +1,244 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"gopkg.in/ldap.v2"
+)
+
+const ProviderName = "ldap"
+const DisplayName = "LDAP"
+
+const defaultGroupNameAttribute = "cn"
+
+var ErrInvalidCACert = errors.New("ldap ca cert is not valid PEM")
+var ErrInvalidSearchFilter = errors.New("ldap search filter must contain exactly one %s")
+
+// ValidateSearchFilter returns ErrInvalidSearchFilter unless the filter has
+// exactly one %s, for the username or user DN to be substituted for, and no
+// other formatting verbs.
+func ValidateSearchFilter(filter string) error {
+	if strings.Count(filter, "%") != 1 || !strings.Contains(filter, "%s") {
+		return ErrInvalidSearchFilter
+	}
+
+	return nil
+}
+
+type Provider interface {
+	Verifier
+}
+
+// Verifier checks a username and password against the directory. It returns
+// false with no error if the credentials are wrong or the user is not
+// allowed in.
+type Verifier interface {
+	Verify(logger lager.Logger, username string, password string) (bool, error)
+}
+
+func NewProvider(ldapAuth *db.LDAPAuth) Provider {
+	return ldapProvider{
+		ldapAuth: ldapAuth,
+	}
+}
+
+type ldapProvider struct {
+	ldapAuth *db.LDAPAuth
+}
+
+func (provider ldapProvider) Verify(logger lager.Logger, username string, password string) (bool, error) {
+	logger = logger.Session("ldap-verify", lager.Data{"username": username})
+
+	// an empty password would be an unauthenticated bind, which most servers
+	// allow regardless of the user
+	if username == "" || password == "" {
+		return false, nil
+	}
+
+	conn, err := provider.dial()
+	if err != nil {
+		logger.Error("failed-to-connect", err)
+		return false, err
+	}
+
+	defer conn.Close()
+
+	err = provider.bindServiceAccount(conn)
+	if err != nil {
+		logger.Error("failed-to-bind-service-account", err)
+		return false, err
+	}
+
+	userDN, found, err := provider.findUser(conn, username)
+	if err != nil {
+		logger.Error("failed-to-search-for-user", err)
+		return false, err
+	}
+
+	if !found {
+		logger.Info("user-not-found")
+		return false, nil
+	}
+
+	err = conn.Bind(userDN, password)
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			logger.Info("invalid-credentials")
+			return false, nil
+		}
+
+		logger.Error("failed-to-bind-user", err)
+		return false, err
+	}
+
+	if len(provider.ldapAuth.Groups) == 0 {
+		return true, nil
+	}
+
+	// rebind, as the user may not be permitted to search groups
+	err = provider.bindServiceAccount(conn)
+	if err != nil {
+		logger.Error("failed-to-bind-service-account", err)
+		return false, err
+	}
+
+	groups, err := provider.findGroups(conn, userDN)
+	if err != nil {
+		logger.Error("failed-to-search-for-groups", err)
+		return false, err
+	}
+
+	for _, group := range provider.ldapAuth.Groups {
+		for _, userGroup := range groups {
+			if userGroup == group {
+				return true, nil
+			}
+		}
+	}
+
+	logger.Info("not-in-groups", lager.Data{
+		"have": groups,
+		"want": provider.ldapAuth.Groups,
+	})
+
+	return false, nil
+}
+
+func (provider ldapProvider) dial() (*ldap.Conn, error) {
+	tlsConfig, err := provider.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if provider.ldapAuth.UseTLS {
+		return ldap.DialTLS("tcp", provider.ldapAuth.Host, tlsConfig)
+	}
+
+	conn, err := ldap.Dial("tcp", provider.ldapAuth.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	if provider.ldapAuth.StartTLS {
+		err = conn.StartTLS(tlsConfig)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+func (provider ldapProvider) tlsConfig() (*tls.Config, error) {
+	serverName, _, err := net.SplitHostPort(provider.ldapAuth.Host)
+	if err != nil {
+		serverName = provider.ldapAuth.Host
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: provider.ldapAuth.InsecureSkipVerify,
+	}
+
+	if provider.ldapAuth.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(provider.ldapAuth.CACert)) {
+			return nil, ErrInvalidCACert
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+func (provider ldapProvider) bindServiceAccount(conn *ldap.Conn) error {
+	if provider.ldapAuth.BindDN == "" {
+		return nil
+	}
+
+	return conn.Bind(provider.ldapAuth.BindDN, provider.ldapAuth.BindPassword)
+}
+
+func (provider ldapProvider) findUser(conn *ldap.Conn, username string) (string, bool, error) {
+	err := ValidateSearchFilter(provider.ldapAuth.UserSearchFilter)
+	if err != nil {
+		return "", false, err
+	}
+
+	result, err := conn.Search(ldap.NewSearchRequest(
+		provider.ldapAuth.UserSearchBaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf(provider.ldapAuth.UserSearchFilter, ldap.EscapeFilter(username)),
+		[]string{"dn"},
+		nil,
+	))
+	if err != nil {
+		return "", false, err
+	}
+
+	// refuse to guess which of several matching users is meant
+	if len(result.Entries) != 1 {
+		return "", false, nil
+	}
+
+	return result.Entries[0].DN, true, nil
+}
+
+func (provider ldapProvider) findGroups(conn *ldap.Conn, userDN string) ([]string, error) {
+	err := ValidateSearchFilter(provider.ldapAuth.GroupSearchFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	nameAttribute := provider.ldapAuth.GroupNameAttribute
+	if nameAttribute == "" {
+		nameAttribute = defaultGroupNameAttribute
+	}
+
+	result, err := conn.Search(ldap.NewSearchRequest(
+		provider.ldapAuth.GroupSearchBaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf(provider.ldapAuth.GroupSearchFilter, ldap.EscapeFilter(userDN)),
+		[]string{nameAttribute},
+		nil,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []string{}
+	for _, entry := range result.Entries {
+		groups = append(groups, entry.GetAttributeValues(nameAttribute)...)
+	}
+
+	return groups, nil
+}
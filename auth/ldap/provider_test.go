@@ -0,0 +1,287 @@
+package ldap_test
+
+import (
+	"net"
+	"sync"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/auth/ldap"
+	"github.com/concourse/atc/db"
+	ldapserver "github.com/nmcclain/ldap"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// directory is an in-process stand-in for an LDAP server. Users and groups are
+// found by exact match on the search filter.
+type directory struct {
+	passwords map[string]string
+	entries   map[string][]*ldapserver.Entry
+
+	searchesL sync.Mutex
+	binds     []string
+	searches  []string
+}
+
+func (d *directory) Bind(bindDN string, password string, conn net.Conn) (ldapserver.LDAPResultCode, error) {
+	d.searchesL.Lock()
+	d.binds = append(d.binds, bindDN)
+	d.searchesL.Unlock()
+
+	if expected, found := d.passwords[bindDN]; found && expected == password {
+		return ldapserver.LDAPResultSuccess, nil
+	}
+
+	return ldapserver.LDAPResultInvalidCredentials, nil
+}
+
+func (d *directory) Search(boundDN string, req ldapserver.SearchRequest, conn net.Conn) (ldapserver.ServerSearchResult, error) {
+	d.searchesL.Lock()
+	d.searches = append(d.searches, boundDN+" "+req.BaseDN+" "+req.Filter)
+	d.searchesL.Unlock()
+
+	return ldapserver.ServerSearchResult{
+		Entries:    d.entries[req.Filter],
+		ResultCode: ldapserver.LDAPResultSuccess,
+	}, nil
+}
+
+func (d *directory) Searches() []string {
+	d.searchesL.Lock()
+	defer d.searchesL.Unlock()
+	return append([]string{}, d.searches...)
+}
+
+func (d *directory) Binds() []string {
+	d.searchesL.Lock()
+	defer d.searchesL.Unlock()
+	return append([]string{}, d.binds...)
+}
+
+var _ = Describe("LDAP Provider", func() {
+	const (
+		serviceDN = "cn=concourse,dc=example,dc=com"
+		aliceDN   = "uid=alice,ou=people,dc=example,dc=com"
+	)
+
+	var (
+		listener net.Listener
+		dir      *directory
+
+		ldapAuth *db.LDAPAuth
+
+		username string
+		password string
+
+		verified  bool
+		verifyErr error
+	)
+
+	BeforeEach(func() {
+		dir = &directory{
+			passwords: map[string]string{
+				serviceDN: "service-password",
+				aliceDN:   "alice-password",
+			},
+			entries: map[string][]*ldapserver.Entry{
+				"(uid=alice)": {
+					{DN: aliceDN},
+				},
+				"(member=" + aliceDN + ")": {
+					{
+						DN: "cn=devs,ou=groups,dc=example,dc=com",
+						Attributes: []*ldapserver.EntryAttribute{
+							{Name: "cn", Values: []string{"devs"}},
+						},
+					},
+				},
+			},
+		}
+
+		server := ldapserver.NewServer()
+		server.BindFunc("", dir)
+		server.SearchFunc("", dir)
+
+		var err error
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+
+		go server.Serve(listener)
+
+		ldapAuth = &db.LDAPAuth{
+			Host:             listener.Addr().String(),
+			BindDN:           serviceDN,
+			BindPassword:     "service-password",
+			UserSearchBaseDN: "ou=people,dc=example,dc=com",
+			UserSearchFilter: "(uid=%s)",
+		}
+
+		username = "alice"
+		password = "alice-password"
+	})
+
+	AfterEach(func() {
+		listener.Close()
+	})
+
+	JustBeforeEach(func() {
+		verified, verifyErr = ldap.NewProvider(ldapAuth).Verify(lagertest.NewTestLogger("test"), username, password)
+	})
+
+	It("verifies the user", func() {
+		Expect(verifyErr).NotTo(HaveOccurred())
+		Expect(verified).To(BeTrue())
+	})
+
+	It("searches for the user as the service account, then binds as the user", func() {
+		Expect(dir.Searches()).To(Equal([]string{
+			serviceDN + " ou=people,dc=example,dc=com (uid=alice)",
+		}))
+
+		Expect(dir.Binds()).To(Equal([]string{serviceDN, aliceDN}))
+	})
+
+	Context("when the password is wrong", func() {
+		BeforeEach(func() {
+			password = "nope"
+		})
+
+		It("does not verify the user", func() {
+			Expect(verifyErr).NotTo(HaveOccurred())
+			Expect(verified).To(BeFalse())
+		})
+	})
+
+	Context("when the password is empty", func() {
+		BeforeEach(func() {
+			password = ""
+		})
+
+		It("does not verify the user without binding", func() {
+			Expect(verifyErr).NotTo(HaveOccurred())
+			Expect(verified).To(BeFalse())
+			Expect(dir.Binds()).To(BeEmpty())
+		})
+	})
+
+	Context("when the user cannot be found", func() {
+		BeforeEach(func() {
+			username = "bob"
+		})
+
+		It("does not verify the user", func() {
+			Expect(verifyErr).NotTo(HaveOccurred())
+			Expect(verified).To(BeFalse())
+		})
+	})
+
+	Context("when the username contains filter syntax", func() {
+		BeforeEach(func() {
+			username = "*"
+		})
+
+		It("escapes it", func() {
+			Expect(verified).To(BeFalse())
+			Expect(dir.Searches()).To(Equal([]string{
+				serviceDN + ` ou=people,dc=example,dc=com (uid=\2a)`,
+			}))
+		})
+	})
+
+	Context("when the user search filter is invalid", func() {
+		BeforeEach(func() {
+			ldapAuth.UserSearchFilter = "(uid=alice)"
+		})
+
+		It("returns an error without searching", func() {
+			Expect(verifyErr).To(Equal(ldap.ErrInvalidSearchFilter))
+			Expect(verified).To(BeFalse())
+			Expect(dir.Searches()).To(BeEmpty())
+		})
+	})
+
+	Context("when the service account credentials are wrong", func() {
+		BeforeEach(func() {
+			ldapAuth.BindPassword = "nope"
+		})
+
+		It("returns an error", func() {
+			Expect(verifyErr).To(HaveOccurred())
+			Expect(verified).To(BeFalse())
+		})
+	})
+
+	Context("when groups are configured", func() {
+		BeforeEach(func() {
+			ldapAuth.GroupSearchBaseDN = "ou=groups,dc=example,dc=com"
+			ldapAuth.GroupSearchFilter = "(member=%s)"
+		})
+
+		Context("when the user is in one of the groups", func() {
+			BeforeEach(func() {
+				ldapAuth.Groups = []string{"ops", "devs"}
+			})
+
+			It("verifies the user", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+				Expect(verified).To(BeTrue())
+			})
+
+			It("searches for groups as the service account", func() {
+				Expect(dir.Searches()).To(ContainElement(
+					serviceDN + " ou=groups,dc=example,dc=com (member=" + aliceDN + ")",
+				))
+			})
+		})
+
+		Context("when the user is in none of the groups", func() {
+			BeforeEach(func() {
+				ldapAuth.Groups = []string{"ops"}
+			})
+
+			It("does not verify the user", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+				Expect(verified).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when the server cannot be reached", func() {
+		BeforeEach(func() {
+			listener.Close()
+		})
+
+		It("returns an error", func() {
+			Expect(verifyErr).To(HaveOccurred())
+			Expect(verified).To(BeFalse())
+		})
+	})
+
+	Context("when the CA cert is not valid", func() {
+		BeforeEach(func() {
+			ldapAuth.StartTLS = true
+			ldapAuth.CACert = "bogus"
+		})
+
+		It("returns an error", func() {
+			Expect(verifyErr).To(Equal(ldap.ErrInvalidCACert))
+		})
+	})
+})
+
+var _ = Describe("ValidateSearchFilter", func() {
+	It("accepts a filter with exactly one %s", func() {
+		Expect(ldap.ValidateSearchFilter("(uid=%s)")).To(Succeed())
+		Expect(ldap.ValidateSearchFilter("(&(objectClass=person)(uid=%s))")).To(Succeed())
+	})
+
+	It("rejects a filter with no %s", func() {
+		Expect(ldap.ValidateSearchFilter("(uid=*)")).To(Equal(ldap.ErrInvalidSearchFilter))
+	})
+
+	It("rejects a filter with several verbs", func() {
+		Expect(ldap.ValidateSearchFilter("(|(uid=%s)(mail=%s))")).To(Equal(ldap.ErrInvalidSearchFilter))
+		Expect(ldap.ValidateSearchFilter("(uid=%s%%)")).To(Equal(ldap.ErrInvalidSearchFilter))
+	})
+})
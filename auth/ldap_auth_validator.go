@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/auth/ldap"
+	"github.com/concourse/atc/db"
+)
+
+type ldapAuthValidator struct {
+	logger   lager.Logger
+	provider ldap.Provider
+}
+
+func NewLDAPAuthValidator(logger lager.Logger, team db.SavedTeam) Validator {
+	return ldapAuthValidator{
+		logger:   logger,
+		provider: ldap.NewProvider(team.LDAPAuth),
+	}
+}
+
+func (v ldapAuthValidator) IsAuthenticated(r *http.Request) bool {
+	auth := r.Header.Get("Authorization")
+	username, password, err := extractUsernameAndPassword(auth)
+	if err != nil {
+		return false
+	}
+
+	verified, err := v.provider.Verify(v.logger, username, password)
+	if err != nil {
+		return false
+	}
+
+	return verified
+}
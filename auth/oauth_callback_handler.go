@@ -161,7 +161,7 @@ func (handler *OAuthCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 
 	exp := time.Now().Add(handler.expire)
 
-	tokenType, signedToken, err := handler.tokenGenerator.GenerateToken(exp, team.Name, team.Admin)
+	tokenType, signedToken, err := handler.tokenGenerator.GenerateToken(exp, team.Name, team.Admin, team.RoleFor(providerName))
 	if err != nil {
 		hLog.Error("failed-to-sign-token", err)
 		http.Error(w, "failed to sign token", http.StatusInternalServerError)
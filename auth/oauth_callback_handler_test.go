@@ -24,6 +24,7 @@ import (
 
 	"regexp"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/authfakes"
 	"github.com/concourse/atc/auth/provider"
@@ -95,6 +96,9 @@ var _ = Describe("OAuthCallbackHandler", func() {
 		team = db.SavedTeam{
 			Team: db.Team{
 				Name: "some-team",
+				Roles: map[string]atc.TeamRole{
+					"some-provider": atc.TeamRolePipelineOperator,
+				},
 			},
 		}
 
@@ -279,6 +283,14 @@ var _ = Describe("OAuthCallbackHandler", func() {
 								Expect(claims["teamName"]).To(Equal(team.Name))
 								Expect(token.Valid).To(BeTrue())
 							})
+
+							It("contains the role mapped for the provider", func() {
+								token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+								Expect(err).ToNot(HaveOccurred())
+
+								claims := token.Claims.(jwt.MapClaims)
+								Expect(claims["role"]).To(Equal("pipeline-operator"))
+							})
 						})
 
 						It("does not redirect", func() {
@@ -3,19 +3,23 @@ package auth
 import (
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/db"
 )
 
 type teamAuthValidator struct {
+	logger        lager.Logger
 	teamDBFactory db.TeamDBFactory
 	jwtValidator  Validator
 }
 
 func NewTeamAuthValidator(
+	logger lager.Logger,
 	teamDBFactory db.TeamDBFactory,
 	jwtValidator Validator,
 ) Validator {
 	return &teamAuthValidator{
+		logger:        logger,
 		teamDBFactory: teamDBFactory,
 		jwtValidator:  jwtValidator,
 	}
@@ -37,5 +41,9 @@ func (v teamAuthValidator) IsAuthenticated(r *http.Request) bool {
 		return true
 	}
 
+	if team.LDAPAuth != nil && NewLDAPAuthValidator(v.logger, team).IsAuthenticated(r) {
+		return true
+	}
+
 	return v.jwtValidator.IsAuthenticated(r)
 }
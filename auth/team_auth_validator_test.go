@@ -3,6 +3,7 @@ package auth_test
 import (
 	"net/http"
 
+	"code.cloudfoundry.org/lager/lagertest"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/concourse/atc"
@@ -48,7 +49,7 @@ var _ = Describe("TeamAuthValidator", func() {
 		teamDB = new(dbfakes.FakeTeamDB)
 		teamDBFactory.GetTeamDBReturns(teamDB)
 
-		validator = auth.NewTeamAuthValidator(teamDBFactory, jwtValidator)
+		validator = auth.NewTeamAuthValidator(lagertest.NewTestLogger("test"), teamDBFactory, jwtValidator)
 
 		request, err = http.NewRequest("GET", "http://example.com", nil)
 		Expect(err).ToNot(HaveOccurred())
@@ -134,6 +135,36 @@ var _ = Describe("TeamAuthValidator", func() {
 			})
 		})
 
+		Context("when team has ldap auth configured", func() {
+			BeforeEach(func() {
+				team.LDAPAuth = &db.LDAPAuth{
+					Host:             "127.0.0.1:1",
+					UserSearchBaseDN: "dc=example,dc=com",
+					UserSearchFilter: "(uid=%s)",
+				}
+				teamDB.GetTeamReturns(team, true, nil)
+
+				request.Header.Set("Authorization", "Basic "+b64(username+":"+password))
+			})
+
+			Context("when the ldap server cannot verify the credentials", func() {
+				It("delegates to jwtValidator", func() {
+					Expect(jwtValidator.IsAuthenticatedCallCount()).To(Equal(1))
+					Expect(jwtValidator.IsAuthenticatedArgsForCall(0)).To(Equal(request))
+				})
+
+				Context("when jwtValidator returns false", func() {
+					BeforeEach(func() {
+						jwtValidator.IsAuthenticatedReturns(false)
+					})
+
+					It("returns false", func() {
+						Expect(isAuthenticated).To(BeFalse())
+					})
+				})
+			})
+		})
+
 		Context("when team has github auth configured", func() {
 			BeforeEach(func() {
 				team.GitHubAuth = &db.GitHubAuth{
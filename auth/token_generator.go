@@ -4,6 +4,7 @@ import (
 	"crypto/rsa"
 	"time"
 
+	"github.com/concourse/atc"
 	"github.com/dgrijalva/jwt-go"
 )
 
@@ -16,9 +17,10 @@ const TokenTypeBearer = "Bearer"
 const expClaimKey = "exp"
 const teamNameClaimKey = "teamName"
 const isAdminClaimKey = "isAdmin"
+const roleClaimKey = "role"
 
 type TokenGenerator interface {
-	GenerateToken(expiration time.Time, teamName string, isAdmin bool) (TokenType, TokenValue, error)
+	GenerateToken(expiration time.Time, teamName string, isAdmin bool, role atc.TeamRole) (TokenType, TokenValue, error)
 }
 
 type tokenGenerator struct {
@@ -31,11 +33,12 @@ func NewTokenGenerator(privateKey *rsa.PrivateKey) TokenGenerator {
 	}
 }
 
-func (generator *tokenGenerator) GenerateToken(expiration time.Time, teamName string, isAdmin bool) (TokenType, TokenValue, error) {
+func (generator *tokenGenerator) GenerateToken(expiration time.Time, teamName string, isAdmin bool, role atc.TeamRole) (TokenType, TokenValue, error) {
 	jwtToken := jwt.NewWithClaims(SigningMethod, jwt.MapClaims{
 		expClaimKey:      expiration.Unix(),
 		teamNameClaimKey: teamName,
 		isAdminClaimKey:  isAdmin,
+		roleClaimKey:     string(role),
 	})
 
 	signed, err := jwtToken.SignedString(generator.privateKey)
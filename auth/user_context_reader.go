@@ -1,10 +1,15 @@
 package auth
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+)
 
 //go:generate counterfeiter . UserContextReader
 
 type UserContextReader interface {
 	GetTeam(r *http.Request) (string, bool, bool)
 	GetSystem(r *http.Request) (bool, bool)
+	GetRole(r *http.Request) (atc.TeamRole, bool)
 }
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+)
+
+type userContextReaderBasket []UserContextReader
+
+// NewUserContextReaderBasket constructs a UserContextReader which returns
+// whatever the first of the given readers to find each value returns.
+func NewUserContextReaderBasket(readers ...UserContextReader) UserContextReader {
+	return userContextReaderBasket(readers)
+}
+
+func (readers userContextReaderBasket) GetTeam(r *http.Request) (string, bool, bool) {
+	for _, reader := range readers {
+		teamName, isAdmin, found := reader.GetTeam(r)
+		if found {
+			return teamName, isAdmin, true
+		}
+	}
+
+	return "", false, false
+}
+
+func (readers userContextReaderBasket) GetSystem(r *http.Request) (bool, bool) {
+	for _, reader := range readers {
+		isSystem, found := reader.GetSystem(r)
+		if found {
+			return isSystem, true
+		}
+	}
+
+	return false, false
+}
+
+func (readers userContextReaderBasket) GetRole(r *http.Request) (atc.TeamRole, bool) {
+	for _, reader := range readers {
+		role, found := reader.GetRole(r)
+		if found {
+			return role, true
+		}
+	}
+
+	return "", false
+}
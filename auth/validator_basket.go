@@ -0,0 +1,21 @@
+package auth
+
+import "net/http"
+
+type validatorBasket []Validator
+
+// NewValidatorBasket constructs a Validator which accepts a request if any of
+// the given validators do.
+func NewValidatorBasket(validators ...Validator) Validator {
+	return validatorBasket(validators)
+}
+
+func (validators validatorBasket) IsAuthenticated(r *http.Request) bool {
+	for _, validator := range validators {
+		if validator.IsAuthenticated(r) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,77 @@
+package auth_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Baskets", func() {
+	var request *http.Request
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("GET", "http://example.com", nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("ValidatorBasket", func() {
+		var (
+			validatorA *authfakes.FakeValidator
+			validatorB *authfakes.FakeValidator
+		)
+
+		BeforeEach(func() {
+			validatorA = new(authfakes.FakeValidator)
+			validatorB = new(authfakes.FakeValidator)
+		})
+
+		It("returns true if any validator does", func() {
+			validatorB.IsAuthenticatedReturns(true)
+			Expect(auth.NewValidatorBasket(validatorA, validatorB).IsAuthenticated(request)).To(BeTrue())
+		})
+
+		It("returns false if no validator does", func() {
+			Expect(auth.NewValidatorBasket(validatorA, validatorB).IsAuthenticated(request)).To(BeFalse())
+		})
+	})
+
+	Describe("UserContextReaderBasket", func() {
+		var (
+			readerA *authfakes.FakeUserContextReader
+			readerB *authfakes.FakeUserContextReader
+			reader  auth.UserContextReader
+		)
+
+		BeforeEach(func() {
+			readerA = new(authfakes.FakeUserContextReader)
+			readerB = new(authfakes.FakeUserContextReader)
+			reader = auth.NewUserContextReaderBasket(readerA, readerB)
+		})
+
+		It("returns the values from the first reader to find them", func() {
+			readerA.GetRoleReturns(atc.TeamRoleViewer, true)
+			readerB.GetRoleReturns(atc.TeamRoleOwner, true)
+			readerB.GetTeamReturns("some-team", true, true)
+
+			role, found := reader.GetRole(request)
+			Expect(found).To(BeTrue())
+			Expect(role).To(Equal(atc.TeamRoleViewer))
+
+			teamName, isAdmin, found := reader.GetTeam(request)
+			Expect(found).To(BeTrue())
+			Expect(teamName).To(Equal("some-team"))
+			Expect(isAdmin).To(BeTrue())
+		})
+
+		It("finds nothing if no reader does", func() {
+			_, found := reader.GetSystem(request)
+			Expect(found).To(BeFalse())
+		})
+	})
+})
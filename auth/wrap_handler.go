@@ -9,6 +9,7 @@ var authenticated = "authenticated"
 var teamNameKey = "teamName"
 var isAdminKey = "isAdmin"
 var isSystemKey = "system"
+var roleKey = "role"
 
 func WrapHandler(
 	handler http.Handler,
@@ -36,6 +37,11 @@ func (h authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		ctx = context.WithValue(ctx, isAdminKey, isAdmin)
 	}
 
+	role, found := h.userContextReader.GetRole(r)
+	if found {
+		ctx = context.WithValue(ctx, roleKey, role)
+	}
+
 	isSystem, found := h.userContextReader.GetSystem(r)
 	if found {
 		ctx = context.WithValue(ctx, isSystemKey, isSystem)
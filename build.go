@@ -23,6 +23,19 @@ type Build struct {
 	StartTime    int64  `json:"start_time,omitempty"`
 	EndTime      int64  `json:"end_time,omitempty"`
 	ReapTime     int64  `json:"reap_time,omitempty"`
+
+	// RerunOf is set if the build was created to rerun another build of the
+	// job with the same inputs.
+	RerunOf *RerunOfBuild `json:"rerun_of,omitempty"`
+
+	// Breakpoint is the ID of the breakpoint plan at which the build is paused,
+	// if any.
+	Breakpoint string `json:"breakpoint,omitempty"`
+}
+
+type RerunOfBuild struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
 }
 
 func (b Build) IsRunning() bool {
@@ -57,7 +70,9 @@ type BuildPreparation struct {
 	PausedPipeline      BuildPreparationStatus            `json:"paused_pipeline"`
 	PausedJob           BuildPreparationStatus            `json:"paused_job"`
 	MaxRunningBuilds    BuildPreparationStatus            `json:"max_running_builds"`
+	TriggerWindow       BuildPreparationStatus            `json:"trigger_window"`
 	Inputs              map[string]BuildPreparationStatus `json:"inputs"`
 	InputsSatisfied     BuildPreparationStatus            `json:"inputs_satisfied"`
 	MissingInputReasons MissingInputReasons               `json:"missing_input_reasons"`
+	InputsExplanation   *InputsExplanation                `json:"inputs_explanation,omitempty"`
 }
@@ -53,10 +53,11 @@ func (groups GroupConfigs) Lookup(name string) (GroupConfig, bool) {
 type ResourceConfig struct {
 	Name string `yaml:"name" json:"name" mapstructure:"name"`
 
-	Type       string `yaml:"type" json:"type" mapstructure:"type"`
-	Source     Source `yaml:"source" json:"source" mapstructure:"source"`
-	CheckEvery string `yaml:"check_every,omitempty" json:"check_every" mapstructure:"check_every"`
-	Tags       Tags   `yaml:"tags,omitempty" json:"tags" mapstructure:"tags"`
+	Type         string `yaml:"type" json:"type" mapstructure:"type"`
+	Source       Source `yaml:"source" json:"source" mapstructure:"source"`
+	CheckEvery   string `yaml:"check_every,omitempty" json:"check_every" mapstructure:"check_every"`
+	Tags         Tags   `yaml:"tags,omitempty" json:"tags" mapstructure:"tags"`
+	WebhookToken string `yaml:"webhook_token,omitempty" json:"webhook_token" mapstructure:"webhook_token"`
 }
 
 type ResourceType struct {
@@ -123,6 +124,11 @@ type JobConfig struct {
 	SerialGroups         []string `yaml:"serial_groups,omitempty" json:"serial_groups,omitempty" mapstructure:"serial_groups"`
 	RawMaxInFlight       int      `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
 	BuildLogsToRetain    int      `yaml:"build_logs_to_retain,omitempty" json:"build_logs_to_retain,omitempty" mapstructure:"build_logs_to_retain"`
+	Priority             int      `yaml:"priority,omitempty" json:"priority,omitempty" mapstructure:"priority"`
+
+	BuildLogRetention *BuildLogRetention `yaml:"build_log_retention,omitempty" json:"build_log_retention,omitempty" mapstructure:"build_log_retention"`
+
+	TriggerWindow *TriggerWindowConfig `yaml:"trigger_window,omitempty" json:"trigger_window,omitempty" mapstructure:"trigger_window"`
 
 	Plan PlanSequence `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
 
@@ -131,6 +137,16 @@ type JobConfig struct {
 	Success *PlanConfig `yaml:"on_success,omitempty" json:"on_success,omitempty" mapstructure:"on_success"`
 }
 
+// BuildLogRetention determines which of a job's build logs are reaped. A
+// build's logs are reaped once they fall outside either the most recent Builds
+// builds or the last Days days, unless they are needed to retain the most
+// recent MinimumSucceededBuilds succeeded builds. Zero values are unlimited.
+type BuildLogRetention struct {
+	Builds                 int `yaml:"builds,omitempty" json:"builds,omitempty" mapstructure:"builds"`
+	Days                   int `yaml:"days,omitempty" json:"days,omitempty" mapstructure:"days"`
+	MinimumSucceededBuilds int `yaml:"minimum_succeeded_builds,omitempty" json:"minimum_succeeded_builds,omitempty" mapstructure:"minimum_succeeded_builds"`
+}
+
 func (config JobConfig) Hooks() Hooks {
 	return Hooks{config.Failure, config.Ensure, config.Success}
 }
@@ -348,7 +364,7 @@ type PlanConfig struct {
 	// name of 'input', e.g. bosh-stemcell
 	Get string `yaml:"get,omitempty" json:"get,omitempty" mapstructure:"get"`
 	// jobs that this resource must have made it through
-	Passed []string `yaml:"passed,omitempty" json:"passed,omitempty" mapstructure:"passed"`
+	Passed PassedConfigs `yaml:"passed,omitempty" json:"passed,omitempty" mapstructure:"passed"`
 	// whether to trigger based on this resource changing
 	Trigger bool `yaml:"trigger,omitempty" json:"trigger,omitempty" mapstructure:"trigger"`
 
@@ -368,6 +384,10 @@ type PlanConfig struct {
 	// inlined task config
 	TaskConfig *TaskConfig `yaml:"config,omitempty" json:"config,omitempty" mapstructure:"config"`
 
+	// corresponds to a SetPipeline plan
+	// name of the pipeline to configure; the config is read from 'file'
+	SetPipeline string `yaml:"set_pipeline,omitempty" json:"set_pipeline,omitempty" mapstructure:"set_pipeline"`
+
 	// used by Get and Put for specifying params to the resource
 	Params Params `yaml:"params,omitempty" json:"params,omitempty" mapstructure:"params"`
 
@@ -399,15 +419,55 @@ type PlanConfig struct {
 	// used on any step to interrupt the step after a given duration
 	Timeout string `yaml:"timeout,omitempty" json:"timeout,omitempty" mapstructure:"timeout"`
 
+	// used on any step to pause the build before it runs or after it fails,
+	// until the build is resumed
+	Breakpoint string `yaml:"breakpoint,omitempty" json:"breakpoint,omitempty" mapstructure:"breakpoint"`
+
 	// not present in yaml
 	DependentGet string `yaml:"-" json:"-"`
 
 	// repeat the step up to N times, until it works
 	Attempts int `yaml:"attempts,omitempty" json:"attempts,omitempty" mapstructure:"attempts"`
 
+	// wait between attempts
+	RetryBackoff *RetryBackoffConfig `yaml:"retry_backoff,omitempty" json:"retry_backoff,omitempty" mapstructure:"retry_backoff"`
+
+	// only retry attempts that ended with one of these statuses
+	RetryOn []BuildStatus `yaml:"retry_on,omitempty" json:"retry_on,omitempty" mapstructure:"retry_on"`
+
+	// run the step once for each combination of the given vars' values
+	Across []AcrossVarConfig `yaml:"across,omitempty" json:"across,omitempty" mapstructure:"across"`
+
 	Version *VersionConfig `yaml:"version,omitempty" json:"version,omitempty" mapstructure:"version"`
 }
 
+const (
+	BreakpointBefore    = "before"
+	BreakpointOnFailure = "on_failure"
+)
+
+const (
+	RetryBackoffFixed       = "fixed"
+	RetryBackoffExponential = "exponential"
+)
+
+// A RetryBackoffConfig is how long to wait before each retry of a step. With
+// the exponential strategy the delay doubles after each attempt, up to
+// MaxDelay if set.
+type RetryBackoffConfig struct {
+	Strategy string `yaml:"strategy,omitempty" json:"strategy,omitempty" mapstructure:"strategy"`
+	Delay    string `yaml:"delay" json:"delay" mapstructure:"delay"`
+	MaxDelay string `yaml:"max_delay,omitempty" json:"max_delay,omitempty" mapstructure:"max_delay"`
+}
+
+// An AcrossVarConfig is a var whose values a step is run across. At most
+// MaxInFlight of the values are run at once; zero means all of them.
+type AcrossVarConfig struct {
+	Var         string        `yaml:"var" json:"var" mapstructure:"var"`
+	Values      []interface{} `yaml:"values" json:"values" mapstructure:"values"`
+	MaxInFlight int           `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
+}
+
 func (config PlanConfig) Name() string {
 	if config.RawName != "" {
 		return config.RawName
@@ -425,6 +485,10 @@ func (config PlanConfig) Name() string {
 		return config.Task
 	}
 
+	if config.SetPipeline != "" {
+		return config.SetPipeline
+	}
+
 	return ""
 }
 
@@ -8,7 +8,7 @@ import "github.com/concourse/atc"
 type JobInput struct {
 	Name     string
 	Resource string
-	Passed   []string
+	Passed   atc.PassedConfigs
 	Trigger  bool
 	Version  *atc.VersionConfig
 	Params   atc.Params
@@ -39,7 +39,7 @@ var _ = Describe("Job config", func() {
 					jobConfig.Plan = atc.PlanSequence{
 						{
 							Get:     "some-get-plan",
-							Passed:  []string{"a", "b"},
+							Passed:  atc.PassedConfigs{{Job: "a"}, {Job: "b"}},
 							Trigger: true,
 						},
 						{
@@ -53,7 +53,7 @@ var _ = Describe("Job config", func() {
 						{
 							Name:     "some-get-plan",
 							Resource: "some-get-plan",
-							Passed:   []string{"a", "b"},
+							Passed:   atc.PassedConfigs{{Job: "a"}, {Job: "b"}},
 							Trigger:  true,
 						},
 						{
@@ -286,7 +286,7 @@ var _ = Describe("Job config", func() {
 							Aggregate: &atc.PlanSequence{
 								{Get: "a"},
 								{Put: "y"},
-								{Get: "b", Resource: "some-resource", Passed: []string{"x"}},
+								{Get: "b", Resource: "some-resource", Passed: atc.PassedConfigs{{Job: "x"}}},
 								{Get: "c", Trigger: true},
 							},
 						},
@@ -303,7 +303,7 @@ var _ = Describe("Job config", func() {
 						{
 							Name:     "b",
 							Resource: "some-resource",
-							Passed:   []string{"x"},
+							Passed:   atc.PassedConfigs{{Job: "x"}},
 							Trigger:  false,
 						},
 						{
@@ -326,7 +326,7 @@ var _ = Describe("Job config", func() {
 										{Get: "a"},
 									},
 								},
-								{Get: "b", Resource: "some-resource", Passed: []string{"x"}},
+								{Get: "b", Resource: "some-resource", Passed: atc.PassedConfigs{{Job: "x"}}},
 								{Get: "c", Trigger: true},
 							},
 						},
@@ -343,7 +343,7 @@ var _ = Describe("Job config", func() {
 						{
 							Name:     "b",
 							Resource: "some-resource",
-							Passed:   []string{"x"},
+							Passed:   atc.PassedConfigs{{Job: "x"}},
 							Trigger:  false,
 						},
 						{
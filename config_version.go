@@ -0,0 +1,104 @@
+package atc
+
+import "reflect"
+
+// PipelineConfigVersion describes one of the configs a pipeline has been
+// saved with.
+type PipelineConfigVersion struct {
+	Version   int    `json:"version"`
+	Author    string `json:"author,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// ConfigDiff lists what changed between two pipeline configs, by name.
+type ConfigDiff struct {
+	Groups        ConfigChanges `json:"groups"`
+	Resources     ConfigChanges `json:"resources"`
+	ResourceTypes ConfigChanges `json:"resource_types"`
+	Jobs          ConfigChanges `json:"jobs"`
+}
+
+type ConfigChanges struct {
+	Added   []string `json:"added,omitempty"`
+	Changed []string `json:"changed,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// DiffConfigs compares two configs. Names are listed in the order they appear
+// in the config they are found in.
+func DiffConfigs(from Config, to Config) ConfigDiff {
+	var diff ConfigDiff
+
+	fromGroups, toGroups := namedConfigs{}, namedConfigs{}
+	for _, group := range from.Groups {
+		fromGroups.add(group.Name, group)
+	}
+	for _, group := range to.Groups {
+		toGroups.add(group.Name, group)
+	}
+	diff.Groups = diffNamedConfigs(fromGroups, toGroups)
+
+	fromResources, toResources := namedConfigs{}, namedConfigs{}
+	for _, resource := range from.Resources {
+		fromResources.add(resource.Name, resource)
+	}
+	for _, resource := range to.Resources {
+		toResources.add(resource.Name, resource)
+	}
+	diff.Resources = diffNamedConfigs(fromResources, toResources)
+
+	fromTypes, toTypes := namedConfigs{}, namedConfigs{}
+	for _, resourceType := range from.ResourceTypes {
+		fromTypes.add(resourceType.Name, resourceType)
+	}
+	for _, resourceType := range to.ResourceTypes {
+		toTypes.add(resourceType.Name, resourceType)
+	}
+	diff.ResourceTypes = diffNamedConfigs(fromTypes, toTypes)
+
+	fromJobs, toJobs := namedConfigs{}, namedConfigs{}
+	for _, job := range from.Jobs {
+		fromJobs.add(job.Name, job)
+	}
+	for _, job := range to.Jobs {
+		toJobs.add(job.Name, job)
+	}
+	diff.Jobs = diffNamedConfigs(fromJobs, toJobs)
+
+	return diff
+}
+
+type namedConfigs struct {
+	names   []string
+	configs map[string]interface{}
+}
+
+func (nc *namedConfigs) add(name string, config interface{}) {
+	if nc.configs == nil {
+		nc.configs = map[string]interface{}{}
+	}
+
+	nc.names = append(nc.names, name)
+	nc.configs[name] = config
+}
+
+func diffNamedConfigs(from namedConfigs, to namedConfigs) ConfigChanges {
+	var changes ConfigChanges
+
+	for _, name := range to.names {
+		fromConfig, found := from.configs[name]
+		if !found {
+			changes.Added = append(changes.Added, name)
+		} else if !reflect.DeepEqual(fromConfig, to.configs[name]) {
+			changes.Changed = append(changes.Changed, name)
+		}
+	}
+
+	for _, name := range from.names {
+		if _, found := to.configs[name]; !found {
+			changes.Removed = append(changes.Removed, name)
+		}
+	}
+
+	return changes
+}
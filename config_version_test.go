@@ -0,0 +1,70 @@
+package atc_test
+
+import (
+	. "github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DiffConfigs", func() {
+	var from Config
+	var to Config
+
+	BeforeEach(func() {
+		from = Config{
+			Groups: GroupConfigs{
+				{Name: "some-group", Jobs: []string{"some-job"}},
+			},
+			Resources: ResourceConfigs{
+				{Name: "some-resource", Type: "git"},
+				{Name: "removed-resource", Type: "git"},
+			},
+			ResourceTypes: ResourceTypes{
+				{Name: "some-type", Type: "docker-image"},
+			},
+			Jobs: JobConfigs{
+				{Name: "some-job", Public: false},
+				{Name: "unchanged-job"},
+			},
+		}
+
+		to = Config{
+			Groups: GroupConfigs{
+				{Name: "some-group", Jobs: []string{"some-job"}},
+				{Name: "added-group"},
+			},
+			Resources: ResourceConfigs{
+				{Name: "some-resource", Type: "git", Source: Source{"uri": "some-uri"}},
+			},
+			ResourceTypes: ResourceTypes{
+				{Name: "some-type", Type: "docker-image"},
+			},
+			Jobs: JobConfigs{
+				{Name: "added-job"},
+				{Name: "some-job", Public: true},
+				{Name: "unchanged-job"},
+			},
+		}
+	})
+
+	It("lists what was added, changed, and removed", func() {
+		Expect(DiffConfigs(from, to)).To(Equal(ConfigDiff{
+			Groups: ConfigChanges{
+				Added: []string{"added-group"},
+			},
+			Resources: ConfigChanges{
+				Changed: []string{"some-resource"},
+				Removed: []string{"removed-resource"},
+			},
+			Jobs: ConfigChanges{
+				Added:   []string{"added-job"},
+				Changed: []string{"some-job"},
+			},
+		}))
+	})
+
+	It("finds no changes between a config and itself", func() {
+		Expect(DiffConfigs(to, to)).To(Equal(ConfigDiff{}))
+	})
+})
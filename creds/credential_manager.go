@@ -0,0 +1,32 @@
+package creds
+
+import "fmt"
+
+//go:generate counterfeiter . CredentialManager
+
+// A CredentialManager looks up the values for ((var)) placeholders found in
+// pipeline and task configuration. Lookups are always scoped to the team and
+// pipeline which owns the configuration.
+type CredentialManager interface {
+	Get(teamName string, pipelineName string, varName string) (interface{}, bool, error)
+}
+
+type UndefinedVariableError struct {
+	Name string
+}
+
+func (err UndefinedVariableError) Error() string {
+	return fmt.Sprintf("undefined variable: %s", err.Name)
+}
+
+// lookupPaths returns the locations a variable is searched for, most specific
+// first, so that pipeline-scoped credentials shadow team-scoped ones.
+func lookupPaths(teamName string, pipelineName string, varName string) []string {
+	paths := []string{}
+
+	if pipelineName != "" {
+		paths = append(paths, teamName+"/"+pipelineName+"/"+varName)
+	}
+
+	return append(paths, teamName+"/"+varName)
+}
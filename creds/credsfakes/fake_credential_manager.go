@@ -0,0 +1,108 @@
+// This file was generated by counterfeiter
+package credsfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/creds"
+)
+
+type FakeCredentialManager struct {
+	GetStub        func(teamName string, pipelineName string, varName string) (interface{}, bool, error)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		teamName     string
+		pipelineName string
+		varName      string
+	}
+	getReturns struct {
+		result1 interface{}
+		result2 bool
+		result3 error
+	}
+	getReturnsOnCall map[int]struct {
+		result1 interface{}
+		result2 bool
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCredentialManager) Get(teamName string, pipelineName string, varName string) (interface{}, bool, error) {
+	fake.getMutex.Lock()
+	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		teamName     string
+		pipelineName string
+		varName      string
+	}{teamName, pipelineName, varName})
+	fake.recordInvocation("Get", []interface{}{teamName, pipelineName, varName})
+	fake.getMutex.Unlock()
+	if fake.GetStub != nil {
+		return fake.GetStub(teamName, pipelineName, varName)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getReturns.result1, fake.getReturns.result2, fake.getReturns.result3
+}
+
+func (fake *FakeCredentialManager) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *FakeCredentialManager) GetArgsForCall(i int) (string, string, string) {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return fake.getArgsForCall[i].teamName, fake.getArgsForCall[i].pipelineName, fake.getArgsForCall[i].varName
+}
+
+func (fake *FakeCredentialManager) GetReturns(result1 interface{}, result2 bool, result3 error) {
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 interface{}
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCredentialManager) GetReturnsOnCall(i int, result1 interface{}, result2 bool, result3 error) {
+	fake.GetStub = nil
+	if fake.getReturnsOnCall == nil {
+		fake.getReturnsOnCall = make(map[int]struct {
+			result1 interface{}
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getReturnsOnCall[i] = struct {
+		result1 interface{}
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCredentialManager) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeCredentialManager) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ creds.CredentialManager = new(FakeCredentialManager)
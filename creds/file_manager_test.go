@@ -0,0 +1,84 @@
+package creds_test
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/concourse/atc/creds"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FileManager", func() {
+	var (
+		credentialsFile *os.File
+		manager         creds.CredentialManager
+	)
+
+	BeforeEach(func() {
+		var err error
+		credentialsFile, err = ioutil.TempFile("", "credentials")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = credentialsFile.WriteString(`
+some-team/some-pipeline/password: pipeline-password
+some-team/password: team-password
+some-team/username: team-username
+some-team/key:
+  private: some-private-key
+other-team/secret: not-yours
+`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(credentialsFile.Close()).To(Succeed())
+
+		manager = creds.NewFileManager(credentialsFile.Name())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(credentialsFile.Name())).To(Succeed())
+	})
+
+	It("prefers pipeline-scoped credentials", func() {
+		val, found, err := manager.Get("some-team", "some-pipeline", "password")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(val).To(Equal("pipeline-password"))
+	})
+
+	It("falls back to team-scoped credentials", func() {
+		val, found, err := manager.Get("some-team", "some-pipeline", "username")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(val).To(Equal("team-username"))
+
+		val, found, err = manager.Get("some-team", "other-pipeline", "password")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(val).To(Equal("team-password"))
+	})
+
+	It("returns structured values with string keys", func() {
+		val, found, err := manager.Get("some-team", "some-pipeline", "key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(val).To(Equal(map[string]interface{}{"private": "some-private-key"}))
+	})
+
+	It("does not find credentials belonging to other teams", func() {
+		_, found, err := manager.Get("some-team", "some-pipeline", "secret")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	Context("when the file does not exist", func() {
+		BeforeEach(func() {
+			manager = creds.NewFileManager("/does/not/exist")
+		})
+
+		It("returns an error", func() {
+			_, _, err := manager.Get("some-team", "some-pipeline", "password")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,274 @@
+package creds
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/concourse/atc"
+)
+
+var (
+	wholeVarRegexp = regexp.MustCompile(`^\(\(([-/\.\w\pL]+)\)\)$`)
+	varRegexp      = regexp.MustCompile(`\(\(([-/\.\w\pL]+)\)\)`)
+)
+
+// Variables resolves ((var)) placeholders against a CredentialManager on
+// behalf of a single pipeline. A nil CredentialManager means no credential
+// manager is configured, in which case values are returned untouched.
+//
+// Values set with WithValues take precedence over the CredentialManager.
+type Variables struct {
+	manager      CredentialManager
+	teamName     string
+	pipelineName string
+	values       map[string]interface{}
+}
+
+func NewVariables(manager CredentialManager, teamName string, pipelineName string) Variables {
+	return Variables{
+		manager:      manager,
+		teamName:     teamName,
+		pipelineName: pipelineName,
+	}
+}
+
+// WithValues returns Variables which resolve the given values before
+// consulting the CredentialManager, e.g. for the vars of an `across` step.
+func (variables Variables) WithValues(values map[string]interface{}) Variables {
+	merged := map[string]interface{}{}
+	for name, value := range variables.values {
+		merged[name] = value
+	}
+
+	for name, value := range values {
+		merged[name] = value
+	}
+
+	variables.values = merged
+
+	return variables
+}
+
+func (variables Variables) EvaluateSource(source atc.Source) (atc.Source, error) {
+	if source == nil {
+		return nil, nil
+	}
+
+	evaluated, err := variables.evaluateMap(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return atc.Source(evaluated), nil
+}
+
+func (variables Variables) EvaluateParams(params atc.Params) (atc.Params, error) {
+	if params == nil {
+		return nil, nil
+	}
+
+	evaluated, err := variables.evaluateMap(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return atc.Params(evaluated), nil
+}
+
+func (variables Variables) EvaluateResourceTypes(resourceTypes atc.VersionedResourceTypes) (atc.VersionedResourceTypes, error) {
+	if resourceTypes == nil {
+		return nil, nil
+	}
+
+	evaluated := atc.VersionedResourceTypes{}
+	for _, resourceType := range resourceTypes {
+		source, err := variables.EvaluateSource(resourceType.Source)
+		if err != nil {
+			return nil, err
+		}
+
+		resourceType.Source = source
+		evaluated = append(evaluated, resourceType)
+	}
+
+	return evaluated, nil
+}
+
+func (variables Variables) EvaluateTaskConfig(config atc.TaskConfig) (atc.TaskConfig, error) {
+	if config.ImageResource != nil {
+		source, err := variables.EvaluateSource(config.ImageResource.Source)
+		if err != nil {
+			return atc.TaskConfig{}, err
+		}
+
+		config.ImageResource = &atc.ImageResource{
+			Type:   config.ImageResource.Type,
+			Source: source,
+		}
+	}
+
+	if config.Params != nil {
+		params := map[string]string{}
+		for name, value := range config.Params {
+			evaluated, err := variables.evaluateString(value)
+			if err != nil {
+				return atc.TaskConfig{}, err
+			}
+
+			str, ok := evaluated.(string)
+			if !ok {
+				str = fmt.Sprintf("%v", evaluated)
+			}
+
+			params[name] = str
+		}
+
+		config.Params = params
+	}
+
+	return config, nil
+}
+
+// Evaluate walks a value decoded from JSON or YAML and replaces every
+// placeholder with its value. A string consisting solely of a placeholder is
+// replaced by the raw value, which need not be a string.
+func (variables Variables) Evaluate(value interface{}) (interface{}, error) {
+	if variables.disabled() {
+		return value, nil
+	}
+
+	switch actual := value.(type) {
+	case string:
+		return variables.evaluateString(actual)
+
+	case map[string]interface{}:
+		return variables.evaluateMap(actual)
+
+	case map[interface{}]interface{}:
+		evaluated := map[interface{}]interface{}{}
+		for k, v := range actual {
+			ev, err := variables.Evaluate(v)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluated[k] = ev
+		}
+
+		return evaluated, nil
+
+	case []interface{}:
+		evaluated := make([]interface{}, len(actual))
+		for i, v := range actual {
+			ev, err := variables.Evaluate(v)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluated[i] = ev
+		}
+
+		return evaluated, nil
+
+	default:
+		return value, nil
+	}
+}
+
+func (variables Variables) evaluateMap(value map[string]interface{}) (map[string]interface{}, error) {
+	if variables.disabled() {
+		return value, nil
+	}
+
+	evaluated := map[string]interface{}{}
+	for k, v := range value {
+		ev, err := variables.Evaluate(v)
+		if err != nil {
+			return nil, err
+		}
+
+		evaluated[k] = ev
+	}
+
+	return evaluated, nil
+}
+
+func (variables Variables) evaluateString(value string) (interface{}, error) {
+	if variables.disabled() {
+		return value, nil
+	}
+
+	if match := wholeVarRegexp.FindStringSubmatch(value); match != nil {
+		val, found, err := variables.get(match[1])
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			return value, nil
+		}
+
+		return val, nil
+	}
+
+	var lookupErr error
+
+	evaluated := varRegexp.ReplaceAllStringFunc(value, func(placeholder string) string {
+		if lookupErr != nil {
+			return placeholder
+		}
+
+		name := varRegexp.FindStringSubmatch(placeholder)[1]
+
+		val, found, err := variables.get(name)
+		if err != nil {
+			lookupErr = err
+			return placeholder
+		}
+
+		if !found {
+			return placeholder
+		}
+
+		switch val.(type) {
+		case string, int, int64, float64, bool:
+			return fmt.Sprintf("%v", val)
+		default:
+			lookupErr = fmt.Errorf("variable '%s' cannot be interpolated into a string", name)
+			return placeholder
+		}
+	})
+	if lookupErr != nil {
+		return nil, lookupErr
+	}
+
+	return evaluated, nil
+}
+
+func (variables Variables) disabled() bool {
+	return variables.manager == nil && len(variables.values) == 0
+}
+
+// get returns false without an error only when the variable is not one of
+// the values and no CredentialManager is configured, in which case the
+// placeholder is left untouched.
+func (variables Variables) get(name string) (interface{}, bool, error) {
+	if val, found := variables.values[name]; found {
+		return val, true, nil
+	}
+
+	if variables.manager == nil {
+		return nil, false, nil
+	}
+
+	val, found, err := variables.manager.Get(variables.teamName, variables.pipelineName, name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, UndefinedVariableError{Name: name}
+	}
+
+	return val, true, nil
+}
@@ -0,0 +1,187 @@
+package creds_test
+
+import (
+	"errors"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Variables", func() {
+	var (
+		fakeCredentialManager *credsfakes.FakeCredentialManager
+		variables             creds.Variables
+	)
+
+	BeforeEach(func() {
+		fakeCredentialManager = new(credsfakes.FakeCredentialManager)
+		fakeCredentialManager.GetStub = func(teamName string, pipelineName string, varName string) (interface{}, bool, error) {
+			switch varName {
+			case "password":
+				return "hunter2", true, nil
+			case "port":
+				return 8080, true, nil
+			case "private-key":
+				return map[string]interface{}{"key": "some-key"}, true, nil
+			}
+
+			return nil, false, nil
+		}
+
+		variables = creds.NewVariables(fakeCredentialManager, "some-team", "some-pipeline")
+	})
+
+	Describe("EvaluateSource", func() {
+		It("replaces placeholders with their values", func() {
+			source, err := variables.EvaluateSource(atc.Source{
+				"password": "((password))",
+				"uri":      "http://example.com:((port))/path",
+				"keys":     []interface{}{"((private-key))"},
+				"plain":    "some-value",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(source).To(Equal(atc.Source{
+				"password": "hunter2",
+				"uri":      "http://example.com:8080/path",
+				"keys":     []interface{}{map[string]interface{}{"key": "some-key"}},
+				"plain":    "some-value",
+			}))
+		})
+
+		It("scopes lookups to the team and pipeline", func() {
+			_, err := variables.EvaluateSource(atc.Source{"password": "((password))"})
+			Expect(err).NotTo(HaveOccurred())
+
+			teamName, pipelineName, varName := fakeCredentialManager.GetArgsForCall(0)
+			Expect(teamName).To(Equal("some-team"))
+			Expect(pipelineName).To(Equal("some-pipeline"))
+			Expect(varName).To(Equal("password"))
+		})
+
+		It("does not modify the original source", func() {
+			source := atc.Source{"password": "((password))"}
+
+			_, err := variables.EvaluateSource(source)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(source).To(Equal(atc.Source{"password": "((password))"}))
+		})
+
+		Context("when a variable is not defined", func() {
+			It("returns an error", func() {
+				_, err := variables.EvaluateSource(atc.Source{"password": "((bogus))"})
+				Expect(err).To(Equal(creds.UndefinedVariableError{Name: "bogus"}))
+			})
+		})
+
+		Context("when a non-scalar variable is interpolated into a string", func() {
+			It("returns an error", func() {
+				_, err := variables.EvaluateSource(atc.Source{"key": "key: ((private-key))"})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("when the credential manager fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeCredentialManager.GetStub = nil
+				fakeCredentialManager.GetReturns(nil, false, disaster)
+			})
+
+			It("returns the error", func() {
+				_, err := variables.EvaluateSource(atc.Source{"password": "((password))"})
+				Expect(err).To(Equal(disaster))
+			})
+		})
+
+		Context("when no credential manager is configured", func() {
+			BeforeEach(func() {
+				variables = creds.NewVariables(nil, "some-team", "some-pipeline")
+			})
+
+			It("leaves placeholders untouched", func() {
+				source, err := variables.EvaluateSource(atc.Source{"password": "((password))"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(source).To(Equal(atc.Source{"password": "((password))"}))
+			})
+
+			Context("when values are given", func() {
+				BeforeEach(func() {
+					variables = variables.WithValues(map[string]interface{}{"go": "1.8"})
+				})
+
+				It("replaces their placeholders and leaves the rest untouched", func() {
+					source, err := variables.EvaluateSource(atc.Source{
+						"version":  "((go))",
+						"tag":      "golang-((go))",
+						"password": "((password))",
+					})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(source).To(Equal(atc.Source{
+						"version":  "1.8",
+						"tag":      "golang-1.8",
+						"password": "((password))",
+					}))
+				})
+			})
+		})
+
+		Context("when values are given", func() {
+			BeforeEach(func() {
+				variables = variables.WithValues(map[string]interface{}{
+					"password": "from-values",
+					"go":       "1.8",
+				})
+			})
+
+			It("prefers them over the credential manager", func() {
+				source, err := variables.EvaluateSource(atc.Source{
+					"password": "((password))",
+					"port":     "((port))",
+					"version":  "go((go))",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(source).To(Equal(atc.Source{
+					"password": "from-values",
+					"port":     8080,
+					"version":  "go1.8",
+				}))
+			})
+		})
+	})
+
+	Describe("EvaluateTaskConfig", func() {
+		It("evaluates params and the image resource source", func() {
+			config, err := variables.EvaluateTaskConfig(atc.TaskConfig{
+				Platform: "linux",
+				ImageResource: &atc.ImageResource{
+					Type:   "docker-image",
+					Source: atc.Source{"password": "((password))"},
+				},
+				Params: map[string]string{
+					"PASSWORD": "((password))",
+					"PORT":     "((port))",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(config).To(Equal(atc.TaskConfig{
+				Platform: "linux",
+				ImageResource: &atc.ImageResource{
+					Type:   "docker-image",
+					Source: atc.Source{"password": "hunter2"},
+				},
+				Params: map[string]string{
+					"PASSWORD": "hunter2",
+					"PORT":     "8080",
+				},
+			}))
+		})
+	})
+})
@@ -0,0 +1,95 @@
+package creds
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const VaultTokenHeader = "X-Vault-Token"
+
+// VaultManager looks up credentials through Vault's HTTP API (or anything
+// speaking the same protocol). A variable is read from
+// <prefix>/<team>/<pipeline>/<var>, falling back to <prefix>/<team>/<var>. If
+// the secret has a single 'value' field, that field is the variable's value;
+// otherwise the whole secret is.
+type VaultManager struct {
+	URL        string
+	Token      string
+	PathPrefix string
+
+	HTTPClient *http.Client
+}
+
+type vaultSecret struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+func NewVaultManager(url string, token string, pathPrefix string, httpClient *http.Client) CredentialManager {
+	return VaultManager{
+		URL:        strings.TrimRight(url, "/"),
+		Token:      token,
+		PathPrefix: strings.Trim(pathPrefix, "/"),
+
+		HTTPClient: httpClient,
+	}
+}
+
+func (manager VaultManager) Get(teamName string, pipelineName string, varName string) (interface{}, bool, error) {
+	for _, path := range lookupPaths(teamName, pipelineName, varName) {
+		secret, found, err := manager.read(path)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if !found {
+			continue
+		}
+
+		if val, found := secret.Data["value"]; found && len(secret.Data) == 1 {
+			return val, true, nil
+		}
+
+		return secret.Data, true, nil
+	}
+
+	return nil, false, nil
+}
+
+func (manager VaultManager) read(path string) (vaultSecret, bool, error) {
+	secretURL := manager.URL + "/v1/" + path
+	if manager.PathPrefix != "" {
+		secretURL = manager.URL + "/v1/" + manager.PathPrefix + "/" + path
+	}
+
+	request, err := http.NewRequest("GET", secretURL, nil)
+	if err != nil {
+		return vaultSecret{}, false, err
+	}
+
+	request.Header.Set(VaultTokenHeader, manager.Token)
+
+	response, err := manager.HTTPClient.Do(request)
+	if err != nil {
+		return vaultSecret{}, false, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return vaultSecret{}, false, nil
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return vaultSecret{}, false, fmt.Errorf("unexpected response from credential manager: %s", response.Status)
+	}
+
+	var secret vaultSecret
+	err = json.NewDecoder(response.Body).Decode(&secret)
+	if err != nil {
+		return vaultSecret{}, false, err
+	}
+
+	return secret, true, nil
+}
@@ -0,0 +1,103 @@
+package creds_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc/creds"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VaultManager", func() {
+	var (
+		vaultServer *ghttp.Server
+		manager     creds.CredentialManager
+
+		val   interface{}
+		found bool
+		err   error
+	)
+
+	BeforeEach(func() {
+		vaultServer = ghttp.NewServer()
+		manager = creds.NewVaultManager(vaultServer.URL(), "some-token", "/concourse", http.DefaultClient)
+	})
+
+	AfterEach(func() {
+		vaultServer.Close()
+	})
+
+	JustBeforeEach(func() {
+		val, found, err = manager.Get("some-team", "some-pipeline", "password")
+	})
+
+	Context("when the secret exists for the pipeline", func() {
+		BeforeEach(func() {
+			vaultServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/concourse/some-team/some-pipeline/password"),
+					ghttp.VerifyHeaderKV(creds.VaultTokenHeader, "some-token"),
+					ghttp.RespondWith(http.StatusOK, `{"data":{"value":"hunter2"}}`),
+				),
+			)
+		})
+
+		It("returns the secret's value", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("hunter2"))
+		})
+	})
+
+	Context("when the secret only exists for the team", func() {
+		BeforeEach(func() {
+			vaultServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/concourse/some-team/some-pipeline/password"),
+					ghttp.RespondWith(http.StatusNotFound, `{"errors":[]}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/concourse/some-team/password"),
+					ghttp.RespondWith(http.StatusOK, `{"data":{"username":"admin","password":"hunter2"}}`),
+				),
+			)
+		})
+
+		It("returns the whole secret", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal(map[string]interface{}{
+				"username": "admin",
+				"password": "hunter2",
+			}))
+		})
+	})
+
+	Context("when the secret does not exist", func() {
+		BeforeEach(func() {
+			vaultServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusNotFound, `{"errors":[]}`),
+				ghttp.RespondWith(http.StatusNotFound, `{"errors":[]}`),
+			)
+		})
+
+		It("is not found", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when the server errors", func() {
+		BeforeEach(func() {
+			vaultServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusForbidden, `{"errors":["permission denied"]}`),
+			)
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -9,6 +9,33 @@ type VersionsDB struct {
 	JobIDs           map[string]int
 	ResourceIDs      map[string]int
 	CachedAt         time.Time
+
+	// DisabledVersions are never candidates for inputs, and are only loaded
+	// to explain why they were skipped.
+	DisabledVersions []ResourceVersion `json:",omitempty"`
+
+	// PinnedVersionIDs maps resource IDs to the version they have been pinned
+	// to through the API.
+	PinnedVersionIDs map[int]int `json:",omitempty"`
+
+	// OtherPipelineJobIDs maps the names of other pipelines to the IDs of
+	// their jobs which are referenced by passed constraints. The outputs of
+	// these jobs are included in BuildOutputs.
+	OtherPipelineJobIDs map[string]map[string]int `json:",omitempty"`
+
+	// OtherPipelinePassedErrors explains why the outputs of jobs of other
+	// pipelines referenced by passed constraints could not be included in
+	// BuildOutputs.
+	OtherPipelinePassedErrors []OtherPipelinePassedError `json:",omitempty"`
+}
+
+// OtherPipelinePassedError is a passed constraint on a job of another
+// pipeline whose outputs could not be used for the local resource.
+type OtherPipelinePassedError struct {
+	Resource string
+	Pipeline string
+	Job      string
+	Message  string
 }
 
 type ResourceVersion struct {
@@ -55,6 +82,17 @@ func (db VersionsDB) AllVersionsOfResource(resourceID int) VersionCandidates {
 	return candidates
 }
 
+func (db VersionsDB) DisabledVersionsOfResource(resourceID int) []int {
+	versionIDs := []int{}
+	for _, v := range db.DisabledVersions {
+		if v.ResourceID == resourceID {
+			versionIDs = append(versionIDs, v.VersionID)
+		}
+	}
+
+	return versionIDs
+}
+
 func (db VersionsDB) LatestVersionOfResource(resourceID int) (VersionCandidate, bool) {
 	var candidate VersionCandidate
 	var found bool
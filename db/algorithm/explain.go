@@ -0,0 +1,167 @@
+package algorithm
+
+import "sort"
+
+// ResolutionTrace explains the outcome of resolving a set of input configs,
+// so that a user can see why a job has no next inputs.
+type ResolutionTrace struct {
+	Resolved bool
+
+	Inputs []InputTrace
+
+	// Conflicts lists the jobs through which several inputs must have passed
+	// together, but for which no single build produced a candidate version of
+	// each of them.
+	Conflicts []JobConflict
+}
+
+type InputTrace struct {
+	Input           string
+	ResourceID      int
+	PinnedVersionID int
+
+	// Passed explains each of the input's passed constraints in turn.
+	Passed []PassedTrace
+
+	// DisabledVersionIDs are the versions of the resource which were skipped
+	// as they are disabled.
+	DisabledVersionIDs []int
+
+	// CandidateVersionIDs are the versions which satisfy the input's own
+	// constraints, newest first. The input can not be satisfied if there are
+	// none.
+	CandidateVersionIDs []int
+}
+
+type PassedTrace struct {
+	JobID int
+
+	// EliminatedVersionIDs are the versions which satisfied the previous
+	// constraints, but were not output by a succeeded build of the job.
+	EliminatedVersionIDs []int
+}
+
+type JobConflict struct {
+	JobID  int
+	Inputs []string
+}
+
+// Explain traces the resolution of the input configs. It is much slower than
+// Resolve, and only intended for showing to users.
+func (configs InputConfigs) Explain(db *VersionsDB) ResolutionTrace {
+	trace := ResolutionTrace{
+		Inputs: []InputTrace{},
+	}
+
+	jobs := JobSet{}
+	inputCandidates := InputCandidates{}
+	satisfiable := true
+
+	for _, inputConfig := range configs {
+		versionCandidates := inputConfig.versionCandidates(db)
+
+		inputTrace := InputTrace{
+			Input:               inputConfig.Name,
+			ResourceID:          inputConfig.ResourceID,
+			PinnedVersionID:     inputConfig.PinnedVersionID,
+			Passed:              explainPassed(db, inputConfig),
+			DisabledVersionIDs:  db.DisabledVersionsOfResource(inputConfig.ResourceID),
+			CandidateVersionIDs: []int{},
+		}
+
+		versionIDs := versionCandidates.VersionIDs()
+		for {
+			id, ok := versionIDs.Next()
+			if !ok {
+				break
+			}
+
+			if inputConfig.PinnedVersionID != 0 && id != inputConfig.PinnedVersionID {
+				continue
+			}
+
+			inputTrace.CandidateVersionIDs = append(inputTrace.CandidateVersionIDs, id)
+		}
+
+		if len(inputTrace.CandidateVersionIDs) == 0 {
+			satisfiable = false
+		}
+
+		trace.Inputs = append(trace.Inputs, inputTrace)
+
+		jobs = jobs.Union(inputConfig.Passed)
+		inputCandidates = append(inputCandidates, InputVersionCandidates{
+			Input:             inputConfig.Name,
+			VersionCandidates: versionCandidates,
+		})
+	}
+
+	if !satisfiable {
+		return trace
+	}
+
+	_, trace.Resolved = configs.Resolve(db)
+	if trace.Resolved {
+		return trace
+	}
+
+	for _, jobID := range jobs.sorted() {
+		inputs := []string{}
+		for _, candidates := range inputCandidates {
+			if len(candidates.BuildIDs(jobID)) != 0 {
+				inputs = append(inputs, candidates.Input)
+			}
+		}
+
+		if len(inputs) < 2 {
+			continue
+		}
+
+		if len(inputCandidates.commonBuildIDs(jobID)) == 0 {
+			trace.Conflicts = append(trace.Conflicts, JobConflict{
+				JobID:  jobID,
+				Inputs: inputs,
+			})
+		}
+	}
+
+	return trace
+}
+
+func explainPassed(db *VersionsDB, inputConfig InputConfig) []PassedTrace {
+	passedTraces := []PassedTrace{}
+
+	remaining := db.AllVersionsOfResource(inputConfig.ResourceID)
+
+	for _, jobID := range inputConfig.Passed.sorted() {
+		passed := db.VersionsOfResourcePassedJobs(inputConfig.ResourceID, JobSet{jobID: struct{}{}})
+
+		passedTrace := PassedTrace{
+			JobID:                jobID,
+			EliminatedVersionIDs: []int{},
+		}
+
+		for _, version := range remaining.versions {
+			if passed.ForVersion(version.id).IsEmpty() {
+				passedTrace.EliminatedVersionIDs = append(passedTrace.EliminatedVersionIDs, version.id)
+			}
+		}
+
+		passedTraces = append(passedTraces, passedTrace)
+
+		remaining = remaining.IntersectByVersion(passed)
+	}
+
+	return passedTraces
+}
+
+func (set JobSet) sorted() []int {
+	ids := []int{}
+	for id := range set {
+		ids = append(ids, id)
+	}
+
+	sort.Ints(ids)
+
+	return ids
+}
@@ -0,0 +1,150 @@
+package algorithm_test
+
+import (
+	"github.com/concourse/atc/db/algorithm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Explain", func() {
+	var (
+		versionsDB *algorithm.VersionsDB
+		configs    algorithm.InputConfigs
+		trace      algorithm.ResolutionTrace
+	)
+
+	BeforeEach(func() {
+		// resource 11 has versions 1-3 and resource 12 has versions 4-5; job 2
+		// and job 3 are upstream of job 1
+		versionsDB = &algorithm.VersionsDB{
+			ResourceVersions: []algorithm.ResourceVersion{
+				{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+				{VersionID: 2, ResourceID: 11, CheckOrder: 2},
+				{VersionID: 3, ResourceID: 11, CheckOrder: 3},
+				{VersionID: 4, ResourceID: 12, CheckOrder: 1},
+				{VersionID: 5, ResourceID: 12, CheckOrder: 2},
+			},
+			BuildOutputs: []algorithm.BuildOutput{
+				{ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 11, CheckOrder: 1}, BuildID: 100, JobID: 2},
+				{ResourceVersion: algorithm.ResourceVersion{VersionID: 2, ResourceID: 11, CheckOrder: 2}, BuildID: 101, JobID: 2},
+				{ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 11, CheckOrder: 1}, BuildID: 200, JobID: 3},
+				{ResourceVersion: algorithm.ResourceVersion{VersionID: 5, ResourceID: 12, CheckOrder: 2}, BuildID: 102, JobID: 2},
+			},
+			DisabledVersions: []algorithm.ResourceVersion{
+				{VersionID: 6, ResourceID: 11, CheckOrder: 4},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		trace = configs.Explain(versionsDB)
+	})
+
+	Context("when the inputs resolve", func() {
+		BeforeEach(func() {
+			configs = algorithm.InputConfigs{
+				{Name: "a", ResourceID: 11, JobID: 1},
+			}
+		})
+
+		It("is resolved, with the candidates of each input", func() {
+			Expect(trace.Resolved).To(BeTrue())
+			Expect(trace.Inputs).To(HaveLen(1))
+			Expect(trace.Inputs[0].Input).To(Equal("a"))
+			Expect(trace.Inputs[0].CandidateVersionIDs).To(Equal([]int{3}))
+		})
+
+		It("reports the skipped disabled versions", func() {
+			Expect(trace.Inputs[0].DisabledVersionIDs).To(Equal([]int{6}))
+		})
+	})
+
+	Context("when passed constraints eliminate versions", func() {
+		BeforeEach(func() {
+			configs = algorithm.InputConfigs{
+				{
+					Name:       "a",
+					ResourceID: 11,
+					JobID:      1,
+					Passed:     algorithm.JobSet{2: struct{}{}, 3: struct{}{}},
+				},
+			}
+		})
+
+		It("reports which versions each job eliminated", func() {
+			Expect(trace.Inputs[0].Passed).To(Equal([]algorithm.PassedTrace{
+				{JobID: 2, EliminatedVersionIDs: []int{3}},
+				{JobID: 3, EliminatedVersionIDs: []int{2}},
+			}))
+
+			Expect(trace.Inputs[0].CandidateVersionIDs).To(Equal([]int{1}))
+			Expect(trace.Resolved).To(BeTrue())
+		})
+	})
+
+	Context("when an input has no candidates", func() {
+		BeforeEach(func() {
+			configs = algorithm.InputConfigs{
+				{Name: "a", ResourceID: 11, JobID: 1},
+				{
+					Name:       "b",
+					ResourceID: 12,
+					JobID:      1,
+					Passed:     algorithm.JobSet{3: struct{}{}},
+				},
+			}
+		})
+
+		It("is not resolved, and the input has no candidates", func() {
+			Expect(trace.Resolved).To(BeFalse())
+			Expect(trace.Inputs[0].CandidateVersionIDs).To(Equal([]int{3}))
+			Expect(trace.Inputs[1].CandidateVersionIDs).To(BeEmpty())
+			Expect(trace.Inputs[1].Passed).To(Equal([]algorithm.PassedTrace{
+				{JobID: 3, EliminatedVersionIDs: []int{5, 4}},
+			}))
+		})
+	})
+
+	Context("when the pinned version is not a candidate", func() {
+		BeforeEach(func() {
+			configs = algorithm.InputConfigs{
+				{
+					Name:            "a",
+					ResourceID:      11,
+					JobID:           1,
+					PinnedVersionID: 3,
+					Passed:          algorithm.JobSet{2: struct{}{}},
+				},
+			}
+		})
+
+		It("has no candidates", func() {
+			Expect(trace.Resolved).To(BeFalse())
+			Expect(trace.Inputs[0].PinnedVersionID).To(Equal(3))
+			Expect(trace.Inputs[0].CandidateVersionIDs).To(BeEmpty())
+		})
+	})
+
+	Context("when inputs passed different builds of the same job", func() {
+		BeforeEach(func() {
+			versionsDB.BuildOutputs = []algorithm.BuildOutput{
+				{ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 11, CheckOrder: 1}, BuildID: 100, JobID: 2},
+				{ResourceVersion: algorithm.ResourceVersion{VersionID: 4, ResourceID: 12, CheckOrder: 1}, BuildID: 101, JobID: 2},
+			}
+
+			configs = algorithm.InputConfigs{
+				{Name: "a", ResourceID: 11, JobID: 1, Passed: algorithm.JobSet{2: struct{}{}}},
+				{Name: "b", ResourceID: 12, JobID: 1, Passed: algorithm.JobSet{2: struct{}{}}},
+			}
+		})
+
+		It("reports the conflicting job", func() {
+			Expect(trace.Resolved).To(BeFalse())
+			Expect(trace.Inputs[0].CandidateVersionIDs).To(Equal([]int{1}))
+			Expect(trace.Inputs[1].CandidateVersionIDs).To(Equal([]int{4}))
+			Expect(trace.Conflicts).To(Equal([]algorithm.JobConflict{
+				{JobID: 2, Inputs: []string{"a", "b"}},
+			}))
+		})
+	})
+})
@@ -17,40 +17,13 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 	inputCandidates := InputCandidates{}
 
 	for _, inputConfig := range configs {
-		versionCandidates := VersionCandidates{}
-
-		if len(inputConfig.Passed) == 0 {
-			if inputConfig.UseEveryVersion {
-				versionCandidates = db.AllVersionsOfResource(inputConfig.ResourceID)
-			} else {
-				var versionCandidate VersionCandidate
-				var found bool
-
-				if inputConfig.PinnedVersionID != 0 {
-					versionCandidate, found = db.FindVersionOfResource(inputConfig.ResourceID, inputConfig.PinnedVersionID)
-				} else {
-					versionCandidate, found = db.LatestVersionOfResource(inputConfig.ResourceID)
-				}
-
-				if found {
-					versionCandidates.Add(versionCandidate)
-				}
-			}
-
-			if versionCandidates.IsEmpty() {
-				return nil, false
-			}
-		} else {
+		if len(inputConfig.Passed) != 0 {
 			jobs = jobs.Union(inputConfig.Passed)
+		}
 
-			versionCandidates = db.VersionsOfResourcePassedJobs(
-				inputConfig.ResourceID,
-				inputConfig.Passed,
-			)
-
-			if versionCandidates.IsEmpty() {
-				return nil, false
-			}
+		versionCandidates := inputConfig.versionCandidates(db)
+		if versionCandidates.IsEmpty() {
+			return nil, false
 		}
 
 		existingBuildResolver := &ExistingBuildResolver{
@@ -87,3 +60,33 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 
 	return mapping, true
 }
+
+// versionCandidates returns the versions which satisfy the input's own
+// constraints, before they are reduced to versions which satisfy every input
+// together.
+func (config InputConfig) versionCandidates(db *VersionsDB) VersionCandidates {
+	if len(config.Passed) != 0 {
+		return db.VersionsOfResourcePassedJobs(config.ResourceID, config.Passed)
+	}
+
+	if config.UseEveryVersion {
+		return db.AllVersionsOfResource(config.ResourceID)
+	}
+
+	versionCandidates := VersionCandidates{}
+
+	var versionCandidate VersionCandidate
+	var found bool
+
+	if config.PinnedVersionID != 0 {
+		versionCandidate, found = db.FindVersionOfResource(config.ResourceID, config.PinnedVersionID)
+	} else {
+		versionCandidate, found = db.LatestVersionOfResource(config.ResourceID)
+	}
+
+	if found {
+		versionCandidates.Add(versionCandidate)
+	}
+
+	return versionCandidates
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/lib/pq"
+)
+
+var ErrAPITokenAlreadyExists = errors.New("an api token with the given name already exists")
+
+// APIToken grants access to a team's API without logging in. Only a hash of
+// the token itself is stored.
+type APIToken struct {
+	Name string
+	Role atc.TeamRole
+
+	// ExpiresAt is zero if the token never expires.
+	ExpiresAt time.Time
+}
+
+type SavedAPIToken struct {
+	APIToken
+
+	ID        int
+	TeamName  string
+	TeamAdmin bool
+
+	CreatedAt time.Time
+
+	// LastUsedAt is zero if the token has never been used.
+	LastUsedAt time.Time
+}
+
+const apiTokenColumns = "a.id, a.name, a.role, a.created_at, a.expires_at, a.last_used_at, t.name, t.admin"
+
+func scanAPIToken(row scannable) (SavedAPIToken, error) {
+	var token SavedAPIToken
+	var role string
+	var expiresAt, lastUsedAt pq.NullTime
+
+	err := row.Scan(
+		&token.ID,
+		&token.Name,
+		&role,
+		&token.CreatedAt,
+		&expiresAt,
+		&lastUsedAt,
+		&token.TeamName,
+		&token.TeamAdmin,
+	)
+	if err != nil {
+		return SavedAPIToken{}, err
+	}
+
+	token.Role = atc.TeamRole(role)
+
+	if expiresAt.Valid {
+		token.ExpiresAt = expiresAt.Time
+	}
+
+	if lastUsedAt.Valid {
+		token.LastUsedAt = lastUsedAt.Time
+	}
+
+	return token, nil
+}
@@ -0,0 +1,167 @@
+// This file was generated by counterfeiter
+package archivefakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/db/archive"
+)
+
+type FakeArchiver struct {
+	ArchiveStub        func(buildID int, events archive.Source) error
+	archiveMutex       sync.RWMutex
+	archiveArgsForCall []struct {
+		buildID int
+		events  archive.Source
+	}
+	archiveReturns struct {
+		result1 error
+	}
+	archiveReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RetrieveStub        func(buildID int) (archive.Reader, bool, error)
+	retrieveMutex       sync.RWMutex
+	retrieveArgsForCall []struct {
+		buildID int
+	}
+	retrieveReturns struct {
+		result1 archive.Reader
+		result2 bool
+		result3 error
+	}
+	retrieveReturnsOnCall map[int]struct {
+		result1 archive.Reader
+		result2 bool
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeArchiver) Archive(buildID int, events archive.Source) error {
+	fake.archiveMutex.Lock()
+	ret, specificReturn := fake.archiveReturnsOnCall[len(fake.archiveArgsForCall)]
+	fake.archiveArgsForCall = append(fake.archiveArgsForCall, struct {
+		buildID int
+		events  archive.Source
+	}{buildID, events})
+	fake.recordInvocation("Archive", []interface{}{buildID, events})
+	fake.archiveMutex.Unlock()
+	if fake.ArchiveStub != nil {
+		return fake.ArchiveStub(buildID, events)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.archiveReturns.result1
+}
+
+func (fake *FakeArchiver) ArchiveCallCount() int {
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	return len(fake.archiveArgsForCall)
+}
+
+func (fake *FakeArchiver) ArchiveArgsForCall(i int) (int, archive.Source) {
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	return fake.archiveArgsForCall[i].buildID, fake.archiveArgsForCall[i].events
+}
+
+func (fake *FakeArchiver) ArchiveReturns(result1 error) {
+	fake.ArchiveStub = nil
+	fake.archiveReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeArchiver) ArchiveReturnsOnCall(i int, result1 error) {
+	fake.ArchiveStub = nil
+	if fake.archiveReturnsOnCall == nil {
+		fake.archiveReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.archiveReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeArchiver) Retrieve(buildID int) (archive.Reader, bool, error) {
+	fake.retrieveMutex.Lock()
+	ret, specificReturn := fake.retrieveReturnsOnCall[len(fake.retrieveArgsForCall)]
+	fake.retrieveArgsForCall = append(fake.retrieveArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.recordInvocation("Retrieve", []interface{}{buildID})
+	fake.retrieveMutex.Unlock()
+	if fake.RetrieveStub != nil {
+		return fake.RetrieveStub(buildID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.retrieveReturns.result1, fake.retrieveReturns.result2, fake.retrieveReturns.result3
+}
+
+func (fake *FakeArchiver) RetrieveCallCount() int {
+	fake.retrieveMutex.RLock()
+	defer fake.retrieveMutex.RUnlock()
+	return len(fake.retrieveArgsForCall)
+}
+
+func (fake *FakeArchiver) RetrieveArgsForCall(i int) int {
+	fake.retrieveMutex.RLock()
+	defer fake.retrieveMutex.RUnlock()
+	return fake.retrieveArgsForCall[i].buildID
+}
+
+func (fake *FakeArchiver) RetrieveReturns(result1 archive.Reader, result2 bool, result3 error) {
+	fake.RetrieveStub = nil
+	fake.retrieveReturns = struct {
+		result1 archive.Reader
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeArchiver) RetrieveReturnsOnCall(i int, result1 archive.Reader, result2 bool, result3 error) {
+	fake.RetrieveStub = nil
+	if fake.retrieveReturnsOnCall == nil {
+		fake.retrieveReturnsOnCall = make(map[int]struct {
+			result1 archive.Reader
+			result2 bool
+			result3 error
+		})
+	}
+	fake.retrieveReturnsOnCall[i] = struct {
+		result1 archive.Reader
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeArchiver) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	fake.retrieveMutex.RLock()
+	defer fake.retrieveMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeArchiver) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ archive.Archiver = new(FakeArchiver)
@@ -0,0 +1,168 @@
+// This file was generated by counterfeiter
+package archivefakes
+
+import (
+	"io"
+	"sync"
+
+	"github.com/concourse/atc/db/archive"
+)
+
+type FakeBackend struct {
+	PutStub        func(key string, contents io.Reader) error
+	putMutex       sync.RWMutex
+	putArgsForCall []struct {
+		key      string
+		contents io.Reader
+	}
+	putReturns struct {
+		result1 error
+	}
+	putReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetStub        func(key string) (io.ReadCloser, bool, error)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		key string
+	}
+	getReturns struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}
+	getReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBackend) Put(key string, contents io.Reader) error {
+	fake.putMutex.Lock()
+	ret, specificReturn := fake.putReturnsOnCall[len(fake.putArgsForCall)]
+	fake.putArgsForCall = append(fake.putArgsForCall, struct {
+		key      string
+		contents io.Reader
+	}{key, contents})
+	fake.recordInvocation("Put", []interface{}{key, contents})
+	fake.putMutex.Unlock()
+	if fake.PutStub != nil {
+		return fake.PutStub(key, contents)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.putReturns.result1
+}
+
+func (fake *FakeBackend) PutCallCount() int {
+	fake.putMutex.RLock()
+	defer fake.putMutex.RUnlock()
+	return len(fake.putArgsForCall)
+}
+
+func (fake *FakeBackend) PutArgsForCall(i int) (string, io.Reader) {
+	fake.putMutex.RLock()
+	defer fake.putMutex.RUnlock()
+	return fake.putArgsForCall[i].key, fake.putArgsForCall[i].contents
+}
+
+func (fake *FakeBackend) PutReturns(result1 error) {
+	fake.PutStub = nil
+	fake.putReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBackend) PutReturnsOnCall(i int, result1 error) {
+	fake.PutStub = nil
+	if fake.putReturnsOnCall == nil {
+		fake.putReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.putReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBackend) Get(key string) (io.ReadCloser, bool, error) {
+	fake.getMutex.Lock()
+	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		key string
+	}{key})
+	fake.recordInvocation("Get", []interface{}{key})
+	fake.getMutex.Unlock()
+	if fake.GetStub != nil {
+		return fake.GetStub(key)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getReturns.result1, fake.getReturns.result2, fake.getReturns.result3
+}
+
+func (fake *FakeBackend) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *FakeBackend) GetArgsForCall(i int) string {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return fake.getArgsForCall[i].key
+}
+
+func (fake *FakeBackend) GetReturns(result1 io.ReadCloser, result2 bool, result3 error) {
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBackend) GetReturnsOnCall(i int, result1 io.ReadCloser, result2 bool, result3 error) {
+	fake.GetStub = nil
+	if fake.getReturnsOnCall == nil {
+		fake.getReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBackend) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.putMutex.RLock()
+	defer fake.putMutex.RUnlock()
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeBackend) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ archive.Backend = new(FakeBackend)
@@ -0,0 +1,136 @@
+package archive
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/concourse/atc/event"
+)
+
+//go:generate counterfeiter . Archiver
+
+// Archiver keeps the events of builds whose logs are reaped from the
+// database, so that they can still be streamed afterwards.
+type Archiver interface {
+	Archive(buildID int, events Source) error
+	Retrieve(buildID int) (Reader, bool, error)
+}
+
+// Source yields the events to archive, returning io.EOF once exhausted.
+type Source interface {
+	Next() (event.Envelope, error)
+}
+
+// Reader yields archived events, returning io.EOF once exhausted.
+type Reader interface {
+	Source
+	Close() error
+}
+
+//go:generate counterfeiter . Backend
+
+// Backend stores the archived event streams.
+type Backend interface {
+	Put(key string, contents io.Reader) error
+	Get(key string) (io.ReadCloser, bool, error)
+}
+
+// NewArchiver constructs an Archiver which stores each build's events in the
+// backend as a gzipped stream of JSON-encoded envelopes.
+func NewArchiver(backend Backend) Archiver {
+	return &archiver{
+		backend: backend,
+	}
+}
+
+type archiver struct {
+	backend Backend
+}
+
+func (a *archiver) Archive(buildID int, events Source) error {
+	pr, pw := io.Pipe()
+
+	go func() {
+		pw.CloseWithError(writeEvents(pw, events))
+	}()
+
+	err := a.backend.Put(buildKey(buildID), pr)
+
+	// unblock the writer if the backend gave up early
+	pr.Close()
+
+	return err
+}
+
+func (a *archiver) Retrieve(buildID int) (Reader, bool, error) {
+	contents, found, err := a.backend.Get(buildKey(buildID))
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	gz, err := gzip.NewReader(contents)
+	if err != nil {
+		contents.Close()
+		return nil, false, err
+	}
+
+	return &reader{
+		contents: contents,
+		gz:       gz,
+		decoder:  json.NewDecoder(gz),
+	}, true, nil
+}
+
+func buildKey(buildID int) string {
+	return fmt.Sprintf("builds/%d.json.gz", buildID)
+}
+
+func writeEvents(w io.Writer, events Source) error {
+	gz := gzip.NewWriter(w)
+	encoder := json.NewEncoder(gz)
+
+	for {
+		ev, err := events.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		err = encoder.Encode(ev)
+		if err != nil {
+			return err
+		}
+	}
+
+	return gz.Close()
+}
+
+type reader struct {
+	contents io.ReadCloser
+	gz       *gzip.Reader
+	decoder  *json.Decoder
+}
+
+func (r *reader) Next() (event.Envelope, error) {
+	var ev event.Envelope
+	err := r.decoder.Decode(&ev)
+	if err != nil {
+		return event.Envelope{}, err
+	}
+
+	return ev, nil
+}
+
+func (r *reader) Close() error {
+	r.gz.Close()
+	return r.contents.Close()
+}
@@ -0,0 +1,139 @@
+package archive_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/archive"
+	"github.com/concourse/atc/db/archive/archivefakes"
+	"github.com/concourse/atc/event"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type sliceSource struct {
+	events []event.Envelope
+	err    error
+}
+
+func (source *sliceSource) Next() (event.Envelope, error) {
+	if len(source.events) == 0 {
+		if source.err != nil {
+			return event.Envelope{}, source.err
+		}
+
+		return event.Envelope{}, io.EOF
+	}
+
+	ev := source.events[0]
+	source.events = source.events[1:]
+	return ev, nil
+}
+
+func envelope(payload string) event.Envelope {
+	data := json.RawMessage(payload)
+	return event.Envelope{
+		Data:    &data,
+		Event:   atc.EventType("log"),
+		Version: atc.EventVersion("5.0"),
+	}
+}
+
+var _ = Describe("Archiver", func() {
+	var (
+		dir      string
+		archiver archive.Archiver
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "archive")
+		Expect(err).NotTo(HaveOccurred())
+
+		archiver = archive.NewArchiver(archive.NewLocalBackend(dir))
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Context("when a build's events have been archived", func() {
+		BeforeEach(func() {
+			err := archiver.Archive(42, &sliceSource{
+				events: []event.Envelope{
+					envelope(`{"payload":"hello"}`),
+					envelope(`{"payload":"world"}`),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("stores them compressed under the build's key", func() {
+			Expect(dir + "/builds/42.json.gz").To(BeARegularFile())
+		})
+
+		It("streams them back in order", func() {
+			reader, found, err := archiver.Retrieve(42)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			defer reader.Close()
+
+			ev, err := reader.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ev).To(Equal(envelope(`{"payload":"hello"}`)))
+
+			ev, err = reader.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ev).To(Equal(envelope(`{"payload":"world"}`)))
+
+			_, err = reader.Next()
+			Expect(err).To(Equal(io.EOF))
+		})
+	})
+
+	Context("when the build has not been archived", func() {
+		It("is not found", func() {
+			_, found, err := archiver.Retrieve(42)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when reading the events fails", func() {
+		disaster := errors.New("nope")
+
+		It("returns the error and stores nothing", func() {
+			err := archiver.Archive(42, &sliceSource{
+				events: []event.Envelope{envelope(`{}`)},
+				err:    disaster,
+			})
+			Expect(err).To(Equal(disaster))
+
+			_, found, err := archiver.Retrieve(42)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when the backend fails to store the events", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeBackend := new(archivefakes.FakeBackend)
+			fakeBackend.PutReturns(disaster)
+			archiver = archive.NewArchiver(fakeBackend)
+		})
+
+		It("returns the error", func() {
+			err := archiver.Archive(42, &sliceSource{
+				events: []event.Envelope{envelope(`{}`)},
+			})
+			Expect(err).To(Equal(disaster))
+		})
+	})
+})
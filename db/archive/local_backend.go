@@ -0,0 +1,68 @@
+package archive
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// NewLocalBackend constructs a Backend which stores archives as files under
+// the given directory.
+func NewLocalBackend(dir string) Backend {
+	return &localBackend{
+		dir: dir,
+	}
+}
+
+type localBackend struct {
+	dir string
+}
+
+func (backend *localBackend) Put(key string, contents io.Reader) error {
+	path := backend.path(key)
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that a partially written archive is
+	// never mistaken for a complete one
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tmp, contents)
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (backend *localBackend) Get(key string) (io.ReadCloser, bool, error) {
+	file, err := os.Open(backend.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return file, true, nil
+}
+
+func (backend *localBackend) path(key string) string {
+	return filepath.Join(backend.dir, filepath.FromSlash(key))
+}
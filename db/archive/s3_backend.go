@@ -0,0 +1,98 @@
+package archive
+
+import (
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+type S3Config struct {
+	// Endpoint is only needed for S3-compatible stores other than AWS.
+	Endpoint string
+
+	Region string
+	Bucket string
+	Prefix string
+
+	// If not specified, credentials are taken from the environment.
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// NewS3Backend constructs a Backend which stores archives as objects in an S3
+// (or S3-compatible) bucket.
+func NewS3Backend(config S3Config) (Backend, error) {
+	awsConfig := aws.NewConfig().WithRegion(config.Region)
+
+	if config.AccessKeyID != "" {
+		awsConfig = awsConfig.WithCredentials(credentials.NewStaticCredentials(
+			config.AccessKeyID,
+			config.SecretAccessKey,
+			"",
+		))
+	}
+
+	if config.Endpoint != "" {
+		awsConfig = awsConfig.
+			WithEndpoint(config.Endpoint).
+			WithS3ForcePathStyle(true)
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3Backend{
+		client:   s3.New(sess),
+		uploader: s3manager.NewUploader(sess),
+
+		bucket: config.Bucket,
+		prefix: config.Prefix,
+	}, nil
+}
+
+type s3Backend struct {
+	client   *s3.S3
+	uploader *s3manager.Uploader
+
+	bucket string
+	prefix string
+}
+
+func (backend *s3Backend) Put(key string, contents io.Reader) error {
+	_, err := backend.uploader.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(backend.bucket),
+		Key:    aws.String(backend.key(key)),
+		Body:   contents,
+	})
+
+	return err
+}
+
+func (backend *s3Backend) Get(key string) (io.ReadCloser, bool, error) {
+	output, err := backend.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(backend.bucket),
+		Key:    aws.String(backend.key(key)),
+	})
+	if err != nil {
+		if reqErr, ok := err.(awserr.RequestFailure); ok && reqErr.StatusCode() == http.StatusNotFound {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return output.Body, true, nil
+}
+
+func (backend *s3Backend) key(key string) string {
+	return path.Join(backend.prefix, key)
+}
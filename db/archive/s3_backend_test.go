@@ -0,0 +1,93 @@
+package archive_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/concourse/atc/db/archive"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("S3Backend", func() {
+	var (
+		s3Server *ghttp.Server
+		objects  map[string][]byte
+		backend  archive.Backend
+	)
+
+	BeforeEach(func() {
+		s3Server = ghttp.NewServer()
+		objects = map[string][]byte{}
+
+		var objectsLock sync.Mutex
+
+		s3Server.RouteToHandler("PUT", "/some-bucket/some-prefix/some/key", func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			objectsLock.Lock()
+			objects[r.URL.Path] = body
+			objectsLock.Unlock()
+		})
+
+		s3Server.RouteToHandler("GET", "/some-bucket/some-prefix/some/key", func(w http.ResponseWriter, r *http.Request) {
+			objectsLock.Lock()
+			body, found := objects[r.URL.Path]
+			objectsLock.Unlock()
+
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`<Error><Code>NoSuchKey</Code></Error>`))
+				return
+			}
+
+			w.Write(body)
+		})
+
+		var err error
+		backend, err = archive.NewS3Backend(archive.S3Config{
+			Endpoint:        s3Server.URL(),
+			Region:          "us-east-1",
+			Bucket:          "some-bucket",
+			Prefix:          "some-prefix",
+			AccessKeyID:     "some-access-key-id",
+			SecretAccessKey: "some-secret-access-key",
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		s3Server.Close()
+	})
+
+	It("uploads objects under the prefix", func() {
+		err := backend.Put("some/key", bytes.NewBufferString("some-contents"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(objects).To(HaveKeyWithValue("/some-bucket/some-prefix/some/key", []byte("some-contents")))
+	})
+
+	It("downloads uploaded objects", func() {
+		err := backend.Put("some/key", bytes.NewBufferString("some-contents"))
+		Expect(err).NotTo(HaveOccurred())
+
+		contents, found, err := backend.Get("some/key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		defer contents.Close()
+		Expect(ioutil.ReadAll(contents)).To(Equal([]byte("some-contents")))
+	})
+
+	Context("when the object does not exist", func() {
+		It("is not found", func() {
+			_, found, err := backend.Get("some/key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+})
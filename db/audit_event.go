@@ -0,0 +1,24 @@
+package db
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+)
+
+type AuditEvent struct {
+	ID   int
+	Time time.Time
+
+	Actor      string
+	RemoteAddr string
+
+	APITokenName string
+	APITokenRole atc.TeamRole
+
+	Route    string
+	TeamName string
+	Params   map[string]string
+
+	Status int
+}
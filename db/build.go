@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/db/archive"
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/event"
 )
@@ -24,8 +25,8 @@ const (
 	StatusErrored   Status = "errored"
 )
 
-const buildColumns = "id, name, job_id, team_id, status, manually_triggered, scheduled, engine, engine_metadata, start_time, end_time, reap_time"
-const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name"
+const buildColumns = "id, name, job_id, team_id, status, manually_triggered, scheduled, engine, engine_metadata, start_time, end_time, reap_time, rerun_of, breakpoint_plan_id"
+const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, b.rerun_of, b.breakpoint_plan_id, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name, (SELECT rb.name FROM builds rb WHERE rb.id = b.rerun_of) as rerun_of_name"
 
 //go:generate counterfeiter . Build
 
@@ -47,6 +48,15 @@ type Build interface {
 	IsRunning() bool
 	IsManuallyTriggered() bool
 
+	// RerunOf returns the ID of the build whose inputs this build reuses, or 0
+	// if it is not a rerun.
+	RerunOf() int
+	RerunOfName() string
+
+	// Breakpoint returns the ID of the breakpoint plan at which the build is
+	// paused, or "" if it is not paused.
+	Breakpoint() atc.PlanID
+
 	Reload() (bool, error)
 
 	Events(from uint) (EventSource, error)
@@ -61,6 +71,10 @@ type Build interface {
 	Abort() error
 	AbortNotifier() (Notifier, error)
 
+	PauseAtBreakpoint(planID atc.PlanID) error
+	ResumeFromBreakpoint() (bool, error)
+	ResumeNotifier() (Notifier, error)
+
 	AcquireTrackingLock(logger lager.Logger, interval time.Duration) (lock.Lock, bool, error)
 
 	GetPreparation() (BuildPreparation, bool, error)
@@ -92,6 +106,11 @@ type build struct {
 
 	isManuallyTriggered bool
 
+	rerunOf     int
+	rerunOfName string
+
+	breakpoint atc.PlanID
+
 	engine         string
 	engineMetadata string
 
@@ -103,6 +122,7 @@ type build struct {
 	bus  *notificationsBus
 
 	lockFactory lock.LockFactory
+	archiver    archive.Archiver
 }
 
 func (b *build) ID() int {
@@ -133,6 +153,18 @@ func (b *build) IsManuallyTriggered() bool {
 	return b.isManuallyTriggered
 }
 
+func (b *build) RerunOf() int {
+	return b.rerunOf
+}
+
+func (b *build) RerunOfName() string {
+	return b.rerunOfName
+}
+
+func (b *build) Breakpoint() atc.PlanID {
+	return b.breakpoint
+}
+
 func (b *build) Engine() string {
 	return b.engine
 }
@@ -175,7 +207,7 @@ func (b *build) IsRunning() bool {
 }
 
 func (b *build) Reload() (bool, error) {
-	buildFactory := newBuildFactory(b.conn, b.bus, b.lockFactory)
+	buildFactory := newBuildFactory(b.conn, b.bus, b.lockFactory, b.archiver)
 	newBuild, found, err := buildFactory.ScanBuild(b.conn.QueryRow(`
 		SELECT `+qualifiedBuildColumns+`
 		FROM builds b
@@ -205,6 +237,9 @@ func (b *build) Reload() (bool, error) {
 	b.teamID = newBuild.TeamID()
 	b.jobName = newBuild.JobName()
 	b.pipelineName = newBuild.PipelineName()
+	b.rerunOf = newBuild.RerunOf()
+	b.rerunOfName = newBuild.RerunOfName()
+	b.breakpoint = newBuild.Breakpoint()
 
 	return found, err
 }
@@ -227,6 +262,7 @@ func (b *build) Events(from uint) (EventSource, error) {
 		table,
 		b.conn,
 		notifier,
+		b.archiver,
 		from,
 	), nil
 }
@@ -308,6 +344,69 @@ func (b *build) AbortNotifier() (Notifier, error) {
 	})
 }
 
+// PauseAtBreakpoint records that the build is paused at the given breakpoint
+// until ResumeFromBreakpoint is called.
+func (b *build) PauseAtBreakpoint(planID atc.PlanID) error {
+	_, err := b.conn.Exec(`
+		UPDATE builds
+		SET breakpoint_plan_id = $2
+		WHERE id = $1
+	`, b.id, string(planID))
+	if err != nil {
+		return err
+	}
+
+	b.breakpoint = planID
+
+	return nil
+}
+
+// ResumeFromBreakpoint resumes the build if it is running and paused at a
+// breakpoint, returning false otherwise.
+func (b *build) ResumeFromBreakpoint() (bool, error) {
+	result, err := b.conn.Exec(`
+		UPDATE builds
+		SET breakpoint_plan_id = NULL
+		WHERE id = $1
+			AND breakpoint_plan_id IS NOT NULL
+			AND status = 'started'
+	`, b.id)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	b.breakpoint = ""
+
+	err = b.bus.Notify(buildResumeChannel(b.id))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *build) ResumeNotifier() (Notifier, error) {
+	return newConditionNotifier(b.bus, buildResumeChannel(b.id), func() (bool, error) {
+		var resumed bool
+		err := b.conn.QueryRow(`
+			SELECT breakpoint_plan_id IS NULL
+			FROM builds
+			WHERE id = $1
+		`, b.id).Scan(&resumed)
+
+		return resumed, err
+	})
+}
+
 func (b *build) Finish(status Status) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -320,7 +419,7 @@ func (b *build) Finish(status Status) error {
 
 	err = tx.QueryRow(`
 		UPDATE builds
-		SET status = $2, end_time = now(), completed = true
+		SET status = $2, end_time = now(), completed = true, breakpoint_plan_id = NULL
 		WHERE id = $1
 		RETURNING end_time
 	`, b.id, string(status)).Scan(&endTime)
@@ -573,6 +672,7 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 			PausedPipeline:      BuildPreparationStatusNotBlocking,
 			PausedJob:           BuildPreparationStatusNotBlocking,
 			MaxRunningBuilds:    BuildPreparationStatusNotBlocking,
+			TriggerWindow:       BuildPreparationStatusNotBlocking,
 			Inputs:              map[string]BuildPreparationStatus{},
 			InputsSatisfied:     BuildPreparationStatusNotBlocking,
 			MissingInputReasons: MissingInputReasons{},
@@ -580,21 +680,22 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 	}
 
 	var (
-		pausedPipeline     bool
-		pausedJob          bool
-		maxInFlightReached bool
-		pipelineID         int
-		jobName            string
+		pausedPipeline       bool
+		pausedJob            bool
+		maxInFlightReached   bool
+		outsideTriggerWindow bool
+		pipelineID           int
+		jobName              string
 	)
 	err := b.conn.QueryRow(`
-			SELECT p.paused, j.paused, j.max_in_flight_reached, j.pipeline_id, j.name
+			SELECT p.paused, j.paused, j.max_in_flight_reached, j.outside_trigger_window, j.pipeline_id, j.name
 			FROM builds b
 			JOIN jobs j
 				ON b.job_id = j.id
 			JOIN pipelines p
 				ON j.pipeline_id = p.id
 			WHERE b.id = $1
-		`, b.id).Scan(&pausedPipeline, &pausedJob, &maxInFlightReached, &pipelineID, &jobName)
+		`, b.id).Scan(&pausedPipeline, &pausedJob, &maxInFlightReached, &outsideTriggerWindow, &pipelineID, &jobName)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return BuildPreparation{}, false, nil
@@ -617,7 +718,12 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 		maxInFlightReachedStatus = BuildPreparationStatusBlocking
 	}
 
-	tdbf := NewTeamDBFactory(b.conn, b.bus, b.lockFactory)
+	triggerWindowStatus := BuildPreparationStatusNotBlocking
+	if outsideTriggerWindow && !b.isManuallyTriggered {
+		triggerWindowStatus = BuildPreparationStatusBlocking
+	}
+
+	tdbf := NewTeamDBFactory(b.conn, b.bus, b.lockFactory, b.archiver)
 	tdb := tdbf.GetTeamDB(b.teamName)
 	savedPipeline, found, err := tdb.GetPipelineByName(b.pipelineName)
 	if err != nil {
@@ -628,7 +734,7 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 		return BuildPreparation{}, false, nil
 	}
 
-	pdbf := NewPipelineDBFactory(b.conn, b.bus, b.lockFactory)
+	pdbf := NewPipelineDBFactory(b.conn, b.bus, b.lockFactory, b.archiver)
 	pdb := pdbf.Build(savedPipeline)
 	if err != nil {
 		return BuildPreparation{}, false, err
@@ -649,6 +755,7 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 	inputsSatisfiedStatus := BuildPreparationStatusBlocking
 	inputs := map[string]BuildPreparationStatus{}
 	missingInputReasons := MissingInputReasons{}
+	var inputsExplanation *atc.InputsExplanation
 
 	if found {
 		inputsSatisfiedStatus = BuildPreparationStatusNotBlocking
@@ -707,6 +814,15 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 				}
 			}
 		}
+
+		explanation, found, err := pdb.ExplainInputs(jobName)
+		if err != nil {
+			return BuildPreparation{}, false, err
+		}
+
+		if found {
+			inputsExplanation = &explanation
+		}
 	}
 
 	buildPreparation := BuildPreparation{
@@ -714,9 +830,11 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 		PausedPipeline:      pausedPipelineStatus,
 		PausedJob:           pausedJobStatus,
 		MaxRunningBuilds:    maxInFlightReachedStatus,
+		TriggerWindow:       triggerWindowStatus,
 		Inputs:              inputs,
 		InputsSatisfied:     inputsSatisfiedStatus,
 		MissingInputReasons: missingInputReasons,
+		InputsExplanation:   inputsExplanation,
 	}
 
 	return buildPreparation, true, nil
@@ -735,7 +853,7 @@ func (b *build) SaveInput(input BuildInput) (SavedVersionedResource, error) {
 		return SavedVersionedResource{}, err
 	}
 
-	pipelineDBFactory := NewPipelineDBFactory(b.conn, b.bus, b.lockFactory)
+	pipelineDBFactory := NewPipelineDBFactory(b.conn, b.bus, b.lockFactory, b.archiver)
 
 	pipelineDB := pipelineDBFactory.Build(savedPipeline)
 
@@ -754,7 +872,7 @@ func (b *build) SaveOutput(vr VersionedResource, explicit bool) (SavedVersionedR
 	if err != nil {
 		return SavedVersionedResource{}, err
 	}
-	pipelineDBFactory := NewPipelineDBFactory(b.conn, b.bus, b.lockFactory)
+	pipelineDBFactory := NewPipelineDBFactory(b.conn, b.bus, b.lockFactory, b.archiver)
 	pipelineDB := pipelineDBFactory.Build(savedPipeline)
 
 	return pipelineDB.SaveOutput(b.id, vr, explicit)
@@ -950,6 +1068,10 @@ func buildAbortChannel(buildID int) string {
 	return fmt.Sprintf("build_abort_%d", buildID)
 }
 
+func buildResumeChannel(buildID int) string {
+	return fmt.Sprintf("build_resume_%d", buildID)
+}
+
 func buildEventsChannel(buildID int) string {
 	return fmt.Sprintf("build_events_%d", buildID)
 }
@@ -3,15 +3,18 @@ package db
 import (
 	"database/sql"
 
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/archive"
 	"github.com/concourse/atc/db/lock"
 	"github.com/lib/pq"
 )
 
-func newBuildFactory(conn Conn, bus *notificationsBus, lockFactory lock.LockFactory) *buildFactory {
+func newBuildFactory(conn Conn, bus *notificationsBus, lockFactory lock.LockFactory, archiver archive.Archiver) *buildFactory {
 	return &buildFactory{
 		conn:        conn,
 		lockFactory: lockFactory,
 		bus:         bus,
+		archiver:    archiver,
 	}
 }
 
@@ -20,22 +23,23 @@ type buildFactory struct {
 	bus  *notificationsBus
 
 	lockFactory lock.LockFactory
+	archiver    archive.Archiver
 }
 
 func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 	var id int
 	var name string
-	var jobID, pipelineID, teamID sql.NullInt64
+	var jobID, pipelineID, teamID, rerunOf sql.NullInt64
 	var status string
 	var scheduled bool
-	var engine, engineMetadata, jobName, pipelineName sql.NullString
+	var engine, engineMetadata, jobName, pipelineName, rerunOfName, breakpoint sql.NullString
 	var startTime pq.NullTime
 	var endTime pq.NullTime
 	var reapTime pq.NullTime
 	var teamName string
 	var isManuallyTriggered bool
 
-	err := row.Scan(&id, &name, &jobID, &teamID, &status, &isManuallyTriggered, &scheduled, &engine, &engineMetadata, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &teamName)
+	err := row.Scan(&id, &name, &jobID, &teamID, &status, &isManuallyTriggered, &scheduled, &engine, &engineMetadata, &startTime, &endTime, &reapTime, &rerunOf, &breakpoint, &jobName, &pipelineID, &pipelineName, &teamName, &rerunOfName)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, false, nil
@@ -48,6 +52,7 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 		conn:        f.conn,
 		bus:         f.bus,
 		lockFactory: f.lockFactory,
+		archiver:    f.archiver,
 
 		id:                  id,
 		name:                name,
@@ -75,5 +80,14 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 		build.teamID = int(teamID.Int64)
 	}
 
+	if rerunOf.Valid {
+		build.rerunOf = int(rerunOf.Int64)
+		build.rerunOfName = rerunOfName.String
+	}
+
+	if breakpoint.Valid {
+		build.breakpoint = atc.PlanID(breakpoint.String)
+	}
+
 	return build, true, nil
 }
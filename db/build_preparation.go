@@ -1,6 +1,10 @@
 package db
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/concourse/atc"
+)
 
 type BuildPreparationStatus string
 
@@ -35,7 +39,9 @@ type BuildPreparation struct {
 	PausedPipeline      BuildPreparationStatus
 	PausedJob           BuildPreparationStatus
 	MaxRunningBuilds    BuildPreparationStatus
+	TriggerWindow       BuildPreparationStatus
 	Inputs              map[string]BuildPreparationStatus
 	InputsSatisfied     BuildPreparationStatus
 	MissingInputReasons MissingInputReasons
+	InputsExplanation   *atc.InputsExplanation
 }
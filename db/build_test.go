@@ -42,7 +42,7 @@ var _ = Describe("Build", func() {
 		retryableConn := &lock.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := lock.NewLockFactory(retryableConn)
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
 		teamDB = teamDBFactory.GetTeamDB(atc.DefaultTeamName)
 
 		pipelineConfig = atc.Config{
@@ -74,7 +74,7 @@ var _ = Describe("Build", func() {
 		pipeline, _, err = teamDB.SaveConfigToBeDeprecated("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
 		Expect(err).NotTo(HaveOccurred())
 
-		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory, nil)
 		pipelineDB = pipelineDBFactory.Build(pipeline)
 	})
 
@@ -276,6 +276,46 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("CreateRerunBuild", func() {
+		It("creates a pending build of the job with the original build's inputs", func() {
+			originalBuild, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = pipelineDB.SaveInput(originalBuild.ID(), db.BuildInput{
+				Name: "some-input",
+				VersionedResource: db.VersionedResource{
+					Resource:   "some-resource",
+					Type:       "some-type",
+					Version:    db.Version{"some": "version"},
+					PipelineID: pipeline.ID,
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			rerunBuild, err := pipelineDB.CreateRerunBuild(originalBuild)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(rerunBuild.ID()).NotTo(Equal(originalBuild.ID()))
+			Expect(rerunBuild.JobName()).To(Equal("some-job"))
+			Expect(rerunBuild.Status()).To(Equal(db.StatusPending))
+			Expect(rerunBuild.IsManuallyTriggered()).To(BeTrue())
+			Expect(rerunBuild.RerunOf()).To(Equal(originalBuild.ID()))
+			Expect(rerunBuild.RerunOfName()).To(Equal(originalBuild.Name()))
+
+			inputs, _, err := rerunBuild.GetResources()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inputs).To(HaveLen(1))
+			Expect(inputs[0].Name).To(Equal("some-input"))
+			Expect(inputs[0].Version).To(Equal(db.Version{"some": "version"}))
+
+			found, err := rerunBuild.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(rerunBuild.RerunOf()).To(Equal(originalBuild.ID()))
+			Expect(rerunBuild.RerunOfName()).To(Equal(originalBuild.Name()))
+		})
+	})
+
 	Describe("SaveOutput", func() {
 		It("can get a build's output", func() {
 			build, err := pipelineDB.CreateJobBuild("some-job")
@@ -397,6 +437,92 @@ var _ = Describe("Build", func() {
 			})
 		})
 
+		Describe("PauseAtBreakpoint", func() {
+			BeforeEach(func() {
+				started, err := build.Start("engine", "metadata")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				err = build.PauseAtBreakpoint("some-plan-id")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("records the breakpoint", func() {
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.Breakpoint()).To(Equal(atc.PlanID("some-plan-id")))
+			})
+
+			Context("when the build is aborted while paused", func() {
+				BeforeEach(func() {
+					err := build.Abort()
+					Expect(err).NotTo(HaveOccurred())
+
+					err = build.Finish(db.StatusAborted)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("clears the breakpoint", func() {
+					found, err := build.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(build.Breakpoint()).To(BeEmpty())
+				})
+			})
+
+			Describe("ResumeFromBreakpoint", func() {
+				var resumes db.Notifier
+
+				BeforeEach(func() {
+					var err error
+					resumes, err = build.ResumeNotifier()
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				AfterEach(func() {
+					resumes.Close()
+				})
+
+				It("clears the breakpoint and notifies", func() {
+					Consistently(resumes.Notify()).ShouldNot(Receive())
+
+					resumed, err := build.ResumeFromBreakpoint()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resumed).To(BeTrue())
+
+					Eventually(resumes.Notify()).Should(Receive())
+
+					found, err := build.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(build.Breakpoint()).To(BeEmpty())
+				})
+
+				It("does not resume a build that is not paused", func() {
+					_, err := build.ResumeFromBreakpoint()
+					Expect(err).NotTo(HaveOccurred())
+
+					resumed, err := build.ResumeFromBreakpoint()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resumed).To(BeFalse())
+				})
+
+				Context("when the build has finished", func() {
+					BeforeEach(func() {
+						err := build.Finish(db.StatusFailed)
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("does not resume it", func() {
+						resumed, err := build.ResumeFromBreakpoint()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(resumed).To(BeFalse())
+					})
+				})
+			})
+		})
+
 		Describe("Finish", func() {
 			JustBeforeEach(func() {
 				err := build.Finish(db.StatusSucceeded)
@@ -474,6 +600,7 @@ var _ = Describe("Build", func() {
 				PausedPipeline:      db.BuildPreparationStatusNotBlocking,
 				PausedJob:           db.BuildPreparationStatusNotBlocking,
 				MaxRunningBuilds:    db.BuildPreparationStatusNotBlocking,
+				TriggerWindow:       db.BuildPreparationStatusNotBlocking,
 				Inputs:              map[string]db.BuildPreparationStatus{},
 				InputsSatisfied:     db.BuildPreparationStatusNotBlocking,
 				MissingInputReasons: db.MissingInputReasons{},
@@ -619,6 +746,64 @@ var _ = Describe("Build", func() {
 					})
 				})
 
+				Context("when the job is outside of its trigger window", func() {
+					BeforeEach(func() {
+						err := pipelineDB.SetOutsideTriggerWindow("some-job", true)
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("returns build preparation with the trigger window not blocking the manually triggered build", func() {
+						buildPrep, found, err := build.GetPreparation()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(found).To(BeTrue())
+						Expect(buildPrep).To(Equal(expectedBuildPrep))
+					})
+
+					Context("when the build was automatically triggered", func() {
+						BeforeEach(func() {
+							err := build.Finish(db.StatusSucceeded)
+							Expect(err).NotTo(HaveOccurred())
+
+							err = pipelineDB.EnsurePendingBuildExists("some-job")
+							Expect(err).NotTo(HaveOccurred())
+
+							pendingBuilds, err := pipelineDB.GetPendingBuildsForJob("some-job")
+							Expect(err).NotTo(HaveOccurred())
+							Expect(pendingBuilds).To(HaveLen(1))
+
+							build = pendingBuilds[0]
+							Expect(build.IsManuallyTriggered()).To(BeFalse())
+
+							expectedBuildPrep.BuildID = build.ID()
+							expectedBuildPrep.TriggerWindow = db.BuildPreparationStatusBlocking
+						})
+
+						It("returns build preparation with the trigger window blocking", func() {
+							buildPrep, found, err := build.GetPreparation()
+							Expect(err).NotTo(HaveOccurred())
+							Expect(found).To(BeTrue())
+							Expect(buildPrep).To(Equal(expectedBuildPrep))
+						})
+					})
+				})
+
+				Context("when the job's trigger window is reopened", func() {
+					BeforeEach(func() {
+						err := pipelineDB.SetOutsideTriggerWindow("some-job", true)
+						Expect(err).NotTo(HaveOccurred())
+
+						err = pipelineDB.SetOutsideTriggerWindow("some-job", false)
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("returns build preparation with the trigger window not blocking", func() {
+						buildPrep, found, err := build.GetPreparation()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(found).To(BeTrue())
+						Expect(buildPrep).To(Equal(expectedBuildPrep))
+					})
+				})
+
 				Context("when max running builds is de-reached", func() {
 					BeforeEach(func() {
 						err := pipelineDB.SetMaxInFlightReached("some-job", true)
@@ -640,6 +825,10 @@ var _ = Describe("Build", func() {
 			Context("when inputs are not satisfied", func() {
 				BeforeEach(func() {
 					expectedBuildPrep.InputsSatisfied = db.BuildPreparationStatusBlocking
+					expectedBuildPrep.InputsExplanation = &atc.InputsExplanation{
+						Resolved: true,
+						Inputs:   []atc.InputExplanation{},
+					}
 				})
 
 				It("returns blocking inputs satisfied", func() {
@@ -659,19 +848,19 @@ var _ = Describe("Build", func() {
 								Plan: atc.PlanSequence{
 									{Get: "input1"},
 									{Get: "input2"},
-									{Get: "input3", Passed: []string{"some-upstream-job"}},
+									{Get: "input3", Passed: atc.PassedConfigs{{Job: "some-upstream-job"}}},
 									{ // version doesn't exist
 										Get:     "input4",
 										Version: &atc.VersionConfig{Pinned: atc.Version{"version": "v4"}},
 									},
 									{ // version doesn't exist so constraint is irrelevant
 										Get:     "input5",
-										Passed:  []string{"some-upstream-job"},
+										Passed:  atc.PassedConfigs{{Job: "some-upstream-job"}},
 										Version: &atc.VersionConfig{Pinned: atc.Version{"version": "v5"}},
 									},
 									{ // version exists but doesn't satisfy constraint
 										Get:     "input6",
-										Passed:  []string{"some-upstream-job"},
+										Passed:  atc.PassedConfigs{{Job: "some-upstream-job"}},
 										Version: &atc.VersionConfig{Pinned: atc.Version{"version": "v6"}},
 									},
 								},
@@ -737,6 +926,46 @@ var _ = Describe("Build", func() {
 						"input5": fmt.Sprintf(db.PinnedVersionUnavailable, `{"version":"v5"}`),
 						"input6": db.NoVerionsSatisfiedPassedConstraints,
 					}
+					expectedBuildPrep.InputsExplanation = &atc.InputsExplanation{
+						Resolved: false,
+						Inputs: []atc.InputExplanation{
+							{Name: "input1", Resource: "input1", Candidates: 1},
+							{Name: "input2", Resource: "input2", Candidates: 0},
+							{
+								Name:       "input3",
+								Resource:   "input3",
+								Candidates: 0,
+								Passed: []atc.PassedExplanation{
+									{Job: "some-upstream-job", EliminatedCount: 0},
+								},
+							},
+							{
+								Name:                     "input4",
+								Resource:                 "input4",
+								PinnedVersion:            atc.Version{"version": "v4"},
+								PinnedVersionUnavailable: true,
+							},
+							{
+								Name:                     "input5",
+								Resource:                 "input5",
+								PinnedVersion:            atc.Version{"version": "v5"},
+								PinnedVersionUnavailable: true,
+							},
+							{
+								Name:          "input6",
+								Resource:      "input6",
+								Candidates:    0,
+								PinnedVersion: atc.Version{"version": "v6"},
+								Passed: []atc.PassedExplanation{
+									{
+										Job:                "some-upstream-job",
+										EliminatedVersions: []atc.Version{{"version": "v6"}},
+										EliminatedCount:    1,
+									},
+								},
+							},
+						},
+					}
 				})
 
 				It("returns blocking inputs satisfied", func() {
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"errors"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/db/lock"
@@ -74,7 +75,14 @@ type DB interface {
 	CreateDefaultTeamIfNotExists() error
 	DeleteTeamByName(teamName string) error
 
+	FindAPIToken(tokenHash string) (SavedAPIToken, bool, error)
+	UpdateAPITokenLastUsed(id int) error
+
+	SaveAuditEvent(event AuditEvent) error
+	GetAuditEvents(page Page) ([]AuditEvent, Pagination, error)
+
 	GetAllStartedBuilds() ([]Build, error)
+	GetActiveBuildsCountByTeam() (map[string]int, error)
 	GetPublicBuilds(page Page) ([]Build, Pagination, error)
 
 	FindJobIDForBuild(buildID int) (int, bool, error)
@@ -83,6 +91,12 @@ type DB interface {
 	GetPipe(pipeGUID string) (Pipe, error)
 
 	GetTaskLock(logger lager.Logger, taskName string) (lock.Lock, bool, error)
+	AcquireBuildStartingLock(logger lager.Logger) (lock.Lock, bool, error)
+
+	SaveQueuedBuild(buildID int, priority int, offeredAt time.Time) (QueuedBuild, error)
+	GetQueuedBuilds() ([]QueuedBuild, error)
+	DeleteQueuedBuildsOfferedBefore(offeredBefore time.Time) error
+	ScheduleQueuedBuild(buildID int) (bool, error)
 
 	DeleteBuildEventsByBuildIDs(buildIDs []int) error
 
@@ -0,0 +1,117 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/lib/pq"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/db/lock/lockfakes"
+)
+
+var _ = Describe("Audit events", func() {
+	var dbConn db.Conn
+	var listener *pq.Listener
+	var database db.DB
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(lockfakes.FakeConnector)
+		retryableConn := &lock.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := lock.NewLockFactory(retryableConn)
+		database = db.NewSQL(dbConn, bus, lockFactory, nil)
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("SaveAuditEvent", func() {
+		It("saves the event", func() {
+			event := db.AuditEvent{
+				Time:         time.Now().Truncate(time.Second),
+				Actor:        "some-team",
+				RemoteAddr:   "1.2.3.4:5678",
+				APITokenName: "some-token",
+				APITokenRole: atc.TeamRolePipelineOperator,
+				Route:        "PausePipeline",
+				TeamName:     "some-team",
+				Params:       map[string]string{"pipeline_name": "some-pipeline"},
+				Status:       200,
+			}
+
+			err := database.SaveAuditEvent(event)
+			Expect(err).NotTo(HaveOccurred())
+
+			events, _, err := database.GetAuditEvents(db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+
+			savedEvent := events[0]
+			Expect(savedEvent.ID).NotTo(BeZero())
+			Expect(savedEvent.Time).To(BeTemporally("==", event.Time))
+
+			savedEvent.ID = 0
+			savedEvent.Time = event.Time
+			Expect(savedEvent).To(Equal(event))
+		})
+
+		It("saves events without params", func() {
+			err := database.SaveAuditEvent(db.AuditEvent{Time: time.Now(), Route: "SetLogLevel", Status: 200})
+			Expect(err).NotTo(HaveOccurred())
+
+			events, _, err := database.GetAuditEvents(db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Params).To(BeNil())
+		})
+	})
+
+	Describe("GetAuditEvents", func() {
+		BeforeEach(func() {
+			for i := 0; i < 5; i++ {
+				err := database.SaveAuditEvent(db.AuditEvent{Time: time.Now(), Route: "PauseJob", Status: 200})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("returns the most recent events first, with pagination", func() {
+			events, pagination, err := database.GetAuditEvents(db.Page{Limit: 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].ID).To(BeNumerically(">", events[1].ID))
+
+			Expect(pagination.Previous).To(BeNil())
+			Expect(pagination.Next).To(Equal(&db.Page{Since: events[1].ID, Limit: 2}))
+
+			olderEvents, pagination, err := database.GetAuditEvents(*pagination.Next)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(olderEvents).To(HaveLen(2))
+			Expect(olderEvents[0].ID).To(BeNumerically("<", events[1].ID))
+
+			Expect(pagination.Previous).To(Equal(&db.Page{Until: olderEvents[0].ID, Limit: 2}))
+			Expect(pagination.Next).To(Equal(&db.Page{Since: olderEvents[1].ID, Limit: 2}))
+
+			newerEvents, _, err := database.GetAuditEvents(*pagination.Previous)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(newerEvents).To(Equal(events))
+		})
+	})
+})
@@ -1,6 +1,9 @@
 package db_test
 
 import (
+	"errors"
+	"io/ioutil"
+	"os"
 	"time"
 
 	"github.com/lib/pq"
@@ -9,6 +12,8 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/archive"
+	"github.com/concourse/atc/db/archive/archivefakes"
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/db/lock/lockfakes"
 	"github.com/concourse/atc/event"
@@ -24,6 +29,13 @@ func createAndFinishBuild(database db.DB, pipelineDB db.PipelineDB, jobName stri
 	return build
 }
 
+func createOneOffBuild(teamDB db.TeamDB) db.Build {
+	build, err := teamDB.CreateOneOffBuild()
+	Expect(err).NotTo(HaveOccurred())
+
+	return build
+}
+
 var _ = Describe("Builds", func() {
 	var (
 		dbConn            db.Conn
@@ -33,7 +45,9 @@ var _ = Describe("Builds", func() {
 		pipelineDBFactory db.PipelineDBFactory
 		pipeline          db.SavedPipeline
 		teamDB            db.TeamDB
+		teamDBFactory     db.TeamDBFactory
 		config            atc.Config
+		fakeArchiver      *archivefakes.FakeArchiver
 	)
 
 	BeforeEach(func() {
@@ -50,11 +64,12 @@ var _ = Describe("Builds", func() {
 		retryableConn := &lock.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := lock.NewLockFactory(retryableConn)
-		database = db.NewSQL(dbConn, bus, lockFactory)
+		fakeArchiver = new(archivefakes.FakeArchiver)
+		database = db.NewSQL(dbConn, bus, lockFactory, fakeArchiver)
 		_, err := database.CreateTeam(db.Team{Name: "some-team"})
 		Expect(err).NotTo(HaveOccurred())
 
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory, fakeArchiver)
 		teamDB = teamDBFactory.GetTeamDB("some-team")
 
 		config = atc.Config{
@@ -88,7 +103,7 @@ var _ = Describe("Builds", func() {
 		pipeline, _, err = teamDB.SaveConfigToBeDeprecated("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
 		Expect(err).NotTo(HaveOccurred())
 
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory, fakeArchiver)
 		pipelineDB = pipelineDBFactory.Build(pipeline)
 	})
 
@@ -187,6 +202,142 @@ var _ = Describe("Builds", func() {
 		})
 	})
 
+	Describe("GetActiveBuildsCountByTeam", func() {
+		BeforeEach(func() {
+			_, err := database.CreateTeam(db.Team{Name: "some-other-team"})
+			Expect(err).NotTo(HaveOccurred())
+
+			otherTeamDB := teamDBFactory.GetTeamDB("some-other-team")
+
+			for _, build := range []db.Build{
+				createOneOffBuild(teamDB),
+				createOneOffBuild(teamDB),
+				createOneOffBuild(otherTeamDB),
+			} {
+				started, err := build.Start("some-engine", "so-meta")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+			}
+
+			createOneOffBuild(otherTeamDB)
+
+			scheduledBuild, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			scheduled, err := database.ScheduleQueuedBuild(scheduledBuild.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(scheduled).To(BeTrue())
+
+			_, err = pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("counts the started and scheduled builds of each team", func() {
+			counts, err := database.GetActiveBuildsCountByTeam()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(counts).To(Equal(map[string]int{
+				"some-team":       3,
+				"some-other-team": 1,
+			}))
+		})
+	})
+
+	Describe("the build queue", func() {
+		var (
+			build      db.Build
+			otherBuild db.Build
+			offeredAt  time.Time
+		)
+
+		BeforeEach(func() {
+			var err error
+			build, err = pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			otherBuild, err = pipelineDB.CreateJobBuild("some-other-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			offeredAt = time.Date(2017, time.March, 6, 12, 0, 0, 0, time.UTC)
+		})
+
+		It("keeps when each build was first offered, and orders them by priority then age", func() {
+			queuedBuild, err := database.SaveQueuedBuild(build.ID(), 0, offeredAt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(queuedBuild.BuildID).To(Equal(build.ID()))
+			Expect(queuedBuild.TeamName).To(Equal("some-team"))
+			Expect(queuedBuild.FirstOffered).To(BeTemporally("==", offeredAt))
+
+			_, err = database.SaveQueuedBuild(otherBuild.ID(), 0, offeredAt.Add(time.Second))
+			Expect(err).NotTo(HaveOccurred())
+
+			queuedBuild, err = database.SaveQueuedBuild(build.ID(), 0, offeredAt.Add(time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(queuedBuild.FirstOffered).To(BeTemporally("==", offeredAt))
+			Expect(queuedBuild.LastOffered).To(BeTemporally("==", offeredAt.Add(time.Minute)))
+
+			queuedBuilds, err := database.GetQueuedBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(queuedBuilds).To(HaveLen(2))
+			Expect(queuedBuilds[0].BuildID).To(Equal(build.ID()))
+			Expect(queuedBuilds[1].BuildID).To(Equal(otherBuild.ID()))
+
+			_, err = database.SaveQueuedBuild(otherBuild.ID(), 10, offeredAt.Add(time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+
+			queuedBuilds, err = database.GetQueuedBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(queuedBuilds[0].BuildID).To(Equal(otherBuild.ID()))
+			Expect(queuedBuilds[0].Priority).To(Equal(10))
+		})
+
+		It("removes builds which have not been offered since the given time", func() {
+			_, err := database.SaveQueuedBuild(build.ID(), 0, offeredAt)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = database.SaveQueuedBuild(otherBuild.ID(), 0, offeredAt.Add(time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+
+			err = database.DeleteQueuedBuildsOfferedBefore(offeredAt.Add(time.Second))
+			Expect(err).NotTo(HaveOccurred())
+
+			queuedBuilds, err := database.GetQueuedBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(queuedBuilds).To(HaveLen(1))
+			Expect(queuedBuilds[0].BuildID).To(Equal(otherBuild.ID()))
+		})
+
+		It("removes builds from the queue once they are scheduled", func() {
+			_, err := database.SaveQueuedBuild(build.ID(), 0, offeredAt)
+			Expect(err).NotTo(HaveOccurred())
+
+			scheduled, err := database.ScheduleQueuedBuild(build.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(scheduled).To(BeTrue())
+
+			queuedBuilds, err := database.GetQueuedBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(queuedBuilds).To(BeEmpty())
+
+			counts, err := database.GetActiveBuildsCountByTeam()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(counts).To(Equal(map[string]int{"some-team": 1}))
+
+			By("no longer counting it as active once it is queued again")
+			_, err = database.SaveQueuedBuild(build.ID(), 0, offeredAt.Add(time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+
+			counts, err = database.GetActiveBuildsCountByTeam()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(counts).To(BeEmpty())
+		})
+
+		It("does not schedule a build which does not exist", func() {
+			scheduled, err := database.ScheduleQueuedBuild(12345)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(scheduled).To(BeFalse())
+		})
+	})
+
 	Describe("DeleteBuildEventsByBuildIDs", func() {
 		It("deletes all build logs corresponding to the given build ids", func() {
 			build1DB, err := teamDB.CreateOneOffBuild()
@@ -301,5 +452,106 @@ var _ = Describe("Builds", func() {
 			// Not required behavior, just a sanity check for what I think will happen
 			Expect(build4DB.ReapTime()).To(Equal(build1DB.ReapTime()))
 		})
+
+		Context("when archiving build events", func() {
+			var (
+				dir   string
+				build db.Build
+			)
+
+			BeforeEach(func() {
+				var err error
+				dir, err = ioutil.TempDir("", "build-events")
+				Expect(err).NotTo(HaveOccurred())
+
+				archiver := archive.NewArchiver(archive.NewLocalBackend(dir))
+				fakeArchiver.ArchiveStub = archiver.Archive
+				fakeArchiver.RetrieveStub = archiver.Retrieve
+
+				build, err = teamDB.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveEvent(event.Log{Payload: "log 1"})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveEvent(event.Log{Payload: "log 2"})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.Finish(db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			It("archives the events before deleting them", func() {
+				err := database.DeleteBuildEventsByBuildIDs([]int{build.ID()})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeArchiver.ArchiveCallCount()).To(Equal(1))
+				buildID, _ := fakeArchiver.ArchiveArgsForCall(0)
+				Expect(buildID).To(Equal(build.ID()))
+
+				By("streaming the events back from the archive")
+				events, err := build.Events(0)
+				Expect(err).NotTo(HaveOccurred())
+				defer events.Close()
+
+				ev, err := events.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ev).To(Equal(envelope(event.Log{Payload: "log 1"})))
+
+				ev, err = events.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ev).To(Equal(envelope(event.Log{Payload: "log 2"})))
+
+				_, err = events.Next() // finish event
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = events.Next()
+				Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+
+				By("streaming from the given event")
+				laterEvents, err := build.Events(1)
+				Expect(err).NotTo(HaveOccurred())
+				defer laterEvents.Close()
+
+				ev, err = laterEvents.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ev).To(Equal(envelope(event.Log{Payload: "log 2"})))
+			})
+
+			It("does not archive builds which have already been reaped", func() {
+				err := database.DeleteBuildEventsByBuildIDs([]int{build.ID()})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = database.DeleteBuildEventsByBuildIDs([]int{build.ID()})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeArchiver.ArchiveCallCount()).To(Equal(1))
+			})
+
+			Context("when archiving fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeArchiver.ArchiveReturns(disaster)
+				})
+
+				It("returns the error and keeps the events", func() {
+					err := database.DeleteBuildEventsByBuildIDs([]int{build.ID()})
+					Expect(err).To(Equal(disaster))
+
+					events, err := build.Events(0)
+					Expect(err).NotTo(HaveOccurred())
+					defer events.Close()
+
+					ev, err := events.Next()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ev).To(Equal(envelope(event.Log{Payload: "log 1"})))
+				})
+			})
+		})
 	})
 })
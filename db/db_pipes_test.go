@@ -34,7 +34,7 @@ var _ = Describe("Pipes", func() {
 		retryableConn := &lock.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := lock.NewLockFactory(retryableConn)
-		database = db.NewSQL(dbConn, bus, lockFactory)
+		database = db.NewSQL(dbConn, bus, lockFactory, nil)
 
 		savedTeam, err = database.CreateTeam(db.Team{Name: "team-name"})
 		Expect(err).NotTo(HaveOccurred())
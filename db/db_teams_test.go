@@ -45,9 +45,9 @@ var _ = Describe("SQL DB Teams", func() {
 		retryableConn := &lock.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := lock.NewLockFactory(retryableConn)
-		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory)
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory)
-		database = db.NewSQL(dbConn, bus, lockFactory)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory, nil)
+		database = db.NewSQL(dbConn, bus, lockFactory, nil)
 
 		workerFactory = dbng.NewWorkerFactory(dbngConn)
 		database.DeleteTeamByName(atc.DefaultTeamName)
@@ -298,6 +298,46 @@ var _ = Describe("SQL DB Teams", func() {
 
 			Expect(savedTeam.GenericOAuth).To(Equal(expectedTeam.GenericOAuth))
 		})
+
+		It("saves a team to the db with LDAP auth", func() {
+			expectedTeam := db.Team{
+				Name: "shield",
+				LDAPAuth: &db.LDAPAuth{
+					Host:             "ldap.example.com:389",
+					UserSearchBaseDN: "ou=people,dc=example,dc=com",
+					UserSearchFilter: "(uid=%s)",
+					StartTLS:         true,
+				},
+			}
+			expectedSavedTeam, err := database.CreateTeam(expectedTeam)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expectedSavedTeam.Team).To(Equal(expectedTeam))
+
+			savedTeam, found, err := teamDBFactory.GetTeamDB("shield").GetTeam()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(savedTeam).To(Equal(expectedSavedTeam))
+
+			Expect(savedTeam.LDAPAuth).To(Equal(expectedTeam.LDAPAuth))
+		})
+
+		It("saves a team to the db with roles", func() {
+			expectedTeam := db.Team{
+				Name: "avengers",
+				Roles: map[string]atc.TeamRole{
+					"github": atc.TeamRolePipelineOperator,
+				},
+			}
+			expectedSavedTeam, err := database.CreateTeam(expectedTeam)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expectedSavedTeam.Team).To(Equal(expectedTeam))
+
+			savedTeam, found, err := teamDBFactory.GetTeamDB("avengers").GetTeam()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(savedTeam.RoleFor("github")).To(Equal(atc.TeamRolePipelineOperator))
+			Expect(savedTeam.RoleFor("basic")).To(Equal(atc.TeamRoleOwner))
+		})
 	})
 
 	Describe("DeleteTeamByName", func() {
@@ -156,6 +156,33 @@ type FakeBuild struct {
 	isManuallyTriggeredReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	RerunOfStub        func() int
+	rerunOfMutex       sync.RWMutex
+	rerunOfArgsForCall []struct{}
+	rerunOfReturns     struct {
+		result1 int
+	}
+	rerunOfReturnsOnCall map[int]struct {
+		result1 int
+	}
+	RerunOfNameStub        func() string
+	rerunOfNameMutex       sync.RWMutex
+	rerunOfNameArgsForCall []struct{}
+	rerunOfNameReturns     struct {
+		result1 string
+	}
+	rerunOfNameReturnsOnCall map[int]struct {
+		result1 string
+	}
+	BreakpointStub        func() atc.PlanID
+	breakpointMutex       sync.RWMutex
+	breakpointArgsForCall []struct{}
+	breakpointReturns     struct {
+		result1 atc.PlanID
+	}
+	breakpointReturnsOnCall map[int]struct {
+		result1 atc.PlanID
+	}
 	ReloadStub        func() (bool, error)
 	reloadMutex       sync.RWMutex
 	reloadArgsForCall []struct{}
@@ -271,6 +298,39 @@ type FakeBuild struct {
 		result1 db.Notifier
 		result2 error
 	}
+	PauseAtBreakpointStub        func(planID atc.PlanID) error
+	pauseAtBreakpointMutex       sync.RWMutex
+	pauseAtBreakpointArgsForCall []struct {
+		planID atc.PlanID
+	}
+	pauseAtBreakpointReturns struct {
+		result1 error
+	}
+	pauseAtBreakpointReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ResumeFromBreakpointStub        func() (bool, error)
+	resumeFromBreakpointMutex       sync.RWMutex
+	resumeFromBreakpointArgsForCall []struct{}
+	resumeFromBreakpointReturns     struct {
+		result1 bool
+		result2 error
+	}
+	resumeFromBreakpointReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	ResumeNotifierStub        func() (db.Notifier, error)
+	resumeNotifierMutex       sync.RWMutex
+	resumeNotifierArgsForCall []struct{}
+	resumeNotifierReturns     struct {
+		result1 db.Notifier
+		result2 error
+	}
+	resumeNotifierReturnsOnCall map[int]struct {
+		result1 db.Notifier
+		result2 error
+	}
 	AcquireTrackingLockStub        func(logger lager.Logger, interval time.Duration) (lock.Lock, bool, error)
 	acquireTrackingLockMutex       sync.RWMutex
 	acquireTrackingLockArgsForCall []struct {
@@ -1029,6 +1089,126 @@ func (fake *FakeBuild) IsManuallyTriggeredReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeBuild) RerunOf() int {
+	fake.rerunOfMutex.Lock()
+	ret, specificReturn := fake.rerunOfReturnsOnCall[len(fake.rerunOfArgsForCall)]
+	fake.rerunOfArgsForCall = append(fake.rerunOfArgsForCall, struct{}{})
+	fake.recordInvocation("RerunOf", []interface{}{})
+	fake.rerunOfMutex.Unlock()
+	if fake.RerunOfStub != nil {
+		return fake.RerunOfStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.rerunOfReturns.result1
+}
+
+func (fake *FakeBuild) RerunOfCallCount() int {
+	fake.rerunOfMutex.RLock()
+	defer fake.rerunOfMutex.RUnlock()
+	return len(fake.rerunOfArgsForCall)
+}
+
+func (fake *FakeBuild) RerunOfReturns(result1 int) {
+	fake.RerunOfStub = nil
+	fake.rerunOfReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) RerunOfReturnsOnCall(i int, result1 int) {
+	fake.RerunOfStub = nil
+	if fake.rerunOfReturnsOnCall == nil {
+		fake.rerunOfReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.rerunOfReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) RerunOfName() string {
+	fake.rerunOfNameMutex.Lock()
+	ret, specificReturn := fake.rerunOfNameReturnsOnCall[len(fake.rerunOfNameArgsForCall)]
+	fake.rerunOfNameArgsForCall = append(fake.rerunOfNameArgsForCall, struct{}{})
+	fake.recordInvocation("RerunOfName", []interface{}{})
+	fake.rerunOfNameMutex.Unlock()
+	if fake.RerunOfNameStub != nil {
+		return fake.RerunOfNameStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.rerunOfNameReturns.result1
+}
+
+func (fake *FakeBuild) RerunOfNameCallCount() int {
+	fake.rerunOfNameMutex.RLock()
+	defer fake.rerunOfNameMutex.RUnlock()
+	return len(fake.rerunOfNameArgsForCall)
+}
+
+func (fake *FakeBuild) RerunOfNameReturns(result1 string) {
+	fake.RerunOfNameStub = nil
+	fake.rerunOfNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) RerunOfNameReturnsOnCall(i int, result1 string) {
+	fake.RerunOfNameStub = nil
+	if fake.rerunOfNameReturnsOnCall == nil {
+		fake.rerunOfNameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.rerunOfNameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) Breakpoint() atc.PlanID {
+	fake.breakpointMutex.Lock()
+	ret, specificReturn := fake.breakpointReturnsOnCall[len(fake.breakpointArgsForCall)]
+	fake.breakpointArgsForCall = append(fake.breakpointArgsForCall, struct{}{})
+	fake.recordInvocation("Breakpoint", []interface{}{})
+	fake.breakpointMutex.Unlock()
+	if fake.BreakpointStub != nil {
+		return fake.BreakpointStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.breakpointReturns.result1
+}
+
+func (fake *FakeBuild) BreakpointCallCount() int {
+	fake.breakpointMutex.RLock()
+	defer fake.breakpointMutex.RUnlock()
+	return len(fake.breakpointArgsForCall)
+}
+
+func (fake *FakeBuild) BreakpointReturns(result1 atc.PlanID) {
+	fake.BreakpointStub = nil
+	fake.breakpointReturns = struct {
+		result1 atc.PlanID
+	}{result1}
+}
+
+func (fake *FakeBuild) BreakpointReturnsOnCall(i int, result1 atc.PlanID) {
+	fake.BreakpointStub = nil
+	if fake.breakpointReturnsOnCall == nil {
+		fake.breakpointReturnsOnCall = make(map[int]struct {
+			result1 atc.PlanID
+		})
+	}
+	fake.breakpointReturnsOnCall[i] = struct {
+		result1 atc.PlanID
+	}{result1}
+}
+
 func (fake *FakeBuild) Reload() (bool, error) {
 	fake.reloadMutex.Lock()
 	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
@@ -1491,6 +1671,140 @@ func (fake *FakeBuild) AbortNotifierReturnsOnCall(i int, result1 db.Notifier, re
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) PauseAtBreakpoint(planID atc.PlanID) error {
+	fake.pauseAtBreakpointMutex.Lock()
+	ret, specificReturn := fake.pauseAtBreakpointReturnsOnCall[len(fake.pauseAtBreakpointArgsForCall)]
+	fake.pauseAtBreakpointArgsForCall = append(fake.pauseAtBreakpointArgsForCall, struct {
+		planID atc.PlanID
+	}{planID})
+	fake.recordInvocation("PauseAtBreakpoint", []interface{}{planID})
+	fake.pauseAtBreakpointMutex.Unlock()
+	if fake.PauseAtBreakpointStub != nil {
+		return fake.PauseAtBreakpointStub(planID)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.pauseAtBreakpointReturns.result1
+}
+
+func (fake *FakeBuild) PauseAtBreakpointCallCount() int {
+	fake.pauseAtBreakpointMutex.RLock()
+	defer fake.pauseAtBreakpointMutex.RUnlock()
+	return len(fake.pauseAtBreakpointArgsForCall)
+}
+
+func (fake *FakeBuild) PauseAtBreakpointArgsForCall(i int) atc.PlanID {
+	fake.pauseAtBreakpointMutex.RLock()
+	defer fake.pauseAtBreakpointMutex.RUnlock()
+	return fake.pauseAtBreakpointArgsForCall[i].planID
+}
+
+func (fake *FakeBuild) PauseAtBreakpointReturns(result1 error) {
+	fake.PauseAtBreakpointStub = nil
+	fake.pauseAtBreakpointReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) PauseAtBreakpointReturnsOnCall(i int, result1 error) {
+	fake.PauseAtBreakpointStub = nil
+	if fake.pauseAtBreakpointReturnsOnCall == nil {
+		fake.pauseAtBreakpointReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pauseAtBreakpointReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ResumeFromBreakpoint() (bool, error) {
+	fake.resumeFromBreakpointMutex.Lock()
+	ret, specificReturn := fake.resumeFromBreakpointReturnsOnCall[len(fake.resumeFromBreakpointArgsForCall)]
+	fake.resumeFromBreakpointArgsForCall = append(fake.resumeFromBreakpointArgsForCall, struct{}{})
+	fake.recordInvocation("ResumeFromBreakpoint", []interface{}{})
+	fake.resumeFromBreakpointMutex.Unlock()
+	if fake.ResumeFromBreakpointStub != nil {
+		return fake.ResumeFromBreakpointStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.resumeFromBreakpointReturns.result1, fake.resumeFromBreakpointReturns.result2
+}
+
+func (fake *FakeBuild) ResumeFromBreakpointCallCount() int {
+	fake.resumeFromBreakpointMutex.RLock()
+	defer fake.resumeFromBreakpointMutex.RUnlock()
+	return len(fake.resumeFromBreakpointArgsForCall)
+}
+
+func (fake *FakeBuild) ResumeFromBreakpointReturns(result1 bool, result2 error) {
+	fake.ResumeFromBreakpointStub = nil
+	fake.resumeFromBreakpointReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ResumeFromBreakpointReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.ResumeFromBreakpointStub = nil
+	if fake.resumeFromBreakpointReturnsOnCall == nil {
+		fake.resumeFromBreakpointReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.resumeFromBreakpointReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ResumeNotifier() (db.Notifier, error) {
+	fake.resumeNotifierMutex.Lock()
+	ret, specificReturn := fake.resumeNotifierReturnsOnCall[len(fake.resumeNotifierArgsForCall)]
+	fake.resumeNotifierArgsForCall = append(fake.resumeNotifierArgsForCall, struct{}{})
+	fake.recordInvocation("ResumeNotifier", []interface{}{})
+	fake.resumeNotifierMutex.Unlock()
+	if fake.ResumeNotifierStub != nil {
+		return fake.ResumeNotifierStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.resumeNotifierReturns.result1, fake.resumeNotifierReturns.result2
+}
+
+func (fake *FakeBuild) ResumeNotifierCallCount() int {
+	fake.resumeNotifierMutex.RLock()
+	defer fake.resumeNotifierMutex.RUnlock()
+	return len(fake.resumeNotifierArgsForCall)
+}
+
+func (fake *FakeBuild) ResumeNotifierReturns(result1 db.Notifier, result2 error) {
+	fake.ResumeNotifierStub = nil
+	fake.resumeNotifierReturns = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ResumeNotifierReturnsOnCall(i int, result1 db.Notifier, result2 error) {
+	fake.ResumeNotifierStub = nil
+	if fake.resumeNotifierReturnsOnCall == nil {
+		fake.resumeNotifierReturnsOnCall = make(map[int]struct {
+			result1 db.Notifier
+			result2 error
+		})
+	}
+	fake.resumeNotifierReturnsOnCall[i] = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) AcquireTrackingLock(logger lager.Logger, interval time.Duration) (lock.Lock, bool, error) {
 	fake.acquireTrackingLockMutex.Lock()
 	ret, specificReturn := fake.acquireTrackingLockReturnsOnCall[len(fake.acquireTrackingLockArgsForCall)]
@@ -1959,6 +2273,12 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.isRunningMutex.RUnlock()
 	fake.isManuallyTriggeredMutex.RLock()
 	defer fake.isManuallyTriggeredMutex.RUnlock()
+	fake.rerunOfMutex.RLock()
+	defer fake.rerunOfMutex.RUnlock()
+	fake.rerunOfNameMutex.RLock()
+	defer fake.rerunOfNameMutex.RUnlock()
+	fake.breakpointMutex.RLock()
+	defer fake.breakpointMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
 	fake.eventsMutex.RLock()
@@ -1979,6 +2299,12 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.abortMutex.RUnlock()
 	fake.abortNotifierMutex.RLock()
 	defer fake.abortNotifierMutex.RUnlock()
+	fake.pauseAtBreakpointMutex.RLock()
+	defer fake.pauseAtBreakpointMutex.RUnlock()
+	fake.resumeFromBreakpointMutex.RLock()
+	defer fake.resumeFromBreakpointMutex.RUnlock()
+	fake.resumeNotifierMutex.RLock()
+	defer fake.resumeNotifierMutex.RUnlock()
 	fake.acquireTrackingLockMutex.RLock()
 	defer fake.acquireTrackingLockMutex.RUnlock()
 	fake.getPreparationMutex.RLock()
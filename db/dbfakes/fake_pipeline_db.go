@@ -60,6 +60,15 @@ type FakePipelineDB struct {
 	teamIDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	TeamNameStub        func() string
+	teamNameMutex       sync.RWMutex
+	teamNameArgsForCall []struct{}
+	teamNameReturns     struct {
+		result1 string
+	}
+	teamNameReturnsOnCall map[int]struct {
+		result1 string
+	}
 	ConfigStub        func() atc.Config
 	configMutex       sync.RWMutex
 	configArgsForCall []struct{}
@@ -322,6 +331,34 @@ type FakePipelineDB struct {
 	disableVersionedResourceReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PinResourceVersionStub        func(resourceName string, versionedResourceID int, comment string) (bool, error)
+	pinResourceVersionMutex       sync.RWMutex
+	pinResourceVersionArgsForCall []struct {
+		resourceName        string
+		versionedResourceID int
+		comment             string
+	}
+	pinResourceVersionReturns struct {
+		result1 bool
+		result2 error
+	}
+	pinResourceVersionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	UnpinResourceVersionStub        func(resourceName string) (bool, error)
+	unpinResourceVersionMutex       sync.RWMutex
+	unpinResourceVersionArgsForCall []struct {
+		resourceName string
+	}
+	unpinResourceVersionReturns struct {
+		result1 bool
+		result2 error
+	}
+	unpinResourceVersionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	SetResourceCheckErrorStub        func(resource db.SavedResource, err error) error
 	setResourceCheckErrorMutex       sync.RWMutex
 	setResourceCheckErrorArgsForCall []struct {
@@ -412,6 +449,18 @@ type FakePipelineDB struct {
 	setMaxInFlightReachedReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetOutsideTriggerWindowStub        func(string, bool) error
+	setOutsideTriggerWindowMutex       sync.RWMutex
+	setOutsideTriggerWindowArgsForCall []struct {
+		arg1 string
+		arg2 bool
+	}
+	setOutsideTriggerWindowReturns struct {
+		result1 error
+	}
+	setOutsideTriggerWindowReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateFirstLoggedBuildIDStub        func(job string, newFirstLoggedBuildID int) error
 	updateFirstLoggedBuildIDMutex       sync.RWMutex
 	updateFirstLoggedBuildIDArgsForCall []struct {
@@ -497,6 +546,19 @@ type FakePipelineDB struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateRerunBuildStub        func(build db.Build) (db.Build, error)
+	createRerunBuildMutex       sync.RWMutex
+	createRerunBuildArgsForCall []struct {
+		build db.Build
+	}
+	createRerunBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createRerunBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	EnsurePendingBuildExistsStub        func(jobName string) error
 	ensurePendingBuildExistsMutex       sync.RWMutex
 	ensurePendingBuildExistsArgsForCall []struct {
@@ -634,6 +696,21 @@ type FakePipelineDB struct {
 	deleteNextInputMappingReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ExplainInputsStub        func(jobName string) (atc.InputsExplanation, bool, error)
+	explainInputsMutex       sync.RWMutex
+	explainInputsArgsForCall []struct {
+		jobName string
+	}
+	explainInputsReturns struct {
+		result1 atc.InputsExplanation
+		result2 bool
+		result3 error
+	}
+	explainInputsReturnsOnCall map[int]struct {
+		result1 atc.InputsExplanation
+		result2 bool
+		result3 error
+	}
 	GetRunningBuildsBySerialGroupStub        func(jobName string, serialGroups []string) ([]db.Build, error)
 	getRunningBuildsBySerialGroupMutex       sync.RWMutex
 	getRunningBuildsBySerialGroupArgsForCall []struct {
@@ -975,6 +1052,46 @@ func (fake *FakePipelineDB) TeamIDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) TeamName() string {
+	fake.teamNameMutex.Lock()
+	ret, specificReturn := fake.teamNameReturnsOnCall[len(fake.teamNameArgsForCall)]
+	fake.teamNameArgsForCall = append(fake.teamNameArgsForCall, struct{}{})
+	fake.recordInvocation("TeamName", []interface{}{})
+	fake.teamNameMutex.Unlock()
+	if fake.TeamNameStub != nil {
+		return fake.TeamNameStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.teamNameReturns.result1
+}
+
+func (fake *FakePipelineDB) TeamNameCallCount() int {
+	fake.teamNameMutex.RLock()
+	defer fake.teamNameMutex.RUnlock()
+	return len(fake.teamNameArgsForCall)
+}
+
+func (fake *FakePipelineDB) TeamNameReturns(result1 string) {
+	fake.TeamNameStub = nil
+	fake.teamNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakePipelineDB) TeamNameReturnsOnCall(i int, result1 string) {
+	fake.TeamNameStub = nil
+	if fake.teamNameReturnsOnCall == nil {
+		fake.teamNameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.teamNameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakePipelineDB) Config() atc.Config {
 	fake.configMutex.Lock()
 	ret, specificReturn := fake.configReturnsOnCall[len(fake.configArgsForCall)]
@@ -2019,6 +2136,110 @@ func (fake *FakePipelineDB) DisableVersionedResourceReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakePipelineDB) PinResourceVersion(resourceName string, versionedResourceID int, comment string) (bool, error) {
+	fake.pinResourceVersionMutex.Lock()
+	ret, specificReturn := fake.pinResourceVersionReturnsOnCall[len(fake.pinResourceVersionArgsForCall)]
+	fake.pinResourceVersionArgsForCall = append(fake.pinResourceVersionArgsForCall, struct {
+		resourceName        string
+		versionedResourceID int
+		comment             string
+	}{resourceName, versionedResourceID, comment})
+	fake.recordInvocation("PinResourceVersion", []interface{}{resourceName, versionedResourceID, comment})
+	fake.pinResourceVersionMutex.Unlock()
+	if fake.PinResourceVersionStub != nil {
+		return fake.PinResourceVersionStub(resourceName, versionedResourceID, comment)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.pinResourceVersionReturns.result1, fake.pinResourceVersionReturns.result2
+}
+
+func (fake *FakePipelineDB) PinResourceVersionCallCount() int {
+	fake.pinResourceVersionMutex.RLock()
+	defer fake.pinResourceVersionMutex.RUnlock()
+	return len(fake.pinResourceVersionArgsForCall)
+}
+
+func (fake *FakePipelineDB) PinResourceVersionArgsForCall(i int) (string, int, string) {
+	fake.pinResourceVersionMutex.RLock()
+	defer fake.pinResourceVersionMutex.RUnlock()
+	return fake.pinResourceVersionArgsForCall[i].resourceName, fake.pinResourceVersionArgsForCall[i].versionedResourceID, fake.pinResourceVersionArgsForCall[i].comment
+}
+
+func (fake *FakePipelineDB) PinResourceVersionReturns(result1 bool, result2 error) {
+	fake.PinResourceVersionStub = nil
+	fake.pinResourceVersionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) PinResourceVersionReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.PinResourceVersionStub = nil
+	if fake.pinResourceVersionReturnsOnCall == nil {
+		fake.pinResourceVersionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.pinResourceVersionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) UnpinResourceVersion(resourceName string) (bool, error) {
+	fake.unpinResourceVersionMutex.Lock()
+	ret, specificReturn := fake.unpinResourceVersionReturnsOnCall[len(fake.unpinResourceVersionArgsForCall)]
+	fake.unpinResourceVersionArgsForCall = append(fake.unpinResourceVersionArgsForCall, struct {
+		resourceName string
+	}{resourceName})
+	fake.recordInvocation("UnpinResourceVersion", []interface{}{resourceName})
+	fake.unpinResourceVersionMutex.Unlock()
+	if fake.UnpinResourceVersionStub != nil {
+		return fake.UnpinResourceVersionStub(resourceName)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.unpinResourceVersionReturns.result1, fake.unpinResourceVersionReturns.result2
+}
+
+func (fake *FakePipelineDB) UnpinResourceVersionCallCount() int {
+	fake.unpinResourceVersionMutex.RLock()
+	defer fake.unpinResourceVersionMutex.RUnlock()
+	return len(fake.unpinResourceVersionArgsForCall)
+}
+
+func (fake *FakePipelineDB) UnpinResourceVersionArgsForCall(i int) string {
+	fake.unpinResourceVersionMutex.RLock()
+	defer fake.unpinResourceVersionMutex.RUnlock()
+	return fake.unpinResourceVersionArgsForCall[i].resourceName
+}
+
+func (fake *FakePipelineDB) UnpinResourceVersionReturns(result1 bool, result2 error) {
+	fake.UnpinResourceVersionStub = nil
+	fake.unpinResourceVersionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) UnpinResourceVersionReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.UnpinResourceVersionStub = nil
+	if fake.unpinResourceVersionReturnsOnCall == nil {
+		fake.unpinResourceVersionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.unpinResourceVersionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) SetResourceCheckError(resource db.SavedResource, err error) error {
 	fake.setResourceCheckErrorMutex.Lock()
 	ret, specificReturn := fake.setResourceCheckErrorReturnsOnCall[len(fake.setResourceCheckErrorArgsForCall)]
@@ -2367,6 +2588,55 @@ func (fake *FakePipelineDB) SetMaxInFlightReachedReturnsOnCall(i int, result1 er
 	}{result1}
 }
 
+func (fake *FakePipelineDB) SetOutsideTriggerWindow(arg1 string, arg2 bool) error {
+	fake.setOutsideTriggerWindowMutex.Lock()
+	ret, specificReturn := fake.setOutsideTriggerWindowReturnsOnCall[len(fake.setOutsideTriggerWindowArgsForCall)]
+	fake.setOutsideTriggerWindowArgsForCall = append(fake.setOutsideTriggerWindowArgsForCall, struct {
+		arg1 string
+		arg2 bool
+	}{arg1, arg2})
+	fake.recordInvocation("SetOutsideTriggerWindow", []interface{}{arg1, arg2})
+	fake.setOutsideTriggerWindowMutex.Unlock()
+	if fake.SetOutsideTriggerWindowStub != nil {
+		return fake.SetOutsideTriggerWindowStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.setOutsideTriggerWindowReturns.result1
+}
+
+func (fake *FakePipelineDB) SetOutsideTriggerWindowCallCount() int {
+	fake.setOutsideTriggerWindowMutex.RLock()
+	defer fake.setOutsideTriggerWindowMutex.RUnlock()
+	return len(fake.setOutsideTriggerWindowArgsForCall)
+}
+
+func (fake *FakePipelineDB) SetOutsideTriggerWindowArgsForCall(i int) (string, bool) {
+	fake.setOutsideTriggerWindowMutex.RLock()
+	defer fake.setOutsideTriggerWindowMutex.RUnlock()
+	return fake.setOutsideTriggerWindowArgsForCall[i].arg1, fake.setOutsideTriggerWindowArgsForCall[i].arg2
+}
+
+func (fake *FakePipelineDB) SetOutsideTriggerWindowReturns(result1 error) {
+	fake.SetOutsideTriggerWindowStub = nil
+	fake.setOutsideTriggerWindowReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) SetOutsideTriggerWindowReturnsOnCall(i int, result1 error) {
+	fake.SetOutsideTriggerWindowStub = nil
+	if fake.setOutsideTriggerWindowReturnsOnCall == nil {
+		fake.setOutsideTriggerWindowReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setOutsideTriggerWindowReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePipelineDB) UpdateFirstLoggedBuildID(job string, newFirstLoggedBuildID int) error {
 	fake.updateFirstLoggedBuildIDMutex.Lock()
 	ret, specificReturn := fake.updateFirstLoggedBuildIDReturnsOnCall[len(fake.updateFirstLoggedBuildIDArgsForCall)]
@@ -2682,6 +2952,57 @@ func (fake *FakePipelineDB) CreateJobBuildReturnsOnCall(i int, result1 db.Build,
 	}{result1, result2}
 }
 
+func (fake *FakePipelineDB) CreateRerunBuild(build db.Build) (db.Build, error) {
+	fake.createRerunBuildMutex.Lock()
+	ret, specificReturn := fake.createRerunBuildReturnsOnCall[len(fake.createRerunBuildArgsForCall)]
+	fake.createRerunBuildArgsForCall = append(fake.createRerunBuildArgsForCall, struct {
+		build db.Build
+	}{build})
+	fake.recordInvocation("CreateRerunBuild", []interface{}{build})
+	fake.createRerunBuildMutex.Unlock()
+	if fake.CreateRerunBuildStub != nil {
+		return fake.CreateRerunBuildStub(build)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createRerunBuildReturns.result1, fake.createRerunBuildReturns.result2
+}
+
+func (fake *FakePipelineDB) CreateRerunBuildCallCount() int {
+	fake.createRerunBuildMutex.RLock()
+	defer fake.createRerunBuildMutex.RUnlock()
+	return len(fake.createRerunBuildArgsForCall)
+}
+
+func (fake *FakePipelineDB) CreateRerunBuildArgsForCall(i int) db.Build {
+	fake.createRerunBuildMutex.RLock()
+	defer fake.createRerunBuildMutex.RUnlock()
+	return fake.createRerunBuildArgsForCall[i].build
+}
+
+func (fake *FakePipelineDB) CreateRerunBuildReturns(result1 db.Build, result2 error) {
+	fake.CreateRerunBuildStub = nil
+	fake.createRerunBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) CreateRerunBuildReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.CreateRerunBuildStub = nil
+	if fake.createRerunBuildReturnsOnCall == nil {
+		fake.createRerunBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createRerunBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) EnsurePendingBuildExists(jobName string) error {
 	fake.ensurePendingBuildExistsMutex.Lock()
 	ret, specificReturn := fake.ensurePendingBuildExistsReturnsOnCall[len(fake.ensurePendingBuildExistsArgsForCall)]
@@ -3227,6 +3548,60 @@ func (fake *FakePipelineDB) DeleteNextInputMappingReturnsOnCall(i int, result1 e
 	}{result1}
 }
 
+func (fake *FakePipelineDB) ExplainInputs(jobName string) (atc.InputsExplanation, bool, error) {
+	fake.explainInputsMutex.Lock()
+	ret, specificReturn := fake.explainInputsReturnsOnCall[len(fake.explainInputsArgsForCall)]
+	fake.explainInputsArgsForCall = append(fake.explainInputsArgsForCall, struct {
+		jobName string
+	}{jobName})
+	fake.recordInvocation("ExplainInputs", []interface{}{jobName})
+	fake.explainInputsMutex.Unlock()
+	if fake.ExplainInputsStub != nil {
+		return fake.ExplainInputsStub(jobName)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.explainInputsReturns.result1, fake.explainInputsReturns.result2, fake.explainInputsReturns.result3
+}
+
+func (fake *FakePipelineDB) ExplainInputsCallCount() int {
+	fake.explainInputsMutex.RLock()
+	defer fake.explainInputsMutex.RUnlock()
+	return len(fake.explainInputsArgsForCall)
+}
+
+func (fake *FakePipelineDB) ExplainInputsArgsForCall(i int) string {
+	fake.explainInputsMutex.RLock()
+	defer fake.explainInputsMutex.RUnlock()
+	return fake.explainInputsArgsForCall[i].jobName
+}
+
+func (fake *FakePipelineDB) ExplainInputsReturns(result1 atc.InputsExplanation, result2 bool, result3 error) {
+	fake.ExplainInputsStub = nil
+	fake.explainInputsReturns = struct {
+		result1 atc.InputsExplanation
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipelineDB) ExplainInputsReturnsOnCall(i int, result1 atc.InputsExplanation, result2 bool, result3 error) {
+	fake.ExplainInputsStub = nil
+	if fake.explainInputsReturnsOnCall == nil {
+		fake.explainInputsReturnsOnCall = make(map[int]struct {
+			result1 atc.InputsExplanation
+			result2 bool
+			result3 error
+		})
+	}
+	fake.explainInputsReturnsOnCall[i] = struct {
+		result1 atc.InputsExplanation
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakePipelineDB) GetRunningBuildsBySerialGroup(jobName string, serialGroups []string) ([]db.Build, error) {
 	var serialGroupsCopy []string
 	if serialGroups != nil {
@@ -3741,6 +4116,8 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.scopedNameMutex.RUnlock()
 	fake.teamIDMutex.RLock()
 	defer fake.teamIDMutex.RUnlock()
+	fake.teamNameMutex.RLock()
+	defer fake.teamNameMutex.RUnlock()
 	fake.configMutex.RLock()
 	defer fake.configMutex.RUnlock()
 	fake.configVersionMutex.RLock()
@@ -3785,6 +4162,10 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.enableVersionedResourceMutex.RUnlock()
 	fake.disableVersionedResourceMutex.RLock()
 	defer fake.disableVersionedResourceMutex.RUnlock()
+	fake.pinResourceVersionMutex.RLock()
+	defer fake.pinResourceVersionMutex.RUnlock()
+	fake.unpinResourceVersionMutex.RLock()
+	defer fake.unpinResourceVersionMutex.RUnlock()
 	fake.setResourceCheckErrorMutex.RLock()
 	defer fake.setResourceCheckErrorMutex.RUnlock()
 	fake.acquireResourceTypeCheckingLockMutex.RLock()
@@ -3799,6 +4180,8 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.unpauseJobMutex.RUnlock()
 	fake.setMaxInFlightReachedMutex.RLock()
 	defer fake.setMaxInFlightReachedMutex.RUnlock()
+	fake.setOutsideTriggerWindowMutex.RLock()
+	defer fake.setOutsideTriggerWindowMutex.RUnlock()
 	fake.updateFirstLoggedBuildIDMutex.RLock()
 	defer fake.updateFirstLoggedBuildIDMutex.RUnlock()
 	fake.getJobFinishedAndNextBuildMutex.RLock()
@@ -3811,6 +4194,8 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.getJobBuildMutex.RUnlock()
 	fake.createJobBuildMutex.RLock()
 	defer fake.createJobBuildMutex.RUnlock()
+	fake.createRerunBuildMutex.RLock()
+	defer fake.createRerunBuildMutex.RUnlock()
 	fake.ensurePendingBuildExistsMutex.RLock()
 	defer fake.ensurePendingBuildExistsMutex.RUnlock()
 	fake.getPendingBuildsForJobMutex.RLock()
@@ -3833,6 +4218,8 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.getNextBuildInputsMutex.RUnlock()
 	fake.deleteNextInputMappingMutex.RLock()
 	defer fake.deleteNextInputMappingMutex.RUnlock()
+	fake.explainInputsMutex.RLock()
+	defer fake.explainInputsMutex.RUnlock()
 	fake.getRunningBuildsBySerialGroupMutex.RLock()
 	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
 	fake.getNextPendingBuildBySerialGroupMutex.RLock()
@@ -133,6 +133,107 @@ type FakeTeamDB struct {
 		result1 db.SavedTeam
 		result2 error
 	}
+	UpdateLDAPAuthStub        func(ldapAuth *db.LDAPAuth) (db.SavedTeam, error)
+	updateLDAPAuthMutex       sync.RWMutex
+	updateLDAPAuthArgsForCall []struct {
+		ldapAuth *db.LDAPAuth
+	}
+	updateLDAPAuthReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	updateLDAPAuthReturnsOnCall map[int]struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	UpdateRolesStub        func(roles map[string]atc.TeamRole) (db.SavedTeam, error)
+	updateRolesMutex       sync.RWMutex
+	updateRolesArgsForCall []struct {
+		roles map[string]atc.TeamRole
+	}
+	updateRolesReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	updateRolesReturnsOnCall map[int]struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	CreateAPITokenStub        func(token db.APIToken, tokenHash string) (db.SavedAPIToken, error)
+	createAPITokenMutex       sync.RWMutex
+	createAPITokenArgsForCall []struct {
+		token     db.APIToken
+		tokenHash string
+	}
+	createAPITokenReturns struct {
+		result1 db.SavedAPIToken
+		result2 error
+	}
+	createAPITokenReturnsOnCall map[int]struct {
+		result1 db.SavedAPIToken
+		result2 error
+	}
+	GetAPITokensStub        func() ([]db.SavedAPIToken, error)
+	getAPITokensMutex       sync.RWMutex
+	getAPITokensArgsForCall []struct{}
+	getAPITokensReturns     struct {
+		result1 []db.SavedAPIToken
+		result2 error
+	}
+	getAPITokensReturnsOnCall map[int]struct {
+		result1 []db.SavedAPIToken
+		result2 error
+	}
+	DeleteAPITokenStub        func(name string) (bool, error)
+	deleteAPITokenMutex       sync.RWMutex
+	deleteAPITokenArgsForCall []struct {
+		name string
+	}
+	deleteAPITokenReturns struct {
+		result1 bool
+		result2 error
+	}
+	deleteAPITokenReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	CreateNotificationSubscriptionStub        func(subscription db.NotificationSubscription) (db.SavedNotificationSubscription, error)
+	createNotificationSubscriptionMutex       sync.RWMutex
+	createNotificationSubscriptionArgsForCall []struct {
+		subscription db.NotificationSubscription
+	}
+	createNotificationSubscriptionReturns struct {
+		result1 db.SavedNotificationSubscription
+		result2 error
+	}
+	createNotificationSubscriptionReturnsOnCall map[int]struct {
+		result1 db.SavedNotificationSubscription
+		result2 error
+	}
+	GetNotificationSubscriptionsStub        func() ([]db.SavedNotificationSubscription, error)
+	getNotificationSubscriptionsMutex       sync.RWMutex
+	getNotificationSubscriptionsArgsForCall []struct{}
+	getNotificationSubscriptionsReturns     struct {
+		result1 []db.SavedNotificationSubscription
+		result2 error
+	}
+	getNotificationSubscriptionsReturnsOnCall map[int]struct {
+		result1 []db.SavedNotificationSubscription
+		result2 error
+	}
+	DeleteNotificationSubscriptionStub        func(id int) (bool, error)
+	deleteNotificationSubscriptionMutex       sync.RWMutex
+	deleteNotificationSubscriptionArgsForCall []struct {
+		id int
+	}
+	deleteNotificationSubscriptionReturns struct {
+		result1 bool
+		result2 error
+	}
+	deleteNotificationSubscriptionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	GetConfigStub        func(pipelineName string) (atc.Config, atc.RawConfig, db.ConfigVersion, error)
 	getConfigMutex       sync.RWMutex
 	getConfigArgsForCall []struct {
@@ -712,6 +813,399 @@ func (fake *FakeTeamDB) UpdateGenericOAuthReturnsOnCall(i int, result1 db.SavedT
 	}{result1, result2}
 }
 
+func (fake *FakeTeamDB) UpdateLDAPAuth(ldapAuth *db.LDAPAuth) (db.SavedTeam, error) {
+	fake.updateLDAPAuthMutex.Lock()
+	ret, specificReturn := fake.updateLDAPAuthReturnsOnCall[len(fake.updateLDAPAuthArgsForCall)]
+	fake.updateLDAPAuthArgsForCall = append(fake.updateLDAPAuthArgsForCall, struct {
+		ldapAuth *db.LDAPAuth
+	}{ldapAuth})
+	fake.recordInvocation("UpdateLDAPAuth", []interface{}{ldapAuth})
+	fake.updateLDAPAuthMutex.Unlock()
+	if fake.UpdateLDAPAuthStub != nil {
+		return fake.UpdateLDAPAuthStub(ldapAuth)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.updateLDAPAuthReturns.result1, fake.updateLDAPAuthReturns.result2
+}
+
+func (fake *FakeTeamDB) UpdateLDAPAuthCallCount() int {
+	fake.updateLDAPAuthMutex.RLock()
+	defer fake.updateLDAPAuthMutex.RUnlock()
+	return len(fake.updateLDAPAuthArgsForCall)
+}
+
+func (fake *FakeTeamDB) UpdateLDAPAuthArgsForCall(i int) *db.LDAPAuth {
+	fake.updateLDAPAuthMutex.RLock()
+	defer fake.updateLDAPAuthMutex.RUnlock()
+	return fake.updateLDAPAuthArgsForCall[i].ldapAuth
+}
+
+func (fake *FakeTeamDB) UpdateLDAPAuthReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateLDAPAuthStub = nil
+	fake.updateLDAPAuthReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) UpdateLDAPAuthReturnsOnCall(i int, result1 db.SavedTeam, result2 error) {
+	fake.UpdateLDAPAuthStub = nil
+	if fake.updateLDAPAuthReturnsOnCall == nil {
+		fake.updateLDAPAuthReturnsOnCall = make(map[int]struct {
+			result1 db.SavedTeam
+			result2 error
+		})
+	}
+	fake.updateLDAPAuthReturnsOnCall[i] = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) UpdateRoles(roles map[string]atc.TeamRole) (db.SavedTeam, error) {
+	fake.updateRolesMutex.Lock()
+	ret, specificReturn := fake.updateRolesReturnsOnCall[len(fake.updateRolesArgsForCall)]
+	fake.updateRolesArgsForCall = append(fake.updateRolesArgsForCall, struct {
+		roles map[string]atc.TeamRole
+	}{roles})
+	fake.recordInvocation("UpdateRoles", []interface{}{roles})
+	fake.updateRolesMutex.Unlock()
+	if fake.UpdateRolesStub != nil {
+		return fake.UpdateRolesStub(roles)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.updateRolesReturns.result1, fake.updateRolesReturns.result2
+}
+
+func (fake *FakeTeamDB) UpdateRolesCallCount() int {
+	fake.updateRolesMutex.RLock()
+	defer fake.updateRolesMutex.RUnlock()
+	return len(fake.updateRolesArgsForCall)
+}
+
+func (fake *FakeTeamDB) UpdateRolesArgsForCall(i int) map[string]atc.TeamRole {
+	fake.updateRolesMutex.RLock()
+	defer fake.updateRolesMutex.RUnlock()
+	return fake.updateRolesArgsForCall[i].roles
+}
+
+func (fake *FakeTeamDB) UpdateRolesReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateRolesStub = nil
+	fake.updateRolesReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) UpdateRolesReturnsOnCall(i int, result1 db.SavedTeam, result2 error) {
+	fake.UpdateRolesStub = nil
+	if fake.updateRolesReturnsOnCall == nil {
+		fake.updateRolesReturnsOnCall = make(map[int]struct {
+			result1 db.SavedTeam
+			result2 error
+		})
+	}
+	fake.updateRolesReturnsOnCall[i] = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) CreateAPIToken(token db.APIToken, tokenHash string) (db.SavedAPIToken, error) {
+	fake.createAPITokenMutex.Lock()
+	ret, specificReturn := fake.createAPITokenReturnsOnCall[len(fake.createAPITokenArgsForCall)]
+	fake.createAPITokenArgsForCall = append(fake.createAPITokenArgsForCall, struct {
+		token     db.APIToken
+		tokenHash string
+	}{token, tokenHash})
+	fake.recordInvocation("CreateAPIToken", []interface{}{token, tokenHash})
+	fake.createAPITokenMutex.Unlock()
+	if fake.CreateAPITokenStub != nil {
+		return fake.CreateAPITokenStub(token, tokenHash)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createAPITokenReturns.result1, fake.createAPITokenReturns.result2
+}
+
+func (fake *FakeTeamDB) CreateAPITokenCallCount() int {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	return len(fake.createAPITokenArgsForCall)
+}
+
+func (fake *FakeTeamDB) CreateAPITokenArgsForCall(i int) (db.APIToken, string) {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	return fake.createAPITokenArgsForCall[i].token, fake.createAPITokenArgsForCall[i].tokenHash
+}
+
+func (fake *FakeTeamDB) CreateAPITokenReturns(result1 db.SavedAPIToken, result2 error) {
+	fake.CreateAPITokenStub = nil
+	fake.createAPITokenReturns = struct {
+		result1 db.SavedAPIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) CreateAPITokenReturnsOnCall(i int, result1 db.SavedAPIToken, result2 error) {
+	fake.CreateAPITokenStub = nil
+	if fake.createAPITokenReturnsOnCall == nil {
+		fake.createAPITokenReturnsOnCall = make(map[int]struct {
+			result1 db.SavedAPIToken
+			result2 error
+		})
+	}
+	fake.createAPITokenReturnsOnCall[i] = struct {
+		result1 db.SavedAPIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) GetAPITokens() ([]db.SavedAPIToken, error) {
+	fake.getAPITokensMutex.Lock()
+	ret, specificReturn := fake.getAPITokensReturnsOnCall[len(fake.getAPITokensArgsForCall)]
+	fake.getAPITokensArgsForCall = append(fake.getAPITokensArgsForCall, struct{}{})
+	fake.recordInvocation("GetAPITokens", []interface{}{})
+	fake.getAPITokensMutex.Unlock()
+	if fake.GetAPITokensStub != nil {
+		return fake.GetAPITokensStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getAPITokensReturns.result1, fake.getAPITokensReturns.result2
+}
+
+func (fake *FakeTeamDB) GetAPITokensCallCount() int {
+	fake.getAPITokensMutex.RLock()
+	defer fake.getAPITokensMutex.RUnlock()
+	return len(fake.getAPITokensArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetAPITokensReturns(result1 []db.SavedAPIToken, result2 error) {
+	fake.GetAPITokensStub = nil
+	fake.getAPITokensReturns = struct {
+		result1 []db.SavedAPIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) GetAPITokensReturnsOnCall(i int, result1 []db.SavedAPIToken, result2 error) {
+	fake.GetAPITokensStub = nil
+	if fake.getAPITokensReturnsOnCall == nil {
+		fake.getAPITokensReturnsOnCall = make(map[int]struct {
+			result1 []db.SavedAPIToken
+			result2 error
+		})
+	}
+	fake.getAPITokensReturnsOnCall[i] = struct {
+		result1 []db.SavedAPIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) DeleteAPIToken(name string) (bool, error) {
+	fake.deleteAPITokenMutex.Lock()
+	ret, specificReturn := fake.deleteAPITokenReturnsOnCall[len(fake.deleteAPITokenArgsForCall)]
+	fake.deleteAPITokenArgsForCall = append(fake.deleteAPITokenArgsForCall, struct {
+		name string
+	}{name})
+	fake.recordInvocation("DeleteAPIToken", []interface{}{name})
+	fake.deleteAPITokenMutex.Unlock()
+	if fake.DeleteAPITokenStub != nil {
+		return fake.DeleteAPITokenStub(name)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.deleteAPITokenReturns.result1, fake.deleteAPITokenReturns.result2
+}
+
+func (fake *FakeTeamDB) DeleteAPITokenCallCount() int {
+	fake.deleteAPITokenMutex.RLock()
+	defer fake.deleteAPITokenMutex.RUnlock()
+	return len(fake.deleteAPITokenArgsForCall)
+}
+
+func (fake *FakeTeamDB) DeleteAPITokenArgsForCall(i int) string {
+	fake.deleteAPITokenMutex.RLock()
+	defer fake.deleteAPITokenMutex.RUnlock()
+	return fake.deleteAPITokenArgsForCall[i].name
+}
+
+func (fake *FakeTeamDB) DeleteAPITokenReturns(result1 bool, result2 error) {
+	fake.DeleteAPITokenStub = nil
+	fake.deleteAPITokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) DeleteAPITokenReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.DeleteAPITokenStub = nil
+	if fake.deleteAPITokenReturnsOnCall == nil {
+		fake.deleteAPITokenReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.deleteAPITokenReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) CreateNotificationSubscription(subscription db.NotificationSubscription) (db.SavedNotificationSubscription, error) {
+	fake.createNotificationSubscriptionMutex.Lock()
+	ret, specificReturn := fake.createNotificationSubscriptionReturnsOnCall[len(fake.createNotificationSubscriptionArgsForCall)]
+	fake.createNotificationSubscriptionArgsForCall = append(fake.createNotificationSubscriptionArgsForCall, struct {
+		subscription db.NotificationSubscription
+	}{subscription})
+	fake.recordInvocation("CreateNotificationSubscription", []interface{}{subscription})
+	fake.createNotificationSubscriptionMutex.Unlock()
+	if fake.CreateNotificationSubscriptionStub != nil {
+		return fake.CreateNotificationSubscriptionStub(subscription)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createNotificationSubscriptionReturns.result1, fake.createNotificationSubscriptionReturns.result2
+}
+
+func (fake *FakeTeamDB) CreateNotificationSubscriptionCallCount() int {
+	fake.createNotificationSubscriptionMutex.RLock()
+	defer fake.createNotificationSubscriptionMutex.RUnlock()
+	return len(fake.createNotificationSubscriptionArgsForCall)
+}
+
+func (fake *FakeTeamDB) CreateNotificationSubscriptionArgsForCall(i int) db.NotificationSubscription {
+	fake.createNotificationSubscriptionMutex.RLock()
+	defer fake.createNotificationSubscriptionMutex.RUnlock()
+	return fake.createNotificationSubscriptionArgsForCall[i].subscription
+}
+
+func (fake *FakeTeamDB) CreateNotificationSubscriptionReturns(result1 db.SavedNotificationSubscription, result2 error) {
+	fake.CreateNotificationSubscriptionStub = nil
+	fake.createNotificationSubscriptionReturns = struct {
+		result1 db.SavedNotificationSubscription
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) CreateNotificationSubscriptionReturnsOnCall(i int, result1 db.SavedNotificationSubscription, result2 error) {
+	fake.CreateNotificationSubscriptionStub = nil
+	if fake.createNotificationSubscriptionReturnsOnCall == nil {
+		fake.createNotificationSubscriptionReturnsOnCall = make(map[int]struct {
+			result1 db.SavedNotificationSubscription
+			result2 error
+		})
+	}
+	fake.createNotificationSubscriptionReturnsOnCall[i] = struct {
+		result1 db.SavedNotificationSubscription
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) GetNotificationSubscriptions() ([]db.SavedNotificationSubscription, error) {
+	fake.getNotificationSubscriptionsMutex.Lock()
+	ret, specificReturn := fake.getNotificationSubscriptionsReturnsOnCall[len(fake.getNotificationSubscriptionsArgsForCall)]
+	fake.getNotificationSubscriptionsArgsForCall = append(fake.getNotificationSubscriptionsArgsForCall, struct{}{})
+	fake.recordInvocation("GetNotificationSubscriptions", []interface{}{})
+	fake.getNotificationSubscriptionsMutex.Unlock()
+	if fake.GetNotificationSubscriptionsStub != nil {
+		return fake.GetNotificationSubscriptionsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getNotificationSubscriptionsReturns.result1, fake.getNotificationSubscriptionsReturns.result2
+}
+
+func (fake *FakeTeamDB) GetNotificationSubscriptionsCallCount() int {
+	fake.getNotificationSubscriptionsMutex.RLock()
+	defer fake.getNotificationSubscriptionsMutex.RUnlock()
+	return len(fake.getNotificationSubscriptionsArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetNotificationSubscriptionsReturns(result1 []db.SavedNotificationSubscription, result2 error) {
+	fake.GetNotificationSubscriptionsStub = nil
+	fake.getNotificationSubscriptionsReturns = struct {
+		result1 []db.SavedNotificationSubscription
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) GetNotificationSubscriptionsReturnsOnCall(i int, result1 []db.SavedNotificationSubscription, result2 error) {
+	fake.GetNotificationSubscriptionsStub = nil
+	if fake.getNotificationSubscriptionsReturnsOnCall == nil {
+		fake.getNotificationSubscriptionsReturnsOnCall = make(map[int]struct {
+			result1 []db.SavedNotificationSubscription
+			result2 error
+		})
+	}
+	fake.getNotificationSubscriptionsReturnsOnCall[i] = struct {
+		result1 []db.SavedNotificationSubscription
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) DeleteNotificationSubscription(id int) (bool, error) {
+	fake.deleteNotificationSubscriptionMutex.Lock()
+	ret, specificReturn := fake.deleteNotificationSubscriptionReturnsOnCall[len(fake.deleteNotificationSubscriptionArgsForCall)]
+	fake.deleteNotificationSubscriptionArgsForCall = append(fake.deleteNotificationSubscriptionArgsForCall, struct {
+		id int
+	}{id})
+	fake.recordInvocation("DeleteNotificationSubscription", []interface{}{id})
+	fake.deleteNotificationSubscriptionMutex.Unlock()
+	if fake.DeleteNotificationSubscriptionStub != nil {
+		return fake.DeleteNotificationSubscriptionStub(id)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.deleteNotificationSubscriptionReturns.result1, fake.deleteNotificationSubscriptionReturns.result2
+}
+
+func (fake *FakeTeamDB) DeleteNotificationSubscriptionCallCount() int {
+	fake.deleteNotificationSubscriptionMutex.RLock()
+	defer fake.deleteNotificationSubscriptionMutex.RUnlock()
+	return len(fake.deleteNotificationSubscriptionArgsForCall)
+}
+
+func (fake *FakeTeamDB) DeleteNotificationSubscriptionArgsForCall(i int) int {
+	fake.deleteNotificationSubscriptionMutex.RLock()
+	defer fake.deleteNotificationSubscriptionMutex.RUnlock()
+	return fake.deleteNotificationSubscriptionArgsForCall[i].id
+}
+
+func (fake *FakeTeamDB) DeleteNotificationSubscriptionReturns(result1 bool, result2 error) {
+	fake.DeleteNotificationSubscriptionStub = nil
+	fake.deleteNotificationSubscriptionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) DeleteNotificationSubscriptionReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.DeleteNotificationSubscriptionStub = nil
+	if fake.deleteNotificationSubscriptionReturnsOnCall == nil {
+		fake.deleteNotificationSubscriptionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.deleteNotificationSubscriptionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeamDB) GetConfig(pipelineName string) (atc.Config, atc.RawConfig, db.ConfigVersion, error) {
 	fake.getConfigMutex.Lock()
 	ret, specificReturn := fake.getConfigReturnsOnCall[len(fake.getConfigArgsForCall)]
@@ -1051,6 +1545,22 @@ func (fake *FakeTeamDB) Invocations() map[string][][]interface{} {
 	defer fake.updateUAAAuthMutex.RUnlock()
 	fake.updateGenericOAuthMutex.RLock()
 	defer fake.updateGenericOAuthMutex.RUnlock()
+	fake.updateLDAPAuthMutex.RLock()
+	defer fake.updateLDAPAuthMutex.RUnlock()
+	fake.updateRolesMutex.RLock()
+	defer fake.updateRolesMutex.RUnlock()
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	fake.getAPITokensMutex.RLock()
+	defer fake.getAPITokensMutex.RUnlock()
+	fake.deleteAPITokenMutex.RLock()
+	defer fake.deleteAPITokenMutex.RUnlock()
+	fake.createNotificationSubscriptionMutex.RLock()
+	defer fake.createNotificationSubscriptionMutex.RUnlock()
+	fake.getNotificationSubscriptionsMutex.RLock()
+	defer fake.getNotificationSubscriptionsMutex.RUnlock()
+	fake.deleteNotificationSubscriptionMutex.RLock()
+	defer fake.deleteNotificationSubscriptionMutex.RUnlock()
 	fake.getConfigMutex.RLock()
 	defer fake.getConfigMutex.RUnlock()
 	fake.saveConfigToBeDeprecatedMutex.RLock()
@@ -19,6 +19,7 @@ const (
 	LockTypeBatch
 	LockTypeVolumeCreating
 	LockTypeContainerCreating
+	LockTypeBuildStarting
 )
 
 func NewBuildTrackingLockID(buildID int) LockID {
@@ -53,6 +54,10 @@ func NewContainerCreatingLockID(containerID int) LockID {
 	return LockID{LockTypeContainerCreating, containerID}
 }
 
+func NewBuildStartingLockID() LockID {
+	return LockID{LockTypeBuildStarting, 0}
+}
+
 //go:generate counterfeiter . LockFactory
 
 type LockFactory interface {
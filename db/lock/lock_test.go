@@ -51,10 +51,10 @@ var _ = Describe("Locks", func() {
 		retryableConn := &lock.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory = lock.NewLockFactory(retryableConn)
-		sqlDB = db.NewSQL(dbConn, bus, lockFactory)
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory, nil)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory, nil)
 
-		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
 		teamDB = teamDBFactory.GetTeamDB(atc.DefaultTeamName)
 
 		_, err := sqlDB.CreateTeam(db.Team{Name: "some-team"})
@@ -681,4 +681,24 @@ var _ = Describe("Locks", func() {
 			})
 		})
 	})
+
+	Describe("AcquireBuildStartingLock", func() {
+		It("gets the lock and stops others from getting it until it is released", func() {
+			lock, acquired, err := sqlDB.AcquireBuildStartingLock(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+
+			_, acquired, err = sqlDB.AcquireBuildStartingLock(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeFalse())
+
+			lock.Release()
+
+			newLock, acquired, err := sqlDB.AcquireBuildStartingLock(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+
+			newLock.Release()
+		})
+	})
 })
@@ -0,0 +1,47 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateWorkerTaskCaches(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+    CREATE TABLE worker_task_caches (
+      id serial PRIMARY KEY,
+      worker_name text REFERENCES workers (name) ON DELETE CASCADE,
+      job_id int REFERENCES jobs (id) ON DELETE CASCADE,
+      step_name text NOT NULL,
+      path text NOT NULL
+    )
+  `)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+    CREATE UNIQUE INDEX worker_task_caches_uniq
+    ON worker_task_caches (worker_name, job_id, step_name, path)
+  `)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`CREATE INDEX worker_task_caches_job_id ON worker_task_caches (job_id)`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+      ALTER TABLE volumes
+      ADD COLUMN worker_task_cache_id INTEGER
+  		REFERENCES worker_task_caches (id) ON DELETE SET NULL
+		`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`CREATE INDEX volumes_worker_task_cache_id ON volumes (worker_task_cache_id)`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,11 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddRolesToTeams(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+    ALTER TABLE teams
+    ADD COLUMN roles json null;
+	`)
+	return err
+}
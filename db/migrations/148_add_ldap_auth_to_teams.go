@@ -0,0 +1,11 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddLDAPAuthToTeams(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+    ALTER TABLE teams
+    ADD COLUMN ldap_auth json null;
+	`)
+	return err
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateAPITokens(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+    CREATE TABLE api_tokens (
+      id serial PRIMARY KEY,
+      team_id int NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+      name text NOT NULL,
+      token_hash text NOT NULL,
+      role text NOT NULL,
+      created_at timestamp with time zone NOT NULL DEFAULT now(),
+      expires_at timestamp with time zone NULL,
+      last_used_at timestamp with time zone NULL
+    )
+  `)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`CREATE UNIQUE INDEX api_tokens_team_id_name ON api_tokens (team_id, name)`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`CREATE UNIQUE INDEX api_tokens_token_hash ON api_tokens (token_hash)`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
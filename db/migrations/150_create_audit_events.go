@@ -0,0 +1,23 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateAuditEvents(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+    CREATE TABLE audit_events (
+      id serial PRIMARY KEY,
+      time timestamp with time zone NOT NULL DEFAULT now(),
+      actor text NOT NULL DEFAULT '',
+      remote_addr text NOT NULL DEFAULT '',
+      route text NOT NULL,
+      team_name text NOT NULL DEFAULT '',
+      params json NULL,
+      status int NOT NULL
+    )
+  `)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
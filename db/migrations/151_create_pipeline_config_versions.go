@@ -0,0 +1,36 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreatePipelineConfigVersions(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+    CREATE TABLE pipeline_config_versions (
+      id serial PRIMARY KEY,
+      pipeline_id int NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+      version int NOT NULL,
+      config text NOT NULL,
+      author text NOT NULL DEFAULT '',
+      created_at timestamp with time zone NOT NULL DEFAULT now()
+    )
+  `)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`CREATE UNIQUE INDEX pipeline_config_versions_pipeline_id_version ON pipeline_config_versions (pipeline_id, version)`)
+	if err != nil {
+		return err
+	}
+
+	// start each existing pipeline's history with its current config
+	_, err = tx.Exec(`
+    INSERT INTO pipeline_config_versions (pipeline_id, version, config)
+    SELECT id, version, config
+    FROM pipelines
+  `)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
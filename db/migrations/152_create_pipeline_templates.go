@@ -0,0 +1,35 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreatePipelineTemplates(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+    CREATE TABLE pipeline_templates (
+      id serial PRIMARY KEY,
+      team_id int NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+      name text NOT NULL,
+      config text NOT NULL,
+      version int NOT NULL DEFAULT 1
+    )
+  `)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`CREATE UNIQUE INDEX pipeline_templates_team_id_name ON pipeline_templates (team_id, name)`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+    ALTER TABLE pipelines
+    ADD COLUMN template_id int NULL REFERENCES pipeline_templates (id) ON DELETE SET NULL,
+    ADD COLUMN template_version int NOT NULL DEFAULT 0,
+    ADD COLUMN instance_vars json NULL
+  `)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateNotificationSubscriptions(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+    CREATE TABLE notification_subscriptions (
+      id serial PRIMARY KEY,
+      team_id int NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+      url text NOT NULL,
+      secret text NOT NULL,
+      events json NOT NULL,
+      pipeline_name text NOT NULL DEFAULT '',
+      job_name text NOT NULL DEFAULT '',
+      created_at timestamp with time zone NOT NULL DEFAULT now()
+    )
+  `)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`CREATE INDEX notification_subscriptions_team_id ON notification_subscriptions (team_id)`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
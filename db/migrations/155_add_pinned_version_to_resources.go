@@ -0,0 +1,13 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddPinnedVersionToResources(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+    ALTER TABLE resources
+    ADD COLUMN pinned_version_id integer NULL REFERENCES versioned_resources (id) ON DELETE SET NULL,
+    ADD COLUMN pin_comment text NULL,
+    ADD COLUMN modified_time timestamp NOT NULL DEFAULT now()
+	`)
+	return err
+}
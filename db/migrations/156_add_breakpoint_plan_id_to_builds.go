@@ -0,0 +1,11 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddBreakpointPlanIDToBuilds(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+    ALTER TABLE builds
+    ADD COLUMN breakpoint_plan_id text NULL
+	`)
+	return err
+}
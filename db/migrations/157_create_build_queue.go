@@ -0,0 +1,15 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateBuildQueue(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+    CREATE TABLE build_queue (
+      build_id integer PRIMARY KEY REFERENCES builds (id) ON DELETE CASCADE,
+      priority integer NOT NULL DEFAULT 0,
+      first_offered timestamp NOT NULL,
+      last_offered timestamp NOT NULL
+    )
+	`)
+	return err
+}
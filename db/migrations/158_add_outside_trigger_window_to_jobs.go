@@ -0,0 +1,11 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddOutsideTriggerWindowToJobs(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+    ALTER TABLE jobs
+    ADD COLUMN outside_trigger_window bool NOT NULL DEFAULT false
+	`)
+	return err
+}
@@ -0,0 +1,12 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddAPITokenToAuditEvents(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+    ALTER TABLE audit_events
+    ADD COLUMN api_token_name text NOT NULL DEFAULT '',
+    ADD COLUMN api_token_role text NOT NULL DEFAULT ''
+	`)
+	return err
+}
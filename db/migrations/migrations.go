@@ -153,4 +153,18 @@ var Migrations = []migration.Migrator{
 	RemoveLastTrackedFromBuilds,
 	AddIndexesToABunchMoreStuff,
 	RemoveDuplicateIndices,
+	CreateWorkerTaskCaches,
+	AddRolesToTeams,
+	AddLDAPAuthToTeams,
+	CreateAPITokens,
+	CreateAuditEvents,
+	CreatePipelineConfigVersions,
+	CreatePipelineTemplates,
+	CreateNotificationSubscriptions,
+	AddRerunOfToBuilds,
+	AddPinnedVersionToResources,
+	AddBreakpointPlanIDToBuilds,
+	CreateBuildQueue,
+	AddOutsideTriggerWindowToJobs,
+	AddAPITokenToAuditEvents,
 }
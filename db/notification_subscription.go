@@ -0,0 +1,78 @@
+package db
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/concourse/atc"
+)
+
+// NotificationSubscription is a webhook to which a team's build status
+// changes are posted. The secret is stored as-is, as it is needed to sign
+// each notification.
+type NotificationSubscription struct {
+	URL    string
+	Secret string
+	Events []atc.BuildStatus
+
+	PipelineName string
+	JobName      string
+}
+
+type SavedNotificationSubscription struct {
+	NotificationSubscription
+
+	ID       int
+	TeamName string
+
+	CreatedAt time.Time
+}
+
+// Matches returns whether the subscription should be notified of a build of
+// the given pipeline and job changing to the given status. One-off builds
+// only match subscriptions which are not restricted to a pipeline or job.
+func (subscription NotificationSubscription) Matches(status atc.BuildStatus, pipelineName string, jobName string) bool {
+	if subscription.PipelineName != "" && subscription.PipelineName != pipelineName {
+		return false
+	}
+
+	if subscription.JobName != "" && subscription.JobName != jobName {
+		return false
+	}
+
+	for _, event := range subscription.Events {
+		if event == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+const notificationSubscriptionColumns = "n.id, n.url, n.secret, n.events, n.pipeline_name, n.job_name, n.created_at, t.name"
+
+func scanNotificationSubscription(row scannable) (SavedNotificationSubscription, error) {
+	var subscription SavedNotificationSubscription
+	var events []byte
+
+	err := row.Scan(
+		&subscription.ID,
+		&subscription.URL,
+		&subscription.Secret,
+		&events,
+		&subscription.PipelineName,
+		&subscription.JobName,
+		&subscription.CreatedAt,
+		&subscription.TeamName,
+	)
+	if err != nil {
+		return SavedNotificationSubscription{}, err
+	}
+
+	err = json.Unmarshal(events, &subscription.Events)
+	if err != nil {
+		return SavedNotificationSubscription{}, err
+	}
+
+	return subscription, nil
+}
@@ -15,5 +15,10 @@ type SavedPipeline struct {
 	TeamID   int
 	TeamName string
 
+	// set for instances of a pipeline template
+	TemplateName    string
+	TemplateVersion int
+	InstanceVars    atc.InstanceVars
+
 	Pipeline
 }
@@ -22,6 +22,7 @@ type PipelineDB interface {
 	GetPipelineID() int
 	ScopedName(string) string
 	TeamID() int
+	TeamName() string
 	Config() atc.Config
 	ConfigVersion() ConfigVersion
 
@@ -50,6 +51,8 @@ type PipelineDB interface {
 	GetLatestEnabledVersionedResource(resourceName string) (SavedVersionedResource, bool, error)
 	EnableVersionedResource(versionedResourceID int) error
 	DisableVersionedResource(versionedResourceID int) error
+	PinResourceVersion(resourceName string, versionedResourceID int, comment string) (bool, error)
+	UnpinResourceVersion(resourceName string) (bool, error)
 	SetResourceCheckError(resource SavedResource, err error) error
 	AcquireResourceTypeCheckingLock(logger lager.Logger, resourceType SavedResourceType, length time.Duration, immediate bool) (lock.Lock, bool, error)
 
@@ -58,6 +61,7 @@ type PipelineDB interface {
 	PauseJob(job string) error
 	UnpauseJob(job string) error
 	SetMaxInFlightReached(string, bool) error
+	SetOutsideTriggerWindow(string, bool) error
 	UpdateFirstLoggedBuildID(job string, newFirstLoggedBuildID int) error
 
 	GetJobFinishedAndNextBuild(job string) (Build, Build, error)
@@ -67,6 +71,7 @@ type PipelineDB interface {
 
 	GetJobBuild(job string, build string) (Build, bool, error)
 	CreateJobBuild(job string) (Build, error)
+	CreateRerunBuild(build Build) (Build, error)
 	EnsurePendingBuildExists(jobName string) error
 	GetPendingBuildsForJob(jobName string) ([]Build, error)
 	GetAllPendingBuilds() (map[string][]Build, error)
@@ -79,6 +84,7 @@ type PipelineDB interface {
 	SaveNextInputMapping(inputMapping algorithm.InputMapping, jobName string) error
 	GetNextBuildInputs(jobName string) ([]BuildInput, bool, error)
 	DeleteNextInputMapping(jobName string) error
+	ExplainInputs(jobName string) (atc.InputsExplanation, bool, error)
 
 	GetRunningBuildsBySerialGroup(jobName string, serialGroups []string) ([]Build, error)
 	GetNextPendingBuildBySerialGroup(jobName string, serialGroups []string) (Build, bool, error)
@@ -153,6 +159,10 @@ func (pdb *pipelineDB) TeamID() int {
 	return pdb.SavedPipeline.TeamID
 }
 
+func (pdb *pipelineDB) TeamName() string {
+	return pdb.SavedPipeline.TeamName
+}
+
 func (pdb *pipelineDB) Config() atc.Config {
 	return pdb.SavedPipeline.Config
 }
@@ -261,10 +271,11 @@ func (pdb *pipelineDB) GetResource(resourceName string) (SavedResource, bool, er
 
 func (pdb *pipelineDB) GetResources() ([]SavedResource, bool, error) {
 	rows, err := pdb.conn.Query(`
-			SELECT id, name, config, check_error, paused
-			FROM resources
-			WHERE pipeline_id = $1
-				AND active = true
+			SELECT r.id, r.name, r.config, r.check_error, r.paused, r.pinned_version_id, v.version, r.pin_comment
+			FROM resources r
+			LEFT OUTER JOIN versioned_resources v ON v.id = r.pinned_version_id
+			WHERE r.pipeline_id = $1
+				AND r.active = true
 		`, pdb.ID)
 
 	if err != nil {
@@ -547,20 +558,22 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 
 func (pdb *pipelineDB) getResource(tx Tx, name string) (SavedResource, bool, error) {
 	return pdb.scanResource(tx.QueryRow(`
-			SELECT id, name, config, check_error, paused
-			FROM resources
-			WHERE name = $1
-				AND pipeline_id = $2
-				AND active = true
+			SELECT r.id, r.name, r.config, r.check_error, r.paused, r.pinned_version_id, v.version, r.pin_comment
+			FROM resources r
+			LEFT OUTER JOIN versioned_resources v ON v.id = r.pinned_version_id
+			WHERE r.name = $1
+				AND r.pipeline_id = $2
+				AND r.active = true
 		`, name, pdb.ID))
 }
 
 func (pdb *pipelineDB) scanResource(row scannable) (SavedResource, bool, error) {
-	var checkErr sql.NullString
+	var checkErr, pinnedVersion, pinComment sql.NullString
+	var pinnedVersionID sql.NullInt64
 	var resource SavedResource
 	var configBlob []byte
 
-	err := row.Scan(&resource.ID, &resource.Name, &configBlob, &checkErr, &resource.Paused)
+	err := row.Scan(&resource.ID, &resource.Name, &configBlob, &checkErr, &resource.Paused, &pinnedVersionID, &pinnedVersion, &pinComment)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return SavedResource{}, false, nil
@@ -582,6 +595,17 @@ func (pdb *pipelineDB) scanResource(row scannable) (SavedResource, bool, error)
 		resource.CheckError = errors.New(checkErr.String)
 	}
 
+	if pinnedVersionID.Valid {
+		resource.PinnedVersionID = int(pinnedVersionID.Int64)
+
+		err = json.Unmarshal([]byte(pinnedVersion.String), &resource.PinnedVersion)
+		if err != nil {
+			return SavedResource{}, false, err
+		}
+
+		resource.PinComment = pinComment.String
+	}
+
 	return resource, true, nil
 }
 
@@ -793,6 +817,49 @@ func (pdb *pipelineDB) toggleVersionedResource(versionedResourceID int, enable b
 	return nil
 }
 
+func (pdb *pipelineDB) PinResourceVersion(resourceName string, versionedResourceID int, comment string) (bool, error) {
+	result, err := pdb.conn.Exec(`
+		UPDATE resources r
+		SET pinned_version_id = v.id, pin_comment = NULLIF($3, ''), modified_time = now()
+		FROM versioned_resources v
+		WHERE v.id = $1
+			AND v.resource_id = r.id
+			AND r.name = $2
+			AND r.pipeline_id = $4
+			AND r.active = true
+	`, versionedResourceID, resourceName, comment, pdb.ID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected != 0, nil
+}
+
+func (pdb *pipelineDB) UnpinResourceVersion(resourceName string) (bool, error) {
+	result, err := pdb.conn.Exec(`
+		UPDATE resources
+		SET pinned_version_id = NULL, pin_comment = NULL, modified_time = now()
+		WHERE name = $1
+			AND pipeline_id = $2
+			AND active = true
+	`, resourceName, pdb.ID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected != 0, nil
+}
+
 func (pdb *pipelineDB) GetLatestEnabledVersionedResource(resourceName string) (SavedVersionedResource, bool, error) {
 	var versionBytes, metadataBytes string
 
@@ -1113,7 +1180,8 @@ func (pdb *pipelineDB) CreateJobBuild(jobName string) (Build, error) {
 			(SELECT name FROM jobs WHERE id = $2),
 			(SELECT id FROM pipelines WHERE id = $4),
 			(SELECT name FROM pipelines WHERE id = $4),
-			(SELECT name FROM teams WHERE id = $3)
+			(SELECT name FROM teams WHERE id = $3),
+			null
 	`, buildName, jobID, pdb.SavedPipeline.TeamID, pdb.ID))
 	if err != nil {
 		return nil, err
@@ -1132,6 +1200,58 @@ func (pdb *pipelineDB) CreateJobBuild(jobName string) (Build, error) {
 	return build, nil
 }
 
+// CreateRerunBuild creates a pending build of the given build's job which
+// reuses its inputs, rather than having them resolved by the scheduler.
+func (pdb *pipelineDB) CreateRerunBuild(originalBuild Build) (Build, error) {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	buildName, jobID, err := getNewBuildNameForJob(tx, originalBuild.JobName(), pdb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	build, _, err := pdb.buildFactory.ScanBuild(tx.QueryRow(`
+		INSERT INTO builds (name, job_id, team_id, status, manually_triggered, rerun_of)
+		VALUES ($1, $2, $3, 'pending', TRUE, $5)
+		RETURNING `+buildColumns+`,
+			(SELECT name FROM jobs WHERE id = $2),
+			(SELECT id FROM pipelines WHERE id = $4),
+			(SELECT name FROM pipelines WHERE id = $4),
+			(SELECT name FROM teams WHERE id = $3),
+			(SELECT name FROM builds WHERE id = $5)
+	`, buildName, jobID, pdb.SavedPipeline.TeamID, pdb.ID, originalBuild.ID()))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO build_inputs (build_id, versioned_resource_id, name)
+		SELECT $1, versioned_resource_id, name
+		FROM build_inputs
+		WHERE build_id = $2
+	`, build.ID(), originalBuild.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	err = createBuildEventSeq(tx, build.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
 func (pdb *pipelineDB) EnsurePendingBuildExists(jobName string) error {
 	tx, err := pdb.conn.Begin()
 	if err != nil {
@@ -1414,7 +1534,7 @@ func (pdb *pipelineDB) GetAllPendingBuilds() (map[string][]Build, error) {
 	builds := map[string][]Build{}
 
 	rows, err := pdb.conn.Query(`
-		SELECT b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name
+		SELECT `+qualifiedBuildColumns+`
 		FROM builds b
 		JOIN jobs j ON b.job_id = j.id
 		JOIN pipelines p ON j.pipeline_id = p.id
@@ -1588,15 +1708,37 @@ func (pdb *pipelineDB) UpdateBuildToScheduled(buildID int) (bool, error) {
 }
 
 func (pdb *pipelineDB) getLatestModifiedTime() (time.Time, error) {
+	latestModifiedTime, err := pdb.getLatestModifiedTimeOfPipeline(pdb.ID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// outputs of the other pipelines in passed constraints are loaded too
+	otherPipelineIDs, err := pdb.getOtherPassedPipelineIDs()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	for _, pipelineID := range otherPipelineIDs {
+		modifiedTime, err := pdb.getLatestModifiedTimeOfPipeline(pipelineID)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if modifiedTime.After(latestModifiedTime) {
+			latestModifiedTime = modifiedTime
+		}
+	}
+
+	return latestModifiedTime, nil
+}
+
+func (pdb *pipelineDB) getLatestModifiedTimeOfPipeline(pipelineID int) (time.Time, error) {
 	var max_modified_time time.Time
 
 	err := pdb.conn.QueryRow(`
 	SELECT
-		CASE
-			WHEN bo_max > vr_max AND bo_max > bi_max THEN bo_max
-			WHEN bi_max > vr_max THEN bi_max
-			ELSE vr_max
-		END
+		GREATEST(bo_max, bi_max, vr_max, r_max)
 	FROM
 		(
 			SELECT COALESCE(MAX(bo.modified_time), 'epoch') as bo_max
@@ -1617,8 +1759,13 @@ func (pdb *pipelineDB) getLatestModifiedTime() (time.Time, error) {
 			FROM versioned_resources vr
 			LEFT OUTER JOIN resources r ON r.id = vr.resource_id
 			WHERE r.pipeline_id = $1
-		) vr
-	`, pdb.ID).Scan(&max_modified_time)
+		) vr,
+		(
+			SELECT COALESCE(MAX(r.modified_time), 'epoch') as r_max
+			FROM resources r
+			WHERE r.pipeline_id = $1
+		) r
+	`, pipelineID).Scan(&max_modified_time)
 
 	return max_modified_time, err
 }
@@ -1640,6 +1787,10 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		JobIDs:           map[string]int{},
 		ResourceIDs:      map[string]int{},
 		CachedAt:         latestModifiedTime,
+		DisabledVersions: []algorithm.ResourceVersion{},
+		PinnedVersionIDs: map[int]int{},
+
+		OtherPipelineJobIDs: map[string]map[string]int{},
 	}
 
 	rows, err := pdb.conn.Query(`
@@ -1716,6 +1867,28 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		db.ResourceVersions = append(db.ResourceVersions, output)
 	}
 
+	rows, err = pdb.conn.Query(`
+    SELECT v.id, v.check_order, r.id
+    FROM versioned_resources v, resources r
+    WHERE r.id = v.resource_id
+    AND NOT v.enabled
+		AND r.pipeline_id = $1
+		ORDER BY v.check_order DESC
+  `, pdb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var disabled algorithm.ResourceVersion
+		err := rows.Scan(&disabled.VersionID, &disabled.CheckOrder, &disabled.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		db.DisabledVersions = append(db.DisabledVersions, disabled)
+	}
+
 	rows, err = pdb.conn.Query(`
     SELECT j.name, j.id
     FROM jobs j
@@ -1737,7 +1910,7 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 	}
 
 	rows, err = pdb.conn.Query(`
-    SELECT r.name, r.id
+    SELECT r.name, r.id, r.pinned_version_id
     FROM resources r
     WHERE r.pipeline_id = $1
   `, pdb.ID)
@@ -1748,12 +1921,22 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 	for rows.Next() {
 		var name string
 		var id int
-		err := rows.Scan(&name, &id)
+		var pinnedVersionID sql.NullInt64
+		err := rows.Scan(&name, &id, &pinnedVersionID)
 		if err != nil {
 			return nil, err
 		}
 
 		db.ResourceIDs[name] = id
+
+		if pinnedVersionID.Valid {
+			db.PinnedVersionIDs[id] = int(pinnedVersionID.Int64)
+		}
+	}
+
+	err = pdb.loadOtherPipelineOutputs(db)
+	if err != nil {
+		return nil, err
 	}
 
 	pdb.versionsDB = db
@@ -2032,6 +2215,31 @@ func (pdb *pipelineDB) SetMaxInFlightReached(jobName string, reached bool) error
 	return nil
 }
 
+// SetOutsideTriggerWindow records whether the job's automatically triggered
+// builds are being held because its trigger window is closed, so that it can
+// be reported by the build's preparation.
+func (pdb *pipelineDB) SetOutsideTriggerWindow(jobName string, outside bool) error {
+	result, err := pdb.conn.Exec(`
+		UPDATE jobs
+		SET outside_trigger_window = $1
+		WHERE name = $2 AND pipeline_id = $3
+	`, outside, jobName, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	return nil
+}
+
 func (pdb *pipelineDB) UpdateFirstLoggedBuildID(job string, newFirstLoggedBuildID int) error {
 	tx, err := pdb.conn.Begin()
 	if err != nil {
@@ -1,6 +1,9 @@
 package db
 
-import "github.com/concourse/atc/db/lock"
+import (
+	"github.com/concourse/atc/db/archive"
+	"github.com/concourse/atc/db/lock"
+)
 
 //go:generate counterfeiter . PipelineDBFactory
 
@@ -13,17 +16,20 @@ type pipelineDBFactory struct {
 	bus  *notificationsBus
 
 	lockFactory lock.LockFactory
+	archiver    archive.Archiver
 }
 
 func NewPipelineDBFactory(
 	sqldbConnection Conn,
 	bus *notificationsBus,
 	lockFactory lock.LockFactory,
+	archiver archive.Archiver,
 ) *pipelineDBFactory {
 	return &pipelineDBFactory{
 		conn:        sqldbConnection,
 		bus:         bus,
 		lockFactory: lockFactory,
+		archiver:    archiver,
 	}
 }
 
@@ -32,7 +38,7 @@ func (pdbf *pipelineDBFactory) Build(pipeline SavedPipeline) PipelineDB {
 		conn: pdbf.conn,
 		bus:  pdbf.bus,
 
-		buildFactory: newBuildFactory(pdbf.conn, pdbf.bus, pdbf.lockFactory),
+		buildFactory: newBuildFactory(pdbf.conn, pdbf.bus, pdbf.lockFactory, pdbf.archiver),
 		lockFactory:  pdbf.lockFactory,
 
 		SavedPipeline: pipeline,
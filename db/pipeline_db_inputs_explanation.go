@@ -0,0 +1,230 @@
+package db
+
+import (
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/db/algorithm"
+)
+
+// only the newest of the versions skipped or eliminated at each step are
+// listed, as there may be thousands of them
+const maxExplainedVersions = 10
+
+func (pdb *pipelineDB) ExplainInputs(jobName string) (atc.InputsExplanation, bool, error) {
+	jobConfig, found := pdb.Config().Jobs.Lookup(jobName)
+	if !found {
+		return atc.InputsExplanation{}, false, nil
+	}
+
+	versionsDB, err := pdb.LoadVersionsDB()
+	if err != nil {
+		return atc.InputsExplanation{}, false, err
+	}
+
+	jobInputs := config.JobInputs(jobConfig)
+
+	explanation := atc.InputsExplanation{
+		Inputs: make([]atc.InputExplanation, len(jobInputs)),
+	}
+
+	inputIndexes := map[string]int{}
+	passedJobs := map[int]atc.PassedConfig{}
+	inputConfigs := algorithm.InputConfigs{}
+	versionIDs := []int{}
+
+	for i, input := range jobInputs {
+		explanation.Inputs[i] = atc.InputExplanation{
+			Name:     input.Name,
+			Resource: input.Resource,
+		}
+
+		inputIndexes[input.Name] = i
+
+		pinnedVersionID := 0
+		if input.Version != nil && input.Version.Pinned != nil {
+			explanation.Inputs[i].PinnedVersion = input.Version.Pinned
+
+			savedVersion, found, err := pdb.GetVersionedResourceByVersion(input.Version.Pinned, input.Resource)
+			if err != nil {
+				return atc.InputsExplanation{}, false, err
+			}
+
+			if !found {
+				explanation.Inputs[i].PinnedVersionUnavailable = true
+				continue
+			}
+
+			pinnedVersionID = savedVersion.ID
+		}
+
+		resourceID := versionsDB.ResourceIDs[input.Resource]
+
+		if pinnedVersionID == 0 {
+			pinnedVersionID = versionsDB.PinnedVersionIDs[resourceID]
+			if pinnedVersionID != 0 {
+				versionIDs = append(versionIDs, pinnedVersionID)
+			}
+		}
+
+		jobs := algorithm.JobSet{}
+		for _, passed := range input.Passed {
+			jobID := versionsDB.JobIDs[passed.Job]
+			if passed.IsOtherPipeline() {
+				jobID = versionsDB.OtherPipelineJobIDs[passed.Pipeline][passed.Job]
+			}
+
+			jobs[jobID] = struct{}{}
+			passedJobs[jobID] = passed
+		}
+
+		inputConfigs = append(inputConfigs, algorithm.InputConfig{
+			Name:            input.Name,
+			UseEveryVersion: input.Version != nil && input.Version.Every,
+			PinnedVersionID: pinnedVersionID,
+			ResourceID:      resourceID,
+			Passed:          jobs,
+			JobID:           versionsDB.JobIDs[jobName],
+		})
+	}
+
+	trace := inputConfigs.Explain(versionsDB)
+
+	explanation.Resolved = trace.Resolved && len(inputConfigs) == len(jobInputs)
+
+	for _, inputTrace := range trace.Inputs {
+		versionIDs = append(versionIDs, newestVersionIDs(inputTrace.DisabledVersionIDs)...)
+
+		for _, passedTrace := range inputTrace.Passed {
+			versionIDs = append(versionIDs, newestVersionIDs(passedTrace.EliminatedVersionIDs)...)
+		}
+	}
+
+	versions, err := pdb.getVersionsByID(versionIDs)
+	if err != nil {
+		return atc.InputsExplanation{}, false, err
+	}
+
+	for _, inputTrace := range trace.Inputs {
+		inputExplanation := &explanation.Inputs[inputIndexes[inputTrace.Input]]
+
+		inputExplanation.Candidates = len(inputTrace.CandidateVersionIDs)
+
+		if inputExplanation.PinnedVersion == nil && inputTrace.PinnedVersionID != 0 {
+			inputExplanation.PinnedVersion = versions[inputTrace.PinnedVersionID]
+		}
+
+		for _, id := range newestVersionIDs(inputTrace.DisabledVersionIDs) {
+			inputExplanation.SkippedDisabledVersions = append(inputExplanation.SkippedDisabledVersions, versions[id])
+		}
+
+		for _, passedTrace := range inputTrace.Passed {
+			passedExplanation := atc.PassedExplanation{
+				Pipeline:        passedJobs[passedTrace.JobID].Pipeline,
+				Job:             passedJobs[passedTrace.JobID].Job,
+				EliminatedCount: len(passedTrace.EliminatedVersionIDs),
+			}
+
+			for _, id := range newestVersionIDs(passedTrace.EliminatedVersionIDs) {
+				passedExplanation.EliminatedVersions = append(passedExplanation.EliminatedVersions, versions[id])
+			}
+
+			inputExplanation.Passed = append(inputExplanation.Passed, passedExplanation)
+		}
+	}
+
+	for i, input := range jobInputs {
+		for _, passed := range input.Passed {
+			if !passed.IsOtherPipeline() {
+				continue
+			}
+
+			for _, passedErr := range versionsDB.OtherPipelinePassedErrors {
+				if passedErr.Resource == input.Resource && passedErr.Pipeline == passed.Pipeline && passedErr.Job == passed.Job {
+					explanation.Inputs[i].Passed = explainPassedError(explanation.Inputs[i].Passed, passedErr)
+				}
+			}
+		}
+	}
+
+	for _, conflict := range trace.Conflicts {
+		explanation.Conflicts = append(explanation.Conflicts, atc.PassedConflict{
+			Pipeline: passedJobs[conflict.JobID].Pipeline,
+			Job:      passedJobs[conflict.JobID].Job,
+			Inputs:   conflict.Inputs,
+		})
+	}
+
+	return explanation, true, nil
+}
+
+func (pdb *pipelineDB) getVersionsByID(ids []int) (map[int]atc.Version, error) {
+	versions := map[int]atc.Version{}
+
+	if len(ids) == 0 {
+		return versions, nil
+	}
+
+	query, args, err := sq.Select("v.id, v.version").
+		From("versioned_resources v").
+		Join("resources r ON r.id = v.resource_id").
+		Where(sq.Eq{"v.id": ids, "r.pipeline_id": pdb.ID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pdb.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		var versionBytes string
+		err := rows.Scan(&id, &versionBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		var version atc.Version
+		err = json.Unmarshal([]byte(versionBytes), &version)
+		if err != nil {
+			return nil, err
+		}
+
+		versions[id] = version
+	}
+
+	return versions, nil
+}
+
+// explainPassedError sets the error on the explanation of the passed
+// constraint, adding one if the constraint was not traced.
+func explainPassedError(passed []atc.PassedExplanation, passedErr algorithm.OtherPipelinePassedError) []atc.PassedExplanation {
+	for i := range passed {
+		if passed[i].Pipeline == passedErr.Pipeline && passed[i].Job == passedErr.Job {
+			passed[i].Error = passedErr.Message
+			return passed
+		}
+	}
+
+	return append(passed, atc.PassedExplanation{
+		Pipeline: passedErr.Pipeline,
+		Job:      passedErr.Job,
+		Error:    passedErr.Message,
+	})
+}
+
+func newestVersionIDs(ids []int) []int {
+	if len(ids) > maxExplainedVersions {
+		return ids[:maxExplainedVersions]
+	}
+
+	return ids
+}
@@ -35,13 +35,13 @@ var _ = Describe("Jobs Builds", func() {
 		retryableConn := &lock.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := lock.NewLockFactory(retryableConn)
-		sqlDB = db.NewSQL(dbConn, bus, lockFactory)
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory, nil)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory, nil)
 
 		_, err := sqlDB.CreateTeam(db.Team{Name: "some-team"})
 		Expect(err).NotTo(HaveOccurred())
 
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
 		teamDB := teamDBFactory.GetTeamDB("some-team")
 
 		config := atc.Config{
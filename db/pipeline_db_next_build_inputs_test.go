@@ -38,8 +38,8 @@ var _ = Describe("next build inputs for job", func() {
 		retryableConn := &lock.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := lock.NewLockFactory(retryableConn)
-		sqlDB = db.NewSQL(dbConn, bus, lockFactory)
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory, nil)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory, nil)
 
 		_, err := sqlDB.CreateTeam(db.Team{Name: "some-team"})
 		Expect(err).NotTo(HaveOccurred())
@@ -61,7 +61,7 @@ var _ = Describe("next build inputs for job", func() {
 			Resources: atc.ResourceConfigs{resourceConfig},
 		}
 
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
 		teamDB := teamDBFactory.GetTeamDB("some-team")
 		savedPipeline, _, err := teamDB.SaveConfigToBeDeprecated("some-pipeline", config, 0, db.PipelineUnpaused)
 		Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,262 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/db/algorithm"
+)
+
+type otherPipelinePassed struct {
+	pipeline string
+	job      string
+	resource string
+}
+
+func (pdb *pipelineDB) otherPipelinePassedConstraints() []otherPipelinePassed {
+	constraints := []otherPipelinePassed{}
+	seen := map[otherPipelinePassed]bool{}
+
+	for _, job := range pdb.Config().Jobs {
+		for _, input := range config.JobInputs(job) {
+			for _, passed := range input.Passed {
+				if !passed.IsOtherPipeline() {
+					continue
+				}
+
+				constraint := otherPipelinePassed{
+					pipeline: passed.Pipeline,
+					job:      passed.Job,
+					resource: input.Resource,
+				}
+
+				if !seen[constraint] {
+					seen[constraint] = true
+					constraints = append(constraints, constraint)
+				}
+			}
+		}
+	}
+
+	return constraints
+}
+
+func (pdb *pipelineDB) getOtherPassedPipelineIDs() ([]int, error) {
+	pipelineIDs := []int{}
+	seen := map[string]bool{}
+
+	for _, constraint := range pdb.otherPipelinePassedConstraints() {
+		if seen[constraint.pipeline] {
+			continue
+		}
+
+		seen[constraint.pipeline] = true
+
+		var pipelineID int
+		err := pdb.conn.QueryRow(`
+			SELECT id
+			FROM pipelines
+			WHERE team_id = $1
+				AND name = $2
+		`, pdb.TeamID(), constraint.pipeline).Scan(&pipelineID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				continue
+			}
+
+			return nil, err
+		}
+
+		pipelineIDs = append(pipelineIDs, pipelineID)
+	}
+
+	return pipelineIDs, nil
+}
+
+// loadOtherPipelineOutputs adds the outputs of the other pipelines' jobs
+// referenced by passed constraints to the versions DB, as if they were
+// outputs of the local resource. Only outputs of resources with the same type
+// and source are considered, as only then are their versions the same.
+//
+// Constraints whose pipeline or job does not exist, or whose job does not use
+// a resource with the same type and source, are recorded in the versions DB's
+// OtherPipelinePassedErrors.
+func (pdb *pipelineDB) loadOtherPipelineOutputs(db *algorithm.VersionsDB) error {
+	for _, constraint := range pdb.otherPipelinePassedConstraints() {
+		resourceConfig, found := pdb.Config().Resources.Lookup(constraint.resource)
+		if !found {
+			continue
+		}
+
+		resourceID, found := db.ResourceIDs[constraint.resource]
+		if !found {
+			continue
+		}
+
+		var pipelineID int
+		var configBlob []byte
+		err := pdb.conn.QueryRow(`
+			SELECT id, config
+			FROM pipelines
+			WHERE team_id = $1
+				AND name = $2
+		`, pdb.TeamID(), constraint.pipeline).Scan(&pipelineID, &configBlob)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				db.OtherPipelinePassedErrors = append(db.OtherPipelinePassedErrors, constraint.error(
+					fmt.Sprintf("pipeline '%s' not found", constraint.pipeline),
+				))
+				continue
+			}
+
+			return err
+		}
+
+		var otherPipelineConfig atc.Config
+		err = json.Unmarshal(configBlob, &otherPipelineConfig)
+		if err != nil {
+			return err
+		}
+
+		otherJobConfig, found := otherPipelineConfig.Jobs.Lookup(constraint.job)
+		if !found {
+			db.OtherPipelinePassedErrors = append(db.OtherPipelinePassedErrors, constraint.error(
+				fmt.Sprintf("job '%s' not found in pipeline '%s'", constraint.job, constraint.pipeline),
+			))
+			continue
+		}
+
+		if !jobUsesResourceConfig(otherPipelineConfig, otherJobConfig, resourceConfig) {
+			db.OtherPipelinePassedErrors = append(db.OtherPipelinePassedErrors, constraint.error(
+				fmt.Sprintf(
+					"job '%s' in pipeline '%s' has no resource with the same type and source as resource '%s'",
+					constraint.job,
+					constraint.pipeline,
+					constraint.resource,
+				),
+			))
+			continue
+		}
+
+		var jobID int
+		err = pdb.conn.QueryRow(`
+			SELECT id
+			FROM jobs
+			WHERE pipeline_id = $1
+				AND name = $2
+				AND active = true
+		`, pipelineID, constraint.job).Scan(&jobID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				db.OtherPipelinePassedErrors = append(db.OtherPipelinePassedErrors, constraint.error(
+					fmt.Sprintf("job '%s' not found in pipeline '%s'", constraint.job, constraint.pipeline),
+				))
+				continue
+			}
+
+			return err
+		}
+
+		if db.OtherPipelineJobIDs[constraint.pipeline] == nil {
+			db.OtherPipelineJobIDs[constraint.pipeline] = map[string]int{}
+		}
+
+		db.OtherPipelineJobIDs[constraint.pipeline][constraint.job] = jobID
+
+		rows, err := pdb.conn.Query(`
+			SELECT lv.id, lv.check_order, o.build_id, r.config
+			FROM build_outputs o, builds b, versioned_resources v, resources r, versioned_resources lv
+			WHERE b.id = o.build_id
+				AND v.id = o.versioned_resource_id
+				AND r.id = v.resource_id
+				AND lv.version = v.version
+				AND b.job_id = $1
+				AND b.status = 'succeeded'
+				AND v.enabled
+				AND lv.resource_id = $2
+				AND lv.enabled
+		`, jobID, resourceID)
+		if err != nil {
+			return err
+		}
+
+		for rows.Next() {
+			var output algorithm.BuildOutput
+			var configBlob []byte
+			err := rows.Scan(&output.VersionID, &output.CheckOrder, &output.BuildID, &configBlob)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+
+			var otherConfig atc.ResourceConfig
+			err = json.Unmarshal(configBlob, &otherConfig)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+
+			if !sameResourceConfig(otherConfig, resourceConfig) {
+				continue
+			}
+
+			output.ResourceID = resourceID
+			output.JobID = jobID
+
+			db.BuildOutputs = append(db.BuildOutputs, output)
+		}
+
+		err = rows.Err()
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		err = rows.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// jobUsesResourceConfig returns true if any of the job's inputs or outputs is
+// a resource of the pipeline with the same type and source as the given one.
+func jobUsesResourceConfig(pipelineConfig atc.Config, jobConfig atc.JobConfig, resourceConfig atc.ResourceConfig) bool {
+	resourceNames := []string{}
+
+	for _, input := range config.JobInputs(jobConfig) {
+		resourceNames = append(resourceNames, input.Resource)
+	}
+
+	for _, output := range config.JobOutputs(jobConfig) {
+		resourceNames = append(resourceNames, output.Resource)
+	}
+
+	for _, name := range resourceNames {
+		otherConfig, found := pipelineConfig.Resources.Lookup(name)
+		if found && sameResourceConfig(otherConfig, resourceConfig) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sameResourceConfig(a atc.ResourceConfig, b atc.ResourceConfig) bool {
+	return a.Type == b.Type && reflect.DeepEqual(a.Source, b.Source)
+}
+
+func (constraint otherPipelinePassed) error(message string) algorithm.OtherPipelinePassedError {
+	return algorithm.OtherPipelinePassedError{
+		Resource: constraint.resource,
+		Pipeline: constraint.pipeline,
+		Job:      constraint.job,
+		Message:  message,
+	}
+}
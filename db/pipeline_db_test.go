@@ -39,9 +39,9 @@ var _ = Describe("PipelineDB", func() {
 		retryableConn := &lock.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := lock.NewLockFactory(retryableConn)
-		sqlDB = db.NewSQL(dbConn, bus, lockFactory)
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory)
-		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory, nil)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory, nil)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
 	})
 
 	AfterEach(func() {
@@ -118,7 +118,7 @@ var _ = Describe("PipelineDB", func() {
 						Params: atc.Params{
 							"some-param": "some-value",
 						},
-						Passed:  []string{"job-1", "job-2"},
+						Passed:  atc.PassedConfigs{{Job: "job-1"}, {Job: "job-2"}},
 						Trigger: true,
 					},
 					{
@@ -1035,6 +1035,210 @@ var _ = Describe("PipelineDB", func() {
 						InputName: "enabled-input",
 					},
 				))
+
+				By("listing it as a disabled version")
+				Expect(versions.DisabledVersions).To(ConsistOf(
+					algorithm.ResourceVersion{
+						VersionID:  disabledVersion.ID,
+						ResourceID: resource.ID,
+						CheckOrder: disabledVersion.CheckOrder,
+					},
+				))
+			})
+		})
+
+		Context("when an input has passed constraints on a job of another pipeline", func() {
+			It("includes the outputs of that job for resources with the same config", func() {
+				sameSource := atc.Source{"source-config": "some-value"}
+
+				upstreamSavedPipeline, _, err := teamDB.SaveConfigToBeDeprecated("upstream-pipeline", atc.Config{
+					Resources: atc.ResourceConfigs{
+						{Name: "same-resource", Type: "some-type", Source: sameSource},
+						{Name: "different-resource", Type: "some-type", Source: atc.Source{"source-config": "other-value"}},
+					},
+					Jobs: atc.JobConfigs{
+						{
+							Name: "upstream-job",
+							Plan: atc.PlanSequence{
+								{Put: "same-resource"},
+								{Put: "different-resource"},
+							},
+						},
+					},
+				}, 0, db.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+
+				downstreamSavedPipeline, _, err := teamDB.SaveConfigToBeDeprecated("downstream-pipeline", atc.Config{
+					Resources: atc.ResourceConfigs{
+						{Name: "some-resource", Type: "some-type", Source: sameSource},
+					},
+					Jobs: atc.JobConfigs{
+						{
+							Name: "downstream-job",
+							Plan: atc.PlanSequence{
+								{
+									Get:    "some-resource",
+									Passed: atc.PassedConfigs{{Pipeline: "upstream-pipeline", Job: "upstream-job"}},
+								},
+							},
+						},
+					},
+				}, 0, db.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+
+				upstreamPipelineDB := pipelineDBFactory.Build(upstreamSavedPipeline)
+				downstreamPipelineDB := pipelineDBFactory.Build(downstreamSavedPipeline)
+
+				saveVersion := func(pdb db.PipelineDB, resourceName string, source atc.Source, version atc.Version) db.SavedVersionedResource {
+					err := pdb.SaveResourceVersions(atc.ResourceConfig{
+						Name:   resourceName,
+						Type:   "some-type",
+						Source: source,
+					}, []atc.Version{version})
+					Expect(err).NotTo(HaveOccurred())
+
+					savedVR, found, err := pdb.GetLatestVersionedResource(resourceName)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					return savedVR
+				}
+
+				localVR1 := saveVersion(downstreamPipelineDB, "some-resource", sameSource, atc.Version{"version": "1"})
+				saveVersion(downstreamPipelineDB, "some-resource", sameSource, atc.Version{"version": "2"})
+
+				sameVR := saveVersion(upstreamPipelineDB, "same-resource", sameSource, atc.Version{"version": "1"})
+				differentVR := saveVersion(upstreamPipelineDB, "different-resource", atc.Source{"source-config": "other-value"}, atc.Version{"version": "2"})
+
+				upstreamBuild, err := upstreamPipelineDB.CreateJobBuild("upstream-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = upstreamBuild.SaveOutput(sameVR.VersionedResource, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = upstreamBuild.SaveOutput(differentVR.VersionedResource, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = upstreamBuild.Finish(db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				upstreamJob, found, err := upstreamPipelineDB.GetJob("upstream-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				localResource, found, err := downstreamPipelineDB.GetResource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				versions, err := downstreamPipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(versions.OtherPipelineJobIDs).To(Equal(map[string]map[string]int{
+					"upstream-pipeline": {"upstream-job": upstreamJob.ID},
+				}))
+
+				Expect(versions.BuildOutputs).To(ConsistOf(
+					algorithm.BuildOutput{
+						ResourceVersion: algorithm.ResourceVersion{
+							VersionID:  localVR1.ID,
+							ResourceID: localResource.ID,
+							CheckOrder: localVR1.CheckOrder,
+						},
+						JobID:   upstreamJob.ID,
+						BuildID: upstreamBuild.ID(),
+					},
+				))
+
+				Expect(versions.OtherPipelinePassedErrors).To(BeEmpty())
+			})
+
+			Context("when the constraint cannot be resolved", func() {
+				var downstreamPipelineDB db.PipelineDB
+
+				BeforeEach(func() {
+					_, _, err := teamDB.SaveConfigToBeDeprecated("upstream-pipeline", atc.Config{
+						Resources: atc.ResourceConfigs{
+							{Name: "different-resource", Type: "some-type", Source: atc.Source{"source-config": "other-value"}},
+						},
+						Jobs: atc.JobConfigs{
+							{
+								Name: "upstream-job",
+								Plan: atc.PlanSequence{{Put: "different-resource"}},
+							},
+						},
+					}, 0, db.PipelineUnpaused)
+					Expect(err).NotTo(HaveOccurred())
+
+					downstreamSavedPipeline, _, err := teamDB.SaveConfigToBeDeprecated("downstream-pipeline", atc.Config{
+						Resources: atc.ResourceConfigs{
+							{Name: "some-resource", Type: "some-type", Source: atc.Source{"source-config": "some-value"}},
+						},
+						Jobs: atc.JobConfigs{
+							{
+								Name: "downstream-job",
+								Plan: atc.PlanSequence{
+									{
+										Get: "some-resource",
+										Passed: atc.PassedConfigs{
+											{Pipeline: "upstream-pipeline", Job: "upstream-job"},
+											{Pipeline: "upstream-pipeline", Job: "bogus-job"},
+											{Pipeline: "bogus-pipeline", Job: "some-job"},
+										},
+									},
+								},
+							},
+						},
+					}, 0, db.PipelineUnpaused)
+					Expect(err).NotTo(HaveOccurred())
+
+					downstreamPipelineDB = pipelineDBFactory.Build(downstreamSavedPipeline)
+				})
+
+				It("records why in the versions DB", func() {
+					versions, err := downstreamPipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(versions.OtherPipelinePassedErrors).To(ConsistOf(
+						algorithm.OtherPipelinePassedError{
+							Resource: "some-resource",
+							Pipeline: "upstream-pipeline",
+							Job:      "upstream-job",
+							Message:  "job 'upstream-job' in pipeline 'upstream-pipeline' has no resource with the same type and source as resource 'some-resource'",
+						},
+						algorithm.OtherPipelinePassedError{
+							Resource: "some-resource",
+							Pipeline: "upstream-pipeline",
+							Job:      "bogus-job",
+							Message:  "job 'bogus-job' not found in pipeline 'upstream-pipeline'",
+						},
+						algorithm.OtherPipelinePassedError{
+							Resource: "some-resource",
+							Pipeline: "bogus-pipeline",
+							Job:      "some-job",
+							Message:  "pipeline 'bogus-pipeline' not found",
+						},
+					))
+				})
+
+				It("explains it in the job's inputs", func() {
+					explanation, found, err := downstreamPipelineDB.ExplainInputs("downstream-job")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					Expect(explanation.Resolved).To(BeFalse())
+					Expect(explanation.Inputs).To(HaveLen(1))
+
+					passedErrors := map[string]string{}
+					for _, passed := range explanation.Inputs[0].Passed {
+						passedErrors[passed.Pipeline+"/"+passed.Job] = passed.Error
+					}
+
+					Expect(passedErrors).To(Equal(map[string]string{
+						"upstream-pipeline/upstream-job": "job 'upstream-job' in pipeline 'upstream-pipeline' has no resource with the same type and source as resource 'some-resource'",
+						"upstream-pipeline/bogus-job":    "job 'bogus-job' not found in pipeline 'upstream-pipeline'",
+						"bogus-pipeline/some-job":        "pipeline 'bogus-pipeline' not found",
+					}))
+				})
 			})
 		})
 
@@ -1298,6 +1502,109 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Describe("pinning and unpinning resource versions", func() {
+			var savedVR db.SavedVersionedResource
+
+			BeforeEach(func() {
+				err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name:   resourceName,
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}, []atc.Version{{"version": "1"}, {"version": "2"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				var found bool
+				savedVR, found, err = pipelineDB.GetVersionedResourceByVersion(atc.Version{"version": "1"}, resourceName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("starts out as unpinned", func() {
+				resource, _, err := pipelineDB.GetResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(resource.PinnedVersionID).To(BeZero())
+				Expect(resource.PinnedVersion).To(BeNil())
+				Expect(resource.PinComment).To(BeEmpty())
+
+				versions, err := pipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versions.PinnedVersionIDs).To(BeEmpty())
+			})
+
+			It("can be pinned to a version with a comment", func() {
+				pinned, err := pipelineDB.PinResourceVersion(resourceName, savedVR.ID, "some-comment")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pinned).To(BeTrue())
+
+				pinnedResource, _, err := pipelineDB.GetResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pinnedResource.PinnedVersionID).To(Equal(savedVR.ID))
+				Expect(pinnedResource.PinnedVersion).To(Equal(db.Version{"version": "1"}))
+				Expect(pinnedResource.PinComment).To(Equal("some-comment"))
+
+				By("including the pin in the versions DB")
+				versions, err := pipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versions.PinnedVersionIDs).To(Equal(map[int]int{
+					pinnedResource.ID: savedVR.ID,
+				}))
+			})
+
+			It("can not be pinned to a version of another resource", func() {
+				err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name:   "some-other-resource",
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				otherVR, found, err := pipelineDB.GetLatestVersionedResource("some-other-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				pinned, err := pipelineDB.PinResourceVersion(resourceName, otherVR.ID, "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pinned).To(BeFalse())
+
+				resource, _, err := pipelineDB.GetResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resource.PinnedVersionID).To(BeZero())
+			})
+
+			It("can be unpinned", func() {
+				_, err := pipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+
+				pinned, err := pipelineDB.PinResourceVersion(resourceName, savedVR.ID, "some-comment")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pinned).To(BeTrue())
+
+				versions, err := pipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versions.PinnedVersionIDs).To(HaveLen(1))
+
+				found, err := pipelineDB.UnpinResourceVersion(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				unpinnedResource, _, err := pipelineDB.GetResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(unpinnedResource.PinnedVersionID).To(BeZero())
+				Expect(unpinnedResource.PinComment).To(BeEmpty())
+
+				versions, err = pipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versions.PinnedVersionIDs).To(BeEmpty())
+			})
+
+			It("can not unpin a resource which does not exist", func() {
+				found, err := pipelineDB.UnpinResourceVersion("bogus-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
 		Describe("enabling and disabling versioned resources", func() {
 			It("returns an error if the resource or version is bogus", func() {
 				err := pipelineDB.EnableVersionedResource(42)
@@ -17,6 +17,10 @@ type SavedResource struct {
 	PipelineName string
 	Config       atc.ResourceConfig
 	Resource
+
+	PinnedVersionID int
+	PinnedVersion   Version
+	PinComment      string
 }
 
 type SavedResourceType struct {
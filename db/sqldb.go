@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/concourse/atc/db/archive"
 	"github.com/concourse/atc/db/lock"
 )
 
@@ -11,20 +12,25 @@ type SQLDB struct {
 	conn        Conn
 	lockFactory lock.LockFactory
 	bus         *notificationsBus
+	archiver    archive.Archiver
 
 	buildFactory *buildFactory
 }
 
+// NewSQL constructs a SQLDB. If archiver is nil, build events are discarded
+// when they are deleted.
 func NewSQL(
 	sqldbConnection Conn,
 	bus *notificationsBus,
 	lockFactory lock.LockFactory,
+	archiver archive.Archiver,
 ) *SQLDB {
 	return &SQLDB{
 		conn:         sqldbConnection,
 		lockFactory:  lockFactory,
 		bus:          bus,
-		buildFactory: newBuildFactory(sqldbConnection, bus, lockFactory),
+		archiver:     archiver,
+		buildFactory: newBuildFactory(sqldbConnection, bus, lockFactory, archiver),
 	}
 }
 
@@ -0,0 +1,38 @@
+package db
+
+import "database/sql"
+
+// last_used_at is only bumped this often, so that a busy token does not cause
+// a write on every request
+const apiTokenLastUsedGranularity = "1 minute"
+
+// FindAPIToken returns the unexpired token with the given hash, across all
+// teams.
+func (db *SQLDB) FindAPIToken(tokenHash string) (SavedAPIToken, bool, error) {
+	token, err := scanAPIToken(db.conn.QueryRow(`
+		SELECT `+apiTokenColumns+`
+		FROM api_tokens a
+		JOIN teams t ON t.id = a.team_id
+		WHERE a.token_hash = $1
+		AND (a.expires_at IS NULL OR a.expires_at > now())
+	`, tokenHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return SavedAPIToken{}, false, nil
+		}
+
+		return SavedAPIToken{}, false, err
+	}
+
+	return token, true, nil
+}
+
+func (db *SQLDB) UpdateAPITokenLastUsed(id int) error {
+	_, err := db.conn.Exec(`
+		UPDATE api_tokens
+		SET last_used_at = now()
+		WHERE id = $1
+		AND (last_used_at IS NULL OR last_used_at < now() - interval '`+apiTokenLastUsedGranularity+`')
+	`, id)
+	return err
+}
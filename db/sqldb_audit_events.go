@@ -0,0 +1,125 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+)
+
+func (db *SQLDB) SaveAuditEvent(event AuditEvent) error {
+	var params sql.NullString
+	if len(event.Params) > 0 {
+		payload, err := json.Marshal(event.Params)
+		if err != nil {
+			return err
+		}
+
+		params = sql.NullString{String: string(payload), Valid: true}
+	}
+
+	_, err := db.conn.Exec(`
+		INSERT INTO audit_events (time, actor, remote_addr, api_token_name, api_token_role, route, team_name, params, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, event.Time, event.Actor, event.RemoteAddr, event.APITokenName, string(event.APITokenRole), event.Route, event.TeamName, params, event.Status)
+	return err
+}
+
+// GetAuditEvents returns a page of audit events, most recent first.
+func (db *SQLDB) GetAuditEvents(page Page) ([]AuditEvent, Pagination, error) {
+	eventsQuery := sq.Select("e.id, e.time, e.actor, e.remote_addr, e.api_token_name, e.api_token_role, e.route, e.team_name, e.params, e.status").
+		From("audit_events e")
+
+	if page.Since == 0 && page.Until == 0 {
+		eventsQuery = eventsQuery.OrderBy("e.id DESC").Limit(uint64(page.Limit))
+	} else if page.Until != 0 {
+		eventsQuery = eventsQuery.Where(sq.Gt{"e.id": uint64(page.Until)}).OrderBy("e.id ASC").Limit(uint64(page.Limit))
+		eventsQuery = sq.Select("sub.*").FromSelect(eventsQuery, "sub").OrderBy("sub.id DESC")
+	} else {
+		eventsQuery = eventsQuery.Where(sq.Lt{"e.id": page.Since}).OrderBy("e.id DESC").Limit(uint64(page.Limit))
+	}
+
+	query, args, err := eventsQuery.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer rows.Close()
+
+	events := []AuditEvent{}
+
+	for rows.Next() {
+		var event AuditEvent
+		var params sql.NullString
+		var apiTokenRole string
+
+		err := rows.Scan(
+			&event.ID,
+			&event.Time,
+			&event.Actor,
+			&event.RemoteAddr,
+			&event.APITokenName,
+			&apiTokenRole,
+			&event.Route,
+			&event.TeamName,
+			&params,
+			&event.Status,
+		)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		event.APITokenRole = atc.TeamRole(apiTokenRole)
+
+		if params.Valid {
+			err = json.Unmarshal([]byte(params.String), &event.Params)
+			if err != nil {
+				return nil, Pagination{}, err
+			}
+		}
+
+		events = append(events, event)
+	}
+
+	if len(events) == 0 {
+		return events, Pagination{}, nil
+	}
+
+	var minID int
+	var maxID int
+
+	err = db.conn.QueryRow(`
+		SELECT COALESCE(MAX(id), 0), COALESCE(MIN(id), 0)
+		FROM audit_events
+	`).Scan(&maxID, &minID)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	first := events[0]
+	last := events[len(events)-1]
+
+	var pagination Pagination
+
+	if first.ID < maxID {
+		pagination.Previous = &Page{
+			Until: first.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	if last.ID > minID {
+		pagination.Next = &Page{
+			Since: last.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	return events, pagination, nil
+}
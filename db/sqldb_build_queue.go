@@ -0,0 +1,156 @@
+package db
+
+import "time"
+
+type QueuedBuild struct {
+	BuildID  int
+	TeamName string
+	Priority int
+
+	FirstOffered time.Time
+	LastOffered  time.Time
+}
+
+func (db *SQLDB) SaveQueuedBuild(buildID int, priority int, offeredAt time.Time) (QueuedBuild, error) {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return QueuedBuild{}, err
+	}
+
+	defer tx.Rollback()
+
+	updated, err := checkIfRowsUpdated(tx, `
+		UPDATE build_queue
+		SET priority = $2, last_offered = $3
+		WHERE build_id = $1
+	`, buildID, priority, offeredAt)
+	if err != nil {
+		return QueuedBuild{}, err
+	}
+
+	if !updated {
+		_, err = tx.Exec(`
+			INSERT INTO build_queue (build_id, priority, first_offered, last_offered)
+			VALUES ($1, $2, $3, $3)
+		`, buildID, priority, offeredAt)
+		if err != nil {
+			return QueuedBuild{}, err
+		}
+	}
+
+	queuedBuild, err := scanQueuedBuild(tx.QueryRow(`
+		SELECT q.build_id, t.name, q.priority, q.first_offered, q.last_offered
+		FROM build_queue q
+		INNER JOIN builds b ON b.id = q.build_id
+		INNER JOIN teams t ON t.id = b.team_id
+		WHERE q.build_id = $1
+	`, buildID))
+	if err != nil {
+		return QueuedBuild{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return QueuedBuild{}, err
+	}
+
+	return queuedBuild, nil
+}
+
+func (db *SQLDB) GetQueuedBuilds() ([]QueuedBuild, error) {
+	rows, err := db.conn.Query(`
+		SELECT q.build_id, t.name, q.priority, q.first_offered, q.last_offered
+		FROM build_queue q
+		INNER JOIN builds b ON b.id = q.build_id
+		INNER JOIN teams t ON t.id = b.team_id
+		WHERE b.status = 'pending'
+		ORDER BY q.priority DESC, q.first_offered ASC, q.build_id ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	queuedBuilds := []QueuedBuild{}
+
+	for rows.Next() {
+		queuedBuild, err := scanQueuedBuild(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		queuedBuilds = append(queuedBuilds, queuedBuild)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return queuedBuilds, nil
+}
+
+func (db *SQLDB) DeleteQueuedBuildsOfferedBefore(offeredBefore time.Time) error {
+	_, err := db.conn.Exec(`
+		DELETE FROM build_queue
+		WHERE last_offered < $1
+	`, offeredBefore)
+	return err
+}
+
+// ScheduleQueuedBuild marks the build as scheduled and removes it from the
+// queue. It returns false if the build does not exist.
+func (db *SQLDB) ScheduleQueuedBuild(buildID int) (bool, error) {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback()
+
+	updated, err := checkIfRowsUpdated(tx, `
+		UPDATE builds
+		SET scheduled = true
+		WHERE id = $1
+	`, buildID)
+	if err != nil {
+		return false, err
+	}
+
+	if !updated {
+		return false, nil
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM build_queue
+		WHERE build_id = $1
+	`, buildID)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func scanQueuedBuild(row scannable) (QueuedBuild, error) {
+	var queuedBuild QueuedBuild
+
+	err := row.Scan(
+		&queuedBuild.BuildID,
+		&queuedBuild.TeamName,
+		&queuedBuild.Priority,
+		&queuedBuild.FirstOffered,
+		&queuedBuild.LastOffered,
+	)
+	if err != nil {
+		return QueuedBuild{}, err
+	}
+
+	return queuedBuild, nil
+}
@@ -2,10 +2,12 @@ package db
 
 import (
 	"database/sql"
+	"io"
 	"strconv"
 	"strings"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc/event"
 )
 
 func (db *SQLDB) FindJobIDForBuild(buildID int) (int, bool, error) {
@@ -77,6 +79,44 @@ func (db *SQLDB) GetAllStartedBuilds() ([]Build, error) {
 	return bs, nil
 }
 
+// GetActiveBuildsCountByTeam counts the builds which are running, or which
+// have been scheduled and are about to start. Scheduled builds which failed to
+// start and are waiting in the build queue again are not counted.
+func (db *SQLDB) GetActiveBuildsCountByTeam() (map[string]int, error) {
+	rows, err := db.conn.Query(`
+		SELECT t.name, COUNT(*)
+		FROM builds b
+		INNER JOIN teams t ON b.team_id = t.id
+		WHERE b.status = 'started'
+		OR (
+			b.status = 'pending'
+			AND b.scheduled
+			AND NOT EXISTS (SELECT 1 FROM build_queue q WHERE q.build_id = b.id)
+		)
+		GROUP BY t.name
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	counts := map[string]int{}
+
+	for rows.Next() {
+		var teamName string
+		var count int
+		err := rows.Scan(&teamName, &count)
+		if err != nil {
+			return nil, err
+		}
+
+		counts[teamName] = count
+	}
+
+	return counts, nil
+}
+
 func (db *SQLDB) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 	if len(buildIDs) == 0 {
 		return nil
@@ -92,6 +132,15 @@ func (db *SQLDB) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 		indexStrings[i] = "$" + strconv.Itoa(i+1)
 	}
 
+	if db.archiver != nil {
+		for _, buildID := range buildIDs {
+			err := db.archiveBuildEvents(buildID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	tx, err := db.conn.Begin()
 	if err != nil {
 		return err
@@ -120,6 +169,54 @@ func (db *SQLDB) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 	return err
 }
 
+func (db *SQLDB) archiveBuildEvents(buildID int) error {
+	var reaped bool
+	err := db.conn.QueryRow(`
+		SELECT reap_time IS NOT NULL
+		FROM builds
+		WHERE id = $1
+	`, buildID).Scan(&reaped)
+	if err != nil {
+		return err
+	}
+
+	// the events are already gone; don't clobber the archive
+	if reaped {
+		return nil
+	}
+
+	rows, err := db.conn.Query(`
+		SELECT type, version, payload
+		FROM build_events
+		WHERE build_id = $1
+		ORDER BY event_id ASC
+	`, buildID)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	return db.archiver.Archive(buildID, buildEventRows{rows})
+}
+
+type buildEventRows struct {
+	rows *sql.Rows
+}
+
+func (r buildEventRows) Next() (event.Envelope, error) {
+	if !r.rows.Next() {
+		err := r.rows.Err()
+		if err != nil {
+			return event.Envelope{}, err
+		}
+
+		return event.Envelope{}, io.EOF
+	}
+
+	return scanBuildEvent(r.rows)
+}
+
 func getBuildsWithPagination(buildsQuery sq.SelectBuilder, page Page, dbConn Conn, buildFactory *buildFactory) ([]Build, Pagination, error) {
 	var rows *sql.Rows
 	var err error
@@ -2,9 +2,11 @@ package db
 
 import (
 	"encoding/json"
+	"io"
 	"sync"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/archive"
 	"github.com/concourse/atc/event"
 )
 
@@ -13,6 +15,7 @@ func newSQLDBBuildEventSource(
 	table string,
 	conn Conn,
 	notifier Notifier,
+	archiver archive.Archiver,
 	from uint,
 ) *sqldbBuildEventSource {
 	wg := new(sync.WaitGroup)
@@ -24,6 +27,7 @@ func newSQLDBBuildEventSource(
 		conn: conn,
 
 		notifier: notifier,
+		archiver: archiver,
 
 		events: make(chan event.Envelope, 2000),
 		stop:   make(chan struct{}),
@@ -42,6 +46,7 @@ type sqldbBuildEventSource struct {
 
 	conn     Conn
 	notifier Notifier
+	archiver archive.Archiver
 
 	events chan event.Envelope
 	stop   chan struct{}
@@ -88,18 +93,33 @@ func (source *sqldbBuildEventSource) collectEvents(cursor uint) {
 		}
 
 		completed := false
+		reaped := false
 
 		err := source.conn.QueryRow(`
-			SELECT builds.completed
+			SELECT builds.completed, builds.reap_time IS NOT NULL
 			FROM builds
 			WHERE builds.id = $1
-		`, source.buildID).Scan(&completed)
+		`, source.buildID).Scan(&completed, &reaped)
 		if err != nil {
 			source.err = err
 			close(source.events)
 			return
 		}
 
+		if reaped && source.archiver != nil {
+			reader, found, err := source.archiver.Retrieve(source.buildID)
+			if err != nil {
+				source.err = err
+				close(source.events)
+				return
+			}
+
+			if found {
+				source.streamArchivedEvents(reader, cursor)
+				return
+			}
+		}
+
 		rows, err := source.conn.Query(`
 			SELECT type, version, payload
 			FROM `+source.table+`
@@ -121,8 +141,7 @@ func (source *sqldbBuildEventSource) collectEvents(cursor uint) {
 
 			cursor++
 
-			var t, v, p string
-			err := rows.Scan(&t, &v, &p)
+			ev, err := scanBuildEvent(rows)
 			if err != nil {
 				rows.Close()
 
@@ -131,14 +150,6 @@ func (source *sqldbBuildEventSource) collectEvents(cursor uint) {
 				return
 			}
 
-			data := json.RawMessage(p)
-
-			ev := event.Envelope{
-				Data:    &data,
-				Event:   atc.EventType(t),
-				Version: atc.EventVersion(v),
-			}
-
 			select {
 			case source.events <- ev:
 			case <-source.stop:
@@ -170,3 +181,55 @@ func (source *sqldbBuildEventSource) collectEvents(cursor uint) {
 		}
 	}
 }
+
+// streamArchivedEvents sends the events of a reaped build from its archive,
+// skipping those before the cursor.
+func (source *sqldbBuildEventSource) streamArchivedEvents(reader archive.Reader, cursor uint) {
+	defer reader.Close()
+
+	var skipped uint
+
+	for {
+		ev, err := reader.Next()
+		if err == io.EOF {
+			source.err = ErrEndOfBuildEventStream
+			close(source.events)
+			return
+		}
+
+		if err != nil {
+			source.err = err
+			close(source.events)
+			return
+		}
+
+		if skipped < cursor {
+			skipped++
+			continue
+		}
+
+		select {
+		case source.events <- ev:
+		case <-source.stop:
+			source.err = ErrBuildEventStreamClosed
+			close(source.events)
+			return
+		}
+	}
+}
+
+func scanBuildEvent(row scannable) (event.Envelope, error) {
+	var t, v, p string
+	err := row.Scan(&t, &v, &p)
+	if err != nil {
+		return event.Envelope{}, err
+	}
+
+	data := json.RawMessage(p)
+
+	return event.Envelope{
+		Data:    &data,
+		Event:   atc.EventType(t),
+		Version: atc.EventVersion(v),
+	}, nil
+}
@@ -58,3 +58,21 @@ func (db *SQLDB) AcquireContainerCreatingLock(logger lager.Logger, containerID i
 
 	return lock, true, nil
 }
+
+func (db *SQLDB) AcquireBuildStartingLock(logger lager.Logger) (lock.Lock, bool, error) {
+	lock := db.lockFactory.NewLock(
+		logger.Session("build-starting-lock"),
+		lock.NewBuildStartingLockID(),
+	)
+
+	acquired, err := lock.Acquire()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !acquired {
+		return nil, false, nil
+	}
+
+	return lock, true, nil
+}
@@ -6,8 +6,8 @@ type PipelinesDB interface {
 	GetAllPublicPipelines() ([]SavedPipeline, error)
 }
 
-const pipelineColumns = "p.id, p.name, p.config, p.version, p.paused, p.team_id, p.public, t.name as team_name"
-const unqualifiedPipelineColumns = "id, name, config, version, paused, team_id, public"
+const pipelineColumns = "p.id, p.name, p.config, p.version, p.paused, p.team_id, p.public, (SELECT pt.name FROM pipeline_templates pt WHERE pt.id = p.template_id), p.template_version, p.instance_vars, t.name as team_name"
+const unqualifiedPipelineColumns = "id, name, config, version, paused, team_id, public, (SELECT pt.name FROM pipeline_templates pt WHERE pt.id = template_id), template_version, instance_vars"
 
 func (db *SQLDB) GetAllPublicPipelines() ([]SavedPipeline, error) {
 	rows, err := db.conn.Query(`
@@ -11,7 +11,7 @@ import (
 
 func (db *SQLDB) GetTeams() ([]SavedTeam, error) {
 	rows, err := db.conn.Query(`
-		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth FROM teams
+		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, ldap_auth, roles FROM teams
 	`)
 	if err != nil {
 		return nil, err
@@ -106,14 +106,24 @@ func (db *SQLDB) CreateTeam(team Team) (SavedTeam, error) {
 		return SavedTeam{}, err
 	}
 
+	jsonEncodedLDAPAuth, err := json.Marshal(team.LDAPAuth)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	jsonEncodedRoles, err := json.Marshal(team.Roles)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
 	savedTeam, err := scanTeam(db.conn.QueryRow(`
 	INSERT INTO teams (
-    name, basic_auth, github_auth, uaa_auth, genericoauth_auth
+    name, basic_auth, github_auth, uaa_auth, genericoauth_auth, ldap_auth, roles
 	) VALUES (
-		$1, $2, $3, $4, $5
+		$1, $2, $3, $4, $5, $6, $7
 	)
-	RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
-	`, team.Name, jsonEncodedBasicAuth, string(jsonEncodedGitHubAuth), string(jsonEncodedUAAAuth), string(jsonEncodedGenericOAuth)))
+	RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, ldap_auth, roles
+	`, team.Name, jsonEncodedBasicAuth, string(jsonEncodedGitHubAuth), string(jsonEncodedUAAAuth), string(jsonEncodedGenericOAuth), string(jsonEncodedLDAPAuth), string(jsonEncodedRoles)))
 	if err != nil {
 		return SavedTeam{}, err
 	}
@@ -130,7 +140,7 @@ func (db *SQLDB) CreateTeam(team Team) (SavedTeam, error) {
 }
 
 func scanTeam(rows scannable) (SavedTeam, error) {
-	var basicAuth, gitHubAuth, uaaAuth, genericOAuth sql.NullString
+	var basicAuth, gitHubAuth, uaaAuth, genericOAuth, ldapAuth, roles sql.NullString
 	var savedTeam SavedTeam
 
 	err := rows.Scan(
@@ -141,6 +151,8 @@ func scanTeam(rows scannable) (SavedTeam, error) {
 		&gitHubAuth,
 		&uaaAuth,
 		&genericOAuth,
+		&ldapAuth,
+		&roles,
 	)
 	if err != nil {
 		return savedTeam, err
@@ -174,6 +186,20 @@ func scanTeam(rows scannable) (SavedTeam, error) {
 		}
 	}
 
+	if ldapAuth.Valid {
+		err = json.Unmarshal([]byte(ldapAuth.String), &savedTeam.LDAPAuth)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
+	if roles.Valid {
+		err = json.Unmarshal([]byte(roles.String), &savedTeam.Roles)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
 	return savedTeam, nil
 }
 
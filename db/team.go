@@ -3,6 +3,7 @@ package db
 import (
 	"encoding/json"
 
+	"github.com/concourse/atc"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -14,10 +15,23 @@ type Team struct {
 	GitHubAuth   *GitHubAuth   `json:"github_auth"`
 	UAAAuth      *UAAAuth      `json:"uaa_auth"`
 	GenericOAuth *GenericOAuth `json:"genericoauth_auth"`
+	LDAPAuth     *LDAPAuth     `json:"ldap_auth"`
+
+	Roles map[string]atc.TeamRole `json:"roles"`
 }
 
 func (t Team) IsAuthConfigured() bool {
-	return t.BasicAuth != nil || t.GitHubAuth != nil || t.UAAAuth != nil
+	return t.BasicAuth != nil || t.GitHubAuth != nil || t.UAAAuth != nil || t.LDAPAuth != nil
+}
+
+// RoleFor returns the role granted to users logging in with the given auth
+// provider. Providers without a role mapped grant owner.
+func (t Team) RoleFor(providerName string) atc.TeamRole {
+	if role, found := t.Roles[providerName]; found {
+		return role
+	}
+
+	return atc.TeamRoleOwner
 }
 
 type BasicAuth struct {
@@ -82,3 +96,19 @@ type GenericOAuth struct {
 	DisplayName   string            `json:"display_name"`
 	Scope         string            `json:"scope"`
 }
+
+type LDAPAuth struct {
+	Host               string   `json:"host"`
+	BindDN             string   `json:"bind_dn"`
+	BindPassword       string   `json:"bind_password"`
+	UserSearchBaseDN   string   `json:"user_search_base_dn"`
+	UserSearchFilter   string   `json:"user_search_filter"`
+	GroupSearchBaseDN  string   `json:"group_search_base_dn"`
+	GroupSearchFilter  string   `json:"group_search_filter"`
+	GroupNameAttribute string   `json:"group_name_attribute"`
+	Groups             []string `json:"groups"`
+	UseTLS             bool     `json:"use_tls"`
+	StartTLS           bool     `json:"start_tls"`
+	InsecureSkipVerify bool     `json:"insecure_skip_verify"`
+	CACert             string   `json:"ca_cert"`
+}
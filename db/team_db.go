@@ -28,6 +28,16 @@ type TeamDB interface {
 	UpdateGitHubAuth(gitHubAuth *GitHubAuth) (SavedTeam, error)
 	UpdateUAAAuth(uaaAuth *UAAAuth) (SavedTeam, error)
 	UpdateGenericOAuth(genericOAuth *GenericOAuth) (SavedTeam, error)
+	UpdateLDAPAuth(ldapAuth *LDAPAuth) (SavedTeam, error)
+	UpdateRoles(roles map[string]atc.TeamRole) (SavedTeam, error)
+
+	CreateAPIToken(token APIToken, tokenHash string) (SavedAPIToken, error)
+	GetAPITokens() ([]SavedAPIToken, error)
+	DeleteAPIToken(name string) (bool, error)
+
+	CreateNotificationSubscription(subscription NotificationSubscription) (SavedNotificationSubscription, error)
+	GetNotificationSubscriptions() ([]SavedNotificationSubscription, error)
+	DeleteNotificationSubscription(id int) (bool, error)
 
 	GetConfig(pipelineName string) (atc.Config, atc.RawConfig, ConfigVersion, error)
 	SaveConfigToBeDeprecated(string, atc.Config, ConfigVersion, PipelinePausedState) (SavedPipeline, bool, error)
@@ -519,7 +529,7 @@ func (db *teamDB) saveResourceType(tx Tx, resourceType atc.ResourceType, pipelin
 
 func (db *teamDB) GetTeam() (SavedTeam, bool, error) {
 	query := `
-		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, ldap_auth, roles
 		FROM teams
 		WHERE LOWER(name) = LOWER($1)
 	`
@@ -537,7 +547,7 @@ func (db *teamDB) GetTeam() (SavedTeam, bool, error) {
 }
 
 func (db *teamDB) queryTeam(query string, params []interface{}) (SavedTeam, error) {
-	var basicAuth, gitHubAuth, uaaAuth, genericOAuth sql.NullString
+	var basicAuth, gitHubAuth, uaaAuth, genericOAuth, ldapAuth, roles sql.NullString
 	var savedTeam SavedTeam
 
 	tx, err := db.conn.Begin()
@@ -554,6 +564,8 @@ func (db *teamDB) queryTeam(query string, params []interface{}) (SavedTeam, erro
 		&gitHubAuth,
 		&uaaAuth,
 		&genericOAuth,
+		&ldapAuth,
+		&roles,
 	)
 	if err != nil {
 		return savedTeam, err
@@ -591,6 +603,20 @@ func (db *teamDB) queryTeam(query string, params []interface{}) (SavedTeam, erro
 		}
 	}
 
+	if ldapAuth.Valid {
+		err = json.Unmarshal([]byte(ldapAuth.String), &savedTeam.LDAPAuth)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
+	if roles.Valid {
+		err = json.Unmarshal([]byte(roles.String), &savedTeam.Roles)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
 	return savedTeam, nil
 }
 
@@ -604,7 +630,7 @@ func (db *teamDB) UpdateBasicAuth(basicAuth *BasicAuth) (SavedTeam, error) {
 		UPDATE teams
 		SET basic_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, ldap_auth, roles
 	`
 
 	params := []interface{}{encryptedBasicAuth, db.teamName}
@@ -626,7 +652,7 @@ func (db *teamDB) UpdateGitHubAuth(gitHubAuth *GitHubAuth) (SavedTeam, error) {
 		UPDATE teams
 		SET github_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, ldap_auth, roles
 	`
 	params := []interface{}{string(jsonEncodedGitHubAuth), db.teamName}
 	return db.queryTeam(query, params)
@@ -642,7 +668,7 @@ func (db *teamDB) UpdateUAAAuth(uaaAuth *UAAAuth) (SavedTeam, error) {
 		UPDATE teams
 		SET uaa_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, ldap_auth, roles
 	`
 	params := []interface{}{string(jsonEncodedUAAAuth), db.teamName}
 	return db.queryTeam(query, params)
@@ -658,12 +684,44 @@ func (db *teamDB) UpdateGenericOAuth(genericOAuth *GenericOAuth) (SavedTeam, err
 		UPDATE teams
 		SET genericoauth_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, ldap_auth, roles
 	`
 	params := []interface{}{string(jsonEncodedGenericOAuth), db.teamName}
 	return db.queryTeam(query, params)
 }
 
+func (db *teamDB) UpdateLDAPAuth(ldapAuth *LDAPAuth) (SavedTeam, error) {
+	jsonEncodedLDAPAuth, err := json.Marshal(ldapAuth)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	query := `
+		UPDATE teams
+		SET ldap_auth = $1
+		WHERE LOWER(name) = LOWER($2)
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, ldap_auth, roles
+	`
+	params := []interface{}{string(jsonEncodedLDAPAuth), db.teamName}
+	return db.queryTeam(query, params)
+}
+
+func (db *teamDB) UpdateRoles(roles map[string]atc.TeamRole) (SavedTeam, error) {
+	jsonEncodedRoles, err := json.Marshal(roles)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	query := `
+		UPDATE teams
+		SET roles = $1
+		WHERE LOWER(name) = LOWER($2)
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, ldap_auth, roles
+	`
+	params := []interface{}{string(jsonEncodedRoles), db.teamName}
+	return db.queryTeam(query, params)
+}
+
 func (db *teamDB) CreateOneOffBuild() (Build, error) {
 	tx, err := db.conn.Begin()
 	if err != nil {
@@ -679,7 +737,8 @@ func (db *teamDB) CreateOneOffBuild() (Build, error) {
 		RETURNING `+buildColumns+`, null, null, null,
 		(
 			SELECT name FROM teams WHERE LOWER(name) = LOWER($1)
-		)
+		),
+		null
 	`, string(db.teamName)))
 	if err != nil {
 		return nil, err
@@ -716,9 +775,12 @@ func scanPipeline(rows scannable) (SavedPipeline, error) {
 	var paused bool
 	var public bool
 	var teamID int
+	var templateName sql.NullString
+	var templateVersion int
+	var instanceVarsBlob []byte
 	var teamName string
 
-	err := rows.Scan(&id, &name, &configBlob, &version, &paused, &teamID, &public, &teamName)
+	err := rows.Scan(&id, &name, &configBlob, &version, &paused, &teamID, &public, &templateName, &templateVersion, &instanceVarsBlob, &teamName)
 	if err != nil {
 		return SavedPipeline{}, err
 	}
@@ -729,12 +791,23 @@ func scanPipeline(rows scannable) (SavedPipeline, error) {
 		return SavedPipeline{}, err
 	}
 
+	var instanceVars atc.InstanceVars
+	if instanceVarsBlob != nil {
+		err = json.Unmarshal(instanceVarsBlob, &instanceVars)
+		if err != nil {
+			return SavedPipeline{}, err
+		}
+	}
+
 	return SavedPipeline{
-		ID:       id,
-		Paused:   paused,
-		Public:   public,
-		TeamID:   teamID,
-		TeamName: teamName,
+		ID:              id,
+		Paused:          paused,
+		Public:          public,
+		TeamID:          teamID,
+		TeamName:        teamName,
+		TemplateName:    templateName.String,
+		TemplateVersion: templateVersion,
+		InstanceVars:    instanceVars,
 		Pipeline: Pipeline{
 			Name:    name,
 			Config:  config,
@@ -0,0 +1,96 @@
+package db
+
+import "github.com/lib/pq"
+
+func (db *teamDB) CreateAPIToken(token APIToken, tokenHash string) (SavedAPIToken, error) {
+	var expiresAt pq.NullTime
+	if !token.ExpiresAt.IsZero() {
+		expiresAt = pq.NullTime{Time: token.ExpiresAt, Valid: true}
+	}
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return SavedAPIToken{}, err
+	}
+
+	defer tx.Rollback()
+
+	var id int
+	err = tx.QueryRow(`
+		INSERT INTO api_tokens (team_id, name, token_hash, role, expires_at)
+		VALUES (
+			(SELECT id FROM teams WHERE LOWER(name) = LOWER($1)),
+			$2, $3, $4, $5
+		)
+		RETURNING id
+	`, db.teamName, token.Name, tokenHash, string(token.Role), expiresAt).Scan(&id)
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code.Name() == "unique_violation" {
+			return SavedAPIToken{}, ErrAPITokenAlreadyExists
+		}
+
+		return SavedAPIToken{}, err
+	}
+
+	savedToken, err := scanAPIToken(tx.QueryRow(`
+		SELECT `+apiTokenColumns+`
+		FROM api_tokens a
+		JOIN teams t ON t.id = a.team_id
+		WHERE a.id = $1
+	`, id))
+	if err != nil {
+		return SavedAPIToken{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return SavedAPIToken{}, err
+	}
+
+	return savedToken, nil
+}
+
+func (db *teamDB) GetAPITokens() ([]SavedAPIToken, error) {
+	rows, err := db.conn.Query(`
+		SELECT `+apiTokenColumns+`
+		FROM api_tokens a
+		JOIN teams t ON t.id = a.team_id
+		WHERE LOWER(t.name) = LOWER($1)
+		ORDER BY a.name ASC
+	`, db.teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	tokens := []SavedAPIToken{}
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+func (db *teamDB) DeleteAPIToken(name string) (bool, error) {
+	result, err := db.conn.Exec(`
+		DELETE FROM api_tokens
+		WHERE name = $1
+		AND team_id = (SELECT id FROM teams WHERE LOWER(name) = LOWER($2))
+	`, name, db.teamName)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
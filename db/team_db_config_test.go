@@ -47,14 +47,14 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		retryableConn := &lock.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := lock.NewLockFactory(retryableConn)
-		database = db.NewSQL(dbConn, bus, lockFactory)
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		database = db.NewSQL(dbConn, bus, lockFactory, nil)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory, nil)
 
 		var err error
 		team, err = database.CreateTeam(db.Team{Name: "some-team"})
 		Expect(err).NotTo(HaveOccurred())
 
-		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
 		teamDB = teamDBFactory.GetTeamDB("some-team")
 
 		config = atc.Config{
@@ -102,7 +102,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 							Params: atc.Params{
 								"some-param": "some-value",
 							},
-							Passed:  []string{"job-1", "job-2"},
+							Passed:  atc.PassedConfigs{{Job: "job-1"}, {Job: "job-2"}},
 							Trigger: true,
 						},
 						{
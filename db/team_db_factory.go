@@ -1,6 +1,7 @@
 package db
 
 import (
+	"github.com/concourse/atc/db/archive"
 	"github.com/concourse/atc/db/lock"
 )
 
@@ -14,13 +15,15 @@ type teamDBFactory struct {
 	conn        Conn
 	bus         *notificationsBus
 	lockFactory lock.LockFactory
+	archiver    archive.Archiver
 }
 
-func NewTeamDBFactory(conn Conn, bus *notificationsBus, lockFactory lock.LockFactory) TeamDBFactory {
+func NewTeamDBFactory(conn Conn, bus *notificationsBus, lockFactory lock.LockFactory, archiver archive.Archiver) TeamDBFactory {
 	return &teamDBFactory{
 		conn:        conn,
 		bus:         bus,
 		lockFactory: lockFactory,
+		archiver:    archiver,
 	}
 }
 
@@ -28,6 +31,6 @@ func (f *teamDBFactory) GetTeamDB(teamName string) TeamDB {
 	return &teamDB{
 		teamName:     teamName,
 		conn:         f.conn,
-		buildFactory: newBuildFactory(f.conn, f.bus, f.lockFactory),
+		buildFactory: newBuildFactory(f.conn, f.bus, f.lockFactory, f.archiver),
 	}
 }
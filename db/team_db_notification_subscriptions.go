@@ -0,0 +1,92 @@
+package db
+
+import "encoding/json"
+
+func (db *teamDB) CreateNotificationSubscription(subscription NotificationSubscription) (SavedNotificationSubscription, error) {
+	events, err := json.Marshal(subscription.Events)
+	if err != nil {
+		return SavedNotificationSubscription{}, err
+	}
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return SavedNotificationSubscription{}, err
+	}
+
+	defer tx.Rollback()
+
+	var id int
+	err = tx.QueryRow(`
+		INSERT INTO notification_subscriptions (team_id, url, secret, events, pipeline_name, job_name)
+		VALUES (
+			(SELECT id FROM teams WHERE LOWER(name) = LOWER($1)),
+			$2, $3, $4, $5, $6
+		)
+		RETURNING id
+	`, db.teamName, subscription.URL, subscription.Secret, events, subscription.PipelineName, subscription.JobName).Scan(&id)
+	if err != nil {
+		return SavedNotificationSubscription{}, err
+	}
+
+	savedSubscription, err := scanNotificationSubscription(tx.QueryRow(`
+		SELECT `+notificationSubscriptionColumns+`
+		FROM notification_subscriptions n
+		JOIN teams t ON t.id = n.team_id
+		WHERE n.id = $1
+	`, id))
+	if err != nil {
+		return SavedNotificationSubscription{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return SavedNotificationSubscription{}, err
+	}
+
+	return savedSubscription, nil
+}
+
+func (db *teamDB) GetNotificationSubscriptions() ([]SavedNotificationSubscription, error) {
+	rows, err := db.conn.Query(`
+		SELECT `+notificationSubscriptionColumns+`
+		FROM notification_subscriptions n
+		JOIN teams t ON t.id = n.team_id
+		WHERE LOWER(t.name) = LOWER($1)
+		ORDER BY n.id ASC
+	`, db.teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	subscriptions := []SavedNotificationSubscription{}
+	for rows.Next() {
+		subscription, err := scanNotificationSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, nil
+}
+
+func (db *teamDB) DeleteNotificationSubscription(id int) (bool, error) {
+	result, err := db.conn.Exec(`
+		DELETE FROM notification_subscriptions
+		WHERE id = $1
+		AND team_id = (SELECT id FROM teams WHERE LOWER(name) = LOWER($2))
+	`, id, db.teamName)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
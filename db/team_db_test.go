@@ -45,8 +45,8 @@ var _ = Describe("TeamDB", func() {
 		retryableConn := &lock.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := lock.NewLockFactory(retryableConn)
-		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory)
-		database = db.NewSQL(dbConn, bus, lockFactory)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
+		database = db.NewSQL(dbConn, bus, lockFactory, nil)
 
 		team := db.Team{Name: "TEAM-name"}
 		var err error
@@ -56,7 +56,7 @@ var _ = Describe("TeamDB", func() {
 		teamDB = teamDBFactory.GetTeamDB("team-NAME")
 		nonExistentTeamDB = teamDBFactory.GetTeamDB("non-existent-name")
 
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory, nil)
 
 		team = db.Team{Name: "other-team-name"}
 		otherSavedTeam, err = database.CreateTeam(team)
@@ -273,6 +273,7 @@ var _ = Describe("TeamDB", func() {
 		var gitHubAuth *db.GitHubAuth
 		var uaaAuth *db.UAAAuth
 		var genericOAuth *db.GenericOAuth
+		var ldapAuth *db.LDAPAuth
 
 		BeforeEach(func() {
 			basicAuth = &db.BasicAuth{
@@ -315,6 +316,18 @@ var _ = Describe("TeamDB", func() {
 				Scope:         "read",
 				TokenURL:      "https://token.url",
 			}
+
+			ldapAuth = &db.LDAPAuth{
+				Host:              "ldap.example.com:636",
+				BindDN:            "cn=admin,dc=example,dc=com",
+				BindPassword:      "secret",
+				UserSearchBaseDN:  "ou=people,dc=example,dc=com",
+				UserSearchFilter:  "(uid=%s)",
+				GroupSearchBaseDN: "ou=groups,dc=example,dc=com",
+				GroupSearchFilter: "(member=%s)",
+				Groups:            []string{"devs"},
+				UseTLS:            true,
+			}
 		})
 
 		Describe("UpdateBasicAuth", func() {
@@ -447,6 +460,245 @@ var _ = Describe("TeamDB", func() {
 				Expect(savedTeam.GenericOAuth).To(Equal(genericOAuth))
 			})
 		})
+
+		Describe("UpdateLDAPAuth", func() {
+			It("saves ldap auth info to the existing team", func() {
+				savedTeam, err := teamDB.UpdateLDAPAuth(ldapAuth)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.LDAPAuth).To(Equal(ldapAuth))
+			})
+
+			It("saves ldap auth info without overwriting the basic auth", func() {
+				_, err := teamDB.UpdateBasicAuth(basicAuth)
+				Expect(err).NotTo(HaveOccurred())
+
+				savedTeam, err := teamDB.UpdateLDAPAuth(ldapAuth)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(savedTeam.BasicAuth.BasicAuthUsername).To(Equal(basicAuth.BasicAuthUsername))
+			})
+		})
+
+		Describe("UpdateRoles", func() {
+			It("saves the roles to the existing team", func() {
+				roles := map[string]atc.TeamRole{
+					"basic":  atc.TeamRoleOwner,
+					"github": atc.TeamRoleViewer,
+				}
+
+				savedTeam, err := teamDB.UpdateRoles(roles)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.Roles).To(Equal(roles))
+
+				actualTeam, found, err := teamDB.GetTeam()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(actualTeam.Roles).To(Equal(roles))
+			})
+		})
+	})
+
+	Describe("API tokens", func() {
+		var token db.APIToken
+
+		BeforeEach(func() {
+			token = db.APIToken{
+				Name: "ci-bot",
+				Role: atc.TeamRolePipelineOperator,
+			}
+		})
+
+		Describe("CreateAPIToken", func() {
+			It("saves the token for the team", func() {
+				savedToken, err := teamDB.CreateAPIToken(token, "some-hash")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedToken.APIToken).To(Equal(token))
+				Expect(savedToken.TeamName).To(Equal(savedTeam.Name))
+				Expect(savedToken.CreatedAt).To(BeTemporally("~", time.Now(), time.Minute))
+				Expect(savedToken.LastUsedAt).To(BeZero())
+			})
+
+			It("saves the expiry", func() {
+				token.ExpiresAt = time.Now().Add(time.Hour).Truncate(time.Second)
+
+				savedToken, err := teamDB.CreateAPIToken(token, "some-hash")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedToken.ExpiresAt).To(BeTemporally("==", token.ExpiresAt))
+			})
+
+			Context("when the team already has a token with the name", func() {
+				BeforeEach(func() {
+					_, err := teamDB.CreateAPIToken(token, "some-hash")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns ErrAPITokenAlreadyExists", func() {
+					_, err := teamDB.CreateAPIToken(token, "some-other-hash")
+					Expect(err).To(Equal(db.ErrAPITokenAlreadyExists))
+				})
+
+				It("allows another team to use the name", func() {
+					_, err := otherTeamDB.CreateAPIToken(token, "some-other-hash")
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		Describe("GetAPITokens", func() {
+			BeforeEach(func() {
+				_, err := teamDB.CreateAPIToken(db.APIToken{Name: "b", Role: atc.TeamRoleViewer}, "hash-b")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = teamDB.CreateAPIToken(db.APIToken{Name: "a", Role: atc.TeamRoleViewer}, "hash-a")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = otherTeamDB.CreateAPIToken(db.APIToken{Name: "c", Role: atc.TeamRoleViewer}, "hash-c")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the team's tokens by name", func() {
+				tokens, err := teamDB.GetAPITokens()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tokens).To(HaveLen(2))
+				Expect(tokens[0].Name).To(Equal("a"))
+				Expect(tokens[1].Name).To(Equal("b"))
+			})
+		})
+
+		Describe("DeleteAPIToken", func() {
+			BeforeEach(func() {
+				_, err := teamDB.CreateAPIToken(token, "some-hash")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("deletes the token", func() {
+				deleted, err := teamDB.DeleteAPIToken(token.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deleted).To(BeTrue())
+
+				_, found, err := database.FindAPIToken("some-hash")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("does not delete another team's token", func() {
+				deleted, err := otherTeamDB.DeleteAPIToken(token.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deleted).To(BeFalse())
+			})
+		})
+
+		Describe("FindAPIToken", func() {
+			It("finds the token by its hash", func() {
+				savedToken, err := teamDB.CreateAPIToken(token, "some-hash")
+				Expect(err).NotTo(HaveOccurred())
+
+				foundToken, found, err := database.FindAPIToken("some-hash")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(foundToken.ID).To(Equal(savedToken.ID))
+				Expect(foundToken.TeamName).To(Equal(savedTeam.Name))
+				Expect(foundToken.Role).To(Equal(atc.TeamRolePipelineOperator))
+			})
+
+			It("does not find expired tokens", func() {
+				token.ExpiresAt = time.Now().Add(-time.Hour)
+				_, err := teamDB.CreateAPIToken(token, "some-hash")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, found, err := database.FindAPIToken("some-hash")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Describe("UpdateAPITokenLastUsed", func() {
+			It("records when the token was used", func() {
+				savedToken, err := teamDB.CreateAPIToken(token, "some-hash")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = database.UpdateAPITokenLastUsed(savedToken.ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				tokens, err := teamDB.GetAPITokens()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tokens[0].LastUsedAt).To(BeTemporally("~", time.Now(), time.Minute))
+			})
+		})
+	})
+
+	Describe("notification subscriptions", func() {
+		var subscription db.NotificationSubscription
+
+		BeforeEach(func() {
+			subscription = db.NotificationSubscription{
+				URL:          "https://example.com/hook",
+				Secret:       "some-secret",
+				Events:       []atc.BuildStatus{atc.StatusFailed, atc.StatusErrored},
+				PipelineName: "some-pipeline",
+			}
+		})
+
+		Describe("CreateNotificationSubscription", func() {
+			It("saves the subscription for the team", func() {
+				savedSubscription, err := teamDB.CreateNotificationSubscription(subscription)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedSubscription.ID).NotTo(BeZero())
+				Expect(savedSubscription.NotificationSubscription).To(Equal(subscription))
+				Expect(savedSubscription.TeamName).To(Equal(savedTeam.Name))
+				Expect(savedSubscription.CreatedAt).To(BeTemporally("~", time.Now(), time.Minute))
+			})
+		})
+
+		Describe("GetNotificationSubscriptions", func() {
+			BeforeEach(func() {
+				_, err := teamDB.CreateNotificationSubscription(subscription)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = teamDB.CreateNotificationSubscription(db.NotificationSubscription{
+					URL:    "https://example.com/other-hook",
+					Events: []atc.BuildStatus{atc.StatusStarted},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = otherTeamDB.CreateNotificationSubscription(subscription)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the team's subscriptions in the order they were created", func() {
+				subscriptions, err := teamDB.GetNotificationSubscriptions()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(subscriptions).To(HaveLen(2))
+				Expect(subscriptions[0].URL).To(Equal("https://example.com/hook"))
+				Expect(subscriptions[1].URL).To(Equal("https://example.com/other-hook"))
+			})
+		})
+
+		Describe("DeleteNotificationSubscription", func() {
+			var savedSubscription db.SavedNotificationSubscription
+
+			BeforeEach(func() {
+				var err error
+				savedSubscription, err = teamDB.CreateNotificationSubscription(subscription)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("deletes the subscription", func() {
+				deleted, err := teamDB.DeleteNotificationSubscription(savedSubscription.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deleted).To(BeTrue())
+
+				subscriptions, err := teamDB.GetNotificationSubscriptions()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(subscriptions).To(BeEmpty())
+			})
+
+			It("does not delete another team's subscription", func() {
+				deleted, err := otherTeamDB.DeleteNotificationSubscription(savedSubscription.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deleted).To(BeFalse())
+			})
+		})
 	})
 
 	Describe("GetTeam", func() {
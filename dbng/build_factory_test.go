@@ -64,7 +64,7 @@ var _ = Describe("BuildFactory", func() {
 							Name: "some-other-job",
 						},
 					},
-				}, dbng.ConfigVersion(0), dbng.PipelineUnpaused)
+				}, dbng.ConfigVersion(0), dbng.PipelineUnpaused, "")
 				Expect(err).NotTo(HaveOccurred())
 
 				pb1, err := p.CreateJobBuild("some-other-job")
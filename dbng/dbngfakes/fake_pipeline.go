@@ -48,6 +48,32 @@ type FakePipeline struct {
 	configVersionReturnsOnCall map[int]struct {
 		result1 dbng.ConfigVersion
 	}
+	ConfigVersionsStub        func() ([]dbng.PipelineConfigVersion, error)
+	configVersionsMutex       sync.RWMutex
+	configVersionsArgsForCall []struct{}
+	configVersionsReturns     struct {
+		result1 []dbng.PipelineConfigVersion
+		result2 error
+	}
+	configVersionsReturnsOnCall map[int]struct {
+		result1 []dbng.PipelineConfigVersion
+		result2 error
+	}
+	ConfigAtVersionStub        func(version dbng.ConfigVersion) (atc.Config, bool, error)
+	configAtVersionMutex       sync.RWMutex
+	configAtVersionArgsForCall []struct {
+		version dbng.ConfigVersion
+	}
+	configAtVersionReturns struct {
+		result1 atc.Config
+		result2 bool
+		result3 error
+	}
+	configAtVersionReturnsOnCall map[int]struct {
+		result1 atc.Config
+		result2 bool
+		result3 error
+	}
 	SaveJobStub        func(job atc.JobConfig) error
 	saveJobMutex       sync.RWMutex
 	saveJobArgsForCall []struct {
@@ -304,6 +330,103 @@ func (fake *FakePipeline) ConfigVersionReturnsOnCall(i int, result1 dbng.ConfigV
 	}{result1}
 }
 
+func (fake *FakePipeline) ConfigVersions() ([]dbng.PipelineConfigVersion, error) {
+	fake.configVersionsMutex.Lock()
+	ret, specificReturn := fake.configVersionsReturnsOnCall[len(fake.configVersionsArgsForCall)]
+	fake.configVersionsArgsForCall = append(fake.configVersionsArgsForCall, struct{}{})
+	fake.recordInvocation("ConfigVersions", []interface{}{})
+	fake.configVersionsMutex.Unlock()
+	if fake.ConfigVersionsStub != nil {
+		return fake.ConfigVersionsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.configVersionsReturns.result1, fake.configVersionsReturns.result2
+}
+
+func (fake *FakePipeline) ConfigVersionsCallCount() int {
+	fake.configVersionsMutex.RLock()
+	defer fake.configVersionsMutex.RUnlock()
+	return len(fake.configVersionsArgsForCall)
+}
+
+func (fake *FakePipeline) ConfigVersionsReturns(result1 []dbng.PipelineConfigVersion, result2 error) {
+	fake.ConfigVersionsStub = nil
+	fake.configVersionsReturns = struct {
+		result1 []dbng.PipelineConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) ConfigVersionsReturnsOnCall(i int, result1 []dbng.PipelineConfigVersion, result2 error) {
+	fake.ConfigVersionsStub = nil
+	if fake.configVersionsReturnsOnCall == nil {
+		fake.configVersionsReturnsOnCall = make(map[int]struct {
+			result1 []dbng.PipelineConfigVersion
+			result2 error
+		})
+	}
+	fake.configVersionsReturnsOnCall[i] = struct {
+		result1 []dbng.PipelineConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) ConfigAtVersion(version dbng.ConfigVersion) (atc.Config, bool, error) {
+	fake.configAtVersionMutex.Lock()
+	ret, specificReturn := fake.configAtVersionReturnsOnCall[len(fake.configAtVersionArgsForCall)]
+	fake.configAtVersionArgsForCall = append(fake.configAtVersionArgsForCall, struct {
+		version dbng.ConfigVersion
+	}{version})
+	fake.recordInvocation("ConfigAtVersion", []interface{}{version})
+	fake.configAtVersionMutex.Unlock()
+	if fake.ConfigAtVersionStub != nil {
+		return fake.ConfigAtVersionStub(version)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.configAtVersionReturns.result1, fake.configAtVersionReturns.result2, fake.configAtVersionReturns.result3
+}
+
+func (fake *FakePipeline) ConfigAtVersionCallCount() int {
+	fake.configAtVersionMutex.RLock()
+	defer fake.configAtVersionMutex.RUnlock()
+	return len(fake.configAtVersionArgsForCall)
+}
+
+func (fake *FakePipeline) ConfigAtVersionArgsForCall(i int) dbng.ConfigVersion {
+	fake.configAtVersionMutex.RLock()
+	defer fake.configAtVersionMutex.RUnlock()
+	return fake.configAtVersionArgsForCall[i].version
+}
+
+func (fake *FakePipeline) ConfigAtVersionReturns(result1 atc.Config, result2 bool, result3 error) {
+	fake.ConfigAtVersionStub = nil
+	fake.configAtVersionReturns = struct {
+		result1 atc.Config
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) ConfigAtVersionReturnsOnCall(i int, result1 atc.Config, result2 bool, result3 error) {
+	fake.ConfigAtVersionStub = nil
+	if fake.configAtVersionReturnsOnCall == nil {
+		fake.configAtVersionReturnsOnCall = make(map[int]struct {
+			result1 atc.Config
+			result2 bool
+			result3 error
+		})
+	}
+	fake.configAtVersionReturnsOnCall[i] = struct {
+		result1 atc.Config
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakePipeline) SaveJob(job atc.JobConfig) error {
 	fake.saveJobMutex.Lock()
 	ret, specificReturn := fake.saveJobReturnsOnCall[len(fake.saveJobArgsForCall)]
@@ -661,6 +784,10 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.teamIDMutex.RUnlock()
 	fake.configVersionMutex.RLock()
 	defer fake.configVersionMutex.RUnlock()
+	fake.configVersionsMutex.RLock()
+	defer fake.configVersionsMutex.RUnlock()
+	fake.configAtVersionMutex.RLock()
+	defer fake.configAtVersionMutex.RUnlock()
 	fake.saveJobMutex.RLock()
 	defer fake.saveJobMutex.RUnlock()
 	fake.createJobBuildMutex.RLock()
@@ -19,13 +19,14 @@ type FakeTeam struct {
 	iDReturnsOnCall map[int]struct {
 		result1 int
 	}
-	SavePipelineStub        func(pipelineName string, config atc.Config, from dbng.ConfigVersion, pausedState dbng.PipelinePausedState) (dbng.Pipeline, bool, error)
+	SavePipelineStub        func(pipelineName string, config atc.Config, from dbng.ConfigVersion, pausedState dbng.PipelinePausedState, author string) (dbng.Pipeline, bool, error)
 	savePipelineMutex       sync.RWMutex
 	savePipelineArgsForCall []struct {
 		pipelineName string
 		config       atc.Config
 		from         dbng.ConfigVersion
 		pausedState  dbng.PipelinePausedState
+		author       string
 	}
 	savePipelineReturns struct {
 		result1 dbng.Pipeline
@@ -52,6 +53,68 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	SavePipelineTemplateStub        func(name string, config interface{}) (dbng.PipelineTemplate, error)
+	savePipelineTemplateMutex       sync.RWMutex
+	savePipelineTemplateArgsForCall []struct {
+		name   string
+		config interface{}
+	}
+	savePipelineTemplateReturns struct {
+		result1 dbng.PipelineTemplate
+		result2 error
+	}
+	savePipelineTemplateReturnsOnCall map[int]struct {
+		result1 dbng.PipelineTemplate
+		result2 error
+	}
+	FindPipelineTemplateStub        func(name string) (dbng.PipelineTemplate, bool, error)
+	findPipelineTemplateMutex       sync.RWMutex
+	findPipelineTemplateArgsForCall []struct {
+		name string
+	}
+	findPipelineTemplateReturns struct {
+		result1 dbng.PipelineTemplate
+		result2 bool
+		result3 error
+	}
+	findPipelineTemplateReturnsOnCall map[int]struct {
+		result1 dbng.PipelineTemplate
+		result2 bool
+		result3 error
+	}
+	FindPipelineTemplateInstancesStub        func(name string) ([]dbng.PipelineInstance, error)
+	findPipelineTemplateInstancesMutex       sync.RWMutex
+	findPipelineTemplateInstancesArgsForCall []struct {
+		name string
+	}
+	findPipelineTemplateInstancesReturns struct {
+		result1 []dbng.PipelineInstance
+		result2 error
+	}
+	findPipelineTemplateInstancesReturnsOnCall map[int]struct {
+		result1 []dbng.PipelineInstance
+		result2 error
+	}
+	SavePipelineInstanceStub        func(pipelineName string, template dbng.PipelineTemplate, vars atc.InstanceVars, config atc.Config, from dbng.ConfigVersion, author string) (dbng.Pipeline, bool, error)
+	savePipelineInstanceMutex       sync.RWMutex
+	savePipelineInstanceArgsForCall []struct {
+		pipelineName string
+		template     dbng.PipelineTemplate
+		vars         atc.InstanceVars
+		config       atc.Config
+		from         dbng.ConfigVersion
+		author       string
+	}
+	savePipelineInstanceReturns struct {
+		result1 dbng.Pipeline
+		result2 bool
+		result3 error
+	}
+	savePipelineInstanceReturnsOnCall map[int]struct {
+		result1 dbng.Pipeline
+		result2 bool
+		result3 error
+	}
 	CreateOneOffBuildStub        func() (dbng.Build, error)
 	createOneOffBuildMutex       sync.RWMutex
 	createOneOffBuildArgsForCall []struct{}
@@ -256,7 +319,7 @@ func (fake *FakeTeam) IDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
-func (fake *FakeTeam) SavePipeline(pipelineName string, config atc.Config, from dbng.ConfigVersion, pausedState dbng.PipelinePausedState) (dbng.Pipeline, bool, error) {
+func (fake *FakeTeam) SavePipeline(pipelineName string, config atc.Config, from dbng.ConfigVersion, pausedState dbng.PipelinePausedState, author string) (dbng.Pipeline, bool, error) {
 	fake.savePipelineMutex.Lock()
 	ret, specificReturn := fake.savePipelineReturnsOnCall[len(fake.savePipelineArgsForCall)]
 	fake.savePipelineArgsForCall = append(fake.savePipelineArgsForCall, struct {
@@ -264,11 +327,12 @@ func (fake *FakeTeam) SavePipeline(pipelineName string, config atc.Config, from
 		config       atc.Config
 		from         dbng.ConfigVersion
 		pausedState  dbng.PipelinePausedState
-	}{pipelineName, config, from, pausedState})
-	fake.recordInvocation("SavePipeline", []interface{}{pipelineName, config, from, pausedState})
+		author       string
+	}{pipelineName, config, from, pausedState, author})
+	fake.recordInvocation("SavePipeline", []interface{}{pipelineName, config, from, pausedState, author})
 	fake.savePipelineMutex.Unlock()
 	if fake.SavePipelineStub != nil {
-		return fake.SavePipelineStub(pipelineName, config, from, pausedState)
+		return fake.SavePipelineStub(pipelineName, config, from, pausedState, author)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -282,10 +346,10 @@ func (fake *FakeTeam) SavePipelineCallCount() int {
 	return len(fake.savePipelineArgsForCall)
 }
 
-func (fake *FakeTeam) SavePipelineArgsForCall(i int) (string, atc.Config, dbng.ConfigVersion, dbng.PipelinePausedState) {
+func (fake *FakeTeam) SavePipelineArgsForCall(i int) (string, atc.Config, dbng.ConfigVersion, dbng.PipelinePausedState, string) {
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
-	return fake.savePipelineArgsForCall[i].pipelineName, fake.savePipelineArgsForCall[i].config, fake.savePipelineArgsForCall[i].from, fake.savePipelineArgsForCall[i].pausedState
+	return fake.savePipelineArgsForCall[i].pipelineName, fake.savePipelineArgsForCall[i].config, fake.savePipelineArgsForCall[i].from, fake.savePipelineArgsForCall[i].pausedState, fake.savePipelineArgsForCall[i].author
 }
 
 func (fake *FakeTeam) SavePipelineReturns(result1 dbng.Pipeline, result2 bool, result3 error) {
@@ -367,6 +431,222 @@ func (fake *FakeTeam) FindPipelineByNameReturnsOnCall(i int, result1 dbng.Pipeli
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) SavePipelineTemplate(name string, config interface{}) (dbng.PipelineTemplate, error) {
+	fake.savePipelineTemplateMutex.Lock()
+	ret, specificReturn := fake.savePipelineTemplateReturnsOnCall[len(fake.savePipelineTemplateArgsForCall)]
+	fake.savePipelineTemplateArgsForCall = append(fake.savePipelineTemplateArgsForCall, struct {
+		name   string
+		config interface{}
+	}{name, config})
+	fake.recordInvocation("SavePipelineTemplate", []interface{}{name, config})
+	fake.savePipelineTemplateMutex.Unlock()
+	if fake.SavePipelineTemplateStub != nil {
+		return fake.SavePipelineTemplateStub(name, config)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.savePipelineTemplateReturns.result1, fake.savePipelineTemplateReturns.result2
+}
+
+func (fake *FakeTeam) SavePipelineTemplateCallCount() int {
+	fake.savePipelineTemplateMutex.RLock()
+	defer fake.savePipelineTemplateMutex.RUnlock()
+	return len(fake.savePipelineTemplateArgsForCall)
+}
+
+func (fake *FakeTeam) SavePipelineTemplateArgsForCall(i int) (string, interface{}) {
+	fake.savePipelineTemplateMutex.RLock()
+	defer fake.savePipelineTemplateMutex.RUnlock()
+	return fake.savePipelineTemplateArgsForCall[i].name, fake.savePipelineTemplateArgsForCall[i].config
+}
+
+func (fake *FakeTeam) SavePipelineTemplateReturns(result1 dbng.PipelineTemplate, result2 error) {
+	fake.SavePipelineTemplateStub = nil
+	fake.savePipelineTemplateReturns = struct {
+		result1 dbng.PipelineTemplate
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SavePipelineTemplateReturnsOnCall(i int, result1 dbng.PipelineTemplate, result2 error) {
+	fake.SavePipelineTemplateStub = nil
+	if fake.savePipelineTemplateReturnsOnCall == nil {
+		fake.savePipelineTemplateReturnsOnCall = make(map[int]struct {
+			result1 dbng.PipelineTemplate
+			result2 error
+		})
+	}
+	fake.savePipelineTemplateReturnsOnCall[i] = struct {
+		result1 dbng.PipelineTemplate
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) FindPipelineTemplate(name string) (dbng.PipelineTemplate, bool, error) {
+	fake.findPipelineTemplateMutex.Lock()
+	ret, specificReturn := fake.findPipelineTemplateReturnsOnCall[len(fake.findPipelineTemplateArgsForCall)]
+	fake.findPipelineTemplateArgsForCall = append(fake.findPipelineTemplateArgsForCall, struct {
+		name string
+	}{name})
+	fake.recordInvocation("FindPipelineTemplate", []interface{}{name})
+	fake.findPipelineTemplateMutex.Unlock()
+	if fake.FindPipelineTemplateStub != nil {
+		return fake.FindPipelineTemplateStub(name)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.findPipelineTemplateReturns.result1, fake.findPipelineTemplateReturns.result2, fake.findPipelineTemplateReturns.result3
+}
+
+func (fake *FakeTeam) FindPipelineTemplateCallCount() int {
+	fake.findPipelineTemplateMutex.RLock()
+	defer fake.findPipelineTemplateMutex.RUnlock()
+	return len(fake.findPipelineTemplateArgsForCall)
+}
+
+func (fake *FakeTeam) FindPipelineTemplateArgsForCall(i int) string {
+	fake.findPipelineTemplateMutex.RLock()
+	defer fake.findPipelineTemplateMutex.RUnlock()
+	return fake.findPipelineTemplateArgsForCall[i].name
+}
+
+func (fake *FakeTeam) FindPipelineTemplateReturns(result1 dbng.PipelineTemplate, result2 bool, result3 error) {
+	fake.FindPipelineTemplateStub = nil
+	fake.findPipelineTemplateReturns = struct {
+		result1 dbng.PipelineTemplate
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) FindPipelineTemplateReturnsOnCall(i int, result1 dbng.PipelineTemplate, result2 bool, result3 error) {
+	fake.FindPipelineTemplateStub = nil
+	if fake.findPipelineTemplateReturnsOnCall == nil {
+		fake.findPipelineTemplateReturnsOnCall = make(map[int]struct {
+			result1 dbng.PipelineTemplate
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findPipelineTemplateReturnsOnCall[i] = struct {
+		result1 dbng.PipelineTemplate
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) FindPipelineTemplateInstances(name string) ([]dbng.PipelineInstance, error) {
+	fake.findPipelineTemplateInstancesMutex.Lock()
+	ret, specificReturn := fake.findPipelineTemplateInstancesReturnsOnCall[len(fake.findPipelineTemplateInstancesArgsForCall)]
+	fake.findPipelineTemplateInstancesArgsForCall = append(fake.findPipelineTemplateInstancesArgsForCall, struct {
+		name string
+	}{name})
+	fake.recordInvocation("FindPipelineTemplateInstances", []interface{}{name})
+	fake.findPipelineTemplateInstancesMutex.Unlock()
+	if fake.FindPipelineTemplateInstancesStub != nil {
+		return fake.FindPipelineTemplateInstancesStub(name)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.findPipelineTemplateInstancesReturns.result1, fake.findPipelineTemplateInstancesReturns.result2
+}
+
+func (fake *FakeTeam) FindPipelineTemplateInstancesCallCount() int {
+	fake.findPipelineTemplateInstancesMutex.RLock()
+	defer fake.findPipelineTemplateInstancesMutex.RUnlock()
+	return len(fake.findPipelineTemplateInstancesArgsForCall)
+}
+
+func (fake *FakeTeam) FindPipelineTemplateInstancesArgsForCall(i int) string {
+	fake.findPipelineTemplateInstancesMutex.RLock()
+	defer fake.findPipelineTemplateInstancesMutex.RUnlock()
+	return fake.findPipelineTemplateInstancesArgsForCall[i].name
+}
+
+func (fake *FakeTeam) FindPipelineTemplateInstancesReturns(result1 []dbng.PipelineInstance, result2 error) {
+	fake.FindPipelineTemplateInstancesStub = nil
+	fake.findPipelineTemplateInstancesReturns = struct {
+		result1 []dbng.PipelineInstance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) FindPipelineTemplateInstancesReturnsOnCall(i int, result1 []dbng.PipelineInstance, result2 error) {
+	fake.FindPipelineTemplateInstancesStub = nil
+	if fake.findPipelineTemplateInstancesReturnsOnCall == nil {
+		fake.findPipelineTemplateInstancesReturnsOnCall = make(map[int]struct {
+			result1 []dbng.PipelineInstance
+			result2 error
+		})
+	}
+	fake.findPipelineTemplateInstancesReturnsOnCall[i] = struct {
+		result1 []dbng.PipelineInstance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SavePipelineInstance(pipelineName string, template dbng.PipelineTemplate, vars atc.InstanceVars, config atc.Config, from dbng.ConfigVersion, author string) (dbng.Pipeline, bool, error) {
+	fake.savePipelineInstanceMutex.Lock()
+	ret, specificReturn := fake.savePipelineInstanceReturnsOnCall[len(fake.savePipelineInstanceArgsForCall)]
+	fake.savePipelineInstanceArgsForCall = append(fake.savePipelineInstanceArgsForCall, struct {
+		pipelineName string
+		template     dbng.PipelineTemplate
+		vars         atc.InstanceVars
+		config       atc.Config
+		from         dbng.ConfigVersion
+		author       string
+	}{pipelineName, template, vars, config, from, author})
+	fake.recordInvocation("SavePipelineInstance", []interface{}{pipelineName, template, vars, config, from, author})
+	fake.savePipelineInstanceMutex.Unlock()
+	if fake.SavePipelineInstanceStub != nil {
+		return fake.SavePipelineInstanceStub(pipelineName, template, vars, config, from, author)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.savePipelineInstanceReturns.result1, fake.savePipelineInstanceReturns.result2, fake.savePipelineInstanceReturns.result3
+}
+
+func (fake *FakeTeam) SavePipelineInstanceCallCount() int {
+	fake.savePipelineInstanceMutex.RLock()
+	defer fake.savePipelineInstanceMutex.RUnlock()
+	return len(fake.savePipelineInstanceArgsForCall)
+}
+
+func (fake *FakeTeam) SavePipelineInstanceArgsForCall(i int) (string, dbng.PipelineTemplate, atc.InstanceVars, atc.Config, dbng.ConfigVersion, string) {
+	fake.savePipelineInstanceMutex.RLock()
+	defer fake.savePipelineInstanceMutex.RUnlock()
+	return fake.savePipelineInstanceArgsForCall[i].pipelineName, fake.savePipelineInstanceArgsForCall[i].template, fake.savePipelineInstanceArgsForCall[i].vars, fake.savePipelineInstanceArgsForCall[i].config, fake.savePipelineInstanceArgsForCall[i].from, fake.savePipelineInstanceArgsForCall[i].author
+}
+
+func (fake *FakeTeam) SavePipelineInstanceReturns(result1 dbng.Pipeline, result2 bool, result3 error) {
+	fake.SavePipelineInstanceStub = nil
+	fake.savePipelineInstanceReturns = struct {
+		result1 dbng.Pipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SavePipelineInstanceReturnsOnCall(i int, result1 dbng.Pipeline, result2 bool, result3 error) {
+	fake.SavePipelineInstanceStub = nil
+	if fake.savePipelineInstanceReturnsOnCall == nil {
+		fake.savePipelineInstanceReturnsOnCall = make(map[int]struct {
+			result1 dbng.Pipeline
+			result2 bool
+			result3 error
+		})
+	}
+	fake.savePipelineInstanceReturnsOnCall[i] = struct {
+		result1 dbng.Pipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) CreateOneOffBuild() (dbng.Build, error) {
 	fake.createOneOffBuildMutex.Lock()
 	ret, specificReturn := fake.createOneOffBuildReturnsOnCall[len(fake.createOneOffBuildArgsForCall)]
@@ -947,6 +1227,14 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.savePipelineMutex.RUnlock()
 	fake.findPipelineByNameMutex.RLock()
 	defer fake.findPipelineByNameMutex.RUnlock()
+	fake.savePipelineTemplateMutex.RLock()
+	defer fake.savePipelineTemplateMutex.RUnlock()
+	fake.findPipelineTemplateMutex.RLock()
+	defer fake.findPipelineTemplateMutex.RUnlock()
+	fake.findPipelineTemplateInstancesMutex.RLock()
+	defer fake.findPipelineTemplateInstancesMutex.RUnlock()
+	fake.savePipelineInstanceMutex.RLock()
+	defer fake.savePipelineInstanceMutex.RUnlock()
 	fake.createOneOffBuildMutex.RLock()
 	defer fake.createOneOffBuildMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
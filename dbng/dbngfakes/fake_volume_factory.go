@@ -131,6 +131,38 @@ type FakeVolumeFactory struct {
 		result1 dbng.CreatingVolume
 		result2 error
 	}
+	FindTaskCacheVolumeStub        func(int, dbng.Worker, dbng.TaskCache) (dbng.CreatingVolume, dbng.CreatedVolume, error)
+	findTaskCacheVolumeMutex       sync.RWMutex
+	findTaskCacheVolumeArgsForCall []struct {
+		arg1 int
+		arg2 dbng.Worker
+		arg3 dbng.TaskCache
+	}
+	findTaskCacheVolumeReturns struct {
+		result1 dbng.CreatingVolume
+		result2 dbng.CreatedVolume
+		result3 error
+	}
+	findTaskCacheVolumeReturnsOnCall map[int]struct {
+		result1 dbng.CreatingVolume
+		result2 dbng.CreatedVolume
+		result3 error
+	}
+	CreateTaskCacheVolumeStub        func(int, dbng.Worker, dbng.TaskCache) (dbng.CreatingVolume, error)
+	createTaskCacheVolumeMutex       sync.RWMutex
+	createTaskCacheVolumeArgsForCall []struct {
+		arg1 int
+		arg2 dbng.Worker
+		arg3 dbng.TaskCache
+	}
+	createTaskCacheVolumeReturns struct {
+		result1 dbng.CreatingVolume
+		result2 error
+	}
+	createTaskCacheVolumeReturnsOnCall map[int]struct {
+		result1 dbng.CreatingVolume
+		result2 error
+	}
 	FindVolumesForContainerStub        func(dbng.CreatedContainer) ([]dbng.CreatedVolume, error)
 	findVolumesForContainerMutex       sync.RWMutex
 	findVolumesForContainerArgsForCall []struct {
@@ -622,6 +654,115 @@ func (fake *FakeVolumeFactory) CreateResourceCacheVolumeReturnsOnCall(i int, res
 	}{result1, result2}
 }
 
+func (fake *FakeVolumeFactory) FindTaskCacheVolume(arg1 int, arg2 dbng.Worker, arg3 dbng.TaskCache) (dbng.CreatingVolume, dbng.CreatedVolume, error) {
+	fake.findTaskCacheVolumeMutex.Lock()
+	ret, specificReturn := fake.findTaskCacheVolumeReturnsOnCall[len(fake.findTaskCacheVolumeArgsForCall)]
+	fake.findTaskCacheVolumeArgsForCall = append(fake.findTaskCacheVolumeArgsForCall, struct {
+		arg1 int
+		arg2 dbng.Worker
+		arg3 dbng.TaskCache
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("FindTaskCacheVolume", []interface{}{arg1, arg2, arg3})
+	fake.findTaskCacheVolumeMutex.Unlock()
+	if fake.FindTaskCacheVolumeStub != nil {
+		return fake.FindTaskCacheVolumeStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.findTaskCacheVolumeReturns.result1, fake.findTaskCacheVolumeReturns.result2, fake.findTaskCacheVolumeReturns.result3
+}
+
+func (fake *FakeVolumeFactory) FindTaskCacheVolumeCallCount() int {
+	fake.findTaskCacheVolumeMutex.RLock()
+	defer fake.findTaskCacheVolumeMutex.RUnlock()
+	return len(fake.findTaskCacheVolumeArgsForCall)
+}
+
+func (fake *FakeVolumeFactory) FindTaskCacheVolumeArgsForCall(i int) (int, dbng.Worker, dbng.TaskCache) {
+	fake.findTaskCacheVolumeMutex.RLock()
+	defer fake.findTaskCacheVolumeMutex.RUnlock()
+	return fake.findTaskCacheVolumeArgsForCall[i].arg1, fake.findTaskCacheVolumeArgsForCall[i].arg2, fake.findTaskCacheVolumeArgsForCall[i].arg3
+}
+
+func (fake *FakeVolumeFactory) FindTaskCacheVolumeReturns(result1 dbng.CreatingVolume, result2 dbng.CreatedVolume, result3 error) {
+	fake.FindTaskCacheVolumeStub = nil
+	fake.findTaskCacheVolumeReturns = struct {
+		result1 dbng.CreatingVolume
+		result2 dbng.CreatedVolume
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeFactory) FindTaskCacheVolumeReturnsOnCall(i int, result1 dbng.CreatingVolume, result2 dbng.CreatedVolume, result3 error) {
+	fake.FindTaskCacheVolumeStub = nil
+	if fake.findTaskCacheVolumeReturnsOnCall == nil {
+		fake.findTaskCacheVolumeReturnsOnCall = make(map[int]struct {
+			result1 dbng.CreatingVolume
+			result2 dbng.CreatedVolume
+			result3 error
+		})
+	}
+	fake.findTaskCacheVolumeReturnsOnCall[i] = struct {
+		result1 dbng.CreatingVolume
+		result2 dbng.CreatedVolume
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeFactory) CreateTaskCacheVolume(arg1 int, arg2 dbng.Worker, arg3 dbng.TaskCache) (dbng.CreatingVolume, error) {
+	fake.createTaskCacheVolumeMutex.Lock()
+	ret, specificReturn := fake.createTaskCacheVolumeReturnsOnCall[len(fake.createTaskCacheVolumeArgsForCall)]
+	fake.createTaskCacheVolumeArgsForCall = append(fake.createTaskCacheVolumeArgsForCall, struct {
+		arg1 int
+		arg2 dbng.Worker
+		arg3 dbng.TaskCache
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("CreateTaskCacheVolume", []interface{}{arg1, arg2, arg3})
+	fake.createTaskCacheVolumeMutex.Unlock()
+	if fake.CreateTaskCacheVolumeStub != nil {
+		return fake.CreateTaskCacheVolumeStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createTaskCacheVolumeReturns.result1, fake.createTaskCacheVolumeReturns.result2
+}
+
+func (fake *FakeVolumeFactory) CreateTaskCacheVolumeCallCount() int {
+	fake.createTaskCacheVolumeMutex.RLock()
+	defer fake.createTaskCacheVolumeMutex.RUnlock()
+	return len(fake.createTaskCacheVolumeArgsForCall)
+}
+
+func (fake *FakeVolumeFactory) CreateTaskCacheVolumeArgsForCall(i int) (int, dbng.Worker, dbng.TaskCache) {
+	fake.createTaskCacheVolumeMutex.RLock()
+	defer fake.createTaskCacheVolumeMutex.RUnlock()
+	return fake.createTaskCacheVolumeArgsForCall[i].arg1, fake.createTaskCacheVolumeArgsForCall[i].arg2, fake.createTaskCacheVolumeArgsForCall[i].arg3
+}
+
+func (fake *FakeVolumeFactory) CreateTaskCacheVolumeReturns(result1 dbng.CreatingVolume, result2 error) {
+	fake.CreateTaskCacheVolumeStub = nil
+	fake.createTaskCacheVolumeReturns = struct {
+		result1 dbng.CreatingVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeFactory) CreateTaskCacheVolumeReturnsOnCall(i int, result1 dbng.CreatingVolume, result2 error) {
+	fake.CreateTaskCacheVolumeStub = nil
+	if fake.createTaskCacheVolumeReturnsOnCall == nil {
+		fake.createTaskCacheVolumeReturnsOnCall = make(map[int]struct {
+			result1 dbng.CreatingVolume
+			result2 error
+		})
+	}
+	fake.createTaskCacheVolumeReturnsOnCall[i] = struct {
+		result1 dbng.CreatingVolume
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolumeFactory) FindVolumesForContainer(arg1 dbng.CreatedContainer) ([]dbng.CreatedVolume, error) {
 	fake.findVolumesForContainerMutex.Lock()
 	ret, specificReturn := fake.findVolumesForContainerReturnsOnCall[len(fake.findVolumesForContainerArgsForCall)]
@@ -841,6 +982,10 @@ func (fake *FakeVolumeFactory) Invocations() map[string][][]interface{} {
 	defer fake.findResourceCacheInitializedVolumeMutex.RUnlock()
 	fake.createResourceCacheVolumeMutex.RLock()
 	defer fake.createResourceCacheVolumeMutex.RUnlock()
+	fake.findTaskCacheVolumeMutex.RLock()
+	defer fake.findTaskCacheVolumeMutex.RUnlock()
+	fake.createTaskCacheVolumeMutex.RLock()
+	defer fake.createTaskCacheVolumeMutex.RUnlock()
 	fake.findVolumesForContainerMutex.RLock()
 	defer fake.findVolumesForContainerMutex.RUnlock()
 	fake.getOrphanedVolumesMutex.RLock()
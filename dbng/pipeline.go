@@ -21,6 +21,9 @@ type Pipeline interface {
 	TeamID() int
 	ConfigVersion() ConfigVersion
 
+	ConfigVersions() ([]PipelineConfigVersion, error)
+	ConfigAtVersion(version ConfigVersion) (atc.Config, bool, error)
+
 	SaveJob(job atc.JobConfig) error
 	CreateJobBuild(jobName string) (Build, error)
 	CreateResource(name string, config atc.ResourceConfig) (*Resource, error)
@@ -52,6 +55,14 @@ type pipeline struct {
 //ConfigVersion is a sequence identifier used for compare-and-swap
 type ConfigVersion int
 
+// PipelineConfigVersion describes one of the configs a pipeline has been
+// saved with.
+type PipelineConfigVersion struct {
+	Version   ConfigVersion
+	Author    string
+	CreatedAt time.Time
+}
+
 type PipelinePausedState string
 
 const unqualifiedPipelineColumns = "id, name, config, version, paused, team_id, public"
@@ -83,6 +94,64 @@ func (p *pipeline) Name() string                 { return p.name }
 func (p *pipeline) TeamID() int                  { return p.teamID }
 func (p *pipeline) ConfigVersion() ConfigVersion { return p.configVersion }
 
+// ConfigVersions returns every config the pipeline has been saved with,
+// newest first.
+func (p *pipeline) ConfigVersions() ([]PipelineConfigVersion, error) {
+	rows, err := psql.Select("version", "author", "created_at").
+		From("pipeline_config_versions").
+		Where(sq.Eq{"pipeline_id": p.id}).
+		OrderBy("version DESC").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	versions := []PipelineConfigVersion{}
+
+	for rows.Next() {
+		var version PipelineConfigVersion
+		err := rows.Scan(&version.Version, &version.Author, &version.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+func (p *pipeline) ConfigAtVersion(version ConfigVersion) (atc.Config, bool, error) {
+	var configBlob []byte
+	err := psql.Select("config").
+		From("pipeline_config_versions").
+		Where(sq.Eq{
+			"pipeline_id": p.id,
+			"version":     version,
+		}).
+		RunWith(p.conn).
+		QueryRow().
+		Scan(&configBlob)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.Config{}, false, nil
+		}
+
+		return atc.Config{}, false, err
+	}
+
+	var config atc.Config
+	err = json.Unmarshal(configBlob, &config)
+	if err != nil {
+		return atc.Config{}, false, err
+	}
+
+	return config, true, nil
+}
+
 func (p *pipeline) CreateJobBuild(jobName string) (Build, error) {
 	tx, err := p.conn.Begin()
 	if err != nil {
@@ -0,0 +1,157 @@
+package dbng
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+)
+
+// PipelineTemplate is a pipeline config with ((var)) placeholders, which
+// pipelines may be instances of. Its version is bumped each time it is saved
+// so that out of date instances can be found.
+type PipelineTemplate struct {
+	ID      int
+	TeamID  int
+	Name    string
+	Config  interface{}
+	Version int
+}
+
+// PipelineInstance is a pipeline which is rendered from a template with its
+// instance vars.
+type PipelineInstance struct {
+	PipelineName string
+	Vars         atc.InstanceVars
+}
+
+func (t *team) SavePipelineTemplate(name string, config interface{}) (PipelineTemplate, error) {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return PipelineTemplate{}, err
+	}
+
+	template := PipelineTemplate{
+		TeamID: t.id,
+		Name:   name,
+		Config: config,
+	}
+
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return PipelineTemplate{}, err
+	}
+
+	defer tx.Rollback()
+
+	err = psql.Update("pipeline_templates").
+		Set("config", payload).
+		Set("version", sq.Expr("version + 1")).
+		Where(sq.Eq{
+			"team_id": t.id,
+			"name":    name,
+		}).
+		Suffix("RETURNING id, version").
+		RunWith(tx).
+		QueryRow().
+		Scan(&template.ID, &template.Version)
+	if err == sql.ErrNoRows {
+		err = psql.Insert("pipeline_templates").
+			Columns("team_id", "name", "config").
+			Values(t.id, name, payload).
+			Suffix("RETURNING id, version").
+			RunWith(tx).
+			QueryRow().
+			Scan(&template.ID, &template.Version)
+	}
+
+	if err != nil {
+		return PipelineTemplate{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return PipelineTemplate{}, err
+	}
+
+	return template, nil
+}
+
+func (t *team) FindPipelineTemplate(name string) (PipelineTemplate, bool, error) {
+	template := PipelineTemplate{
+		TeamID: t.id,
+		Name:   name,
+	}
+
+	var configBlob []byte
+	err := psql.Select("id", "config", "version").
+		From("pipeline_templates").
+		Where(sq.Eq{
+			"team_id": t.id,
+			"name":    name,
+		}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&template.ID, &configBlob, &template.Version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return PipelineTemplate{}, false, nil
+		}
+
+		return PipelineTemplate{}, false, err
+	}
+
+	err = json.Unmarshal(configBlob, &template.Config)
+	if err != nil {
+		return PipelineTemplate{}, false, err
+	}
+
+	return template, true, nil
+}
+
+// FindPipelineTemplateInstances returns the pipelines which are instances of
+// the template, so that a new version of it can be checked against their vars
+// before it is saved.
+func (t *team) FindPipelineTemplateInstances(name string) ([]PipelineInstance, error) {
+	rows, err := psql.Select("p.name", "p.instance_vars").
+		From("pipelines p").
+		Join("pipeline_templates pt ON pt.id = p.template_id").
+		Where(sq.Eq{
+			"pt.team_id": t.id,
+			"pt.name":    name,
+		}).
+		OrderBy("p.name").
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	instances := []PipelineInstance{}
+
+	for rows.Next() {
+		var instance PipelineInstance
+		var varsBlob []byte
+		err := rows.Scan(&instance.PipelineName, &varsBlob)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(varsBlob, &instance.Vars)
+		if err != nil {
+			return nil, err
+		}
+
+		instances = append(instances, instance)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return instances, nil
+}
@@ -0,0 +1,170 @@
+package dbng_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PipelineTemplate", func() {
+	var templateConfig map[string]interface{}
+
+	BeforeEach(func() {
+		templateConfig = map[string]interface{}{
+			"jobs": []interface{}{
+				map[string]interface{}{"name": "build-((branch))"},
+			},
+		}
+	})
+
+	Describe("SavePipelineTemplate", func() {
+		It("saves the template at version 1", func() {
+			template, err := defaultTeam.SavePipelineTemplate("some-template", templateConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(template.ID).NotTo(BeZero())
+			Expect(template.Version).To(Equal(1))
+
+			foundTemplate, found, err := defaultTeam.FindPipelineTemplate("some-template")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(foundTemplate).To(Equal(dbng.PipelineTemplate{
+				ID:      template.ID,
+				TeamID:  defaultTeam.ID(),
+				Name:    "some-template",
+				Config:  map[string]interface{}{"jobs": []interface{}{map[string]interface{}{"name": "build-((branch))"}}},
+				Version: 1,
+			}))
+		})
+
+		Context("when the template already exists", func() {
+			var original dbng.PipelineTemplate
+
+			BeforeEach(func() {
+				var err error
+				original, err = defaultTeam.SavePipelineTemplate("some-template", templateConfig)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("updates it and bumps its version", func() {
+				template, err := defaultTeam.SavePipelineTemplate("some-template", map[string]interface{}{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(template.ID).To(Equal(original.ID))
+				Expect(template.Version).To(Equal(2))
+
+				foundTemplate, _, err := defaultTeam.FindPipelineTemplate("some-template")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(foundTemplate.Config).To(Equal(map[string]interface{}{}))
+				Expect(foundTemplate.Version).To(Equal(2))
+			})
+		})
+	})
+
+	Describe("FindPipelineTemplate", func() {
+		It("returns false when the template does not exist", func() {
+			_, found, err := defaultTeam.FindPipelineTemplate("bogus")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("FindPipelineTemplateInstances", func() {
+		It("returns the pipelines rendered from the template with their vars", func() {
+			template, err := defaultTeam.SavePipelineTemplate("some-template", templateConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, branch := range []string{"master", "release"} {
+				vars := atc.InstanceVars{"branch": branch}
+
+				config, err := atc.RenderTemplate(template.Config, vars)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, err = defaultTeam.SavePipelineInstance("build-"+branch, template, vars, config, 0, "some-author")
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			_, _, err = defaultTeam.SavePipeline("some-other-pipeline", atc.Config{}, 0, dbng.PipelineNoChange, "some-author")
+			Expect(err).NotTo(HaveOccurred())
+
+			instances, err := defaultTeam.FindPipelineTemplateInstances("some-template")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instances).To(Equal([]dbng.PipelineInstance{
+				{PipelineName: "build-master", Vars: atc.InstanceVars{"branch": "master"}},
+				{PipelineName: "build-release", Vars: atc.InstanceVars{"branch": "release"}},
+			}))
+		})
+
+		It("returns none when the template does not exist", func() {
+			instances, err := defaultTeam.FindPipelineTemplateInstances("bogus")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instances).To(BeEmpty())
+		})
+	})
+
+	Describe("SavePipelineInstance", func() {
+		It("saves the pipeline as an instance of the template", func() {
+			template, err := defaultTeam.SavePipelineTemplate("some-template", templateConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			vars := atc.InstanceVars{"branch": "master"}
+
+			config, err := atc.RenderTemplate(template.Config, vars)
+			Expect(err).NotTo(HaveOccurred())
+
+			pipeline, created, err := defaultTeam.SavePipelineInstance("build-master", template, vars, config, 0, "some-author")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeTrue())
+
+			var templateID, templateVersion int
+			var instanceVars string
+			err = dbConn.QueryRow(`
+				SELECT template_id, template_version, instance_vars
+				FROM pipelines
+				WHERE id = $1
+			`, pipeline.ID()).Scan(&templateID, &templateVersion, &instanceVars)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(templateID).To(Equal(template.ID))
+			Expect(templateVersion).To(Equal(1))
+			Expect(instanceVars).To(MatchJSON(`{"branch":"master"}`))
+
+			savedConfig, found, err := pipeline.ConfigAtVersion(pipeline.ConfigVersion())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(savedConfig).To(Equal(config))
+		})
+
+		Context("when the instance is then saved directly", func() {
+			It("detaches it from the template", func() {
+				template, err := defaultTeam.SavePipelineTemplate("some-template", templateConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				vars := atc.InstanceVars{"branch": "master"}
+
+				config, err := atc.RenderTemplate(template.Config, vars)
+				Expect(err).NotTo(HaveOccurred())
+
+				pipeline, _, err := defaultTeam.SavePipelineInstance("build-master", template, vars, config, 0, "some-author")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, err = defaultTeam.SavePipeline("build-master", config, pipeline.ConfigVersion(), dbng.PipelineNoChange, "some-author")
+				Expect(err).NotTo(HaveOccurred())
+
+				var templateID *int
+				var templateVersion int
+				var instanceVars *string
+				err = dbConn.QueryRow(`
+					SELECT template_id, template_version, instance_vars
+					FROM pipelines
+					WHERE id = $1
+				`, pipeline.ID()).Scan(&templateID, &templateVersion, &instanceVars)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(templateID).To(BeNil())
+				Expect(templateVersion).To(BeZero())
+				Expect(instanceVars).To(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,81 @@
+package dbng_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pipeline", func() {
+	Describe("config history", func() {
+		var (
+			pipeline dbng.Pipeline
+
+			firstConfig  atc.Config
+			secondConfig atc.Config
+		)
+
+		BeforeEach(func() {
+			firstConfig = atc.Config{
+				Jobs: atc.JobConfigs{{Name: "some-job"}},
+			}
+
+			secondConfig = atc.Config{
+				Jobs: atc.JobConfigs{{Name: "some-other-job"}},
+			}
+
+			var err error
+			pipeline, _, err = defaultTeam.SavePipeline("history-pipeline", firstConfig, 0, dbng.PipelineUnpaused, "some-author")
+			Expect(err).NotTo(HaveOccurred())
+
+			pipeline, _, err = defaultTeam.SavePipeline("history-pipeline", secondConfig, pipeline.ConfigVersion(), dbng.PipelineNoChange, "some-other-author")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Describe("ConfigVersions", func() {
+			It("returns every saved version, newest first", func() {
+				versions, err := pipeline.ConfigVersions()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versions).To(HaveLen(2))
+
+				Expect(versions[0].Version).To(Equal(pipeline.ConfigVersion()))
+				Expect(versions[0].Author).To(Equal("some-other-author"))
+				Expect(versions[0].CreatedAt).NotTo(BeZero())
+
+				Expect(versions[1].Version).To(BeNumerically("<", versions[0].Version))
+				Expect(versions[1].Author).To(Equal("some-author"))
+			})
+
+			It("does not include other pipelines' versions", func() {
+				versions, err := defaultPipeline.ConfigVersions()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versions).To(HaveLen(1))
+			})
+		})
+
+		Describe("ConfigAtVersion", func() {
+			It("returns the config saved at each version", func() {
+				versions, err := pipeline.ConfigVersions()
+				Expect(err).NotTo(HaveOccurred())
+
+				config, found, err := pipeline.ConfigAtVersion(versions[1].Version)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(config).To(Equal(firstConfig))
+
+				config, found, err = pipeline.ConfigAtVersion(versions[0].Version)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(config).To(Equal(secondConfig))
+			})
+
+			It("returns false when there is no such version", func() {
+				_, found, err := pipeline.ConfigAtVersion(pipeline.ConfigVersion() + 1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
@@ -116,6 +116,7 @@ var _ = Describe("ResourceCacheFactory", func() {
 			},
 			dbng.ConfigVersion(0),
 			dbng.PipelineUnpaused,
+			"",
 		)
 		Expect(err).ToNot(HaveOccurred())
 
@@ -30,6 +30,7 @@ var _ = Describe("ResourceType", func() {
 			},
 			0,
 			dbng.PipelineUnpaused,
+			"",
 		)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(created).To(BeTrue())
@@ -85,6 +86,7 @@ var _ = Describe("ResourceType", func() {
 					},
 					pipeline.ConfigVersion(),
 					dbng.PipelineUnpaused,
+					"",
 				)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(created).To(BeFalse())
@@ -26,10 +26,23 @@ type Team interface {
 		config atc.Config,
 		from ConfigVersion,
 		pausedState PipelinePausedState,
+		author string,
 	) (Pipeline, bool, error)
 
 	FindPipelineByName(pipelineName string) (Pipeline, bool, error)
 
+	SavePipelineTemplate(name string, config interface{}) (PipelineTemplate, error)
+	FindPipelineTemplate(name string) (PipelineTemplate, bool, error)
+	FindPipelineTemplateInstances(name string) ([]PipelineInstance, error)
+	SavePipelineInstance(
+		pipelineName string,
+		template PipelineTemplate,
+		vars atc.InstanceVars,
+		config atc.Config,
+		from ConfigVersion,
+		author string,
+	) (Pipeline, bool, error)
+
 	CreateOneOffBuild() (Build, error)
 
 	SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error)
@@ -306,21 +319,61 @@ func (t *team) FindContainerByHandle(
 	return nil, false, nil
 }
 
+// SavePipeline saves the config as-is. If the pipeline was an instance of a
+// template, it is detached from the template so that re-rendering the
+// template does not overwrite the config.
 func (t *team) SavePipeline(
 	pipelineName string,
 	config atc.Config,
 	from ConfigVersion,
 	pausedState PipelinePausedState,
+	author string,
 ) (Pipeline, bool, error) {
-	payload, err := json.Marshal(config)
+	tx, err := t.conn.Begin()
 	if err != nil {
 		return nil, false, err
 	}
 
-	var created bool
-	var existingConfig int
+	defer tx.Rollback()
 
-	var savedPipeline *pipeline
+	savedPipeline, created, err := t.savePipeline(tx, pipelineName, config, from, pausedState, author)
+	if err != nil {
+		return nil, false, err
+	}
+
+	_, err = psql.Update("pipelines").
+		Set("template_id", nil).
+		Set("template_version", 0).
+		Set("instance_vars", nil).
+		Where(sq.Eq{"id": savedPipeline.ID()}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return savedPipeline, created, nil
+}
+
+// SavePipelineInstance saves the config rendered from a template with the
+// given vars, and records that the pipeline is an instance of the template.
+func (t *team) SavePipelineInstance(
+	pipelineName string,
+	template PipelineTemplate,
+	vars atc.InstanceVars,
+	config atc.Config,
+	from ConfigVersion,
+	author string,
+) (Pipeline, bool, error) {
+	varsPayload, err := json.Marshal(vars)
+	if err != nil {
+		return nil, false, err
+	}
 
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -329,6 +382,48 @@ func (t *team) SavePipeline(
 
 	defer tx.Rollback()
 
+	savedPipeline, created, err := t.savePipeline(tx, pipelineName, config, from, PipelineNoChange, author)
+	if err != nil {
+		return nil, false, err
+	}
+
+	_, err = psql.Update("pipelines").
+		Set("template_id", template.ID).
+		Set("template_version", template.Version).
+		Set("instance_vars", varsPayload).
+		Where(sq.Eq{"id": savedPipeline.ID()}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return savedPipeline, created, nil
+}
+
+func (t *team) savePipeline(
+	tx Tx,
+	pipelineName string,
+	config atc.Config,
+	from ConfigVersion,
+	pausedState PipelinePausedState,
+	author string,
+) (*pipeline, bool, error) {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var created bool
+	var existingConfig int
+
+	var savedPipeline *pipeline
+
 	err = tx.QueryRow(`
 		SELECT COUNT(1)
 		FROM pipelines
@@ -461,6 +556,15 @@ func (t *team) SavePipeline(
 		}
 	}
 
+	_, err = psql.Insert("pipeline_config_versions").
+		Columns("pipeline_id", "version", "config", "author").
+		Values(savedPipeline.ID(), savedPipeline.ConfigVersion(), payload, author).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, false, err
+	}
+
 	for _, resource := range config.Resources {
 		err = t.saveResource(tx, resource, savedPipeline.ID())
 		if err != nil {
@@ -489,7 +593,14 @@ func (t *team) SavePipeline(
 		}
 	}
 
-	err = tx.Commit()
+	_, err = tx.Exec(`
+		DELETE FROM worker_task_caches
+		WHERE job_id IN (
+			SELECT id
+			FROM jobs
+			WHERE pipeline_id = $1 AND active = false
+		)
+	`, savedPipeline.ID())
 	if err != nil {
 		return nil, false, err
 	}
@@ -589,6 +700,19 @@ func (t *team) saveJob(tx Tx, job atc.JobConfig, pipelineID int) error {
 		return err
 	}
 
+	// task caches are only valid for the config they were populated with
+	_, err = tx.Exec(`
+		DELETE FROM worker_task_caches
+		WHERE job_id IN (
+			SELECT id
+			FROM jobs
+			WHERE name = $1 AND pipeline_id = $2 AND config::text != $3
+		)
+	`, job.Name, pipelineID, string(configPayload))
+	if err != nil {
+		return err
+	}
+
 	updated, err := checkIfRowsUpdated(tx, `
 		UPDATE jobs
 		SET config = $3, interruptible = $4, active = true
@@ -43,6 +43,7 @@ const (
 	VolumeTypeContainer    = "container"
 	VolumeTypeResource     = "resource"
 	VolumeTypeResourceType = "resource-type"
+	VolumeTypeTaskCache    = "task-cache"
 	VolumeTypeUknown       = "unknown" // for migration to life
 )
 
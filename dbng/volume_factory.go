@@ -23,6 +23,9 @@ type VolumeFactory interface {
 	FindResourceCacheInitializedVolume(Worker, *UsedResourceCache) (CreatedVolume, bool, error)
 	CreateResourceCacheVolume(Worker, *UsedResourceCache) (CreatingVolume, error)
 
+	FindTaskCacheVolume(int, Worker, TaskCache) (CreatingVolume, CreatedVolume, error)
+	CreateTaskCacheVolume(int, Worker, TaskCache) (CreatingVolume, error)
+
 	FindVolumesForContainer(CreatedContainer) ([]CreatedVolume, error)
 	GetOrphanedVolumes() ([]CreatedVolume, []DestroyingVolume, error)
 	GetDuplicateResourceCacheVolumes() ([]CreatingVolume, []CreatedVolume, []DestroyingVolume, error)
@@ -125,6 +128,37 @@ func (factory *volumeFactory) CreateBaseResourceTypeVolume(teamID int, uwbrt *Us
 	return volume, nil
 }
 
+func (factory *volumeFactory) CreateTaskCacheVolume(teamID int, worker Worker, taskCache TaskCache) (CreatingVolume, error) {
+	var workerTaskCache *UsedWorkerTaskCache
+	err := safeFindOrCreate(factory.conn, func(tx Tx) error {
+		var err error
+		workerTaskCache, err = WorkerTaskCache{
+			WorkerName: worker.Name(),
+			TaskCache:  taskCache,
+		}.FindOrCreate(tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	volume, err := factory.createVolume(
+		teamID,
+		worker,
+		map[string]interface{}{
+			"worker_task_cache_id": workerTaskCache.ID,
+			"initialized":          true,
+		},
+		VolumeTypeTaskCache,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	volume.path = taskCache.Path
+	return volume, nil
+}
+
 func (factory *volumeFactory) CreateContainerVolume(teamID int, worker Worker, container CreatingContainer, mountPath string) (CreatingVolume, error) {
 	volume, err := factory.createVolume(
 		teamID,
@@ -213,6 +247,24 @@ func (factory *volumeFactory) FindResourceCacheVolume(worker Worker, resourceCac
 	})
 }
 
+func (factory *volumeFactory) FindTaskCacheVolume(teamID int, worker Worker, taskCache TaskCache) (CreatingVolume, CreatedVolume, error) {
+	workerTaskCache, found, err := WorkerTaskCache{
+		WorkerName: worker.Name(),
+		TaskCache:  taskCache,
+	}.Find(factory.conn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !found {
+		return nil, nil, nil
+	}
+
+	return factory.findVolume(teamID, worker, map[string]interface{}{
+		"v.worker_task_cache_id": workerTaskCache.ID,
+	})
+}
+
 func (factory *volumeFactory) FindResourceCacheInitializedVolume(worker Worker, resourceCache *UsedResourceCache) (CreatedVolume, bool, error) {
 	workerResourceCache, found, err := WorkerResourceCache{
 		WorkerName:    worker.Name(),
@@ -267,6 +319,7 @@ func (factory *volumeFactory) GetOrphanedVolumes() ([]CreatedVolume, []Destroyin
 			"v.initialized":                  true,
 			"v.worker_resource_cache_id":     nil,
 			"v.worker_base_resource_type_id": nil,
+			"v.worker_task_cache_id":         nil,
 			"v.container_id":                 nil,
 		}).
 		Where(sq.Or{
@@ -472,6 +525,7 @@ var volumeColumns = []string{
 	`case when v.container_id is not NULL then 'container'
 	  when v.worker_resource_cache_id is not NULL then 'resource'
 		when v.worker_base_resource_type_id is not NULL then 'resource-type'
+		when v.worker_task_cache_id is not NULL then 'task-cache'
 		else 'unknown'
 	end`,
 }
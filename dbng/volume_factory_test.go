@@ -297,6 +297,105 @@ var _ = Describe("VolumeFactory", func() {
 		})
 	})
 
+	Describe("FindTaskCacheVolume", func() {
+		var taskCache dbng.TaskCache
+
+		BeforeEach(func() {
+			taskCache = dbng.TaskCache{
+				PipelineID: defaultPipeline.ID(),
+				JobName:    "some-job",
+				StepName:   "some-task",
+				Path:       "/tmp/build/some-dir/some-cache",
+			}
+		})
+
+		Context("when there is a created volume for the task cache", func() {
+			var existingVolume dbng.CreatedVolume
+
+			BeforeEach(func() {
+				volume, err := volumeFactory.CreateTaskCacheVolume(defaultTeam.ID(), defaultWorker, taskCache)
+				Expect(err).NotTo(HaveOccurred())
+				existingVolume, err = volume.Created()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns created volume", func() {
+				creatingVolume, createdVolume, err := volumeFactory.FindTaskCacheVolume(defaultTeam.ID(), defaultWorker, taskCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(creatingVolume).To(BeNil())
+				Expect(createdVolume).ToNot(BeNil())
+				Expect(createdVolume.Handle()).To(Equal(existingVolume.Handle()))
+				Expect(createdVolume.Type()).To(Equal(dbng.VolumeType(dbng.VolumeTypeTaskCache)))
+			})
+
+			It("does not return it for a different step", func() {
+				taskCache.StepName = "some-other-task"
+
+				creatingVolume, createdVolume, err := volumeFactory.FindTaskCacheVolume(defaultTeam.ID(), defaultWorker, taskCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(creatingVolume).To(BeNil())
+				Expect(createdVolume).To(BeNil())
+			})
+
+			It("is not orphaned", func() {
+				createdVolumes, _, err := volumeFactory.GetOrphanedVolumes()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createdVolumes).To(BeEmpty())
+			})
+
+			Context("when the job config changes", func() {
+				BeforeEach(func() {
+					_, _, err := defaultTeam.SavePipeline("default-pipeline", atc.Config{
+						Jobs: atc.JobConfigs{
+							{
+								Name:   "some-job",
+								Public: true,
+							},
+						},
+					}, defaultPipeline.ConfigVersion(), dbng.PipelineUnpaused, "")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("orphans the cache volume", func() {
+					createdVolumes, _, err := volumeFactory.GetOrphanedVolumes()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(createdVolumes).To(HaveLen(1))
+					Expect(createdVolumes[0].Handle()).To(Equal(existingVolume.Handle()))
+				})
+			})
+
+			Context("when the pipeline is saved with the same job config", func() {
+				BeforeEach(func() {
+					_, _, err := defaultTeam.SavePipeline("default-pipeline", atc.Config{
+						Jobs: atc.JobConfigs{
+							{
+								Name: "some-job",
+							},
+						},
+					}, defaultPipeline.ConfigVersion(), dbng.PipelineUnpaused, "")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("keeps the cache volume", func() {
+					createdVolumes, _, err := volumeFactory.GetOrphanedVolumes()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(createdVolumes).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("when the job does not exist", func() {
+			BeforeEach(func() {
+				taskCache.JobName = "bogus-job"
+			})
+
+			It("fails to create the volume", func() {
+				_, err := volumeFactory.CreateTaskCacheVolume(defaultTeam.ID(), defaultWorker, taskCache)
+				Expect(err).To(Equal(dbng.ErrTaskCacheJobNotFound))
+			})
+		})
+	})
+
 	Describe("FindResourceCacheVolume", func() {
 		var usedResourceCache *dbng.UsedResourceCache
 
@@ -188,7 +188,7 @@ var _ = Describe("Worker Lifecycle", func() {
 								Interruptible: false,
 							},
 						},
-					}, dbng.ConfigVersion(0), dbng.PipelineUnpaused)
+					}, dbng.ConfigVersion(0), dbng.PipelineUnpaused, "")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(created).To(BeTrue())
 
@@ -216,7 +216,7 @@ var _ = Describe("Worker Lifecycle", func() {
 								Interruptible: true,
 							},
 						},
-					}, dbng.ConfigVersion(0), dbng.PipelineUnpaused)
+					}, dbng.ConfigVersion(0), dbng.PipelineUnpaused, "")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(created).To(BeTrue())
 
@@ -402,7 +402,7 @@ var _ = Describe("Worker Lifecycle", func() {
 								Interruptible: false,
 							},
 						},
-					}, dbng.ConfigVersion(0), dbng.PipelineUnpaused)
+					}, dbng.ConfigVersion(0), dbng.PipelineUnpaused, "")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(created).To(BeTrue())
 
@@ -430,7 +430,7 @@ var _ = Describe("Worker Lifecycle", func() {
 								Interruptible: true,
 							},
 						},
-					}, dbng.ConfigVersion(0), dbng.PipelineUnpaused)
+					}, dbng.ConfigVersion(0), dbng.PipelineUnpaused, "")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(created).To(BeTrue())
 
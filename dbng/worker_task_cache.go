@@ -0,0 +1,157 @@
+package dbng
+
+import (
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+// TaskCache identifies a directory persisted between builds of a job's task
+// step. The job is referenced by pipeline and name, as that is what is known
+// to the step running the task.
+type TaskCache struct {
+	PipelineID int
+	JobName    string
+	StepName   string
+	Path       string
+}
+
+type WorkerTaskCache struct {
+	WorkerName string
+	TaskCache  TaskCache
+}
+
+type UsedWorkerTaskCache struct {
+	ID int
+}
+
+var ErrTaskCacheJobNotFound = errors.New("job for task cache not found")
+
+func (workerTaskCache WorkerTaskCache) FindOrCreate(tx Tx) (*UsedWorkerTaskCache, error) {
+	jobID, found, err := workerTaskCache.findJobID(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, ErrTaskCacheJobNotFound
+	}
+
+	id, found, err := workerTaskCache.find(tx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if found {
+		return &UsedWorkerTaskCache{
+			ID: id,
+		}, nil
+	}
+
+	err = psql.Insert("worker_task_caches").
+		Columns(
+			"worker_name",
+			"job_id",
+			"step_name",
+			"path",
+		).
+		Values(
+			workerTaskCache.WorkerName,
+			jobID,
+			workerTaskCache.TaskCache.StepName,
+			workerTaskCache.TaskCache.Path,
+		).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			return nil, ErrSafeRetryFindOrCreate
+		}
+
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			return nil, ErrSafeRetryFindOrCreate
+		}
+
+		return nil, err
+	}
+
+	return &UsedWorkerTaskCache{
+		ID: id,
+	}, nil
+}
+
+func (workerTaskCache WorkerTaskCache) Find(runner sq.Runner) (*UsedWorkerTaskCache, bool, error) {
+	jobID, found, err := workerTaskCache.findJobID(runner)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	id, found, err := workerTaskCache.find(runner, jobID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if found {
+		return &UsedWorkerTaskCache{
+			ID: id,
+		}, true, nil
+	}
+
+	return nil, false, nil
+}
+
+func (workerTaskCache WorkerTaskCache) findJobID(runner sq.Runner) (int, bool, error) {
+	var id int
+
+	err := psql.Select("id").
+		From("jobs").
+		Where(sq.Eq{
+			"pipeline_id": workerTaskCache.TaskCache.PipelineID,
+			"name":        workerTaskCache.TaskCache.JobName,
+		}).
+		RunWith(runner).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, nil
+		}
+
+		return 0, false, err
+	}
+
+	return id, true, nil
+}
+
+func (workerTaskCache WorkerTaskCache) find(runner sq.Runner, jobID int) (int, bool, error) {
+	var id int
+
+	err := psql.Select("id").
+		From("worker_task_caches").
+		Where(sq.Eq{
+			"worker_name": workerTaskCache.WorkerName,
+			"job_id":      jobID,
+			"step_name":   workerTaskCache.TaskCache.StepName,
+			"path":        workerTaskCache.TaskCache.Path,
+		}).
+		RunWith(runner).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, nil
+		}
+
+		return 0, false, err
+	}
+
+	return id, true, nil
+}
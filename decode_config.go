@@ -0,0 +1,74 @@
+package atc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	ErrFailedToConstructDecoder = errors.New("decoder could not be constructed")
+	ErrCouldNotDecode           = errors.New("data could not be decoded into config structure")
+)
+
+type ExtraKeysError struct {
+	ExtraKeys []string
+}
+
+func (eke ExtraKeysError) Error() string {
+	msg := &bytes.Buffer{}
+
+	fmt.Fprintln(msg, "unknown/extra keys:")
+	for _, unusedKey := range eke.ExtraKeys {
+		fmt.Fprintf(msg, "  - %s\n", unusedKey)
+	}
+
+	return msg.String()
+}
+
+// DecodeConfig decodes a pipeline config which has been unmarshaled from JSON
+// or YAML into an untyped structure. Any keys which do not correspond to a
+// field of the config result in an ExtraKeysError.
+func DecodeConfig(untypedConfig interface{}) (Config, error) {
+	var config Config
+	var md mapstructure.Metadata
+	msConfig := &mapstructure.DecoderConfig{
+		Metadata:         &md,
+		Result:           &config,
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			SanitizeDecodeHook,
+			VersionConfigDecodeHook,
+			PassedConfigDecodeHook,
+		),
+	}
+
+	decoder, err := mapstructure.NewDecoder(msConfig)
+	if err != nil {
+		return Config{}, ErrFailedToConstructDecoder
+	}
+
+	if err := decoder.Decode(untypedConfig); err != nil {
+		return Config{}, ErrCouldNotDecode
+	}
+
+	if len(md.Unused) != 0 {
+		return Config{}, ExtraKeysError{ExtraKeys: md.Unused}
+	}
+
+	return config, nil
+}
+
+// LoadConfig unmarshals a pipeline config from YAML and decodes it the same
+// way as DecodeConfig.
+func LoadConfig(configBytes []byte) (Config, error) {
+	var untypedConfig interface{}
+	if err := yaml.Unmarshal(configBytes, &untypedConfig); err != nil {
+		return Config{}, err
+	}
+
+	return DecodeConfig(untypedConfig)
+}
@@ -47,6 +47,17 @@ func (build *execBuild) buildTimeoutStep(logger lager.Logger, plan atc.Plan) exe
 	return exec.Timeout(step, plan.Timeout.Duration, clock.NewClock())
 }
 
+func (build *execBuild) buildBreakpointStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	innerPlan := plan.Breakpoint.Step
+	innerPlan.Attempts = plan.Attempts
+	step := build.buildStepFactory(logger, innerPlan)
+	return exec.Breakpoint(
+		step,
+		plan.Breakpoint.When,
+		build.delegate.BreakpointDelegate(logger, event.OriginID(plan.ID)),
+	)
+}
+
 func (build *execBuild) buildTryStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	innerPlan := plan.Try.Step
 	innerPlan.Attempts = plan.Attempts
@@ -123,6 +134,7 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 		plan.Task.OutputMapping,
 		plan.Task.ImageArtifactName,
 		clock,
+		build.variables.WithValues(plan.Task.AcrossValues),
 	)
 }
 
@@ -157,6 +169,7 @@ func (build *execBuild) buildGetStep(logger lager.Logger, plan atc.Plan) exec.St
 		plan.Get.Params,
 		plan.Get.Version,
 		plan.Get.VersionedResourceTypes,
+		build.variables,
 	)
 }
 
@@ -189,6 +202,7 @@ func (build *execBuild) buildPutStep(logger lager.Logger, plan atc.Plan) exec.St
 		build.teamID,
 		plan.Put.Params,
 		plan.Put.VersionedResourceTypes,
+		build.variables,
 	)
 }
 
@@ -223,20 +237,45 @@ func (build *execBuild) buildDependentGetStep(logger lager.Logger, plan atc.Plan
 		build.teamID,
 		getPlan.Params,
 		getPlan.VersionedResourceTypes,
+		build.variables,
+	)
+}
+
+func (build *execBuild) buildSetPipelineStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("set-pipeline", lager.Data{
+		"name": plan.SetPipeline.Name,
+	})
+
+	return build.factory.SetPipeline(
+		logger,
+		build.delegate.SetPipelineDelegate(logger, *plan.SetPipeline, event.OriginID(plan.ID)),
+		build.teamID,
+		plan.SetPipeline.Name,
+		plan.SetPipeline.File,
 	)
 }
 
 func (build *execBuild) buildRetryStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	logger = logger.Session("retry")
 
-	step := exec.Retry{}
+	attempts := []exec.StepFactory{}
 
 	for index, innerPlan := range *plan.Retry {
 		innerPlan.Attempts = append(plan.Attempts, index+1)
 
 		stepFactory := build.buildStepFactory(logger, innerPlan)
-		step = append(step, stepFactory)
+		attempts = append(attempts, stepFactory)
 	}
 
-	return step
+	var policy atc.RetryPolicy
+	if plan.RetryPolicy != nil {
+		policy = *plan.RetryPolicy
+	}
+
+	return exec.Retry(
+		attempts,
+		policy,
+		build.delegate.RetryDelegate(logger, event.OriginID(plan.ID)),
+		clock.NewClock(),
+	)
 }
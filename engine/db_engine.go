@@ -10,13 +10,15 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/notifications"
 )
 
 const trackLockDuration = time.Minute
 
-func NewDBEngine(engines Engines) Engine {
+func NewDBEngine(engines Engines, notifier notifications.Notifier) Engine {
 	return &dbEngine{
 		engines:   engines,
+		notifier:  notifier,
 		releaseCh: make(chan struct{}),
 		waitGroup: new(sync.WaitGroup),
 	}
@@ -32,6 +34,7 @@ func (err UnknownEngineError) Error() string {
 
 type dbEngine struct {
 	engines   Engines
+	notifier  notifications.Notifier
 	releaseCh chan struct{}
 	waitGroup *sync.WaitGroup
 }
@@ -53,12 +56,15 @@ func (engine *dbEngine) CreateBuild(logger lager.Logger, build db.Build, plan at
 		return nil, err
 	}
 
-	if !started {
+	if started {
+		engine.notifier.BuildStatusChanged(logger, build, atc.StatusStarted)
+	} else {
 		createdBuild.Abort(logger.Session("aborted-immediately"))
 	}
 
 	return &dbBuild{
 		engines:   engine.engines,
+		notifier:  engine.notifier,
 		releaseCh: engine.releaseCh,
 		waitGroup: engine.waitGroup,
 		build:     build,
@@ -68,6 +74,7 @@ func (engine *dbEngine) CreateBuild(logger lager.Logger, build db.Build, plan at
 func (engine *dbEngine) LookupBuild(logger lager.Logger, build db.Build) (Build, error) {
 	return &dbBuild{
 		engines:   engine.engines,
+		notifier:  engine.notifier,
 		releaseCh: engine.releaseCh,
 		waitGroup: engine.waitGroup,
 		build:     build,
@@ -92,6 +99,7 @@ func (engine *dbEngine) ReleaseAll(logger lager.Logger) {
 
 type dbBuild struct {
 	engines   Engines
+	notifier  notifications.Notifier
 	releaseCh chan struct{}
 	build     db.Build
 	waitGroup *sync.WaitGroup
@@ -166,7 +174,15 @@ func (build *dbBuild) Abort(logger lager.Logger) error {
 		// finish the build so that the aborted event is put into the event stream
 		// even if the build has not started yet
 		logger.Info("finishing-build-with-no-engine")
-		return build.build.Finish(db.StatusAborted)
+
+		err = build.build.Finish(db.StatusAborted)
+		if err != nil {
+			return err
+		}
+
+		build.notifier.BuildStatusChanged(logger, build.build, atc.StatusAborted)
+
+		return nil
 	}
 
 	buildEngine, found := build.engines.Lookup(buildEngineName)
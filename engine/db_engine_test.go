@@ -16,6 +16,7 @@ import (
 	"github.com/concourse/atc/db/lock/lockfakes"
 	. "github.com/concourse/atc/engine"
 	"github.com/concourse/atc/engine/enginefakes"
+	"github.com/concourse/atc/notifications/notificationsfakes"
 )
 
 var _ = Describe("DBEngine", func() {
@@ -26,6 +27,8 @@ var _ = Describe("DBEngine", func() {
 		fakeEngineB *enginefakes.FakeEngine
 		dbBuild     *dbfakes.FakeBuild
 
+		fakeNotifier *notificationsfakes.FakeNotifier
+
 		dbEngine Engine
 	)
 
@@ -41,7 +44,9 @@ var _ = Describe("DBEngine", func() {
 		dbBuild = new(dbfakes.FakeBuild)
 		dbBuild.IDReturns(128)
 
-		dbEngine = NewDBEngine(Engines{fakeEngineA, fakeEngineB})
+		fakeNotifier = new(notificationsfakes.FakeNotifier)
+
+		dbEngine = NewDBEngine(Engines{fakeEngineA, fakeEngineB}, fakeNotifier)
 	})
 
 	Describe("CreateBuild", func() {
@@ -106,6 +111,14 @@ var _ = Describe("DBEngine", func() {
 				Expect(metadata).To(Equal("some-metadata"))
 			})
 
+			It("notifies subscribers that the build started", func() {
+				Expect(fakeNotifier.BuildStatusChangedCallCount()).To(Equal(1))
+
+				_, build, status := fakeNotifier.BuildStatusChangedArgsForCall(0)
+				Expect(build).To(Equal(dbBuild))
+				Expect(status).To(Equal(atc.StatusStarted))
+			})
+
 			Context("when the build fails to transition to started", func() {
 				BeforeEach(func() {
 					dbBuild.StartReturns(false, nil)
@@ -114,6 +127,10 @@ var _ = Describe("DBEngine", func() {
 				It("aborts the build", func() {
 					Expect(fakeBuild.AbortCallCount()).To(Equal(1))
 				})
+
+				It("does not notify subscribers", func() {
+					Expect(fakeNotifier.BuildStatusChangedCallCount()).To(BeZero())
+				})
 			})
 		})
 
@@ -359,6 +376,14 @@ var _ = Describe("DBEngine", func() {
 						Expect(status).To(Equal(db.StatusAborted))
 					})
 
+					It("notifies subscribers that the build was aborted", func() {
+						Expect(fakeNotifier.BuildStatusChangedCallCount()).To(Equal(1))
+
+						_, build, status := fakeNotifier.BuildStatusChangedArgsForCall(0)
+						Expect(build).To(Equal(dbBuild))
+						Expect(status).To(Equal(atc.StatusAborted))
+					})
+
 					It("releases the lock", func() {
 						Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
 					})
@@ -51,6 +51,43 @@ type FakeBuildDelegate struct {
 	outputDelegateReturnsOnCall map[int]struct {
 		result1 exec.PutDelegate
 	}
+	SetPipelineDelegateStub        func(lager.Logger, atc.SetPipelinePlan, event.OriginID) exec.SetPipelineDelegate
+	setPipelineDelegateMutex       sync.RWMutex
+	setPipelineDelegateArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.SetPipelinePlan
+		arg3 event.OriginID
+	}
+	setPipelineDelegateReturns struct {
+		result1 exec.SetPipelineDelegate
+	}
+	setPipelineDelegateReturnsOnCall map[int]struct {
+		result1 exec.SetPipelineDelegate
+	}
+	RetryDelegateStub        func(lager.Logger, event.OriginID) exec.RetryDelegate
+	retryDelegateMutex       sync.RWMutex
+	retryDelegateArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 event.OriginID
+	}
+	retryDelegateReturns struct {
+		result1 exec.RetryDelegate
+	}
+	retryDelegateReturnsOnCall map[int]struct {
+		result1 exec.RetryDelegate
+	}
+	BreakpointDelegateStub        func(lager.Logger, event.OriginID) exec.BreakpointDelegate
+	breakpointDelegateMutex       sync.RWMutex
+	breakpointDelegateArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 event.OriginID
+	}
+	breakpointDelegateReturns struct {
+		result1 exec.BreakpointDelegate
+	}
+	breakpointDelegateReturnsOnCall map[int]struct {
+		result1 exec.BreakpointDelegate
+	}
 	FinishStub        func(lager.Logger, error, exec.Success, bool)
 	finishMutex       sync.RWMutex
 	finishArgsForCall []struct {
@@ -213,6 +250,154 @@ func (fake *FakeBuildDelegate) OutputDelegateReturnsOnCall(i int, result1 exec.P
 	}{result1}
 }
 
+func (fake *FakeBuildDelegate) SetPipelineDelegate(arg1 lager.Logger, arg2 atc.SetPipelinePlan, arg3 event.OriginID) exec.SetPipelineDelegate {
+	fake.setPipelineDelegateMutex.Lock()
+	ret, specificReturn := fake.setPipelineDelegateReturnsOnCall[len(fake.setPipelineDelegateArgsForCall)]
+	fake.setPipelineDelegateArgsForCall = append(fake.setPipelineDelegateArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.SetPipelinePlan
+		arg3 event.OriginID
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("SetPipelineDelegate", []interface{}{arg1, arg2, arg3})
+	fake.setPipelineDelegateMutex.Unlock()
+	if fake.SetPipelineDelegateStub != nil {
+		return fake.SetPipelineDelegateStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.setPipelineDelegateReturns.result1
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateCallCount() int {
+	fake.setPipelineDelegateMutex.RLock()
+	defer fake.setPipelineDelegateMutex.RUnlock()
+	return len(fake.setPipelineDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateArgsForCall(i int) (lager.Logger, atc.SetPipelinePlan, event.OriginID) {
+	fake.setPipelineDelegateMutex.RLock()
+	defer fake.setPipelineDelegateMutex.RUnlock()
+	return fake.setPipelineDelegateArgsForCall[i].arg1, fake.setPipelineDelegateArgsForCall[i].arg2, fake.setPipelineDelegateArgsForCall[i].arg3
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateReturns(result1 exec.SetPipelineDelegate) {
+	fake.SetPipelineDelegateStub = nil
+	fake.setPipelineDelegateReturns = struct {
+		result1 exec.SetPipelineDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateReturnsOnCall(i int, result1 exec.SetPipelineDelegate) {
+	fake.SetPipelineDelegateStub = nil
+	if fake.setPipelineDelegateReturnsOnCall == nil {
+		fake.setPipelineDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.SetPipelineDelegate
+		})
+	}
+	fake.setPipelineDelegateReturnsOnCall[i] = struct {
+		result1 exec.SetPipelineDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) RetryDelegate(arg1 lager.Logger, arg2 event.OriginID) exec.RetryDelegate {
+	fake.retryDelegateMutex.Lock()
+	ret, specificReturn := fake.retryDelegateReturnsOnCall[len(fake.retryDelegateArgsForCall)]
+	fake.retryDelegateArgsForCall = append(fake.retryDelegateArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 event.OriginID
+	}{arg1, arg2})
+	fake.recordInvocation("RetryDelegate", []interface{}{arg1, arg2})
+	fake.retryDelegateMutex.Unlock()
+	if fake.RetryDelegateStub != nil {
+		return fake.RetryDelegateStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.retryDelegateReturns.result1
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateCallCount() int {
+	fake.retryDelegateMutex.RLock()
+	defer fake.retryDelegateMutex.RUnlock()
+	return len(fake.retryDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateArgsForCall(i int) (lager.Logger, event.OriginID) {
+	fake.retryDelegateMutex.RLock()
+	defer fake.retryDelegateMutex.RUnlock()
+	return fake.retryDelegateArgsForCall[i].arg1, fake.retryDelegateArgsForCall[i].arg2
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateReturns(result1 exec.RetryDelegate) {
+	fake.RetryDelegateStub = nil
+	fake.retryDelegateReturns = struct {
+		result1 exec.RetryDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateReturnsOnCall(i int, result1 exec.RetryDelegate) {
+	fake.RetryDelegateStub = nil
+	if fake.retryDelegateReturnsOnCall == nil {
+		fake.retryDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.RetryDelegate
+		})
+	}
+	fake.retryDelegateReturnsOnCall[i] = struct {
+		result1 exec.RetryDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) BreakpointDelegate(arg1 lager.Logger, arg2 event.OriginID) exec.BreakpointDelegate {
+	fake.breakpointDelegateMutex.Lock()
+	ret, specificReturn := fake.breakpointDelegateReturnsOnCall[len(fake.breakpointDelegateArgsForCall)]
+	fake.breakpointDelegateArgsForCall = append(fake.breakpointDelegateArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 event.OriginID
+	}{arg1, arg2})
+	fake.recordInvocation("BreakpointDelegate", []interface{}{arg1, arg2})
+	fake.breakpointDelegateMutex.Unlock()
+	if fake.BreakpointDelegateStub != nil {
+		return fake.BreakpointDelegateStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.breakpointDelegateReturns.result1
+}
+
+func (fake *FakeBuildDelegate) BreakpointDelegateCallCount() int {
+	fake.breakpointDelegateMutex.RLock()
+	defer fake.breakpointDelegateMutex.RUnlock()
+	return len(fake.breakpointDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) BreakpointDelegateArgsForCall(i int) (lager.Logger, event.OriginID) {
+	fake.breakpointDelegateMutex.RLock()
+	defer fake.breakpointDelegateMutex.RUnlock()
+	return fake.breakpointDelegateArgsForCall[i].arg1, fake.breakpointDelegateArgsForCall[i].arg2
+}
+
+func (fake *FakeBuildDelegate) BreakpointDelegateReturns(result1 exec.BreakpointDelegate) {
+	fake.BreakpointDelegateStub = nil
+	fake.breakpointDelegateReturns = struct {
+		result1 exec.BreakpointDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) BreakpointDelegateReturnsOnCall(i int, result1 exec.BreakpointDelegate) {
+	fake.BreakpointDelegateStub = nil
+	if fake.breakpointDelegateReturnsOnCall == nil {
+		fake.breakpointDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.BreakpointDelegate
+		})
+	}
+	fake.breakpointDelegateReturnsOnCall[i] = struct {
+		result1 exec.BreakpointDelegate
+	}{result1}
+}
+
 func (fake *FakeBuildDelegate) Finish(arg1 lager.Logger, arg2 error, arg3 exec.Success, arg4 bool) {
 	fake.finishMutex.Lock()
 	fake.finishArgsForCall = append(fake.finishArgsForCall, struct {
@@ -249,6 +434,12 @@ func (fake *FakeBuildDelegate) Invocations() map[string][][]interface{} {
 	defer fake.executionDelegateMutex.RUnlock()
 	fake.outputDelegateMutex.RLock()
 	defer fake.outputDelegateMutex.RUnlock()
+	fake.setPipelineDelegateMutex.RLock()
+	defer fake.setPipelineDelegateMutex.RUnlock()
+	fake.retryDelegateMutex.RLock()
+	defer fake.retryDelegateMutex.RUnlock()
+	fake.breakpointDelegateMutex.RLock()
+	defer fake.breakpointDelegateMutex.RUnlock()
 	fake.finishMutex.RLock()
 	defer fake.finishMutex.RUnlock()
 	return fake.invocations
@@ -9,6 +9,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/worker"
@@ -22,25 +23,28 @@ type execMetadata struct {
 const execEngineName = "exec.v2"
 
 type execEngine struct {
-	factory         exec.Factory
-	delegateFactory BuildDelegateFactory
-	teamDBFactory   db.TeamDBFactory
-	externalURL     string
-	releaseCh       chan struct{}
+	factory           exec.Factory
+	delegateFactory   BuildDelegateFactory
+	teamDBFactory     db.TeamDBFactory
+	credentialManager creds.CredentialManager
+	externalURL       string
+	releaseCh         chan struct{}
 }
 
 func NewExecEngine(
 	factory exec.Factory,
 	delegateFactory BuildDelegateFactory,
 	teamDBFactory db.TeamDBFactory,
+	credentialManager creds.CredentialManager,
 	externalURL string,
 ) Engine {
 	return &execEngine{
-		factory:         factory,
-		delegateFactory: delegateFactory,
-		teamDBFactory:   teamDBFactory,
-		externalURL:     externalURL,
-		releaseCh:       make(chan struct{}),
+		factory:           factory,
+		delegateFactory:   delegateFactory,
+		teamDBFactory:     teamDBFactory,
+		credentialManager: credentialManager,
+		externalURL:       externalURL,
+		releaseCh:         make(chan struct{}),
 	}
 }
 
@@ -54,6 +58,7 @@ func (engine *execEngine) CreateBuild(logger lager.Logger, build db.Build, plan
 		teamName:     build.TeamName(),
 		teamID:       build.TeamID(),
 		stepMetadata: buildMetadata(build, engine.externalURL),
+		variables:    creds.NewVariables(engine.credentialManager, build.TeamName(), build.PipelineName()),
 
 		factory:  engine.factory,
 		delegate: engine.delegateFactory.Delegate(build),
@@ -84,6 +89,7 @@ func (engine *execEngine) LookupBuild(logger lager.Logger, build db.Build) (Buil
 		teamName:     build.TeamName(),
 		teamID:       build.TeamID(),
 		stepMetadata: buildMetadata(build, engine.externalURL),
+		variables:    creds.NewVariables(engine.credentialManager, build.TeamName(), build.PipelineName()),
 
 		factory:  engine.factory,
 		delegate: engine.delegateFactory.Delegate(build),
@@ -165,6 +171,10 @@ type execBuild struct {
 	teamName     string
 	teamID       int
 
+	// variables resolve the ((var)) placeholders in the plan as each step
+	// runs; the plan itself is persisted with them unresolved
+	variables creds.Variables
+
 	factory  exec.Factory
 	delegate BuildDelegate
 
@@ -245,6 +255,10 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return build.buildTimeoutStep(logger, plan)
 	}
 
+	if plan.Breakpoint != nil {
+		return build.buildBreakpointStep(logger, plan)
+	}
+
 	if plan.Try != nil {
 		return build.buildTryStep(logger, plan)
 	}
@@ -281,6 +295,10 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return build.buildRetryStep(logger, plan)
 	}
 
+	if plan.SetPipeline != nil {
+		return build.buildSetPipelineStep(logger, plan)
+	}
+
 	return exec.Identity{}
 }
 
@@ -305,6 +323,7 @@ func (build *execBuild) stepIdentifier(
 			StepName:   stepName,
 			Type:       stepType,
 			PipelineID: pipelineID,
+			JobName:    build.stepMetadata.JobName,
 			TeamID:     build.teamID,
 			Attempts:   attempts,
 		}
@@ -2,6 +2,7 @@ package engine
 
 import (
 	"io"
+	"os"
 	"sync"
 	"time"
 	"unicode/utf8"
@@ -11,6 +12,7 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/notifications"
 	"github.com/concourse/atc/worker"
 )
 
@@ -25,6 +27,9 @@ type BuildDelegate interface {
 	InputDelegate(lager.Logger, atc.GetPlan, event.OriginID) exec.GetDelegate
 	ExecutionDelegate(lager.Logger, atc.TaskPlan, event.OriginID) exec.TaskDelegate
 	OutputDelegate(lager.Logger, atc.PutPlan, event.OriginID) exec.PutDelegate
+	SetPipelineDelegate(lager.Logger, atc.SetPipelinePlan, event.OriginID) exec.SetPipelineDelegate
+	RetryDelegate(lager.Logger, event.OriginID) exec.RetryDelegate
+	BreakpointDelegate(lager.Logger, event.OriginID) exec.BreakpointDelegate
 
 	Finish(lager.Logger, error, exec.Success, bool)
 }
@@ -35,27 +40,33 @@ type BuildDelegateFactory interface {
 	Delegate(db.Build) BuildDelegate
 }
 
-type buildDelegateFactory struct{}
+type buildDelegateFactory struct {
+	notifier notifications.Notifier
+}
 
-func NewBuildDelegateFactory() BuildDelegateFactory {
-	return buildDelegateFactory{}
+func NewBuildDelegateFactory(notifier notifications.Notifier) BuildDelegateFactory {
+	return buildDelegateFactory{
+		notifier: notifier,
+	}
 }
 
 func (factory buildDelegateFactory) Delegate(build db.Build) BuildDelegate {
-	return newBuildDelegate(build)
+	return newBuildDelegate(build, factory.notifier)
 }
 
 type delegate struct {
-	build db.Build
+	build    db.Build
+	notifier notifications.Notifier
 
 	implicitOutputs map[string]implicitOutput
 
 	lock sync.Mutex
 }
 
-func newBuildDelegate(build db.Build) BuildDelegate {
+func newBuildDelegate(build db.Build, notifier notifications.Notifier) BuildDelegate {
 	return &delegate{
-		build: build,
+		build:    build,
+		notifier: notifier,
 
 		implicitOutputs: make(map[string]implicitOutput),
 	}
@@ -91,6 +102,34 @@ func (delegate *delegate) ExecutionDelegate(logger lager.Logger, plan atc.TaskPl
 	}
 }
 
+func (delegate *delegate) SetPipelineDelegate(logger lager.Logger, plan atc.SetPipelinePlan, id event.OriginID) exec.SetPipelineDelegate {
+	return &setPipelineDelegate{
+		logger: logger,
+
+		id:       id,
+		plan:     plan,
+		delegate: delegate,
+	}
+}
+
+func (delegate *delegate) RetryDelegate(logger lager.Logger, id event.OriginID) exec.RetryDelegate {
+	return &retryDelegate{
+		logger: logger,
+
+		id:       id,
+		delegate: delegate,
+	}
+}
+
+func (delegate *delegate) BreakpointDelegate(logger lager.Logger, id event.OriginID) exec.BreakpointDelegate {
+	return &breakpointDelegate{
+		logger: logger,
+
+		id:       id,
+		delegate: delegate,
+	}
+}
+
 func (delegate *delegate) Finish(logger lager.Logger, err error, succeeded exec.Success, aborted bool) {
 	if aborted {
 		delegate.saveStatus(logger, atc.StatusAborted)
@@ -157,6 +196,26 @@ func (delegate *delegate) saveInitializePut(logger lager.Logger, origin event.Or
 	}
 }
 
+func (delegate *delegate) saveInitializeSetPipeline(logger lager.Logger, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.InitializeSetPipeline{
+		Origin: origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-initialize-event", err)
+	}
+}
+
+func (delegate *delegate) saveFinishSetPipeline(logger lager.Logger, status exec.ExitStatus, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.FinishSetPipeline{
+		ExitStatus: int(status),
+		Time:       time.Now().Unix(),
+		Origin:     origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-finish-event", err)
+	}
+}
+
 func (delegate *delegate) saveStart(logger lager.Logger, origin event.Origin) {
 	err := delegate.build.SaveEvent(event.StartTask{
 		Time:   time.Now().Unix(),
@@ -178,11 +237,33 @@ func (delegate *delegate) saveFinish(logger lager.Logger, status exec.ExitStatus
 	}
 }
 
+func (delegate *delegate) saveFinishAttempt(logger lager.Logger, attempt int, status atc.BuildStatus, retrying bool, delay time.Duration, origin event.Origin) {
+	finishAttempt := event.FinishAttempt{
+		Origin:   origin,
+		Time:     time.Now().Unix(),
+		Attempt:  attempt,
+		Status:   status,
+		Retrying: retrying,
+	}
+
+	if delay > 0 {
+		finishAttempt.Delay = delay.String()
+	}
+
+	err := delegate.build.SaveEvent(finishAttempt)
+	if err != nil {
+		logger.Error("failed-to-save-finish-attempt-event", err)
+	}
+}
+
 func (delegate *delegate) saveStatus(logger lager.Logger, status atc.BuildStatus) {
 	err := delegate.build.Finish(db.Status(status))
 	if err != nil {
 		logger.Error("failed-to-finish-build", err)
+		return
 	}
+
+	delegate.notifier.BuildStatusChanged(logger, delegate.build, status)
 }
 
 func (delegate *delegate) saveErr(logger lager.Logger, errVal error, origin event.Origin) {
@@ -456,6 +537,131 @@ func (execution *executionDelegate) Stderr() io.Writer {
 	})
 }
 
+type setPipelineDelegate struct {
+	logger lager.Logger
+
+	plan atc.SetPipelinePlan
+	id   event.OriginID
+
+	delegate *delegate
+}
+
+func (setPipeline *setPipelineDelegate) Initializing() {
+	setPipeline.delegate.saveInitializeSetPipeline(setPipeline.logger, event.Origin{
+		ID: setPipeline.id,
+	})
+
+	setPipeline.logger.Info("initializing")
+}
+
+func (setPipeline *setPipelineDelegate) Finished(status exec.ExitStatus) {
+	setPipeline.delegate.saveFinishSetPipeline(setPipeline.logger, status, event.Origin{
+		ID: setPipeline.id,
+	})
+
+	setPipeline.logger.Info("finished", lager.Data{"exit-status": status})
+}
+
+func (setPipeline *setPipelineDelegate) Failed(err error) {
+	setPipeline.delegate.saveErr(setPipeline.logger, err, event.Origin{
+		ID: setPipeline.id,
+	})
+	setPipeline.logger.Info("errored", lager.Data{"error": err.Error()})
+}
+
+func (setPipeline *setPipelineDelegate) Stdout() io.Writer {
+	return setPipeline.delegate.eventWriter(event.Origin{
+		Source: event.OriginSourceStdout,
+		ID:     setPipeline.id,
+	})
+}
+
+func (setPipeline *setPipelineDelegate) Stderr() io.Writer {
+	return setPipeline.delegate.eventWriter(event.Origin{
+		Source: event.OriginSourceStderr,
+		ID:     setPipeline.id,
+	})
+}
+
+type retryDelegate struct {
+	logger lager.Logger
+
+	id event.OriginID
+
+	delegate *delegate
+}
+
+func (retry *retryDelegate) AttemptFinished(attempt int, status atc.BuildStatus, retrying bool, delay time.Duration) {
+	retry.delegate.saveFinishAttempt(retry.logger, attempt, status, retrying, delay, event.Origin{
+		ID: retry.id,
+	})
+
+	retry.logger.Info("attempt-finished", lager.Data{
+		"attempt":  attempt,
+		"status":   status,
+		"retrying": retrying,
+		"delay":    delay.String(),
+	})
+}
+
+type breakpointDelegate struct {
+	logger lager.Logger
+
+	id event.OriginID
+
+	delegate *delegate
+}
+
+func (breakpoint *breakpointDelegate) Paused(when string) error {
+	err := breakpoint.delegate.build.PauseAtBreakpoint(atc.PlanID(breakpoint.id))
+	if err != nil {
+		breakpoint.logger.Error("failed-to-pause", err)
+		return err
+	}
+
+	err = breakpoint.delegate.build.SaveEvent(event.PauseBreakpoint{
+		Origin: event.Origin{ID: breakpoint.id},
+		Time:   time.Now().Unix(),
+		When:   when,
+	})
+	if err != nil {
+		breakpoint.logger.Error("failed-to-save-pause-breakpoint-event", err)
+	}
+
+	breakpoint.logger.Info("paused", lager.Data{"when": when})
+
+	return nil
+}
+
+func (breakpoint *breakpointDelegate) WaitForResume(signals <-chan os.Signal) error {
+	resumes, err := breakpoint.delegate.build.ResumeNotifier()
+	if err != nil {
+		breakpoint.logger.Error("failed-to-listen-for-resume", err)
+		return err
+	}
+
+	defer resumes.Close()
+
+	select {
+	case <-resumes.Notify():
+		return nil
+	case <-signals:
+		return exec.ErrInterrupted
+	}
+}
+
+func (breakpoint *breakpointDelegate) Resumed() {
+	err := breakpoint.delegate.build.SaveEvent(event.ResumeBreakpoint{
+		Origin: event.Origin{ID: breakpoint.id},
+		Time:   time.Now().Unix(),
+	})
+	if err != nil {
+		breakpoint.logger.Error("failed-to-save-resume-breakpoint-event", err)
+	}
+
+	breakpoint.logger.Info("resumed")
+}
+
 type dbEventWriter struct {
 	build db.Build
 
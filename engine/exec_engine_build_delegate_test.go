@@ -3,6 +3,7 @@ package engine_test
 import (
 	"errors"
 	"io"
+	"os"
 	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
@@ -12,6 +13,7 @@ import (
 	. "github.com/concourse/atc/engine"
 	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/notifications/notificationsfakes"
 	"github.com/concourse/atc/worker"
 
 	. "github.com/onsi/ginkgo"
@@ -22,6 +24,8 @@ var _ = Describe("BuildDelegate", func() {
 	var (
 		factory BuildDelegateFactory
 
+		fakeNotifier *notificationsfakes.FakeNotifier
+
 		fakeBuild *dbfakes.FakeBuild
 
 		delegate BuildDelegate
@@ -32,7 +36,9 @@ var _ = Describe("BuildDelegate", func() {
 	)
 
 	BeforeEach(func() {
-		factory = NewBuildDelegateFactory()
+		fakeNotifier = new(notificationsfakes.FakeNotifier)
+
+		factory = NewBuildDelegateFactory(fakeNotifier)
 
 		fakeBuild = new(dbfakes.FakeBuild)
 		delegate = factory.Delegate(fakeBuild)
@@ -1114,6 +1120,94 @@ var _ = Describe("BuildDelegate", func() {
 		})
 	})
 
+	Describe("SetPipelineDelegate", func() {
+		var setPipelineDelegate exec.SetPipelineDelegate
+
+		BeforeEach(func() {
+			setPipelineDelegate = delegate.SetPipelineDelegate(logger, atc.SetPipelinePlan{
+				Name: "some-pipeline",
+				File: "some-input/pipeline.yml",
+			}, originID)
+		})
+
+		Describe("Initializing", func() {
+			JustBeforeEach(func() {
+				setPipelineDelegate.Initializing()
+			})
+
+			It("saves an initialize event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(Equal(event.InitializeSetPipeline{
+					Origin: event.Origin{
+						ID: originID,
+					},
+				}))
+			})
+		})
+
+		Describe("Finished", func() {
+			JustBeforeEach(func() {
+				setPipelineDelegate.Finished(0)
+			})
+
+			It("saves a finish event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.FinishSetPipeline{}))
+				Expect(savedEvent.(event.FinishSetPipeline).ExitStatus).To(Equal(0))
+				Expect(savedEvent.(event.FinishSetPipeline).Time).To(BeNumerically("<=", time.Now().Unix(), 1))
+				Expect(savedEvent.(event.FinishSetPipeline).Origin).To(Equal(event.Origin{
+					ID: originID,
+				}))
+			})
+		})
+
+		Describe("Failed", func() {
+			JustBeforeEach(func() {
+				setPipelineDelegate.Failed(errors.New("nope"))
+			})
+
+			It("saves an error event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(Equal(event.Error{
+					Message: "nope",
+					Origin: event.Origin{
+						ID: originID,
+					},
+				}))
+			})
+		})
+
+		Describe("Stdout", func() {
+			var writer io.Writer
+
+			BeforeEach(func() {
+				writer = setPipelineDelegate.Stdout()
+			})
+
+			It("saves log events with the correct origin", func() {
+				_, err := writer.Write([]byte("some stdout"))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(Equal(event.Log{
+					Origin: event.Origin{
+						Source: event.OriginSourceStdout,
+						ID:     originID,
+					},
+					Payload: "some stdout",
+				}))
+			})
+		})
+	})
+
 	Describe("Aborted", func() {
 		var aborted bool
 
@@ -1164,4 +1258,182 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 	})
+
+	Describe("Finish", func() {
+		It("notifies subscribers of the build's status", func() {
+			delegate.Finish(logger, nil, false, false)
+
+			Expect(fakeNotifier.BuildStatusChangedCallCount()).To(Equal(1))
+
+			_, build, status := fakeNotifier.BuildStatusChangedArgsForCall(0)
+			Expect(build).To(Equal(fakeBuild))
+			Expect(status).To(Equal(atc.StatusFailed))
+		})
+
+		Context("when saving the status fails", func() {
+			BeforeEach(func() {
+				fakeBuild.FinishReturns(errors.New("nope"))
+			})
+
+			It("does not notify subscribers", func() {
+				delegate.Finish(logger, nil, true, false)
+
+				Expect(fakeNotifier.BuildStatusChangedCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("RetryDelegate", func() {
+		var retryDelegate exec.RetryDelegate
+
+		BeforeEach(func() {
+			retryDelegate = delegate.RetryDelegate(logger, originID)
+		})
+
+		Describe("AttemptFinished", func() {
+			Context("when retrying after a delay", func() {
+				JustBeforeEach(func() {
+					retryDelegate.AttemptFinished(1, atc.StatusFailed, true, 10*time.Second)
+				})
+
+				It("saves a finish-attempt event with the delay", func() {
+					Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+					savedEvent := fakeBuild.SaveEventArgsForCall(0)
+					Expect(savedEvent).To(BeAssignableToTypeOf(event.FinishAttempt{}))
+
+					finishAttempt := savedEvent.(event.FinishAttempt)
+					Expect(finishAttempt.Time).To(BeNumerically("~", time.Now().Unix(), 1))
+					Expect(finishAttempt.Origin).To(Equal(event.Origin{
+						ID: originID,
+					}))
+					Expect(finishAttempt.Attempt).To(Equal(1))
+					Expect(finishAttempt.Status).To(Equal(atc.StatusFailed))
+					Expect(finishAttempt.Retrying).To(BeTrue())
+					Expect(finishAttempt.Delay).To(Equal("10s"))
+				})
+			})
+
+			Context("when not retrying", func() {
+				JustBeforeEach(func() {
+					retryDelegate.AttemptFinished(3, atc.StatusErrored, false, 0)
+				})
+
+				It("saves a finish-attempt event without a delay", func() {
+					Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+					finishAttempt := fakeBuild.SaveEventArgsForCall(0).(event.FinishAttempt)
+					Expect(finishAttempt.Attempt).To(Equal(3))
+					Expect(finishAttempt.Status).To(Equal(atc.StatusErrored))
+					Expect(finishAttempt.Retrying).To(BeFalse())
+					Expect(finishAttempt.Delay).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("BreakpointDelegate", func() {
+		var breakpointDelegate exec.BreakpointDelegate
+
+		BeforeEach(func() {
+			breakpointDelegate = delegate.BreakpointDelegate(logger, originID)
+		})
+
+		Describe("Paused", func() {
+			var pauseErr error
+
+			JustBeforeEach(func() {
+				pauseErr = breakpointDelegate.Paused(atc.BreakpointOnFailure)
+			})
+
+			It("pauses the build at the breakpoint", func() {
+				Expect(pauseErr).NotTo(HaveOccurred())
+
+				Expect(fakeBuild.PauseAtBreakpointCallCount()).To(Equal(1))
+				Expect(fakeBuild.PauseAtBreakpointArgsForCall(0)).To(Equal(atc.PlanID(originID)))
+			})
+
+			It("saves a pause-breakpoint event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.PauseBreakpoint{}))
+				Expect(savedEvent.(event.PauseBreakpoint).Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(savedEvent.(event.PauseBreakpoint).Origin).To(Equal(event.Origin{
+					ID: originID,
+				}))
+				Expect(savedEvent.(event.PauseBreakpoint).When).To(Equal(atc.BreakpointOnFailure))
+			})
+
+			Context("when pausing the build fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeBuild.PauseAtBreakpointReturns(disaster)
+				})
+
+				It("returns the error without saving an event", func() {
+					Expect(pauseErr).To(Equal(disaster))
+					Expect(fakeBuild.SaveEventCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Describe("WaitForResume", func() {
+			var (
+				fakeNotifier *dbfakes.FakeNotifier
+				notify       chan struct{}
+				signals      chan os.Signal
+				waitErr      chan error
+			)
+
+			BeforeEach(func() {
+				notify = make(chan struct{})
+				fakeNotifier = new(dbfakes.FakeNotifier)
+				fakeNotifier.NotifyReturns(notify)
+				fakeBuild.ResumeNotifierReturns(fakeNotifier, nil)
+
+				signals = make(chan os.Signal, 1)
+			})
+
+			JustBeforeEach(func() {
+				waitErr = make(chan error, 1)
+				go func() {
+					waitErr <- breakpointDelegate.WaitForResume(signals)
+				}()
+			})
+
+			It("returns once the build is resumed", func() {
+				Consistently(waitErr).ShouldNot(Receive())
+
+				close(notify)
+
+				Eventually(waitErr).Should(Receive(BeNil()))
+				Expect(fakeNotifier.CloseCallCount()).To(Equal(1))
+			})
+
+			It("returns ErrInterrupted when signalled", func() {
+				signals <- os.Interrupt
+
+				Eventually(waitErr).Should(Receive(Equal(exec.ErrInterrupted)))
+				Expect(fakeNotifier.CloseCallCount()).To(Equal(1))
+			})
+		})
+
+		Describe("Resumed", func() {
+			JustBeforeEach(func() {
+				breakpointDelegate.Resumed()
+			})
+
+			It("saves a resume-breakpoint event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.ResumeBreakpoint{}))
+				Expect(savedEvent.(event.ResumeBreakpoint).Origin).To(Equal(event.Origin{
+					ID: originID,
+				}))
+			})
+		})
+	})
 })
@@ -40,6 +40,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 			fakeFactory,
 			fakeDelegateFactory,
 			fakeTeamDBFactory,
+			nil,
 			"http://example.com",
 		)
 
@@ -185,11 +186,12 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the step correctly", func() {
 					Expect(fakeFactory.GetCallCount()).To(Equal(1))
-					logger, metadata, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _ := fakeFactory.GetArgsForCall(0)
+					logger, metadata, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _ := fakeFactory.GetArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(sourceName).To(Equal(worker.ArtifactName("some-input")))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
+						JobName:    "some-job",
 						PipelineID: 57,
 						StepName:   "some-input",
 						Type:       db.ContainerTypeGet,
@@ -206,10 +208,11 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the completion hook correctly", func() {
 					Expect(fakeFactory.TaskCallCount()).To(Equal(4))
-					logger, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(2)
+					logger, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(2)
 					Expect(logger).NotTo(BeNil())
 					Expect(sourceName).To(Equal(worker.ArtifactName("some-completion-task")))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
+						JobName:    "some-job",
 						PipelineID: 57,
 						StepName:   "some-completion-task",
 						Type:       db.ContainerTypeTask,
@@ -227,10 +230,11 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the failure hook correctly", func() {
 					Expect(fakeFactory.TaskCallCount()).To(Equal(4))
-					logger, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+					logger, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(sourceName).To(Equal(worker.ArtifactName("some-failure-task")))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
+						JobName:    "some-job",
 						PipelineID: 57,
 						StepName:   "some-failure-task",
 						Type:       db.ContainerTypeTask,
@@ -248,10 +252,11 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the success hook correctly", func() {
 					Expect(fakeFactory.TaskCallCount()).To(Equal(4))
-					logger, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(1)
+					logger, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(1)
 					Expect(logger).NotTo(BeNil())
 					Expect(sourceName).To(Equal(worker.ArtifactName("some-success-task")))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
+						JobName:    "some-job",
 						PipelineID: 57,
 						StepName:   "some-success-task",
 						Type:       db.ContainerTypeTask,
@@ -269,10 +274,11 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the next step correctly", func() {
 					Expect(fakeFactory.TaskCallCount()).To(Equal(4))
-					logger, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(3)
+					logger, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(3)
 					Expect(logger).NotTo(BeNil())
 					Expect(sourceName).To(Equal(worker.ArtifactName("some-next-task")))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
+						JobName:    "some-job",
 						PipelineID: 57,
 						StepName:   "some-next-task",
 						Type:       db.ContainerTypeTask,
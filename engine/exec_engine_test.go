@@ -8,6 +8,8 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine"
@@ -23,10 +25,11 @@ import (
 
 var _ = Describe("ExecEngine", func() {
 	var (
-		fakeFactory         *execfakes.FakeFactory
-		fakeTeamDB          *dbfakes.FakeTeamDB
-		fakeDelegateFactory *enginefakes.FakeBuildDelegateFactory
-		logger              *lagertest.TestLogger
+		fakeFactory           *execfakes.FakeFactory
+		fakeTeamDB            *dbfakes.FakeTeamDB
+		fakeDelegateFactory   *enginefakes.FakeBuildDelegateFactory
+		fakeCredentialManager *credsfakes.FakeCredentialManager
+		logger                *lagertest.TestLogger
 
 		execEngine engine.Engine
 		teamID     = 17
@@ -35,6 +38,7 @@ var _ = Describe("ExecEngine", func() {
 	BeforeEach(func() {
 		fakeFactory = new(execfakes.FakeFactory)
 		fakeDelegateFactory = new(enginefakes.FakeBuildDelegateFactory)
+		fakeCredentialManager = new(credsfakes.FakeCredentialManager)
 		logger = lagertest.NewTestLogger("test")
 
 		fakeTeamDBFactory := new(dbfakes.FakeTeamDBFactory)
@@ -44,6 +48,7 @@ var _ = Describe("ExecEngine", func() {
 			fakeFactory,
 			fakeDelegateFactory,
 			fakeTeamDBFactory,
+			fakeCredentialManager,
 			"http://example.com",
 		)
 	})
@@ -54,6 +59,9 @@ var _ = Describe("ExecEngine", func() {
 			fakeInputDelegate     *execfakes.FakeGetDelegate
 			fakeExecutionDelegate *execfakes.FakeTaskDelegate
 			fakeOutputDelegate    *execfakes.FakePutDelegate
+			fakeRetryDelegate     *execfakes.FakeRetryDelegate
+
+			fakeSetPipelineDelegate *execfakes.FakeSetPipelineDelegate
 
 			dbBuild          *dbfakes.FakeBuild
 			expectedMetadata engine.StepMetadata
@@ -109,6 +117,12 @@ var _ = Describe("ExecEngine", func() {
 			fakeOutputDelegate = new(execfakes.FakePutDelegate)
 			fakeDelegate.OutputDelegateReturns(fakeOutputDelegate)
 
+			fakeRetryDelegate = new(execfakes.FakeRetryDelegate)
+			fakeDelegate.RetryDelegateReturns(fakeRetryDelegate)
+
+			fakeSetPipelineDelegate = new(execfakes.FakeSetPipelineDelegate)
+			fakeDelegate.SetPipelineDelegateReturns(fakeSetPipelineDelegate)
+
 			inputStepFactory = new(execfakes.FakeStepFactory)
 			inputStep = new(execfakes.FakeStep)
 			inputStep.ResultStub = successResult(true)
@@ -198,10 +212,11 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.PutCallCount()).To(Equal(2))
 
-					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _ := fakeFactory.PutArgsForCall(0)
+					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _ := fakeFactory.PutArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
+						JobName:      "some-job",
 						ResourceName: "",
 						Type:         db.ContainerTypePut,
 						StepName:     "some-put",
@@ -221,10 +236,11 @@ var _ = Describe("ExecEngine", func() {
 					Expect(resourceConfig.Source).To(Equal(atc.Source{"some": "source"}))
 					Expect(params).To(Equal(atc.Params{"some": "params"}))
 
-					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _ = fakeFactory.PutArgsForCall(1)
+					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _ = fakeFactory.PutArgsForCall(1)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
+						JobName:      "some-job",
 						ResourceName: "",
 						Type:         db.ContainerTypePut,
 						StepName:     "some-put-2",
@@ -253,10 +269,11 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.DependentGetCallCount()).To(Equal(2))
 
-					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _ := fakeFactory.DependentGetArgsForCall(0)
+					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _ := fakeFactory.DependentGetArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
+						JobName:      "some-job",
 						ResourceName: "",
 						Type:         db.ContainerTypeGet,
 						StepName:     "some-get",
@@ -281,10 +298,11 @@ var _ = Describe("ExecEngine", func() {
 					Expect(resourceConfig.Source).To(Equal(atc.Source{"some": "source"}))
 					Expect(params).To(Equal(atc.Params{"another": "params"}))
 
-					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _ = fakeFactory.DependentGetArgsForCall(1)
+					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _ = fakeFactory.DependentGetArgsForCall(1)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
+						JobName:      "some-job",
 						ResourceName: "",
 						Type:         db.ContainerTypeGet,
 						StepName:     "some-get-2",
@@ -373,10 +391,11 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs the first get correctly", func() {
-				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _ := fakeFactory.GetArgsForCall(0)
+				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _ := fakeFactory.GetArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(metadata).To(Equal(expectedMetadata))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
+					JobName:      "some-job",
 					ResourceName: "",
 					Type:         db.ContainerTypeGet,
 					StepName:     "some-get",
@@ -401,10 +420,11 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs the second get correctly", func() {
-				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _ := fakeFactory.GetArgsForCall(1)
+				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _ := fakeFactory.GetArgsForCall(1)
 				Expect(logger).NotTo(BeNil())
 				Expect(metadata).To(Equal(expectedMetadata))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
+					JobName:      "some-job",
 					ResourceName: "",
 					Type:         db.ContainerTypeGet,
 					StepName:     "some-get",
@@ -432,11 +452,23 @@ var _ = Describe("ExecEngine", func() {
 				Expect(*retryPlanTwo.Retry).To(HaveLen(2))
 			})
 
+			It("constructs a retry delegate for each retry", func() {
+				Expect(fakeDelegate.RetryDelegateCallCount()).To(Equal(2))
+
+				_, firstID := fakeDelegate.RetryDelegateArgsForCall(0)
+				_, secondID := fakeDelegate.RetryDelegateArgsForCall(1)
+				Expect([]event.OriginID{firstID, secondID}).To(ConsistOf(
+					event.OriginID(retryPlan.ID),
+					event.OriginID(retryPlanTwo.ID),
+				))
+			})
+
 			It("constructs nested steps correctly", func() {
-				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(sourceName).To(Equal(worker.ArtifactName("some-task")))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
+					JobName:      "some-job",
 					ResourceName: "",
 					Type:         db.ContainerTypeTask,
 					StepName:     "some-task",
@@ -455,10 +487,11 @@ var _ = Describe("ExecEngine", func() {
 				Expect(actualTeamID).To(Equal(teamID))
 				Expect(configSource).To(Equal(exec.ValidatingConfigSource{exec.FileConfigSource{"some-config-path"}}))
 
-				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
+				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(logger).NotTo(BeNil())
 				Expect(sourceName).To(Equal(worker.ArtifactName("some-task")))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
+					JobName:      "some-job",
 					ResourceName: "",
 					Type:         db.ContainerTypeTask,
 					StepName:     "some-task",
@@ -523,13 +556,13 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested steps correctly", func() {
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
+				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(2)
+				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(2)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(3)
+				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(3)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
 			})
 		})
@@ -560,10 +593,11 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.GetCallCount()).To(Equal(1))
 
-					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, version, _ := fakeFactory.GetArgsForCall(0)
+					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, version, _, _ := fakeFactory.GetArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
+						JobName:      "some-job",
 						ResourceName: "",
 						Type:         db.ContainerTypeGet,
 						StepName:     "some-input",
@@ -589,6 +623,37 @@ var _ = Describe("ExecEngine", func() {
 					_, _, planID := fakeDelegate.InputDelegateArgsForCall(0)
 					Expect(planID).To(Equal(event.OriginID(plan.ID)))
 				})
+
+				Context("when the plan contains credentials", func() {
+					BeforeEach(func() {
+						plan = planFactory.NewPlan(atc.GetPlan{
+							Name:     "some-input",
+							Resource: "some-input-resource",
+							Type:     "get",
+							Source:   atc.Source{"private_key": "((git-key))"},
+						})
+					})
+
+					It("leaves them for the step to resolve with the build's team and pipeline", func() {
+						build, err := execEngine.CreateBuild(logger, dbBuild, plan)
+						Expect(err).NotTo(HaveOccurred())
+
+						build.Resume(logger)
+						Expect(fakeFactory.GetCallCount()).To(Equal(1))
+
+						_, _, _, _, _, _, resourceConfig, _, _, _, _, _, variables := fakeFactory.GetArgsForCall(0)
+						Expect(resourceConfig.Source).To(Equal(atc.Source{"private_key": "((git-key))"}))
+						Expect(variables).To(Equal(creds.NewVariables(fakeCredentialManager, "some-team", "some-pipeline")))
+						Expect(fakeCredentialManager.GetCallCount()).To(BeZero())
+					})
+
+					It("does not resolve them in the build's metadata", func() {
+						build, err := execEngine.CreateBuild(logger, dbBuild, plan)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(build.Metadata()).To(ContainSubstring("((git-key))"))
+					})
+				})
 			})
 
 			Context("that contains tasks", func() {
@@ -628,10 +693,11 @@ var _ = Describe("ExecEngine", func() {
 						build.Resume(logger)
 						Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-						logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, actualInputMapping, actualOutputMapping, _, _ := fakeFactory.TaskArgsForCall(0)
+						logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, actualInputMapping, actualOutputMapping, _, _, _ := fakeFactory.TaskArgsForCall(0)
 						Expect(logger).NotTo(BeNil())
 						Expect(sourceName).To(Equal(worker.ArtifactName("some-task")))
 						Expect(workerMetadata).To(Equal(worker.Metadata{
+							JobName:      "some-job",
 							ResourceName: "",
 							Type:         db.ContainerTypeTask,
 							StepName:     "some-task",
@@ -670,11 +736,29 @@ var _ = Describe("ExecEngine", func() {
 							build.Resume(logger)
 							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-							_, _, _, _, _, _, _, _, _, _, _, _, actualImageArtifactName, _ := fakeFactory.TaskArgsForCall(0)
+							_, _, _, _, _, _, _, _, _, _, _, _, actualImageArtifactName, _, _ := fakeFactory.TaskArgsForCall(0)
 							Expect(actualImageArtifactName).To(Equal("some-image-artifact-name"))
 						})
 					})
 
+					Context("when the task is run across vars", func() {
+						BeforeEach(func() {
+							taskPlan.AcrossValues = map[string]interface{}{"go": "1.8"}
+						})
+
+						It("constructs the task with the values, to evaluate its config file with", func() {
+							var err error
+							build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+							Expect(err).NotTo(HaveOccurred())
+
+							build.Resume(logger)
+							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
+
+							_, _, _, _, _, _, _, _, _, _, _, _, _, _, variables := fakeFactory.TaskArgsForCall(0)
+							Expect(variables).To(Equal(creds.NewVariables(fakeCredentialManager, "some-team", "some-pipeline").WithValues(map[string]interface{}{"go": "1.8"})))
+						})
+					})
+
 					Context("when the plan contains params and config path", func() {
 						BeforeEach(func() {
 							taskPlan.Params = map[string]interface{}{
@@ -690,7 +774,7 @@ var _ = Describe("ExecEngine", func() {
 							build.Resume(logger)
 							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-							_, _, _, _, _, _, _, _, configSource, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+							_, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 							vcs, ok := configSource.(exec.ValidatingConfigSource)
 							Expect(ok).To(BeTrue())
 							_, ok = vcs.ConfigSource.(exec.MergedConfigSource)
@@ -715,7 +799,7 @@ var _ = Describe("ExecEngine", func() {
 							build.Resume(logger)
 							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-							_, _, _, _, _, _, _, _, configSource, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+							_, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 							vcs, ok := configSource.(exec.ValidatingConfigSource)
 							Expect(ok).To(BeTrue())
 							_, ok = vcs.ConfigSource.(exec.MergedConfigSource)
@@ -766,10 +850,11 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.PutCallCount()).To(Equal(1))
 
-					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _ := fakeFactory.PutArgsForCall(0)
+					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _ := fakeFactory.PutArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
+						JobName:      "some-job",
 						ResourceName: "",
 						Type:         db.ContainerTypePut,
 						StepName:     "some-put",
@@ -802,10 +887,11 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.DependentGetCallCount()).To(Equal(1))
 
-					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _ := fakeFactory.DependentGetArgsForCall(0)
+					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _ := fakeFactory.DependentGetArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
+						JobName:      "some-job",
 						ResourceName: "",
 						Type:         db.ContainerTypeGet,
 						StepName:     "some-get",
@@ -831,6 +917,43 @@ var _ = Describe("ExecEngine", func() {
 					Expect(planID).To(Equal(event.OriginID(dependentGetPlan.ID)))
 				})
 			})
+
+			Context("that contains a set_pipeline step", func() {
+				var plan atc.Plan
+
+				BeforeEach(func() {
+					setPipelineStepFactory := new(execfakes.FakeStepFactory)
+					setPipelineStep := new(execfakes.FakeStep)
+					setPipelineStep.ResultStub = successResult(true)
+					setPipelineStepFactory.UsingReturns(setPipelineStep)
+					fakeFactory.SetPipelineReturns(setPipelineStepFactory)
+
+					plan = planFactory.NewPlan(atc.SetPipelinePlan{
+						Name: "some-other-pipeline",
+						File: "some-input/pipeline.yml",
+					})
+				})
+
+				It("constructs the set_pipeline step correctly", func() {
+					var err error
+					build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+					Expect(fakeFactory.SetPipelineCallCount()).To(Equal(1))
+
+					logger, delegate, actualTeamID, pipelineName, configPath := fakeFactory.SetPipelineArgsForCall(0)
+					Expect(logger).NotTo(BeNil())
+					Expect(delegate).To(Equal(fakeSetPipelineDelegate))
+
+					_, delegatePlan, originID := fakeDelegate.SetPipelineDelegateArgsForCall(0)
+					Expect(delegatePlan).To(Equal(*plan.SetPipeline))
+					Expect(originID).To(Equal(event.OriginID(plan.ID)))
+					Expect(actualTeamID).To(Equal(teamID))
+					Expect(pipelineName).To(Equal("some-other-pipeline"))
+					Expect(configPath).To(Equal("some-input/pipeline.yml"))
+				})
+			})
 		})
 	})
 
@@ -941,7 +1064,7 @@ var _ = Describe("ExecEngine", func() {
 
 				foundBuild.Resume(logger)
 				Expect(fakeFactory.GetCallCount()).To(Equal(1))
-				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _ := fakeFactory.GetArgsForCall(0)
+				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _ := fakeFactory.GetArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(metadata).To(Equal(engine.StepMetadata{
 					BuildID:      42,
@@ -952,6 +1075,7 @@ var _ = Describe("ExecEngine", func() {
 					ExternalURL:  "http://example.com",
 				}))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
+					JobName:      "some-job",
 					ResourceName: "",
 					Type:         db.ContainerTypeGet,
 					StepName:     "some-get",
@@ -40,6 +40,7 @@ var _ = Describe("Exec Engine with Try", func() {
 			fakeFactory,
 			fakeDelegateFactory,
 			fakeTeamDBFactory,
+			nil,
 			"http://example.com",
 		)
 
@@ -122,11 +123,12 @@ var _ = Describe("Exec Engine with Try", func() {
 
 			It("constructs the step correctly", func() {
 				Expect(fakeFactory.GetCallCount()).To(Equal(1))
-				logger, metadata, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _ := fakeFactory.GetArgsForCall(0)
+				logger, metadata, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _ := fakeFactory.GetArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(metadata).To(Equal(expectedMetadata))
 				Expect(sourceName).To(Equal(worker.ArtifactName("some-input")))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
+					JobName:    "some-job",
 					Type:       db.ContainerTypeGet,
 					StepName:   "some-input",
 					PipelineID: 42,
@@ -155,3 +155,48 @@ type InitializePut struct {
 
 func (InitializePut) EventType() atc.EventType  { return EventTypeInitializePut }
 func (InitializePut) Version() atc.EventVersion { return "1.0" }
+
+type InitializeSetPipeline struct {
+	Origin Origin `json:"origin"`
+}
+
+func (InitializeSetPipeline) EventType() atc.EventType  { return EventTypeInitializeSetPipeline }
+func (InitializeSetPipeline) Version() atc.EventVersion { return "1.0" }
+
+type FinishSetPipeline struct {
+	Origin     Origin `json:"origin"`
+	Time       int64  `json:"time"`
+	ExitStatus int    `json:"exit_status"`
+}
+
+func (FinishSetPipeline) EventType() atc.EventType  { return EventTypeFinishSetPipeline }
+func (FinishSetPipeline) Version() atc.EventVersion { return "1.0" }
+
+type FinishAttempt struct {
+	Origin   Origin          `json:"origin"`
+	Time     int64           `json:"time"`
+	Attempt  int             `json:"attempt"`
+	Status   atc.BuildStatus `json:"status"`
+	Retrying bool            `json:"retrying"`
+	Delay    string          `json:"delay,omitempty"`
+}
+
+func (FinishAttempt) EventType() atc.EventType  { return EventTypeFinishAttempt }
+func (FinishAttempt) Version() atc.EventVersion { return "1.0" }
+
+type PauseBreakpoint struct {
+	Origin Origin `json:"origin"`
+	Time   int64  `json:"time"`
+	When   string `json:"when"`
+}
+
+func (PauseBreakpoint) EventType() atc.EventType  { return EventTypePauseBreakpoint }
+func (PauseBreakpoint) Version() atc.EventVersion { return "1.0" }
+
+type ResumeBreakpoint struct {
+	Origin Origin `json:"origin"`
+	Time   int64  `json:"time"`
+}
+
+func (ResumeBreakpoint) EventType() atc.EventType  { return EventTypeResumeBreakpoint }
+func (ResumeBreakpoint) Version() atc.EventVersion { return "1.0" }
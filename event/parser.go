@@ -40,6 +40,11 @@ func init() {
 	registerEvent(FinishGet{})
 	registerEvent(InitializePut{})
 	registerEvent(FinishPut{})
+	registerEvent(InitializeSetPipeline{})
+	registerEvent(FinishSetPipeline{})
+	registerEvent(FinishAttempt{})
+	registerEvent(PauseBreakpoint{})
+	registerEvent(ResumeBreakpoint{})
 	registerEvent(Status{})
 	registerEvent(Log{})
 	registerEvent(Error{})
@@ -30,6 +30,21 @@ const (
 	// finished putting something
 	EventTypeFinishPut atc.EventType = "finish-put"
 
+	// set_pipeline step initializing
+	EventTypeInitializeSetPipeline atc.EventType = "initialize-set-pipeline"
+
+	// finished setting a pipeline
+	EventTypeFinishSetPipeline atc.EventType = "finish-set-pipeline"
+
+	// build paused at a breakpoint
+	EventTypePauseBreakpoint atc.EventType = "pause-breakpoint"
+
+	// build resumed from a breakpoint
+	EventTypeResumeBreakpoint atc.EventType = "resume-breakpoint"
+
+	// attempt of a retried step finished
+	EventTypeFinishAttempt atc.EventType = "finish-attempt"
+
 	// error occurred
 	EventTypeError atc.EventType = "error"
 )
@@ -0,0 +1,93 @@
+package exec
+
+import (
+	"os"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/worker"
+)
+
+// BreakpointStep pauses the build before running its step, or after its step
+// fails or errors, until the build is resumed. While paused the build keeps
+// running, so the step's containers remain available for hijacking.
+type BreakpointStep struct {
+	step     StepFactory
+	runStep  Step
+	when     string
+	delegate BreakpointDelegate
+}
+
+// Breakpoint constructs a BreakpointStep factory.
+func Breakpoint(
+	step StepFactory,
+	when string,
+	delegate BreakpointDelegate,
+) BreakpointStep {
+	return BreakpointStep{
+		step:     step,
+		when:     when,
+		delegate: delegate,
+	}
+}
+
+// Using constructs a *BreakpointStep.
+func (bs BreakpointStep) Using(prev Step, repo *worker.ArtifactRepository) Step {
+	bs.runStep = bs.step.Using(prev, repo)
+
+	return &bs
+}
+
+// Run pauses before invoking the nested step if the breakpoint is "before",
+// or after the nested step if it is "on_failure" and the step did not
+// succeed.
+//
+// If the BreakpointStep is interrupted while paused, ErrInterrupted is
+// returned. Otherwise the result of the nested step's Run is returned.
+func (bs *BreakpointStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+
+	if bs.when == atc.BreakpointBefore {
+		err := bs.pause(signals)
+		if err != nil {
+			return err
+		}
+	}
+
+	runErr := bs.runStep.Run(signals, make(chan struct{}))
+	if runErr == ErrInterrupted {
+		return runErr
+	}
+
+	if bs.when == atc.BreakpointOnFailure {
+		var succeeded Success
+		if runErr != nil || !bs.runStep.Result(&succeeded) || !bool(succeeded) {
+			err := bs.pause(signals)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return runErr
+}
+
+// Result delegates to the nested step.
+func (bs *BreakpointStep) Result(x interface{}) bool {
+	return bs.runStep.Result(x)
+}
+
+func (bs *BreakpointStep) pause(signals <-chan os.Signal) error {
+	err := bs.delegate.Paused(bs.when)
+	if err != nil {
+		return err
+	}
+
+	err = bs.delegate.WaitForResume(signals)
+	if err != nil {
+		return err
+	}
+
+	bs.delegate.Resumed()
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/exec"
+	"github.com/tedsuo/ifrit"
+
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Breakpoint Step", func() {
+	var (
+		fakeStepFactory *execfakes.FakeStepFactory
+		runStep         *execfakes.FakeStep
+
+		fakeDelegate *execfakes.FakeBreakpointDelegate
+
+		when string
+
+		step Step
+	)
+
+	BeforeEach(func() {
+		fakeStepFactory = new(execfakes.FakeStepFactory)
+		runStep = new(execfakes.FakeStep)
+		fakeStepFactory.UsingReturns(runStep)
+
+		fakeDelegate = new(execfakes.FakeBreakpointDelegate)
+	})
+
+	JustBeforeEach(func() {
+		step = Breakpoint(fakeStepFactory, when, fakeDelegate).Using(nil, nil)
+	})
+
+	Context("when the breakpoint is before the step", func() {
+		BeforeEach(func() {
+			when = atc.BreakpointBefore
+			runStep.ResultStub = successResult(true)
+		})
+
+		It("pauses before running the step", func() {
+			fakeDelegate.WaitForResumeStub = func(<-chan os.Signal) error {
+				defer GinkgoRecover()
+				Expect(runStep.RunCallCount()).To(BeZero())
+				return nil
+			}
+
+			process := ifrit.Invoke(step)
+			Expect(<-process.Wait()).To(Succeed())
+
+			Expect(fakeDelegate.PausedCallCount()).To(Equal(1))
+			Expect(fakeDelegate.PausedArgsForCall(0)).To(Equal(atc.BreakpointBefore))
+			Expect(fakeDelegate.WaitForResumeCallCount()).To(Equal(1))
+			Expect(fakeDelegate.ResumedCallCount()).To(Equal(1))
+
+			Expect(runStep.RunCallCount()).To(Equal(1))
+		})
+
+		Context("when interrupted while paused", func() {
+			BeforeEach(func() {
+				fakeDelegate.WaitForResumeStub = func(signals <-chan os.Signal) error {
+					<-signals
+					return ErrInterrupted
+				}
+			})
+
+			It("returns ErrInterrupted without running the step", func() {
+				process := ifrit.Invoke(step)
+				process.Signal(os.Interrupt)
+
+				Expect(<-process.Wait()).To(Equal(ErrInterrupted))
+				Expect(runStep.RunCallCount()).To(BeZero())
+				Expect(fakeDelegate.ResumedCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when pausing fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeDelegate.PausedReturns(disaster)
+			})
+
+			It("returns the error without running the step", func() {
+				process := ifrit.Invoke(step)
+				Expect(<-process.Wait()).To(Equal(disaster))
+
+				Expect(fakeDelegate.WaitForResumeCallCount()).To(BeZero())
+				Expect(runStep.RunCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when the breakpoint is on failure", func() {
+		BeforeEach(func() {
+			when = atc.BreakpointOnFailure
+		})
+
+		Context("when the step succeeds", func() {
+			BeforeEach(func() {
+				runStep.ResultStub = successResult(true)
+			})
+
+			It("does not pause", func() {
+				process := ifrit.Invoke(step)
+				Expect(<-process.Wait()).To(Succeed())
+
+				Expect(fakeDelegate.PausedCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the step fails", func() {
+			BeforeEach(func() {
+				runStep.ResultStub = successResult(false)
+			})
+
+			It("pauses after running the step", func() {
+				process := ifrit.Invoke(step)
+				Expect(<-process.Wait()).To(Succeed())
+
+				Expect(runStep.RunCallCount()).To(Equal(1))
+				Expect(fakeDelegate.PausedCallCount()).To(Equal(1))
+				Expect(fakeDelegate.PausedArgsForCall(0)).To(Equal(atc.BreakpointOnFailure))
+				Expect(fakeDelegate.ResumedCallCount()).To(Equal(1))
+			})
+
+			It("delegates Result to the step", func() {
+				process := ifrit.Invoke(step)
+				<-process.Wait()
+
+				var succeeded Success
+				Expect(step.Result(&succeeded)).To(BeTrue())
+				Expect(succeeded).To(Equal(Success(false)))
+			})
+		})
+
+		Context("when the step errors", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				runStep.RunReturns(disaster)
+			})
+
+			It("pauses and then returns the error", func() {
+				process := ifrit.Invoke(step)
+				Expect(<-process.Wait()).To(Equal(disaster))
+
+				Expect(fakeDelegate.PausedCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the step is interrupted", func() {
+			BeforeEach(func() {
+				runStep.RunReturns(ErrInterrupted)
+			})
+
+			It("does not pause", func() {
+				process := ifrit.Invoke(step)
+				Expect(<-process.Wait()).To(Equal(ErrInterrupted))
+
+				Expect(fakeDelegate.PausedCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -3,6 +3,7 @@ package exec
 import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
@@ -24,6 +25,7 @@ type DependentGetStep struct {
 	resourceFetcher        resource.Fetcher
 	resourceTypes          atc.VersionedResourceTypes
 	dbResourceCacheFactory dbng.ResourceCacheFactory
+	variables              creds.Variables
 }
 
 func newDependentGetStep(
@@ -39,6 +41,7 @@ func newDependentGetStep(
 	resourceFetcher resource.Fetcher,
 	resourceTypes atc.VersionedResourceTypes,
 	dbResourceCacheFactory dbng.ResourceCacheFactory,
+	variables creds.Variables,
 ) DependentGetStep {
 	return DependentGetStep{
 		logger:                 logger,
@@ -53,6 +56,7 @@ func newDependentGetStep(
 		resourceFetcher:        resourceFetcher,
 		resourceTypes:          resourceTypes,
 		dbResourceCacheFactory: dbResourceCacheFactory,
+		variables:              variables,
 	}
 }
 
@@ -68,15 +72,6 @@ func (step DependentGetStep) Using(prev Step, repo *worker.ArtifactRepository) S
 		step.resourceConfig,
 		info.Version,
 		step.params,
-		resource.NewResourceInstance(
-			resource.ResourceType(step.resourceConfig.Type),
-			info.Version,
-			step.resourceConfig.Source,
-			step.params,
-			dbng.ForBuild{BuildID: step.session.ID.BuildID},
-			step.resourceTypes,
-			step.dbResourceCacheFactory,
-		),
 		step.stepMetadata,
 		step.session,
 		step.tags,
@@ -84,5 +79,7 @@ func (step DependentGetStep) Using(prev Step, repo *worker.ArtifactRepository) S
 		step.delegate,
 		step.resourceFetcher,
 		step.resourceTypes,
+		step.dbResourceCacheFactory,
+		step.variables,
 	).Using(prev, repo)
 }
@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/dbng/dbngfakes"
@@ -76,7 +77,7 @@ var _ = Describe("DependentGet", func() {
 		fakeResourceFactory := new(resourcefakes.FakeResourceFactory)
 		fakeDBResourceCacheFactory = new(dbngfakes.FakeResourceCacheFactory)
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory)
+		factory = NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory, nil, atc.ContainerLimits{})
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
@@ -142,6 +143,7 @@ var _ = Describe("DependentGet", func() {
 			teamID,
 			params,
 			resourceTypes,
+			creds.NewVariables(nil, "some-team", "some-pipeline"),
 		).Using(inStep, repo)
 
 		process = ifrit.Invoke(step)
@@ -0,0 +1,177 @@
+// This file was generated by counterfeiter
+package execfakes
+
+import (
+	"os"
+	"sync"
+
+	"github.com/concourse/atc/exec"
+)
+
+type FakeBreakpointDelegate struct {
+	PausedStub        func(when string) error
+	pausedMutex       sync.RWMutex
+	pausedArgsForCall []struct {
+		when string
+	}
+	pausedReturns struct {
+		result1 error
+	}
+	pausedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WaitForResumeStub        func(signals <-chan os.Signal) error
+	waitForResumeMutex       sync.RWMutex
+	waitForResumeArgsForCall []struct {
+		signals <-chan os.Signal
+	}
+	waitForResumeReturns struct {
+		result1 error
+	}
+	waitForResumeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ResumedStub        func()
+	resumedMutex       sync.RWMutex
+	resumedArgsForCall []struct{}
+	invocations        map[string][][]interface{}
+	invocationsMutex   sync.RWMutex
+}
+
+func (fake *FakeBreakpointDelegate) Paused(when string) error {
+	fake.pausedMutex.Lock()
+	ret, specificReturn := fake.pausedReturnsOnCall[len(fake.pausedArgsForCall)]
+	fake.pausedArgsForCall = append(fake.pausedArgsForCall, struct {
+		when string
+	}{when})
+	fake.recordInvocation("Paused", []interface{}{when})
+	fake.pausedMutex.Unlock()
+	if fake.PausedStub != nil {
+		return fake.PausedStub(when)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.pausedReturns.result1
+}
+
+func (fake *FakeBreakpointDelegate) PausedCallCount() int {
+	fake.pausedMutex.RLock()
+	defer fake.pausedMutex.RUnlock()
+	return len(fake.pausedArgsForCall)
+}
+
+func (fake *FakeBreakpointDelegate) PausedArgsForCall(i int) string {
+	fake.pausedMutex.RLock()
+	defer fake.pausedMutex.RUnlock()
+	return fake.pausedArgsForCall[i].when
+}
+
+func (fake *FakeBreakpointDelegate) PausedReturns(result1 error) {
+	fake.PausedStub = nil
+	fake.pausedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBreakpointDelegate) PausedReturnsOnCall(i int, result1 error) {
+	fake.PausedStub = nil
+	if fake.pausedReturnsOnCall == nil {
+		fake.pausedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pausedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBreakpointDelegate) WaitForResume(signals <-chan os.Signal) error {
+	fake.waitForResumeMutex.Lock()
+	ret, specificReturn := fake.waitForResumeReturnsOnCall[len(fake.waitForResumeArgsForCall)]
+	fake.waitForResumeArgsForCall = append(fake.waitForResumeArgsForCall, struct {
+		signals <-chan os.Signal
+	}{signals})
+	fake.recordInvocation("WaitForResume", []interface{}{signals})
+	fake.waitForResumeMutex.Unlock()
+	if fake.WaitForResumeStub != nil {
+		return fake.WaitForResumeStub(signals)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.waitForResumeReturns.result1
+}
+
+func (fake *FakeBreakpointDelegate) WaitForResumeCallCount() int {
+	fake.waitForResumeMutex.RLock()
+	defer fake.waitForResumeMutex.RUnlock()
+	return len(fake.waitForResumeArgsForCall)
+}
+
+func (fake *FakeBreakpointDelegate) WaitForResumeArgsForCall(i int) <-chan os.Signal {
+	fake.waitForResumeMutex.RLock()
+	defer fake.waitForResumeMutex.RUnlock()
+	return fake.waitForResumeArgsForCall[i].signals
+}
+
+func (fake *FakeBreakpointDelegate) WaitForResumeReturns(result1 error) {
+	fake.WaitForResumeStub = nil
+	fake.waitForResumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBreakpointDelegate) WaitForResumeReturnsOnCall(i int, result1 error) {
+	fake.WaitForResumeStub = nil
+	if fake.waitForResumeReturnsOnCall == nil {
+		fake.waitForResumeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.waitForResumeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBreakpointDelegate) Resumed() {
+	fake.resumedMutex.Lock()
+	fake.resumedArgsForCall = append(fake.resumedArgsForCall, struct{}{})
+	fake.recordInvocation("Resumed", []interface{}{})
+	fake.resumedMutex.Unlock()
+	if fake.ResumedStub != nil {
+		fake.ResumedStub()
+	}
+}
+
+func (fake *FakeBreakpointDelegate) ResumedCallCount() int {
+	fake.resumedMutex.RLock()
+	defer fake.resumedMutex.RUnlock()
+	return len(fake.resumedArgsForCall)
+}
+
+func (fake *FakeBreakpointDelegate) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.pausedMutex.RLock()
+	defer fake.pausedMutex.RUnlock()
+	fake.waitForResumeMutex.RLock()
+	defer fake.waitForResumeMutex.RUnlock()
+	fake.resumedMutex.RLock()
+	defer fake.resumedMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeBreakpointDelegate) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.BreakpointDelegate = new(FakeBreakpointDelegate)
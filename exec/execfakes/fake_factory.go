@@ -7,12 +7,13 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/worker"
 )
 
 type FakeFactory struct {
-	GetStub        func(lager.Logger, exec.StepMetadata, worker.ArtifactName, worker.Identifier, worker.Metadata, exec.GetDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.Version, atc.VersionedResourceTypes) exec.StepFactory
+	GetStub        func(lager.Logger, exec.StepMetadata, worker.ArtifactName, worker.Identifier, worker.Metadata, exec.GetDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.Version, atc.VersionedResourceTypes, creds.Variables) exec.StepFactory
 	getMutex       sync.RWMutex
 	getArgsForCall []struct {
 		arg1  lager.Logger
@@ -27,6 +28,7 @@ type FakeFactory struct {
 		arg10 atc.Params
 		arg11 atc.Version
 		arg12 atc.VersionedResourceTypes
+		arg13 creds.Variables
 	}
 	getReturns struct {
 		result1 exec.StepFactory
@@ -34,7 +36,7 @@ type FakeFactory struct {
 	getReturnsOnCall map[int]struct {
 		result1 exec.StepFactory
 	}
-	PutStub        func(lager.Logger, exec.StepMetadata, worker.Identifier, worker.Metadata, exec.PutDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.VersionedResourceTypes) exec.StepFactory
+	PutStub        func(lager.Logger, exec.StepMetadata, worker.Identifier, worker.Metadata, exec.PutDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.VersionedResourceTypes, creds.Variables) exec.StepFactory
 	putMutex       sync.RWMutex
 	putArgsForCall []struct {
 		arg1  lager.Logger
@@ -47,6 +49,7 @@ type FakeFactory struct {
 		arg8  int
 		arg9  atc.Params
 		arg10 atc.VersionedResourceTypes
+		arg11 creds.Variables
 	}
 	putReturns struct {
 		result1 exec.StepFactory
@@ -54,7 +57,7 @@ type FakeFactory struct {
 	putReturnsOnCall map[int]struct {
 		result1 exec.StepFactory
 	}
-	DependentGetStub        func(lager.Logger, exec.StepMetadata, worker.ArtifactName, worker.Identifier, worker.Metadata, exec.GetDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.VersionedResourceTypes) exec.StepFactory
+	DependentGetStub        func(lager.Logger, exec.StepMetadata, worker.ArtifactName, worker.Identifier, worker.Metadata, exec.GetDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.VersionedResourceTypes, creds.Variables) exec.StepFactory
 	dependentGetMutex       sync.RWMutex
 	dependentGetArgsForCall []struct {
 		arg1  lager.Logger
@@ -68,6 +71,7 @@ type FakeFactory struct {
 		arg9  int
 		arg10 atc.Params
 		arg11 atc.VersionedResourceTypes
+		arg12 creds.Variables
 	}
 	dependentGetReturns struct {
 		result1 exec.StepFactory
@@ -75,7 +79,7 @@ type FakeFactory struct {
 	dependentGetReturnsOnCall map[int]struct {
 		result1 exec.StepFactory
 	}
-	TaskStub        func(lager.Logger, worker.ArtifactName, worker.Identifier, worker.Metadata, exec.TaskDelegate, exec.Privileged, atc.Tags, int, exec.TaskConfigSource, atc.VersionedResourceTypes, map[string]string, map[string]string, string, clock.Clock) exec.StepFactory
+	TaskStub        func(lager.Logger, worker.ArtifactName, worker.Identifier, worker.Metadata, exec.TaskDelegate, exec.Privileged, atc.Tags, int, exec.TaskConfigSource, atc.VersionedResourceTypes, map[string]string, map[string]string, string, clock.Clock, creds.Variables) exec.StepFactory
 	taskMutex       sync.RWMutex
 	taskArgsForCall []struct {
 		arg1  lager.Logger
@@ -92,6 +96,7 @@ type FakeFactory struct {
 		arg12 map[string]string
 		arg13 string
 		arg14 clock.Clock
+		arg15 creds.Variables
 	}
 	taskReturns struct {
 		result1 exec.StepFactory
@@ -99,11 +104,26 @@ type FakeFactory struct {
 	taskReturnsOnCall map[int]struct {
 		result1 exec.StepFactory
 	}
+	SetPipelineStub        func(lager.Logger, exec.SetPipelineDelegate, int, string, string) exec.StepFactory
+	setPipelineMutex       sync.RWMutex
+	setPipelineArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 exec.SetPipelineDelegate
+		arg3 int
+		arg4 string
+		arg5 string
+	}
+	setPipelineReturns struct {
+		result1 exec.StepFactory
+	}
+	setPipelineReturnsOnCall map[int]struct {
+		result1 exec.StepFactory
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeFactory) Get(arg1 lager.Logger, arg2 exec.StepMetadata, arg3 worker.ArtifactName, arg4 worker.Identifier, arg5 worker.Metadata, arg6 exec.GetDelegate, arg7 atc.ResourceConfig, arg8 atc.Tags, arg9 int, arg10 atc.Params, arg11 atc.Version, arg12 atc.VersionedResourceTypes) exec.StepFactory {
+func (fake *FakeFactory) Get(arg1 lager.Logger, arg2 exec.StepMetadata, arg3 worker.ArtifactName, arg4 worker.Identifier, arg5 worker.Metadata, arg6 exec.GetDelegate, arg7 atc.ResourceConfig, arg8 atc.Tags, arg9 int, arg10 atc.Params, arg11 atc.Version, arg12 atc.VersionedResourceTypes, arg13 creds.Variables) exec.StepFactory {
 	fake.getMutex.Lock()
 	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
 	fake.getArgsForCall = append(fake.getArgsForCall, struct {
@@ -119,11 +139,12 @@ func (fake *FakeFactory) Get(arg1 lager.Logger, arg2 exec.StepMetadata, arg3 wor
 		arg10 atc.Params
 		arg11 atc.Version
 		arg12 atc.VersionedResourceTypes
-	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12})
-	fake.recordInvocation("Get", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12})
+		arg13 creds.Variables
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13})
+	fake.recordInvocation("Get", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13})
 	fake.getMutex.Unlock()
 	if fake.GetStub != nil {
-		return fake.GetStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12)
+		return fake.GetStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13)
 	}
 	if specificReturn {
 		return ret.result1
@@ -137,10 +158,10 @@ func (fake *FakeFactory) GetCallCount() int {
 	return len(fake.getArgsForCall)
 }
 
-func (fake *FakeFactory) GetArgsForCall(i int) (lager.Logger, exec.StepMetadata, worker.ArtifactName, worker.Identifier, worker.Metadata, exec.GetDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.Version, atc.VersionedResourceTypes) {
+func (fake *FakeFactory) GetArgsForCall(i int) (lager.Logger, exec.StepMetadata, worker.ArtifactName, worker.Identifier, worker.Metadata, exec.GetDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.Version, atc.VersionedResourceTypes, creds.Variables) {
 	fake.getMutex.RLock()
 	defer fake.getMutex.RUnlock()
-	return fake.getArgsForCall[i].arg1, fake.getArgsForCall[i].arg2, fake.getArgsForCall[i].arg3, fake.getArgsForCall[i].arg4, fake.getArgsForCall[i].arg5, fake.getArgsForCall[i].arg6, fake.getArgsForCall[i].arg7, fake.getArgsForCall[i].arg8, fake.getArgsForCall[i].arg9, fake.getArgsForCall[i].arg10, fake.getArgsForCall[i].arg11, fake.getArgsForCall[i].arg12
+	return fake.getArgsForCall[i].arg1, fake.getArgsForCall[i].arg2, fake.getArgsForCall[i].arg3, fake.getArgsForCall[i].arg4, fake.getArgsForCall[i].arg5, fake.getArgsForCall[i].arg6, fake.getArgsForCall[i].arg7, fake.getArgsForCall[i].arg8, fake.getArgsForCall[i].arg9, fake.getArgsForCall[i].arg10, fake.getArgsForCall[i].arg11, fake.getArgsForCall[i].arg12, fake.getArgsForCall[i].arg13
 }
 
 func (fake *FakeFactory) GetReturns(result1 exec.StepFactory) {
@@ -162,7 +183,7 @@ func (fake *FakeFactory) GetReturnsOnCall(i int, result1 exec.StepFactory) {
 	}{result1}
 }
 
-func (fake *FakeFactory) Put(arg1 lager.Logger, arg2 exec.StepMetadata, arg3 worker.Identifier, arg4 worker.Metadata, arg5 exec.PutDelegate, arg6 atc.ResourceConfig, arg7 atc.Tags, arg8 int, arg9 atc.Params, arg10 atc.VersionedResourceTypes) exec.StepFactory {
+func (fake *FakeFactory) Put(arg1 lager.Logger, arg2 exec.StepMetadata, arg3 worker.Identifier, arg4 worker.Metadata, arg5 exec.PutDelegate, arg6 atc.ResourceConfig, arg7 atc.Tags, arg8 int, arg9 atc.Params, arg10 atc.VersionedResourceTypes, arg11 creds.Variables) exec.StepFactory {
 	fake.putMutex.Lock()
 	ret, specificReturn := fake.putReturnsOnCall[len(fake.putArgsForCall)]
 	fake.putArgsForCall = append(fake.putArgsForCall, struct {
@@ -176,11 +197,12 @@ func (fake *FakeFactory) Put(arg1 lager.Logger, arg2 exec.StepMetadata, arg3 wor
 		arg8  int
 		arg9  atc.Params
 		arg10 atc.VersionedResourceTypes
-	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10})
-	fake.recordInvocation("Put", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10})
+		arg11 creds.Variables
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11})
+	fake.recordInvocation("Put", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11})
 	fake.putMutex.Unlock()
 	if fake.PutStub != nil {
-		return fake.PutStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10)
+		return fake.PutStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11)
 	}
 	if specificReturn {
 		return ret.result1
@@ -194,10 +216,10 @@ func (fake *FakeFactory) PutCallCount() int {
 	return len(fake.putArgsForCall)
 }
 
-func (fake *FakeFactory) PutArgsForCall(i int) (lager.Logger, exec.StepMetadata, worker.Identifier, worker.Metadata, exec.PutDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.VersionedResourceTypes) {
+func (fake *FakeFactory) PutArgsForCall(i int) (lager.Logger, exec.StepMetadata, worker.Identifier, worker.Metadata, exec.PutDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.VersionedResourceTypes, creds.Variables) {
 	fake.putMutex.RLock()
 	defer fake.putMutex.RUnlock()
-	return fake.putArgsForCall[i].arg1, fake.putArgsForCall[i].arg2, fake.putArgsForCall[i].arg3, fake.putArgsForCall[i].arg4, fake.putArgsForCall[i].arg5, fake.putArgsForCall[i].arg6, fake.putArgsForCall[i].arg7, fake.putArgsForCall[i].arg8, fake.putArgsForCall[i].arg9, fake.putArgsForCall[i].arg10
+	return fake.putArgsForCall[i].arg1, fake.putArgsForCall[i].arg2, fake.putArgsForCall[i].arg3, fake.putArgsForCall[i].arg4, fake.putArgsForCall[i].arg5, fake.putArgsForCall[i].arg6, fake.putArgsForCall[i].arg7, fake.putArgsForCall[i].arg8, fake.putArgsForCall[i].arg9, fake.putArgsForCall[i].arg10, fake.putArgsForCall[i].arg11
 }
 
 func (fake *FakeFactory) PutReturns(result1 exec.StepFactory) {
@@ -219,7 +241,7 @@ func (fake *FakeFactory) PutReturnsOnCall(i int, result1 exec.StepFactory) {
 	}{result1}
 }
 
-func (fake *FakeFactory) DependentGet(arg1 lager.Logger, arg2 exec.StepMetadata, arg3 worker.ArtifactName, arg4 worker.Identifier, arg5 worker.Metadata, arg6 exec.GetDelegate, arg7 atc.ResourceConfig, arg8 atc.Tags, arg9 int, arg10 atc.Params, arg11 atc.VersionedResourceTypes) exec.StepFactory {
+func (fake *FakeFactory) DependentGet(arg1 lager.Logger, arg2 exec.StepMetadata, arg3 worker.ArtifactName, arg4 worker.Identifier, arg5 worker.Metadata, arg6 exec.GetDelegate, arg7 atc.ResourceConfig, arg8 atc.Tags, arg9 int, arg10 atc.Params, arg11 atc.VersionedResourceTypes, arg12 creds.Variables) exec.StepFactory {
 	fake.dependentGetMutex.Lock()
 	ret, specificReturn := fake.dependentGetReturnsOnCall[len(fake.dependentGetArgsForCall)]
 	fake.dependentGetArgsForCall = append(fake.dependentGetArgsForCall, struct {
@@ -234,11 +256,12 @@ func (fake *FakeFactory) DependentGet(arg1 lager.Logger, arg2 exec.StepMetadata,
 		arg9  int
 		arg10 atc.Params
 		arg11 atc.VersionedResourceTypes
-	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11})
-	fake.recordInvocation("DependentGet", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11})
+		arg12 creds.Variables
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12})
+	fake.recordInvocation("DependentGet", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12})
 	fake.dependentGetMutex.Unlock()
 	if fake.DependentGetStub != nil {
-		return fake.DependentGetStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11)
+		return fake.DependentGetStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12)
 	}
 	if specificReturn {
 		return ret.result1
@@ -252,10 +275,10 @@ func (fake *FakeFactory) DependentGetCallCount() int {
 	return len(fake.dependentGetArgsForCall)
 }
 
-func (fake *FakeFactory) DependentGetArgsForCall(i int) (lager.Logger, exec.StepMetadata, worker.ArtifactName, worker.Identifier, worker.Metadata, exec.GetDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.VersionedResourceTypes) {
+func (fake *FakeFactory) DependentGetArgsForCall(i int) (lager.Logger, exec.StepMetadata, worker.ArtifactName, worker.Identifier, worker.Metadata, exec.GetDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.VersionedResourceTypes, creds.Variables) {
 	fake.dependentGetMutex.RLock()
 	defer fake.dependentGetMutex.RUnlock()
-	return fake.dependentGetArgsForCall[i].arg1, fake.dependentGetArgsForCall[i].arg2, fake.dependentGetArgsForCall[i].arg3, fake.dependentGetArgsForCall[i].arg4, fake.dependentGetArgsForCall[i].arg5, fake.dependentGetArgsForCall[i].arg6, fake.dependentGetArgsForCall[i].arg7, fake.dependentGetArgsForCall[i].arg8, fake.dependentGetArgsForCall[i].arg9, fake.dependentGetArgsForCall[i].arg10, fake.dependentGetArgsForCall[i].arg11
+	return fake.dependentGetArgsForCall[i].arg1, fake.dependentGetArgsForCall[i].arg2, fake.dependentGetArgsForCall[i].arg3, fake.dependentGetArgsForCall[i].arg4, fake.dependentGetArgsForCall[i].arg5, fake.dependentGetArgsForCall[i].arg6, fake.dependentGetArgsForCall[i].arg7, fake.dependentGetArgsForCall[i].arg8, fake.dependentGetArgsForCall[i].arg9, fake.dependentGetArgsForCall[i].arg10, fake.dependentGetArgsForCall[i].arg11, fake.dependentGetArgsForCall[i].arg12
 }
 
 func (fake *FakeFactory) DependentGetReturns(result1 exec.StepFactory) {
@@ -277,7 +300,7 @@ func (fake *FakeFactory) DependentGetReturnsOnCall(i int, result1 exec.StepFacto
 	}{result1}
 }
 
-func (fake *FakeFactory) Task(arg1 lager.Logger, arg2 worker.ArtifactName, arg3 worker.Identifier, arg4 worker.Metadata, arg5 exec.TaskDelegate, arg6 exec.Privileged, arg7 atc.Tags, arg8 int, arg9 exec.TaskConfigSource, arg10 atc.VersionedResourceTypes, arg11 map[string]string, arg12 map[string]string, arg13 string, arg14 clock.Clock) exec.StepFactory {
+func (fake *FakeFactory) Task(arg1 lager.Logger, arg2 worker.ArtifactName, arg3 worker.Identifier, arg4 worker.Metadata, arg5 exec.TaskDelegate, arg6 exec.Privileged, arg7 atc.Tags, arg8 int, arg9 exec.TaskConfigSource, arg10 atc.VersionedResourceTypes, arg11 map[string]string, arg12 map[string]string, arg13 string, arg14 clock.Clock, arg15 creds.Variables) exec.StepFactory {
 	fake.taskMutex.Lock()
 	ret, specificReturn := fake.taskReturnsOnCall[len(fake.taskArgsForCall)]
 	fake.taskArgsForCall = append(fake.taskArgsForCall, struct {
@@ -295,11 +318,12 @@ func (fake *FakeFactory) Task(arg1 lager.Logger, arg2 worker.ArtifactName, arg3
 		arg12 map[string]string
 		arg13 string
 		arg14 clock.Clock
-	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14})
-	fake.recordInvocation("Task", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14})
+		arg15 creds.Variables
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14, arg15})
+	fake.recordInvocation("Task", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14, arg15})
 	fake.taskMutex.Unlock()
 	if fake.TaskStub != nil {
-		return fake.TaskStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14)
+		return fake.TaskStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14, arg15)
 	}
 	if specificReturn {
 		return ret.result1
@@ -313,10 +337,10 @@ func (fake *FakeFactory) TaskCallCount() int {
 	return len(fake.taskArgsForCall)
 }
 
-func (fake *FakeFactory) TaskArgsForCall(i int) (lager.Logger, worker.ArtifactName, worker.Identifier, worker.Metadata, exec.TaskDelegate, exec.Privileged, atc.Tags, int, exec.TaskConfigSource, atc.VersionedResourceTypes, map[string]string, map[string]string, string, clock.Clock) {
+func (fake *FakeFactory) TaskArgsForCall(i int) (lager.Logger, worker.ArtifactName, worker.Identifier, worker.Metadata, exec.TaskDelegate, exec.Privileged, atc.Tags, int, exec.TaskConfigSource, atc.VersionedResourceTypes, map[string]string, map[string]string, string, clock.Clock, creds.Variables) {
 	fake.taskMutex.RLock()
 	defer fake.taskMutex.RUnlock()
-	return fake.taskArgsForCall[i].arg1, fake.taskArgsForCall[i].arg2, fake.taskArgsForCall[i].arg3, fake.taskArgsForCall[i].arg4, fake.taskArgsForCall[i].arg5, fake.taskArgsForCall[i].arg6, fake.taskArgsForCall[i].arg7, fake.taskArgsForCall[i].arg8, fake.taskArgsForCall[i].arg9, fake.taskArgsForCall[i].arg10, fake.taskArgsForCall[i].arg11, fake.taskArgsForCall[i].arg12, fake.taskArgsForCall[i].arg13, fake.taskArgsForCall[i].arg14
+	return fake.taskArgsForCall[i].arg1, fake.taskArgsForCall[i].arg2, fake.taskArgsForCall[i].arg3, fake.taskArgsForCall[i].arg4, fake.taskArgsForCall[i].arg5, fake.taskArgsForCall[i].arg6, fake.taskArgsForCall[i].arg7, fake.taskArgsForCall[i].arg8, fake.taskArgsForCall[i].arg9, fake.taskArgsForCall[i].arg10, fake.taskArgsForCall[i].arg11, fake.taskArgsForCall[i].arg12, fake.taskArgsForCall[i].arg13, fake.taskArgsForCall[i].arg14, fake.taskArgsForCall[i].arg15
 }
 
 func (fake *FakeFactory) TaskReturns(result1 exec.StepFactory) {
@@ -338,6 +362,58 @@ func (fake *FakeFactory) TaskReturnsOnCall(i int, result1 exec.StepFactory) {
 	}{result1}
 }
 
+func (fake *FakeFactory) SetPipeline(arg1 lager.Logger, arg2 exec.SetPipelineDelegate, arg3 int, arg4 string, arg5 string) exec.StepFactory {
+	fake.setPipelineMutex.Lock()
+	ret, specificReturn := fake.setPipelineReturnsOnCall[len(fake.setPipelineArgsForCall)]
+	fake.setPipelineArgsForCall = append(fake.setPipelineArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 exec.SetPipelineDelegate
+		arg3 int
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("SetPipeline", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.setPipelineMutex.Unlock()
+	if fake.SetPipelineStub != nil {
+		return fake.SetPipelineStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.setPipelineReturns.result1
+}
+
+func (fake *FakeFactory) SetPipelineCallCount() int {
+	fake.setPipelineMutex.RLock()
+	defer fake.setPipelineMutex.RUnlock()
+	return len(fake.setPipelineArgsForCall)
+}
+
+func (fake *FakeFactory) SetPipelineArgsForCall(i int) (lager.Logger, exec.SetPipelineDelegate, int, string, string) {
+	fake.setPipelineMutex.RLock()
+	defer fake.setPipelineMutex.RUnlock()
+	return fake.setPipelineArgsForCall[i].arg1, fake.setPipelineArgsForCall[i].arg2, fake.setPipelineArgsForCall[i].arg3, fake.setPipelineArgsForCall[i].arg4, fake.setPipelineArgsForCall[i].arg5
+}
+
+func (fake *FakeFactory) SetPipelineReturns(result1 exec.StepFactory) {
+	fake.SetPipelineStub = nil
+	fake.setPipelineReturns = struct {
+		result1 exec.StepFactory
+	}{result1}
+}
+
+func (fake *FakeFactory) SetPipelineReturnsOnCall(i int, result1 exec.StepFactory) {
+	fake.SetPipelineStub = nil
+	if fake.setPipelineReturnsOnCall == nil {
+		fake.setPipelineReturnsOnCall = make(map[int]struct {
+			result1 exec.StepFactory
+		})
+	}
+	fake.setPipelineReturnsOnCall[i] = struct {
+		result1 exec.StepFactory
+	}{result1}
+}
+
 func (fake *FakeFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -349,6 +425,8 @@ func (fake *FakeFactory) Invocations() map[string][][]interface{} {
 	defer fake.dependentGetMutex.RUnlock()
 	fake.taskMutex.RLock()
 	defer fake.taskMutex.RUnlock()
+	fake.setPipelineMutex.RLock()
+	defer fake.setPipelineMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -0,0 +1,72 @@
+// This file was generated by counterfeiter
+package execfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/exec"
+)
+
+type FakeRetryDelegate struct {
+	AttemptFinishedStub        func(attempt int, status atc.BuildStatus, retrying bool, delay time.Duration)
+	attemptFinishedMutex       sync.RWMutex
+	attemptFinishedArgsForCall []struct {
+		attempt  int
+		status   atc.BuildStatus
+		retrying bool
+		delay    time.Duration
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRetryDelegate) AttemptFinished(attempt int, status atc.BuildStatus, retrying bool, delay time.Duration) {
+	fake.attemptFinishedMutex.Lock()
+	fake.attemptFinishedArgsForCall = append(fake.attemptFinishedArgsForCall, struct {
+		attempt  int
+		status   atc.BuildStatus
+		retrying bool
+		delay    time.Duration
+	}{attempt, status, retrying, delay})
+	fake.recordInvocation("AttemptFinished", []interface{}{attempt, status, retrying, delay})
+	fake.attemptFinishedMutex.Unlock()
+	if fake.AttemptFinishedStub != nil {
+		fake.AttemptFinishedStub(attempt, status, retrying, delay)
+	}
+}
+
+func (fake *FakeRetryDelegate) AttemptFinishedCallCount() int {
+	fake.attemptFinishedMutex.RLock()
+	defer fake.attemptFinishedMutex.RUnlock()
+	return len(fake.attemptFinishedArgsForCall)
+}
+
+func (fake *FakeRetryDelegate) AttemptFinishedArgsForCall(i int) (int, atc.BuildStatus, bool, time.Duration) {
+	fake.attemptFinishedMutex.RLock()
+	defer fake.attemptFinishedMutex.RUnlock()
+	return fake.attemptFinishedArgsForCall[i].attempt, fake.attemptFinishedArgsForCall[i].status, fake.attemptFinishedArgsForCall[i].retrying, fake.attemptFinishedArgsForCall[i].delay
+}
+
+func (fake *FakeRetryDelegate) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.attemptFinishedMutex.RLock()
+	defer fake.attemptFinishedMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeRetryDelegate) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.RetryDelegate = new(FakeRetryDelegate)
@@ -2,16 +2,23 @@ package exec
 
 import (
 	"io"
+	"os"
+	"time"
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/worker"
 )
 
 //go:generate counterfeiter . Factory
 
 // Factory is used when building up the steps for a build.
+//
+// The configuration given to the factory may contain ((var)) placeholders,
+// which the steps resolve with the given creds.Variables only once they run,
+// so that credentials never end up in the build's persisted plan.
 type Factory interface {
 	// Get constructs a GetStep factory.
 	Get(
@@ -27,6 +34,7 @@ type Factory interface {
 		atc.Params,
 		atc.Version,
 		atc.VersionedResourceTypes,
+		creds.Variables,
 	) StepFactory
 
 	// Put constructs a PutStep factory.
@@ -41,6 +49,7 @@ type Factory interface {
 		int,
 		atc.Params,
 		atc.VersionedResourceTypes,
+		creds.Variables,
 	) StepFactory
 
 	// DependentGet constructs a GetStep factory whose version is determined by
@@ -57,6 +66,7 @@ type Factory interface {
 		int,
 		atc.Params,
 		atc.VersionedResourceTypes,
+		creds.Variables,
 	) StepFactory
 
 	// Task constructs a TaskStep factory.
@@ -75,6 +85,16 @@ type Factory interface {
 		map[string]string,
 		string,
 		clock.Clock,
+		creds.Variables,
+	) StepFactory
+
+	// SetPipeline constructs a SetPipelineStep factory.
+	SetPipeline(
+		lager.Logger,
+		SetPipelineDelegate,
+		int,
+		string,
+		string,
 	) StepFactory
 }
 
@@ -131,6 +151,41 @@ type PutDelegate interface {
 	ResourceDelegate
 }
 
+//go:generate counterfeiter . SetPipelineDelegate
+
+// SetPipelineDelegate is used to record events related to a SetPipelineStep's
+// runtime behavior.
+type SetPipelineDelegate interface {
+	Initializing()
+
+	Finished(ExitStatus)
+	Failed(error)
+
+	Stdout() io.Writer
+	Stderr() io.Writer
+}
+
+//go:generate counterfeiter . RetryDelegate
+
+// RetryDelegate is used to record events related to a RetryStep's attempts.
+type RetryDelegate interface {
+	AttemptFinished(attempt int, status atc.BuildStatus, retrying bool, delay time.Duration)
+}
+
+//go:generate counterfeiter . BreakpointDelegate
+
+// BreakpointDelegate is used to pause a build at a BreakpointStep and to wait
+// for it to be resumed.
+type BreakpointDelegate interface {
+	Paused(when string) error
+
+	// WaitForResume blocks until the build is resumed, returning
+	// ErrInterrupted if a signal is received first.
+	WaitForResume(signals <-chan os.Signal) error
+
+	Resumed()
+}
+
 // Privileged is used to indicate whether the given step should run with
 // special privileges (i.e. as an administrator user).
 type Privileged bool
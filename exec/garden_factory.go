@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
@@ -19,6 +20,8 @@ type gardenFactory struct {
 	resourceFetcher        resource.Fetcher
 	resourceFactory        resource.ResourceFactory
 	dbResourceCacheFactory dbng.ResourceCacheFactory
+	dbTeamFactory          dbng.TeamFactory
+	defaultLimits          atc.ContainerLimits
 }
 
 func NewGardenFactory(
@@ -26,12 +29,16 @@ func NewGardenFactory(
 	resourceFetcher resource.Fetcher,
 	resourceFactory resource.ResourceFactory,
 	dbResourceCacheFactory dbng.ResourceCacheFactory,
+	dbTeamFactory dbng.TeamFactory,
+	defaultLimits atc.ContainerLimits,
 ) Factory {
 	return &gardenFactory{
 		workerClient:           workerClient,
 		resourceFetcher:        resourceFetcher,
 		resourceFactory:        resourceFactory,
 		dbResourceCacheFactory: dbResourceCacheFactory,
+		dbTeamFactory:          dbTeamFactory,
+		defaultLimits:          defaultLimits,
 	}
 }
 
@@ -47,6 +54,7 @@ func (factory *gardenFactory) DependentGet(
 	teamID int,
 	params atc.Params,
 	resourceTypes atc.VersionedResourceTypes,
+	variables creds.Variables,
 ) StepFactory {
 	return newDependentGetStep(
 		logger,
@@ -65,6 +73,7 @@ func (factory *gardenFactory) DependentGet(
 		factory.resourceFetcher,
 		resourceTypes,
 		factory.dbResourceCacheFactory,
+		variables,
 	)
 }
 
@@ -81,6 +90,7 @@ func (factory *gardenFactory) Get(
 	params atc.Params,
 	version atc.Version,
 	resourceTypes atc.VersionedResourceTypes,
+	variables creds.Variables,
 ) StepFactory {
 	workerMetadata.WorkingDirectory = resource.ResourcesDir("get")
 	return newGetStep(
@@ -89,15 +99,6 @@ func (factory *gardenFactory) Get(
 		resourceConfig,
 		version,
 		params,
-		resource.NewResourceInstance(
-			resource.ResourceType(resourceConfig.Type),
-			version,
-			resourceConfig.Source,
-			params,
-			dbng.ForBuild{BuildID: id.BuildID},
-			resourceTypes,
-			factory.dbResourceCacheFactory,
-		),
 		stepMetadata,
 		resource.Session{
 			ID:        id,
@@ -109,6 +110,8 @@ func (factory *gardenFactory) Get(
 		delegate,
 		factory.resourceFetcher,
 		resourceTypes,
+		factory.dbResourceCacheFactory,
+		variables,
 	)
 }
 
@@ -123,6 +126,7 @@ func (factory *gardenFactory) Put(
 	teamID int,
 	params atc.Params,
 	resourceTypes atc.VersionedResourceTypes,
+	variables creds.Variables,
 ) StepFactory {
 	workerMetadata.WorkingDirectory = resource.ResourcesDir("put")
 	return newPutStep(
@@ -140,6 +144,7 @@ func (factory *gardenFactory) Put(
 		delegate,
 		factory.resourceFactory,
 		resourceTypes,
+		variables,
 	)
 }
 
@@ -158,6 +163,7 @@ func (factory *gardenFactory) Task(
 	outputMapping map[string]string,
 	imageArtifactName string,
 	clock clock.Clock,
+	variables creds.Variables,
 ) StepFactory {
 	workingDirectory := factory.taskWorkingDirectory(sourceName)
 	workerMetadata.WorkingDirectory = workingDirectory
@@ -178,6 +184,25 @@ func (factory *gardenFactory) Task(
 		outputMapping,
 		imageArtifactName,
 		clock,
+		factory.defaultLimits,
+		variables,
+	)
+}
+
+func (factory *gardenFactory) SetPipeline(
+	logger lager.Logger,
+	delegate SetPipelineDelegate,
+	teamID int,
+	pipelineName string,
+	configPath string,
+) StepFactory {
+	return newSetPipelineStep(
+		logger,
+		delegate,
+		teamID,
+		pipelineName,
+		configPath,
+		factory.dbTeamFactory,
 	)
 }
 
@@ -10,7 +10,9 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
 )
@@ -18,22 +20,25 @@ import (
 // GetStep will fetch a version of a resource on a worker that supports the
 // resource type.
 type GetStep struct {
-	logger           lager.Logger
-	sourceName       worker.ArtifactName
-	resourceConfig   atc.ResourceConfig
-	version          atc.Version
-	params           atc.Params
-	resourceInstance resource.ResourceInstance
-	stepMetadata     StepMetadata
-	session          resource.Session
-	tags             atc.Tags
-	teamID           int
-	delegate         GetDelegate
-	resourceFetcher  resource.Fetcher
-	resourceTypes    atc.VersionedResourceTypes
+	logger                 lager.Logger
+	sourceName             worker.ArtifactName
+	resourceConfig         atc.ResourceConfig
+	version                atc.Version
+	params                 atc.Params
+	stepMetadata           StepMetadata
+	session                resource.Session
+	tags                   atc.Tags
+	teamID                 int
+	delegate               GetDelegate
+	resourceFetcher        resource.Fetcher
+	resourceTypes          atc.VersionedResourceTypes
+	dbResourceCacheFactory dbng.ResourceCacheFactory
+	variables              creds.Variables
 
 	repository *worker.ArtifactRepository
 
+	resourceInstance resource.ResourceInstance
+
 	fetchSource resource.FetchSource
 
 	succeeded bool
@@ -45,7 +50,6 @@ func newGetStep(
 	resourceConfig atc.ResourceConfig,
 	version atc.Version,
 	params atc.Params,
-	resourceInstance resource.ResourceInstance,
 	stepMetadata StepMetadata,
 	session resource.Session,
 	tags atc.Tags,
@@ -53,21 +57,24 @@ func newGetStep(
 	delegate GetDelegate,
 	resourceFetcher resource.Fetcher,
 	resourceTypes atc.VersionedResourceTypes,
+	dbResourceCacheFactory dbng.ResourceCacheFactory,
+	variables creds.Variables,
 ) GetStep {
 	return GetStep{
-		logger:           logger,
-		sourceName:       sourceName,
-		resourceConfig:   resourceConfig,
-		version:          version,
-		params:           params,
-		resourceInstance: resourceInstance,
-		stepMetadata:     stepMetadata,
-		session:          session,
-		tags:             tags,
-		teamID:           teamID,
-		delegate:         delegate,
-		resourceFetcher:  resourceFetcher,
-		resourceTypes:    resourceTypes,
+		logger:                 logger,
+		sourceName:             sourceName,
+		resourceConfig:         resourceConfig,
+		version:                version,
+		params:                 params,
+		stepMetadata:           stepMetadata,
+		session:                session,
+		tags:                   tags,
+		teamID:                 teamID,
+		delegate:               delegate,
+		resourceFetcher:        resourceFetcher,
+		resourceTypes:          resourceTypes,
+		dbResourceCacheFactory: dbResourceCacheFactory,
+		variables:              variables,
 	}
 }
 
@@ -86,8 +93,9 @@ func (step GetStep) Using(prev Step, repo *worker.ArtifactRepository) Step {
 // under the configured SourceName. How it actually does this is determined by
 // a few factors.
 //
-// First, a worker that supports the given resource type is chosen, and a
-// container is created on the worker.
+// First, any ((var)) placeholders in the source, params, and resource types are
+// resolved. A worker that supports the given resource type is then chosen, and
+// a container is created on the worker.
 //
 // If the worker has a VolumeManager, and its cache is already warmed, the
 // cache will be mounted into the container, and no fetching will be performed.
@@ -108,24 +116,48 @@ func (step GetStep) Using(prev Step, repo *worker.ArtifactRepository) Step {
 func (step *GetStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 	step.delegate.Initializing()
 
+	source, err := step.variables.EvaluateSource(step.resourceConfig.Source)
+	if err != nil {
+		return err
+	}
+
+	params, err := step.variables.EvaluateParams(step.params)
+	if err != nil {
+		return err
+	}
+
+	resourceTypes, err := step.variables.EvaluateResourceTypes(step.resourceTypes)
+	if err != nil {
+		return err
+	}
+
+	step.resourceInstance = resource.NewResourceInstance(
+		resource.ResourceType(step.resourceConfig.Type),
+		step.version,
+		source,
+		params,
+		dbng.ForBuild{BuildID: step.session.ID.BuildID},
+		resourceTypes,
+		step.dbResourceCacheFactory,
+	)
+
 	runSession := step.session
 	runSession.ID.Stage = db.ContainerStageRun
 
 	resourceDefinition := &getStepResource{
-		source:       step.resourceConfig.Source,
+		source:       source,
 		resourceType: resource.ResourceType(step.resourceConfig.Type),
 		delegate:     step.delegate,
-		params:       step.params,
+		params:       params,
 		version:      step.version,
 	}
 
-	var err error
 	step.fetchSource, err = step.resourceFetcher.Fetch(
 		step.logger,
 		runSession,
 		step.tags,
 		step.teamID,
-		step.resourceTypes,
+		resourceTypes,
 		step.resourceInstance,
 		step.stepMetadata,
 		step.delegate,
@@ -12,6 +12,8 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/dbng/dbngfakes"
@@ -32,6 +34,7 @@ var _ = Describe("Get", func() {
 		fakeWorkerClient           *workerfakes.FakeClient
 		fakeResourceFetcher        *resourcefakes.FakeFetcher
 		fakeDBResourceCacheFactory *dbngfakes.FakeResourceCacheFactory
+		fakeCredentialManager      *credsfakes.FakeCredentialManager
 
 		fakeCache           *resourcefakes.FakeCache
 		fakeVolume          *workerfakes.FakeVolume
@@ -120,8 +123,9 @@ var _ = Describe("Get", func() {
 		fakeFetchSource.VersionedSourceReturns(fakeVersionedSource)
 
 		fakeDBResourceCacheFactory = new(dbngfakes.FakeResourceCacheFactory)
+		fakeCredentialManager = new(credsfakes.FakeCredentialManager)
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory)
+		factory = NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory, nil, atc.ContainerLimits{})
 	})
 
 	JustBeforeEach(func() {
@@ -138,6 +142,7 @@ var _ = Describe("Get", func() {
 			params,
 			version,
 			resourceTypes,
+			creds.NewVariables(fakeCredentialManager, "some-team", "some-pipeline"),
 		).Using(inStep, repo)
 
 		process = ifrit.Invoke(step)
@@ -216,6 +221,52 @@ var _ = Describe("Get", func() {
 		Expect(resourceOptions.LockName("fake-worker")).To(Equal(expectedLockName))
 	})
 
+	Context("when the source, params, and resource types contain credentials", func() {
+		BeforeEach(func() {
+			resourceConfig.Source = atc.Source{"some": "((source-cred))"}
+			params = atc.Params{"some-param": "((param-cred))"}
+			resourceTypes[0].Source = atc.Source{"some-custom": "((type-cred))"}
+
+			fakeCredentialManager.GetStub = func(teamName string, pipelineName string, varName string) (interface{}, bool, error) {
+				return "some-" + varName, true, nil
+			}
+		})
+
+		It("resolves them when the step runs", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			_, _, _, _, actualResourceTypes, resourceInstance, _, _, resourceOptions, _, _ := fakeResourceFetcher.FetchArgsForCall(0)
+			Expect(resourceOptions.Source()).To(Equal(atc.Source{"some": "some-source-cred"}))
+			Expect(resourceOptions.Params()).To(Equal(atc.Params{"some-param": "some-param-cred"}))
+			Expect(actualResourceTypes[0].Source).To(Equal(atc.Source{"some-custom": "some-type-cred"}))
+			Expect(resourceInstance).To(Equal(resource.NewResourceInstance(
+				"some-resource-type",
+				version,
+				atc.Source{"some": "some-source-cred"},
+				atc.Params{"some-param": "some-param-cred"},
+				dbng.ForBuild{42},
+				actualResourceTypes,
+				fakeDBResourceCacheFactory,
+			)))
+		})
+	})
+
+	Context("when a credential is not defined", func() {
+		BeforeEach(func() {
+			resourceConfig.Source = atc.Source{"some": "((bogus))"}
+		})
+
+		It("exits with the error without fetching the resource", func() {
+			Eventually(process.Wait()).Should(Receive(Equal(creds.UndefinedVariableError{Name: "bogus"})))
+			Expect(fakeResourceFetcher.FetchCallCount()).To(BeZero())
+		})
+
+		It("invokes the delegate's Failed callback", func() {
+			Eventually(process.Wait()).Should(Receive(HaveOccurred()))
+			Expect(getDelegate.FailedCallCount()).To(Equal(1))
+		})
+	})
+
 	Context("when fetching resource succeeds", func() {
 		BeforeEach(func() {
 			fakeResourceFetcher.FetchReturns(fakeFetchSource, nil)
@@ -7,6 +7,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
@@ -25,6 +26,7 @@ type PutStep struct {
 	delegate        PutDelegate
 	resourceFactory resource.ResourceFactory
 	resourceTypes   atc.VersionedResourceTypes
+	variables       creds.Variables
 
 	repository *worker.ArtifactRepository
 
@@ -46,6 +48,7 @@ func newPutStep(
 	delegate PutDelegate,
 	resourceFactory resource.ResourceFactory,
 	resourceTypes atc.VersionedResourceTypes,
+	variables creds.Variables,
 ) PutStep {
 	return PutStep{
 		logger:          logger,
@@ -58,6 +61,7 @@ func newPutStep(
 		delegate:        delegate,
 		resourceFactory: resourceFactory,
 		resourceTypes:   resourceTypes,
+		variables:       variables,
 	}
 }
 
@@ -72,8 +76,9 @@ func (step PutStep) Using(prev Step, repo *worker.ArtifactRepository) Step {
 	}
 }
 
-// Run chooses a worker that supports the step's resource type and creates a
-// container.
+// Run resolves any ((var)) placeholders in the step's source, params, and
+// resource types, then chooses a worker that supports the step's resource type
+// and creates a container.
 //
 // All worker.ArtifactSources present in the worker.ArtifactRepository are then brought into
 // the container, using volumes if possible, and streaming content over if not.
@@ -83,6 +88,21 @@ func (step PutStep) Using(prev Step, repo *worker.ArtifactRepository) Step {
 func (step *PutStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 	step.delegate.Initializing()
 
+	source, err := step.variables.EvaluateSource(step.resourceConfig.Source)
+	if err != nil {
+		return err
+	}
+
+	params, err := step.variables.EvaluateParams(step.params)
+	if err != nil {
+		return err
+	}
+
+	resourceTypes, err := step.variables.EvaluateResourceTypes(step.resourceTypes)
+	if err != nil {
+		return err
+	}
+
 	runSession := step.session
 	runSession.ID.Stage = db.ContainerStageRun
 
@@ -110,7 +130,7 @@ func (step *PutStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		runSession.ID,
 		runSession.Metadata,
 		resourceSpec,
-		step.resourceTypes,
+		resourceTypes,
 		step.delegate,
 		inputSources,
 		map[string]string{},
@@ -143,8 +163,8 @@ func (step *PutStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 			Stdout: step.delegate.Stdout(),
 			Stderr: step.delegate.Stderr(),
 		},
-		step.resourceConfig.Source,
-		step.params,
+		source,
+		params,
 		artifactSource,
 		signals,
 		ready,
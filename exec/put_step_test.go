@@ -9,6 +9,8 @@ import (
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/dbng/dbngfakes"
 	. "github.com/concourse/atc/exec"
@@ -53,7 +55,7 @@ var _ = Describe("GardenFactory", func() {
 		fakeResourceFactory = new(resourcefakes.FakeResourceFactory)
 		fakeDBResourceCacheFactory = new(dbngfakes.FakeResourceCacheFactory)
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory)
+		factory = NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory, nil, atc.ContainerLimits{})
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
@@ -67,6 +69,8 @@ var _ = Describe("GardenFactory", func() {
 			tags           []string
 			resourceTypes  atc.VersionedResourceTypes
 
+			fakeCredentialManager *credsfakes.FakeCredentialManager
+
 			inStep *execfakes.FakeStep
 			repo   *worker.ArtifactRepository
 
@@ -101,6 +105,8 @@ var _ = Describe("GardenFactory", func() {
 					Version: atc.Version{"some-custom": "version"},
 				},
 			}
+
+			fakeCredentialManager = new(credsfakes.FakeCredentialManager)
 		})
 
 		JustBeforeEach(func() {
@@ -115,6 +121,7 @@ var _ = Describe("GardenFactory", func() {
 				teamID,
 				params,
 				resourceTypes,
+				creds.NewVariables(fakeCredentialManager, "some-team", "some-pipeline"),
 			).Using(inStep, repo)
 
 			process = ifrit.Invoke(step)
@@ -237,6 +244,53 @@ var _ = Describe("GardenFactory", func() {
 					Expect(fakeMountedSource.StreamToCallCount()).To(Equal(0))
 				})
 
+				Context("when the source, params, and resource types contain credentials", func() {
+					BeforeEach(func() {
+						resourceConfig.Source = atc.Source{"some": "((source-cred))"}
+						params = atc.Params{"some-param": "((param-cred))"}
+						resourceTypes[0].Source = atc.Source{"some-custom": "((type-cred))"}
+
+						fakeCredentialManager.GetStub = func(teamName string, pipelineName string, varName string) (interface{}, bool, error) {
+							return "some-" + varName, true, nil
+						}
+					})
+
+					It("resolves them when the step runs", func() {
+						Eventually(process.Wait()).Should(Receive(BeNil()))
+
+						_, putSource, putParams, _, _, _ := fakeResource.PutArgsForCall(0)
+						Expect(putSource).To(Equal(atc.Source{"some": "some-source-cred"}))
+						Expect(putParams).To(Equal(atc.Params{"some-param": "some-param-cred"}))
+
+						_, _, _, _, actualResourceTypes, _, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
+						Expect(actualResourceTypes[0].Source).To(Equal(atc.Source{"some-custom": "some-type-cred"}))
+					})
+
+					It("does not modify the step's configuration", func() {
+						Eventually(process.Wait()).Should(Receive(BeNil()))
+
+						Expect(resourceConfig.Source).To(Equal(atc.Source{"some": "((source-cred))"}))
+						Expect(resourceTypes[0].Source).To(Equal(atc.Source{"some-custom": "((type-cred))"}))
+					})
+				})
+
+				Context("when a credential is not defined", func() {
+					BeforeEach(func() {
+						params = atc.Params{"some-param": "((bogus))"}
+					})
+
+					It("exits with the error without creating the resource", func() {
+						Eventually(process.Wait()).Should(Receive(Equal(creds.UndefinedVariableError{Name: "bogus"})))
+						Expect(fakeResourceFactory.NewBuildResourceCallCount()).To(BeZero())
+					})
+
+					It("invokes the delegate's Failed callback", func() {
+						Eventually(process.Wait()).Should(Receive(HaveOccurred()))
+						Expect(putDelegate.FailedCallCount()).To(Equal(1))
+						Expect(putDelegate.FailedArgsForCall(0)).To(Equal(creds.UndefinedVariableError{Name: "bogus"}))
+					})
+				})
+
 				It("puts the resource with the io config forwarded", func() {
 					Expect(fakeResource.PutCallCount()).To(Equal(1))
 
@@ -2,61 +2,195 @@ package exec
 
 import (
 	"os"
+	"time"
 
+	"code.cloudfoundry.org/clock"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/worker"
 )
 
-// Retry constructs a Step that will run the steps in order until one of them
-// succeeds.
-type Retry []StepFactory
+// RetryStep runs each of its attempts in order until one of them succeeds,
+// waiting between attempts as configured by its atc.RetryPolicy.
+type RetryStep struct {
+	attempts []StepFactory
+	policy   atc.RetryPolicy
+	delegate RetryDelegate
+	clock    clock.Clock
 
-// Using constructs a *RetryStep.
-func (stepFactory Retry) Using(prev Step, repo *worker.ArtifactRepository) Step {
-	retry := &RetryStep{}
+	runAttempts []Step
+	lastAttempt Step
+}
 
-	for _, subStepFactory := range stepFactory {
-		retry.Attempts = append(retry.Attempts, subStepFactory.Using(prev, repo))
+// Retry constructs a RetryStep factory.
+func Retry(
+	attempts []StepFactory,
+	policy atc.RetryPolicy,
+	delegate RetryDelegate,
+	clock clock.Clock,
+) RetryStep {
+	return RetryStep{
+		attempts: attempts,
+		policy:   policy,
+		delegate: delegate,
+		clock:    clock,
 	}
-
-	return retry
 }
 
-// RetryStep is a step that will run the steps in order until one of them
-// succeeds.
-type RetryStep struct {
-	Attempts    []Step
-	LastAttempt Step
+// Using constructs a *RetryStep.
+func (step RetryStep) Using(prev Step, repo *worker.ArtifactRepository) Step {
+	step.runAttempts = nil
+
+	for _, attempt := range step.attempts {
+		step.runAttempts = append(step.runAttempts, attempt.Using(prev, repo))
+	}
+
+	return &step
 }
 
-// Run iterates through each step, stopping once a step succeeds. If all steps
-// fail, the RetryStep will fail.
+// Run iterates through each attempt, stopping once an attempt succeeds.
+//
+// An attempt which fails or errors is only retried if its status is one of
+// the policy's retry conditions, or if there are none. Before the next
+// attempt, the RetryStep waits for the policy's backoff delay; if it is
+// interrupted while waiting, ErrInterrupted is returned.
+//
+// The outcome of each attempt is reported to the RetryDelegate. The error of
+// the last attempt that was run is returned.
 func (step *RetryStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	backoff, err := parseRetryBackoff(step.policy.Backoff)
+	if err != nil {
+		return err
+	}
+
 	close(ready)
 
 	var attemptErr error
 
-	for _, attempt := range step.Attempts {
-		step.LastAttempt = attempt
+	for i, attempt := range step.runAttempts {
+		step.lastAttempt = attempt
 
-		var succeeded Success
 		attemptErr = attempt.Run(signals, make(chan struct{}))
 		if attemptErr == ErrInterrupted {
 			return attemptErr
 		}
 
+		var status atc.BuildStatus
 		if attemptErr != nil {
-			continue
+			status = atc.StatusErrored
+		} else {
+			var succeeded Success
+			if attempt.Result(&succeeded) && bool(succeeded) {
+				status = atc.StatusSucceeded
+			} else {
+				status = atc.StatusFailed
+			}
+		}
+
+		number := i + 1
+
+		retrying := status != atc.StatusSucceeded &&
+			number < len(step.runAttempts) &&
+			step.retriesOn(status)
+
+		var delay time.Duration
+		if retrying {
+			delay = backoff.delayAfter(number)
 		}
 
-		if attempt.Result(&succeeded) && bool(succeeded) {
+		step.delegate.AttemptFinished(number, status, retrying, delay)
+
+		if !retrying {
 			break
 		}
+
+		if delay > 0 {
+			timer := step.clock.NewTimer(delay)
+
+			select {
+			case <-timer.C():
+			case <-signals:
+				timer.Stop()
+				return ErrInterrupted
+			}
+		}
 	}
 
 	return attemptErr
 }
 
-// Result delegates to the last step that it ran.
+// Result delegates to the last attempt that it ran.
 func (step *RetryStep) Result(x interface{}) bool {
-	return step.LastAttempt.Result(x)
+	return step.lastAttempt.Result(x)
+}
+
+func (step *RetryStep) retriesOn(status atc.BuildStatus) bool {
+	if len(step.policy.On) == 0 {
+		return true
+	}
+
+	for _, retryStatus := range step.policy.On {
+		if retryStatus == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+type retryBackoff struct {
+	exponential bool
+	delay       time.Duration
+	maxDelay    time.Duration
+}
+
+func parseRetryBackoff(config *atc.RetryBackoffConfig) (retryBackoff, error) {
+	if config == nil {
+		return retryBackoff{}, nil
+	}
+
+	delay, err := time.ParseDuration(config.Delay)
+	if err != nil {
+		return retryBackoff{}, err
+	}
+
+	var maxDelay time.Duration
+	if config.MaxDelay != "" {
+		maxDelay, err = time.ParseDuration(config.MaxDelay)
+		if err != nil {
+			return retryBackoff{}, err
+		}
+	}
+
+	return retryBackoff{
+		exponential: config.Strategy == atc.RetryBackoffExponential,
+		delay:       delay,
+		maxDelay:    maxDelay,
+	}, nil
+}
+
+// delayAfter returns how long to wait after the given attempt, counting from
+// 1, before running the next one.
+func (backoff retryBackoff) delayAfter(attempt int) time.Duration {
+	delay := backoff.delay
+
+	if backoff.exponential {
+		for i := 1; i < attempt && delay > 0; i++ {
+			if backoff.maxDelay > 0 && delay >= backoff.maxDelay {
+				break
+			}
+
+			// stop doubling before the duration would overflow
+			if delay > time.Duration(1<<62) {
+				break
+			}
+
+			delay *= 2
+		}
+	}
+
+	if backoff.maxDelay > 0 && delay > backoff.maxDelay {
+		return backoff.maxDelay
+	}
+
+	return delay
 }
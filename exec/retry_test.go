@@ -3,7 +3,10 @@ package exec_test
 import (
 	"errors"
 	"os"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/concourse/atc"
 	. "github.com/concourse/atc/exec"
 	"github.com/tedsuo/ifrit"
 
@@ -23,6 +26,10 @@ var _ = Describe("Retry Step", func() {
 		attempt3Factory *execfakes.FakeStepFactory
 		attempt3Step    *execfakes.FakeStep
 
+		policy       atc.RetryPolicy
+		fakeDelegate *execfakes.FakeRetryDelegate
+		fakeClock    *fakeclock.FakeClock
+
 		stepFactory StepFactory
 		step        Step
 	)
@@ -40,7 +47,19 @@ var _ = Describe("Retry Step", func() {
 		attempt3Step = new(execfakes.FakeStep)
 		attempt3Factory.UsingReturns(attempt3Step)
 
-		stepFactory = Retry{attempt1Factory, attempt2Factory, attempt3Factory}
+		policy = atc.RetryPolicy{}
+		fakeDelegate = new(execfakes.FakeRetryDelegate)
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+	})
+
+	JustBeforeEach(func() {
+		stepFactory = Retry(
+			[]StepFactory{attempt1Factory, attempt2Factory, attempt3Factory},
+			policy,
+			fakeDelegate,
+			fakeClock,
+		)
+
 		step = stepFactory.Using(nil, nil)
 	})
 
@@ -343,4 +362,191 @@ var _ = Describe("Retry Step", func() {
 			})
 		})
 	})
+
+	Context("when attempt 1 errors, and attempt 2 succeeds", func() {
+		BeforeEach(func() {
+			attempt1Step.RunReturns(errors.New("nope"))
+			attempt2Step.ResultStub = successResult(true)
+		})
+
+		It("reports the outcome of each attempt", func() {
+			process := ifrit.Invoke(step)
+			Expect(<-process.Wait()).ToNot(HaveOccurred())
+
+			Expect(fakeDelegate.AttemptFinishedCallCount()).To(Equal(2))
+
+			attempt, status, retrying, delay := fakeDelegate.AttemptFinishedArgsForCall(0)
+			Expect(attempt).To(Equal(1))
+			Expect(status).To(Equal(atc.StatusErrored))
+			Expect(retrying).To(BeTrue())
+			Expect(delay).To(BeZero())
+
+			attempt, status, retrying, delay = fakeDelegate.AttemptFinishedArgsForCall(1)
+			Expect(attempt).To(Equal(2))
+			Expect(status).To(Equal(atc.StatusSucceeded))
+			Expect(retrying).To(BeFalse())
+			Expect(delay).To(BeZero())
+		})
+	})
+
+	Context("when only retrying on errored", func() {
+		BeforeEach(func() {
+			policy.On = []atc.BuildStatus{atc.StatusErrored}
+		})
+
+		Context("when attempt 1 fails", func() {
+			BeforeEach(func() {
+				attempt1Step.ResultStub = successResult(false)
+			})
+
+			It("returns nil having only run the first attempt", func() {
+				process := ifrit.Invoke(step)
+				Expect(<-process.Wait()).ToNot(HaveOccurred())
+
+				Expect(attempt1Step.RunCallCount()).To(Equal(1))
+				Expect(attempt2Step.RunCallCount()).To(Equal(0))
+
+				Expect(fakeDelegate.AttemptFinishedCallCount()).To(Equal(1))
+
+				_, status, retrying, _ := fakeDelegate.AttemptFinishedArgsForCall(0)
+				Expect(status).To(Equal(atc.StatusFailed))
+				Expect(retrying).To(BeFalse())
+			})
+		})
+
+		Context("when attempt 1 errors, and attempt 2 succeeds", func() {
+			BeforeEach(func() {
+				attempt1Step.RunReturns(errors.New("nope"))
+				attempt2Step.ResultStub = successResult(true)
+			})
+
+			It("returns nil having run the first and second attempts", func() {
+				process := ifrit.Invoke(step)
+				Expect(<-process.Wait()).ToNot(HaveOccurred())
+
+				Expect(attempt1Step.RunCallCount()).To(Equal(1))
+				Expect(attempt2Step.RunCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Context("when only retrying on failed", func() {
+		BeforeEach(func() {
+			policy.On = []atc.BuildStatus{atc.StatusFailed}
+		})
+
+		Context("when attempt 1 errors", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				attempt1Step.RunReturns(disaster)
+			})
+
+			It("returns the error having only run the first attempt", func() {
+				process := ifrit.Invoke(step)
+				Expect(<-process.Wait()).To(Equal(disaster))
+
+				Expect(attempt1Step.RunCallCount()).To(Equal(1))
+				Expect(attempt2Step.RunCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("with a fixed backoff", func() {
+		BeforeEach(func() {
+			policy.Backoff = &atc.RetryBackoffConfig{
+				Strategy: atc.RetryBackoffFixed,
+				Delay:    "10s",
+			}
+
+			attempt1Step.ResultStub = successResult(false)
+			attempt2Step.ResultStub = successResult(true)
+		})
+
+		It("waits for the delay before the next attempt", func() {
+			process := ifrit.Invoke(step)
+
+			Eventually(fakeClock.WatcherCount).Should(Equal(1))
+			Expect(attempt2Step.RunCallCount()).To(Equal(0))
+
+			attempt, status, retrying, delay := fakeDelegate.AttemptFinishedArgsForCall(0)
+			Expect(attempt).To(Equal(1))
+			Expect(status).To(Equal(atc.StatusFailed))
+			Expect(retrying).To(BeTrue())
+			Expect(delay).To(Equal(10 * time.Second))
+
+			fakeClock.Increment(10 * time.Second)
+
+			Expect(<-process.Wait()).ToNot(HaveOccurred())
+			Expect(attempt2Step.RunCallCount()).To(Equal(1))
+		})
+
+		Context("when interrupted while waiting", func() {
+			It("returns ErrInterrupted without running the next attempt", func() {
+				process := ifrit.Invoke(step)
+
+				Eventually(fakeClock.WatcherCount).Should(Equal(1))
+
+				process.Signal(os.Interrupt)
+
+				Expect(<-process.Wait()).To(Equal(ErrInterrupted))
+				Expect(attempt2Step.RunCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the delay cannot be parsed", func() {
+			BeforeEach(func() {
+				policy.Backoff.Delay = "nope"
+			})
+
+			It("returns an error without running any attempts", func() {
+				process := ifrit.Invoke(step)
+				Expect(<-process.Wait()).To(HaveOccurred())
+
+				Expect(attempt1Step.RunCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("with an exponential backoff", func() {
+		BeforeEach(func() {
+			policy.Backoff = &atc.RetryBackoffConfig{
+				Strategy: atc.RetryBackoffExponential,
+				Delay:    "10s",
+				MaxDelay: "15s",
+			}
+
+			attempt1Step.ResultStub = successResult(false)
+			attempt2Step.ResultStub = successResult(false)
+			attempt3Step.ResultStub = successResult(false)
+		})
+
+		It("doubles the delay after each attempt, up to the max delay", func() {
+			process := ifrit.Invoke(step)
+
+			Eventually(fakeClock.WatcherCount).Should(Equal(1))
+			fakeClock.Increment(10 * time.Second)
+
+			Eventually(attempt2Step.RunCallCount).Should(Equal(1))
+			Eventually(fakeClock.WatcherCount).Should(Equal(1))
+			fakeClock.Increment(15 * time.Second)
+
+			Expect(<-process.Wait()).ToNot(HaveOccurred())
+			Expect(attempt3Step.RunCallCount()).To(Equal(1))
+
+			Expect(fakeDelegate.AttemptFinishedCallCount()).To(Equal(3))
+
+			_, _, retrying, delay := fakeDelegate.AttemptFinishedArgsForCall(0)
+			Expect(retrying).To(BeTrue())
+			Expect(delay).To(Equal(10 * time.Second))
+
+			_, _, retrying, delay = fakeDelegate.AttemptFinishedArgsForCall(1)
+			Expect(retrying).To(BeTrue())
+			Expect(delay).To(Equal(15 * time.Second))
+
+			_, _, retrying, delay = fakeDelegate.AttemptFinishedArgsForCall(2)
+			Expect(retrying).To(BeFalse())
+			Expect(delay).To(BeZero())
+		})
+	})
 })
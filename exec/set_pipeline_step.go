@@ -0,0 +1,182 @@
+package exec
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/baggageclaim"
+)
+
+// InvalidPipelineConfigError is returned when the pipeline config read by a
+// SetPipelineStep does not pass validation.
+type InvalidPipelineConfigError struct {
+	Errors []string
+}
+
+// Error prints each of the validation errors on its own line.
+func (err InvalidPipelineConfigError) Error() string {
+	return fmt.Sprintf("invalid pipeline config:\n%s", strings.Join(err.Errors, "\n"))
+}
+
+// setPipelineAuthor is recorded as the author of configs saved by a
+// SetPipelineStep.
+const setPipelineAuthor = "set_pipeline"
+
+// SetPipelineStep reads a pipeline config file out of the
+// worker.ArtifactRepository and saves it as a pipeline of the build's team.
+type SetPipelineStep struct {
+	logger       lager.Logger
+	delegate     SetPipelineDelegate
+	teamID       int
+	pipelineName string
+	configPath   string
+	teamFactory  dbng.TeamFactory
+	repo         *worker.ArtifactRepository
+
+	succeeded bool
+}
+
+func newSetPipelineStep(
+	logger lager.Logger,
+	delegate SetPipelineDelegate,
+	teamID int,
+	pipelineName string,
+	configPath string,
+	teamFactory dbng.TeamFactory,
+) SetPipelineStep {
+	return SetPipelineStep{
+		logger:       logger,
+		delegate:     delegate,
+		teamID:       teamID,
+		pipelineName: pipelineName,
+		configPath:   configPath,
+		teamFactory:  teamFactory,
+	}
+}
+
+// Using finishes construction of the SetPipelineStep and returns a
+// *SetPipelineStep. If the *SetPipelineStep errors, its error is reported to
+// the delegate.
+func (step SetPipelineStep) Using(prev Step, repo *worker.ArtifactRepository) Step {
+	step.repo = repo
+
+	return errorReporter{
+		Step:          &step,
+		ReportFailure: step.delegate.Failed,
+	}
+}
+
+// Run loads the pipeline config from the file, validates it, and saves it for
+// the build's team, creating the pipeline if it does not already exist.
+//
+// The path must be in the format SOURCE_NAME/FILE/PATH.yml, the same as a
+// task's config file.
+//
+// Any warnings about the config, and the version of the config which was
+// saved, are written to the delegate's stdout.
+//
+// If the config is invalid, InvalidPipelineConfigError is returned.
+func (step *SetPipelineStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	step.delegate.Initializing()
+
+	config, err := step.fetchConfig()
+	if err != nil {
+		return err
+	}
+
+	close(ready)
+
+	warnings, errorMessages := config.ValidateForPipeline(step.pipelineName)
+	if len(errorMessages) > 0 {
+		return InvalidPipelineConfigError{Errors: errorMessages}
+	}
+
+	stdout := step.delegate.Stdout()
+
+	for _, warning := range warnings {
+		fmt.Fprintf(stdout, "WARNING: %s\n", warning.Message)
+	}
+
+	team := step.teamFactory.GetByID(step.teamID)
+
+	var fromVersion dbng.ConfigVersion
+
+	pipeline, found, err := team.FindPipelineByName(step.pipelineName)
+	if err != nil {
+		step.logger.Error("failed-to-find-pipeline", err)
+		return err
+	}
+
+	if found {
+		fromVersion = pipeline.ConfigVersion()
+	}
+
+	savedPipeline, _, err := team.SavePipeline(step.pipelineName, config, fromVersion, dbng.PipelineNoChange, setPipelineAuthor)
+	if err != nil {
+		step.logger.Error("failed-to-save-config", err)
+		return err
+	}
+
+	fmt.Fprintf(stdout, "saved pipeline '%s' at config version %d\n", step.pipelineName, savedPipeline.ConfigVersion())
+
+	step.delegate.Finished(ExitStatus(0))
+
+	step.succeeded = true
+
+	return nil
+}
+
+// Result indicates Success as true once the pipeline has been saved.
+func (step *SetPipelineStep) Result(x interface{}) bool {
+	switch v := x.(type) {
+	case *Success:
+		*v = Success(step.succeeded)
+		return true
+
+	default:
+		return false
+	}
+}
+
+func (step *SetPipelineStep) fetchConfig() (atc.Config, error) {
+	segs := strings.SplitN(step.configPath, "/", 2)
+	if len(segs) != 2 {
+		return atc.Config{}, UnspecifiedArtifactSourceError{step.configPath}
+	}
+
+	sourceName := worker.ArtifactName(segs[0])
+	filePath := segs[1]
+
+	source, found := step.repo.SourceFor(sourceName)
+	if !found {
+		return atc.Config{}, UnknownArtifactSourceError{sourceName}
+	}
+
+	stream, err := source.StreamFile(filePath)
+	if err != nil {
+		if err == baggageclaim.ErrFileNotFound {
+			return atc.Config{}, fmt.Errorf("pipeline config '%s/%s' not found", sourceName, filePath)
+		}
+		return atc.Config{}, err
+	}
+
+	defer stream.Close()
+
+	streamedFile, err := ioutil.ReadAll(stream)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	config, err := atc.LoadConfig(streamedFile)
+	if err != nil {
+		return atc.Config{}, fmt.Errorf("failed to load %s: %s", step.configPath, err)
+	}
+
+	return config, nil
+}
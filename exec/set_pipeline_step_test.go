@@ -0,0 +1,298 @@
+package exec_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/dbng/dbngfakes"
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+	"github.com/concourse/baggageclaim"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("SetPipelineStep", func() {
+	var (
+		fakeTeamFactory    *dbngfakes.FakeTeamFactory
+		fakeTeam           *dbngfakes.FakeTeam
+		fakeArtifactSource *workerfakes.FakeArtifactSource
+		fakeDelegate       *execfakes.FakeSetPipelineDelegate
+		fakeSavedPipeline  *dbngfakes.FakePipeline
+		stdoutBuf          *gbytes.Buffer
+
+		factory Factory
+
+		configPath string
+		repo       *worker.ArtifactRepository
+
+		step    Step
+		process ifrit.Process
+		runErr  error
+	)
+
+	validConfig := `
+resources:
+- name: some-resource
+  type: git
+  source:
+    uri: "git://some-resource"
+    nested: {some: value}
+
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+`
+
+	BeforeEach(func() {
+		fakeTeam = new(dbngfakes.FakeTeam)
+		fakeTeamFactory = new(dbngfakes.FakeTeamFactory)
+		fakeTeamFactory.GetByIDReturns(fakeTeam)
+
+		fakeSavedPipeline = new(dbngfakes.FakePipeline)
+		fakeSavedPipeline.ConfigVersionReturns(43)
+		fakeTeam.SavePipelineReturns(fakeSavedPipeline, true, nil)
+
+		stdoutBuf = gbytes.NewBuffer()
+		fakeDelegate = new(execfakes.FakeSetPipelineDelegate)
+		fakeDelegate.StdoutReturns(stdoutBuf)
+
+		factory = NewGardenFactory(
+			new(workerfakes.FakeClient),
+			nil,
+			nil,
+			new(dbngfakes.FakeResourceCacheFactory),
+			fakeTeamFactory,
+			atc.ContainerLimits{},
+		)
+
+		configPath = "some-source/pipeline.yml"
+
+		fakeArtifactSource = new(workerfakes.FakeArtifactSource)
+		repo = worker.NewArtifactRepository()
+		repo.RegisterSource("some-source", fakeArtifactSource)
+	})
+
+	JustBeforeEach(func() {
+		step = factory.SetPipeline(
+			lagertest.NewTestLogger("test"),
+			fakeDelegate,
+			123,
+			"some-pipeline",
+			configPath,
+		).Using(new(execfakes.FakeStep), repo)
+
+		process = ifrit.Invoke(step)
+		runErr = <-process.Wait()
+	})
+
+	Context("when the config file is valid", func() {
+		BeforeEach(func() {
+			fakeArtifactSource.StreamFileReturns(gbytes.BufferWithBytes([]byte(validConfig)), nil)
+		})
+
+		It("reads the file from the artifact source", func() {
+			Expect(fakeArtifactSource.StreamFileArgsForCall(0)).To(Equal("pipeline.yml"))
+		})
+
+		It("looks up the build's team", func() {
+			Expect(fakeTeamFactory.GetByIDArgsForCall(0)).To(Equal(123))
+		})
+
+		It("initializes and finishes via the delegate", func() {
+			Expect(fakeDelegate.InitializingCallCount()).To(Equal(1))
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			Expect(fakeDelegate.FinishedArgsForCall(0)).To(Equal(ExitStatus(0)))
+		})
+
+		It("writes the saved config version to stdout", func() {
+			Expect(stdoutBuf).To(gbytes.Say("saved pipeline 'some-pipeline' at config version 43"))
+		})
+
+		Context("when the config has warnings", func() {
+			BeforeEach(func() {
+				fakeArtifactSource.StreamFileReturns(gbytes.BufferWithBytes([]byte(validConfig+`
+  - task: some-task
+    file: some-resource/task.yml
+    config:
+      platform: linux
+      run: {path: ls}
+`)), nil)
+			})
+
+			It("writes them to stdout and still saves the pipeline", func() {
+				Expect(runErr).NotTo(HaveOccurred())
+				Expect(stdoutBuf).To(gbytes.Say("WARNING: .*specifies both `file` and `config` in a task step"))
+				Expect(fakeTeam.SavePipelineCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the pipeline does not exist", func() {
+			It("creates it", func() {
+				Expect(runErr).NotTo(HaveOccurred())
+
+				Expect(fakeTeam.SavePipelineCallCount()).To(Equal(1))
+				name, config, version, pausedState, author := fakeTeam.SavePipelineArgsForCall(0)
+				Expect(name).To(Equal("some-pipeline"))
+				Expect(config.Jobs).To(Equal(atc.JobConfigs{
+					{
+						Name: "some-job",
+						Plan: atc.PlanSequence{{Get: "some-resource"}},
+					},
+				}))
+				Expect(version).To(BeZero())
+				Expect(pausedState).To(Equal(dbng.PipelineNoChange))
+				Expect(author).To(Equal("set_pipeline"))
+			})
+
+			It("decodes nested config so that it can be saved as JSON", func() {
+				_, config, _, _, _ := fakeTeam.SavePipelineArgsForCall(0)
+				Expect(config.Resources[0].Source).To(Equal(atc.Source{
+					"uri":    "git://some-resource",
+					"nested": map[string]interface{}{"some": "value"},
+				}))
+
+				_, err := json.Marshal(config)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("succeeds", func() {
+				var success Success
+				Expect(step.Result(&success)).To(BeTrue())
+				Expect(bool(success)).To(BeTrue())
+			})
+		})
+
+		Context("when the pipeline already exists", func() {
+			BeforeEach(func() {
+				fakePipeline := new(dbngfakes.FakePipeline)
+				fakePipeline.ConfigVersionReturns(42)
+				fakeTeam.FindPipelineByNameReturns(fakePipeline, true, nil)
+			})
+
+			It("saves it from the current config version", func() {
+				Expect(runErr).NotTo(HaveOccurred())
+
+				Expect(fakeTeam.FindPipelineByNameArgsForCall(0)).To(Equal("some-pipeline"))
+
+				_, _, version, _, _ := fakeTeam.SavePipelineArgsForCall(0)
+				Expect(version).To(Equal(dbng.ConfigVersion(42)))
+			})
+		})
+
+		Context("when saving the pipeline fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeTeam.SavePipelineReturns(nil, false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(runErr).To(Equal(disaster))
+			})
+
+			It("reports the failure to the delegate without finishing", func() {
+				Expect(fakeDelegate.FailedCallCount()).To(Equal(1))
+				Expect(fakeDelegate.FailedArgsForCall(0)).To(Equal(disaster))
+				Expect(fakeDelegate.FinishedCallCount()).To(BeZero())
+			})
+
+			It("does not succeed", func() {
+				var success Success
+				Expect(step.Result(&success)).To(BeTrue())
+				Expect(bool(success)).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when the config file is invalid", func() {
+		BeforeEach(func() {
+			fakeArtifactSource.StreamFileReturns(gbytes.BufferWithBytes([]byte(`
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+`)), nil)
+		})
+
+		It("returns the validation errors without saving", func() {
+			Expect(runErr).To(BeAssignableToTypeOf(InvalidPipelineConfigError{}))
+			Expect(runErr.Error()).To(ContainSubstring("refers to a resource that does not exist"))
+			Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+		})
+
+		It("reports the failure to the delegate", func() {
+			Expect(fakeDelegate.FailedCallCount()).To(Equal(1))
+			Expect(fakeDelegate.FailedArgsForCall(0)).To(Equal(runErr))
+		})
+	})
+
+	Context("when the config file has unknown keys", func() {
+		BeforeEach(func() {
+			fakeArtifactSource.StreamFileReturns(gbytes.BufferWithBytes([]byte(validConfig+`
+bogus_key: true
+`)), nil)
+		})
+
+		It("returns an error without saving", func() {
+			Expect(runErr).To(MatchError(ContainSubstring("unknown/extra keys")))
+			Expect(runErr).To(MatchError(ContainSubstring("bogus_key")))
+			Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the config file is malformed", func() {
+		var streamedOut *gbytes.Buffer
+
+		BeforeEach(func() {
+			streamedOut = gbytes.BufferWithBytes([]byte("bogus"))
+			fakeArtifactSource.StreamFileReturns(streamedOut, nil)
+		})
+
+		It("returns an error", func() {
+			Expect(runErr).To(HaveOccurred())
+		})
+
+		It("closes the stream", func() {
+			Expect(streamedOut.Closed()).To(BeTrue())
+		})
+	})
+
+	Context("when the config file does not exist", func() {
+		BeforeEach(func() {
+			fakeArtifactSource.StreamFileReturns(nil, baggageclaim.ErrFileNotFound)
+		})
+
+		It("returns an error", func() {
+			Expect(runErr).To(MatchError("pipeline config 'some-source/pipeline.yml' not found"))
+		})
+	})
+
+	Context("when the artifact source is unknown", func() {
+		BeforeEach(func() {
+			configPath = "bogus-source/pipeline.yml"
+		})
+
+		It("returns an UnknownArtifactSourceError", func() {
+			Expect(runErr).To(Equal(UnknownArtifactSourceError{"bogus-source"}))
+		})
+	})
+
+	Context("when the path does not specify an artifact source", func() {
+		BeforeEach(func() {
+			configPath = "pipeline.yml"
+		})
+
+		It("returns an UnspecifiedArtifactSourceError", func() {
+			Expect(runErr).To(Equal(UnspecifiedArtifactSourceError{"pipeline.yml"}))
+		})
+	})
+})
@@ -14,6 +14,7 @@ import (
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
@@ -61,6 +62,8 @@ type TaskStep struct {
 	outputMapping     map[string]string
 	imageArtifactName string
 	clock             clock.Clock
+	defaultLimits     atc.ContainerLimits
+	variables         creds.Variables
 	repo              *worker.ArtifactRepository
 
 	process garden.Process
@@ -85,6 +88,8 @@ func newTaskStep(
 	outputMapping map[string]string,
 	imageArtifactName string,
 	clock clock.Clock,
+	defaultLimits atc.ContainerLimits,
+	variables creds.Variables,
 ) TaskStep {
 	return TaskStep{
 		logger:            logger,
@@ -103,6 +108,8 @@ func newTaskStep(
 		outputMapping:     outputMapping,
 		imageArtifactName: imageArtifactName,
 		clock:             clock,
+		defaultLimits:     defaultLimits,
+		variables:         variables,
 	}
 }
 
@@ -117,10 +124,12 @@ func (step TaskStep) Using(prev Step, repo *worker.ArtifactRepository) Step {
 	}
 }
 
-// Run will first load the TaskConfig. A worker will be selected based on the
-// TaskConfig's platform, the TaskStep's tags, and prioritized by availability
-// of volumes for the TaskConfig's inputs. Inputs that did not have volumes
-// available on the worker will be streamed in to the container.
+// Run will first load the TaskConfig and resolve any ((var)) placeholders in
+// its params and image resource, as well as in the resource types. A worker
+// will be selected based on the TaskConfig's platform, the TaskStep's tags,
+// and prioritized by availability of volumes for the TaskConfig's inputs.
+// Inputs that did not have volumes available on the worker will be streamed
+// in to the container.
 //
 // If any inputs are not available in the worker.ArtifactRepository, MissingInputsError
 // is returned.
@@ -152,6 +161,16 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		return err
 	}
 
+	config, err = step.variables.EvaluateTaskConfig(config)
+	if err != nil {
+		return err
+	}
+
+	step.resourceTypes, err = step.variables.EvaluateResourceTypes(step.resourceTypes)
+	if err != nil {
+		return err
+	}
+
 	step.metadata.EnvironmentVariables = step.envForParams(config.Params)
 
 	runContainerID := step.containerID
@@ -345,12 +364,22 @@ func (step *TaskStep) createContainer(config atc.TaskConfig, signals <-chan os.S
 		return nil, nil, MissingInputsError{missingInputs}
 	}
 
+	limits := step.defaultLimits
+	if config.Limits != nil {
+		limits = config.Limits.WithDefaults(step.defaultLimits)
+	}
+
 	containerSpec := worker.ContainerSpec{
 		Platform:  config.Platform,
 		Tags:      step.tags,
 		TeamID:    step.teamID,
 		ImageSpec: imageSpec,
 		User:      config.Run.User,
+		Limits:    limits,
+	}
+
+	for _, cache := range config.Caches {
+		containerSpec.Caches = append(containerSpec.Caches, filepath.Join(step.artifactsRoot, cache.Path))
 	}
 
 	resource, missingInputSources, err := step.resourceFactory.NewBuildResource(
@@ -15,6 +15,8 @@ import (
 	"code.cloudfoundry.org/garden/gardenfakes"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/dbng/dbngfakes"
 	. "github.com/concourse/atc/exec"
@@ -53,7 +55,7 @@ var _ = Describe("GardenFactory", func() {
 		fakeResourceFactory = new(resourcefakes.FakeResourceFactory)
 		fakeResourceFetcher := new(resourcefakes.FakeFetcher)
 		fakeDBResourceCacheFactory = new(dbngfakes.FakeResourceCacheFactory)
-		factory = NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory)
+		factory = NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory, nil, atc.ContainerLimits{})
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
@@ -70,6 +72,8 @@ var _ = Describe("GardenFactory", func() {
 			inputMapping  map[string]string
 			outputMapping map[string]string
 
+			fakeCredentialManager *credsfakes.FakeCredentialManager
+
 			inStep *execfakes.FakeStep
 			repo   *worker.ArtifactRepository
 
@@ -104,6 +108,7 @@ var _ = Describe("GardenFactory", func() {
 			inputMapping = nil
 			outputMapping = nil
 			imageArtifactName = ""
+			fakeCredentialManager = new(credsfakes.FakeCredentialManager)
 			fakeClock = fakeclock.NewFakeClock(time.Unix(0, 123))
 
 			identifier = worker.Identifier{
@@ -134,6 +139,7 @@ var _ = Describe("GardenFactory", func() {
 				outputMapping,
 				imageArtifactName,
 				fakeClock,
+				creds.NewVariables(fakeCredentialManager, "some-team", "some-pipeline"),
 			).Using(inStep, repo)
 
 			process = ifrit.Invoke(step)
@@ -245,6 +251,45 @@ var _ = Describe("GardenFactory", func() {
 						Expect(actualResourceTypes).To(Equal(resourceTypes))
 					})
 
+					Context("when the config and resource types contain credentials", func() {
+						BeforeEach(func() {
+							fetchedConfig.ImageResource.Source = atc.Source{"some": "((image-cred))"}
+							fetchedConfig.Params = map[string]string{"SOME": "((param-cred))"}
+							configSource.FetchConfigReturns(fetchedConfig, nil)
+
+							resourceTypes[0].Source = atc.Source{"some-custom": "((type-cred))"}
+
+							fakeCredentialManager.GetStub = func(teamName string, pipelineName string, varName string) (interface{}, bool, error) {
+								return "some-" + varName, true, nil
+							}
+						})
+
+						It("resolves them when the step runs", func() {
+							Expect(fakeResourceFactory.NewBuildResourceCallCount()).To(Equal(1))
+							_, _, createdMetadata, spec, actualResourceTypes, _, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
+							Expect(createdMetadata.EnvironmentVariables).To(Equal([]string{"SOME=some-param-cred"}))
+							Expect(spec.ImageSpec.ImageResource.Source).To(Equal(atc.Source{"some": "some-image-cred"}))
+							Expect(actualResourceTypes[0].Source).To(Equal(atc.Source{"some-custom": "some-type-cred"}))
+						})
+					})
+
+					Context("when a credential is not defined", func() {
+						BeforeEach(func() {
+							fetchedConfig.Params = map[string]string{"SOME": "((bogus))"}
+							configSource.FetchConfigReturns(fetchedConfig, nil)
+						})
+
+						It("exits with the error without creating the container", func() {
+							Eventually(process.Wait()).Should(Receive(Equal(creds.UndefinedVariableError{Name: "bogus"})))
+							Expect(fakeResourceFactory.NewBuildResourceCallCount()).To(BeZero())
+						})
+
+						It("invokes the delegate's Failed callback", func() {
+							Eventually(process.Wait()).Should(Receive(HaveOccurred()))
+							Expect(taskDelegate.FailedCallCount()).To(Equal(1))
+						})
+					})
+
 					It("ensures artifacts root exists by streaming in an empty payload", func() {
 						Expect(fakeContainer.StreamInCallCount()).To(Equal(1))
 
@@ -338,6 +383,66 @@ var _ = Describe("GardenFactory", func() {
 						})
 					})
 
+					Context("when the configuration specifies caches", func() {
+						BeforeEach(func() {
+							fetchedConfig.Caches = []atc.CacheConfig{
+								{Path: "some-cache"},
+								{Path: "some/other/cache"},
+							}
+
+							configSource.FetchConfigReturns(fetchedConfig, nil)
+						})
+
+						It("mounts the caches relative to the working directory", func() {
+							Expect(fakeResourceFactory.NewBuildResourceCallCount()).To(Equal(1))
+							_, _, _, spec, _, _, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
+							Expect(spec.Caches).To(Equal([]string{
+								"/tmp/build/a1f5c0c1/some-cache",
+								"/tmp/build/a1f5c0c1/some/other/cache",
+							}))
+						})
+					})
+
+					Context("when the configuration specifies container limits", func() {
+						BeforeEach(func() {
+							fetchedConfig.Limits = &atc.ContainerLimits{Memory: 1024}
+
+							configSource.FetchConfigReturns(fetchedConfig, nil)
+
+							factory = NewGardenFactory(fakeWorkerClient, nil, fakeResourceFactory, fakeDBResourceCacheFactory, nil, atc.ContainerLimits{
+								CPU:    512,
+								Memory: 4096,
+							})
+						})
+
+						It("creates the container with the limits, falling back to the defaults", func() {
+							Expect(fakeResourceFactory.NewBuildResourceCallCount()).To(Equal(1))
+							_, _, _, spec, _, _, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
+							Expect(spec.Limits).To(Equal(atc.ContainerLimits{
+								CPU:    512,
+								Memory: 1024,
+							}))
+						})
+					})
+
+					Context("when the configuration does not specify container limits", func() {
+						BeforeEach(func() {
+							factory = NewGardenFactory(fakeWorkerClient, nil, fakeResourceFactory, fakeDBResourceCacheFactory, nil, atc.ContainerLimits{
+								CPU:    512,
+								Memory: 4096,
+							})
+						})
+
+						It("creates the container with the defaults", func() {
+							Expect(fakeResourceFactory.NewBuildResourceCallCount()).To(Equal(1))
+							_, _, _, spec, _, _, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
+							Expect(spec.Limits).To(Equal(atc.ContainerLimits{
+								CPU:    512,
+								Memory: 4096,
+							}))
+						})
+					})
+
 					Context("when the configuration specifies paths for inputs", func() {
 						var inputSource *workerfakes.FakeArtifactSource
 						var otherInputSource *workerfakes.FakeArtifactSource
@@ -1,7 +1,11 @@
 package buildreaper
 
 import (
+	"math"
+
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 )
 
@@ -21,19 +25,28 @@ type buildReaper struct {
 	db                BuildReaperDB
 	pipelineDBFactory db.PipelineDBFactory
 	batchSize         int
+	defaultRetention  atc.BuildLogRetention
+	clock             clock.Clock
 }
 
+// NewBuildReaper constructs a BuildReaper which reaps build logs of jobs in
+// all pipelines, paused or not. Jobs which do not configure any retention use
+// defaultRetention.
 func NewBuildReaper(
 	logger lager.Logger,
 	db BuildReaperDB,
 	pipelineDBFactory db.PipelineDBFactory,
 	batchSize int,
+	defaultRetention atc.BuildLogRetention,
+	clock clock.Clock,
 ) BuildReaper {
 	return &buildReaper{
 		logger:            logger,
 		db:                db,
 		pipelineDBFactory: pipelineDBFactory,
 		batchSize:         batchSize,
+		defaultRetention:  defaultRetention,
+		clock:             clock,
 	}
 }
 
@@ -45,10 +58,6 @@ func (br *buildReaper) Run() error {
 	}
 
 	for _, pipeline := range pipelines {
-		if pipeline.Paused {
-			continue
-		}
-
 		pipelineDB := br.pipelineDBFactory.Build(pipeline)
 
 		jobs, err := pipelineDB.GetJobs()
@@ -58,98 +67,184 @@ func (br *buildReaper) Run() error {
 		}
 
 		for _, job := range jobs {
-			if job.Config.BuildLogsToRetain == 0 {
-				continue
+			err = br.reapJob(pipelineDB, job)
+			if err != nil {
+				return err
 			}
+		}
+	}
 
-			buildsToConsiderDeleting := []db.Build{}
-			until := job.FirstLoggedBuildID - 1
-			limit := br.batchSize
+	return nil
+}
 
-			if job.FirstLoggedBuildID <= 1 {
-				until = 1
+func (br *buildReaper) retentionFor(job db.SavedJob) atc.BuildLogRetention {
+	if job.Config.BuildLogRetention != nil {
+		return *job.Config.BuildLogRetention
+	}
 
-				buildsToConsiderDeleting, _, err = pipelineDB.GetJobBuilds(
-					job.Job.Name,
-					db.Page{Since: 2, Limit: 1},
-				)
-				if err != nil {
-					br.logger.Error("could-not-get-job-build-1-to-delete", err)
-					return err
-				}
+	if job.Config.BuildLogsToRetain != 0 {
+		return atc.BuildLogRetention{Builds: job.Config.BuildLogsToRetain}
+	}
 
-				limit -= len(buildsToConsiderDeleting)
-			}
+	return br.defaultRetention
+}
 
-			if limit > 0 {
-				moreBuildsToConsiderDeleting, _, err := pipelineDB.GetJobBuilds(
-					job.Job.Name,
-					db.Page{Until: until, Limit: limit},
-				)
-				if err != nil {
-					br.logger.Error("could-not-get-job-builds-to-delete", err)
-					return err
-				}
-
-				buildsToConsiderDeleting = append(
-					moreBuildsToConsiderDeleting,
-					buildsToConsiderDeleting...,
-				)
-			}
+func (br *buildReaper) reapJob(pipelineDB db.PipelineDB, job db.SavedJob) error {
+	retention := br.retentionFor(job)
+	if retention.Builds == 0 && retention.Days == 0 {
+		return nil
+	}
 
-			buildIDsToConsiderDeleting := []int{}
-			for _, build := range buildsToConsiderDeleting {
-				buildIDsToConsiderDeleting = append(buildIDsToConsiderDeleting, build.ID())
-			}
+	buildsToConsiderDeleting, err := br.buildsToConsiderDeleting(pipelineDB, job)
+	if err != nil {
+		return err
+	}
 
-			buildsToRetain, _, err := pipelineDB.GetJobBuilds(
-				job.Job.Name,
-				db.Page{Limit: job.Config.BuildLogsToRetain},
-			)
-			if err != nil {
-				br.logger.Error("could-not-get-job-builds-to-retain", err)
-				return err
-			}
+	firstBuildToRetain := math.MaxInt32
+	if retention.Builds > 0 {
+		buildsToRetain, _, err := pipelineDB.GetJobBuilds(
+			job.Job.Name,
+			db.Page{Limit: retention.Builds},
+		)
+		if err != nil {
+			br.logger.Error("could-not-get-job-builds-to-retain", err)
+			return err
+		}
 
-			buildIDsToRetain := []int{}
-			for _, build := range buildsToRetain {
-				buildIDsToRetain = append(buildIDsToRetain, build.ID())
-			}
+		if len(buildsToRetain) == 0 {
+			return nil
+		}
 
-			if len(buildsToRetain) == 0 {
-				continue
-			}
+		firstBuildToRetain = buildsToRetain[len(buildsToRetain)-1].ID()
+	}
 
-			firstBuildToRetain := buildsToRetain[len(buildsToRetain)-1].ID()
+	firstSucceededBuildToRetain := math.MaxInt32
+	if retention.MinimumSucceededBuilds > 0 {
+		firstSucceededBuildToRetain, err = br.firstSucceededBuildToRetain(pipelineDB, job, retention.MinimumSucceededBuilds)
+		if err != nil {
+			return err
+		}
+	}
+
+	expiredBefore := br.clock.Now().AddDate(0, 0, -retention.Days)
+
+	buildIDsToDelete := []int{}
+	for i := len(buildsToConsiderDeleting) - 1; i >= 0; i-- {
+		build := buildsToConsiderDeleting[i]
 
-			buildIDsToDelete := []int{}
-			for i := len(buildsToConsiderDeleting) - 1; i >= 0; i-- {
-				build := buildsToConsiderDeleting[i]
+		if build.IsRunning() || build.ID() >= firstSucceededBuildToRetain {
+			break
+		}
+
+		exceedsBuilds := retention.Builds > 0 && build.ID() < firstBuildToRetain
+		exceedsDays := retention.Days > 0 && !build.EndTime().IsZero() && build.EndTime().Before(expiredBefore)
 
-				if build.ID() >= firstBuildToRetain || build.IsRunning() {
-					break
-				}
+		if !exceedsBuilds && !exceedsDays {
+			break
+		}
+
+		buildIDsToDelete = append(buildIDsToDelete, build.ID())
+	}
 
-				buildIDsToDelete = append(buildIDsToDelete, build.ID())
+	if len(buildIDsToDelete) == 0 {
+		return nil
+	}
+
+	err = br.db.DeleteBuildEventsByBuildIDs(buildIDsToDelete)
+	if err != nil {
+		br.logger.Error("could-not-delete-build-events", err)
+		return err
+	}
+
+	err = pipelineDB.UpdateFirstLoggedBuildID(job.Job.Name, buildIDsToDelete[len(buildIDsToDelete)-1]+1)
+	if err != nil {
+		br.logger.Error("could-not-update-first-logged-build-id", err)
+		return err
+	}
+
+	return nil
+}
+
+// buildsToConsiderDeleting returns up to batchSize of the job's oldest builds
+// whose logs have not yet been reaped, newest first.
+func (br *buildReaper) buildsToConsiderDeleting(pipelineDB db.PipelineDB, job db.SavedJob) ([]db.Build, error) {
+	var err error
+
+	buildsToConsiderDeleting := []db.Build{}
+	until := job.FirstLoggedBuildID - 1
+	limit := br.batchSize
+
+	if job.FirstLoggedBuildID <= 1 {
+		until = 1
+
+		buildsToConsiderDeleting, _, err = pipelineDB.GetJobBuilds(
+			job.Job.Name,
+			db.Page{Since: 2, Limit: 1},
+		)
+		if err != nil {
+			br.logger.Error("could-not-get-job-build-1-to-delete", err)
+			return nil, err
+		}
+
+		limit -= len(buildsToConsiderDeleting)
+	}
+
+	if limit > 0 {
+		moreBuildsToConsiderDeleting, _, err := pipelineDB.GetJobBuilds(
+			job.Job.Name,
+			db.Page{Until: until, Limit: limit},
+		)
+		if err != nil {
+			br.logger.Error("could-not-get-job-builds-to-delete", err)
+			return nil, err
+		}
+
+		buildsToConsiderDeleting = append(
+			moreBuildsToConsiderDeleting,
+			buildsToConsiderDeleting...,
+		)
+	}
+
+	return buildsToConsiderDeleting, nil
+}
+
+// firstSucceededBuildToRetain walks back through the job's logged builds and
+// returns the ID of the oldest of the most recent minimum succeeded builds.
+// If the job has no logged succeeded builds, nothing needs to be retained on
+// their account.
+func (br *buildReaper) firstSucceededBuildToRetain(pipelineDB db.PipelineDB, job db.SavedJob, minimum int) (int, error) {
+	firstSucceededBuildToRetain := math.MaxInt32
+	succeeded := 0
+
+	page := db.Page{Limit: br.batchSize}
+	for {
+		builds, _, err := pipelineDB.GetJobBuilds(job.Job.Name, page)
+		if err != nil {
+			br.logger.Error("could-not-get-job-builds-to-find-succeeded", err)
+			return 0, err
+		}
+
+		for _, build := range builds {
+			if build.ID() < job.FirstLoggedBuildID {
+				return firstSucceededBuildToRetain, nil
 			}
 
-			if len(buildIDsToDelete) == 0 {
+			if build.Status() != db.StatusSucceeded {
 				continue
 			}
 
-			err = br.db.DeleteBuildEventsByBuildIDs(buildIDsToDelete)
-			if err != nil {
-				br.logger.Error("could-not-delete-build-events", err)
-				return err
-			}
+			firstSucceededBuildToRetain = build.ID()
+			succeeded++
 
-			err = pipelineDB.UpdateFirstLoggedBuildID(job.Job.Name, buildIDsToDelete[len(buildIDsToDelete)-1]+1)
-			if err != nil {
-				br.logger.Error("could-not-update-first-logged-build-id", err)
-				return err
+			if succeeded == minimum {
+				return firstSucceededBuildToRetain, nil
 			}
 		}
-	}
 
-	return nil
+		if len(builds) < page.Limit {
+			return firstSucceededBuildToRetain, nil
+		}
+
+		page = db.Page{Since: builds[len(builds)-1].ID(), Limit: br.batchSize}
+	}
 }
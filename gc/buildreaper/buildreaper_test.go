@@ -3,7 +3,9 @@ package buildreaper_test
 import (
 	"errors"
 	"fmt"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
@@ -21,12 +23,16 @@ var _ = Describe("BuildReaper", func() {
 		fakeBuildReaperDB     *buildreaperfakes.FakeBuildReaperDB
 		fakePipelineDBFactory *dbfakes.FakePipelineDBFactory
 		batchSize             int
+		defaultRetention      atc.BuildLogRetention
+		fakeClock             *fakeclock.FakeClock
 	)
 
 	BeforeEach(func() {
 		fakeBuildReaperDB = new(buildreaperfakes.FakeBuildReaperDB)
 		fakePipelineDBFactory = new(dbfakes.FakePipelineDBFactory)
 		batchSize = 5
+		defaultRetention = atc.BuildLogRetention{}
+		fakeClock = fakeclock.NewFakeClock(time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC))
 	})
 
 	JustBeforeEach(func() {
@@ -36,6 +42,8 @@ var _ = Describe("BuildReaper", func() {
 			fakeBuildReaperDB,
 			fakePipelineDBFactory,
 			batchSize,
+			defaultRetention,
+			fakeClock,
 		)
 	})
 
@@ -512,19 +520,202 @@ var _ = Describe("BuildReaper", func() {
 	})
 
 	Context("when there is a paused pipeline", func() {
+		var fakePipelineDB *dbfakes.FakePipelineDB
+
 		BeforeEach(func() {
 			fakeBuildReaperDB.GetAllPipelinesReturns([]db.SavedPipeline{
 				{ID: 42, Paused: true},
 			}, nil)
+
+			fakePipelineDB = new(dbfakes.FakePipelineDB)
+			fakePipelineDBFactory.BuildReturns(fakePipelineDB)
+
+			fakePipelineDB.GetJobsReturns([]db.SavedJob{
+				db.SavedJob{
+					Job:                db.Job{Name: "job-1"},
+					FirstLoggedBuildID: 6,
+					Config: atc.JobConfig{
+						BuildLogsToRetain: 2,
+					},
+				},
+			}, nil)
+
+			fakePipelineDB.GetJobBuildsStub = func(job string, page db.Page) ([]db.Build, db.Pagination, error) {
+				if page == (db.Page{Limit: 2}) {
+					return []db.Build{sb(8), sb(7)}, db.Pagination{}, nil
+				} else if page == (db.Page{Until: 5, Limit: 5}) {
+					return []db.Build{sb(8), sb(7), sb(6)}, db.Pagination{}, nil
+				}
+				Fail(fmt.Sprintf("GetJobBuilds called with unexpected arguments: job=%s, page=%#v", job, page))
+				return nil, db.Pagination{}, nil
+			}
+		})
+
+		It("reaps the pipeline's build logs too", func() {
+			err := buildReaper.Run()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakePipelineDBFactory.BuildCallCount()).To(Equal(1))
+			Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+			Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(ConsistOf(6))
+		})
+	})
+
+	Context("when a job configures build_log_retention", func() {
+		var (
+			fakePipelineDB *dbfakes.FakePipelineDB
+			retention      atc.BuildLogRetention
+			builds         []db.Build
+		)
+
+		BeforeEach(func() {
+			fakeBuildReaperDB.GetAllPipelinesReturns([]db.SavedPipeline{{ID: 42}}, nil)
+
+			fakePipelineDB = new(dbfakes.FakePipelineDB)
+			fakePipelineDBFactory.BuildReturns(fakePipelineDB)
+
+			now := fakeClock.Now()
+
+			builds = []db.Build{
+				finishedBuild(10, db.StatusFailed, now.Add(-1*time.Hour)),
+				finishedBuild(9, db.StatusFailed, now.Add(-24*time.Hour)),
+				finishedBuild(8, db.StatusSucceeded, now.Add(-2*24*time.Hour)),
+				finishedBuild(7, db.StatusFailed, now.Add(-3*24*time.Hour)),
+				finishedBuild(6, db.StatusSucceeded, now.Add(-4*24*time.Hour)),
+			}
+
+			fakePipelineDB.GetJobBuildsStub = func(job string, page db.Page) ([]db.Build, db.Pagination, error) {
+				switch {
+				case page.Until == 5:
+					return builds, db.Pagination{}, nil
+				case page.Since == 0 && page.Until == 0:
+					if page.Limit > len(builds) {
+						return builds, db.Pagination{}, nil
+					}
+					return builds[:page.Limit], db.Pagination{}, nil
+				case page.Since != 0:
+					return []db.Build{}, db.Pagination{}, nil
+				}
+				Fail(fmt.Sprintf("GetJobBuilds called with unexpected arguments: job=%s, page=%#v", job, page))
+				return nil, db.Pagination{}, nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			fakePipelineDB.GetJobsReturns([]db.SavedJob{
+				db.SavedJob{
+					Job:                db.Job{Name: "job-1"},
+					FirstLoggedBuildID: 6,
+					Config: atc.JobConfig{
+						BuildLogRetention: &retention,
+					},
+				},
+			}, nil)
+		})
+
+		Context("with days", func() {
+			BeforeEach(func() {
+				retention = atc.BuildLogRetention{Days: 2}
+			})
+
+			It("reaps builds which finished before then", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(ConsistOf(6, 7))
+
+				_, newFirstLoggedBuildID := fakePipelineDB.UpdateFirstLoggedBuildIDArgsForCall(0)
+				Expect(newFirstLoggedBuildID).To(Equal(8))
+			})
+		})
+
+		Context("with days and builds", func() {
+			BeforeEach(func() {
+				retention = atc.BuildLogRetention{Days: 3, Builds: 2}
+			})
+
+			It("reaps builds outside of either", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(ConsistOf(6, 7, 8))
+			})
+		})
+
+		Context("with minimum_succeeded_builds", func() {
+			BeforeEach(func() {
+				retention = atc.BuildLogRetention{Builds: 2, MinimumSucceededBuilds: 1}
+			})
+
+			It("retains builds back to the most recent succeeded builds", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(ConsistOf(6, 7))
+			})
+
+			Context("when there are fewer succeeded builds than the minimum", func() {
+				BeforeEach(func() {
+					retention = atc.BuildLogRetention{Builds: 2, MinimumSucceededBuilds: 2}
+				})
+
+				It("retains all of them", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
+
+	Context("when a job configures no retention", func() {
+		var fakePipelineDB *dbfakes.FakePipelineDB
+
+		BeforeEach(func() {
+			fakeBuildReaperDB.GetAllPipelinesReturns([]db.SavedPipeline{{ID: 42}}, nil)
+
+			fakePipelineDB = new(dbfakes.FakePipelineDB)
+			fakePipelineDBFactory.BuildReturns(fakePipelineDB)
+
+			fakePipelineDB.GetJobsReturns([]db.SavedJob{
+				db.SavedJob{
+					Job:                db.Job{Name: "job-1"},
+					FirstLoggedBuildID: 6,
+				},
+			}, nil)
+
+			fakePipelineDB.GetJobBuildsStub = func(job string, page db.Page) ([]db.Build, db.Pagination, error) {
+				if page == (db.Page{Limit: 1}) {
+					return []db.Build{sb(8)}, db.Pagination{}, nil
+				} else if page == (db.Page{Until: 5, Limit: 5}) {
+					return []db.Build{sb(8), sb(7), sb(6)}, db.Pagination{}, nil
+				}
+				Fail(fmt.Sprintf("GetJobBuilds called with unexpected arguments: job=%s, page=%#v", job, page))
+				return nil, db.Pagination{}, nil
+			}
 		})
 
-		It("skips the reaping step for that pipeline", func() {
+		It("does not reap anything", func() {
 			err := buildReaper.Run()
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(fakePipelineDBFactory.BuildCallCount()).To(BeZero())
+			Expect(fakePipelineDB.GetJobBuildsCallCount()).To(BeZero())
 			Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
 		})
+
+		Context("when a default is configured", func() {
+			BeforeEach(func() {
+				defaultRetention = atc.BuildLogRetention{Builds: 1}
+			})
+
+			It("reaps according to the default", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(ConsistOf(6, 7))
+			})
+		})
 	})
 
 	Context("when getting the pipelines fails", func() {
@@ -550,6 +741,15 @@ func sb(id int) db.Build {
 	return build
 }
 
+func finishedBuild(id int, status db.Status, endTime time.Time) db.Build {
+	build := new(dbfakes.FakeBuild)
+	build.IDReturns(id)
+	build.IsRunningReturns(false)
+	build.StatusReturns(status)
+	build.EndTimeReturns(endTime)
+	return build
+}
+
 func runningBuild(id int) db.Build {
 	build := new(dbfakes.FakeBuild)
 	build.IDReturns(id)
@@ -76,7 +76,7 @@ var _ = BeforeEach(func() {
 	defaultBuild, err = defaultTeam.CreateOneOffBuild()
 	Expect(err).NotTo(HaveOccurred())
 
-	defaultPipeline, _, err = defaultTeam.SavePipeline("default-pipeline", atc.Config{}, dbng.ConfigVersion(0), dbng.PipelineUnpaused)
+	defaultPipeline, _, err = defaultTeam.SavePipeline("default-pipeline", atc.Config{}, dbng.ConfigVersion(0), dbng.PipelineUnpaused, "")
 	Expect(err).NotTo(HaveOccurred())
 
 	usedResource, err = defaultPipeline.CreateResource(
@@ -71,6 +71,7 @@ var _ = Describe("ResourceCacheUseCollector", func() {
 					},
 					0,
 					dbng.PipelineNoChange,
+					"",
 				)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(created).To(BeTrue())
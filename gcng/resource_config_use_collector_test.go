@@ -73,6 +73,7 @@ var _ = Describe("ResourceConfigUseCollector", func() {
 					},
 					0,
 					dbng.PipelineNoChange,
+					"",
 				)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(created).To(BeTrue())
@@ -0,0 +1,54 @@
+package atc
+
+// InputsExplanation explains why a job's next inputs could or could not be
+// determined.
+type InputsExplanation struct {
+	Resolved  bool               `json:"resolved"`
+	Inputs    []InputExplanation `json:"inputs"`
+	Conflicts []PassedConflict   `json:"conflicts,omitempty"`
+}
+
+type InputExplanation struct {
+	Name     string `json:"name"`
+	Resource string `json:"resource"`
+
+	// Candidates is the number of versions which satisfy the input's own
+	// constraints. The input can not be satisfied if there are none.
+	Candidates int `json:"candidates"`
+
+	PinnedVersion            Version `json:"pinned_version,omitempty"`
+	PinnedVersionUnavailable bool    `json:"pinned_version_unavailable,omitempty"`
+
+	Passed []PassedExplanation `json:"passed,omitempty"`
+
+	SkippedDisabledVersions []Version `json:"skipped_disabled_versions,omitempty"`
+}
+
+type PassedExplanation struct {
+	Pipeline string `json:"pipeline,omitempty"`
+	Job      string `json:"job"`
+
+	// EliminatedVersions are the newest of the versions which satisfied the
+	// previous constraints but did not pass the job; EliminatedCount counts
+	// all of them.
+	EliminatedVersions []Version `json:"eliminated_versions,omitempty"`
+	EliminatedCount    int       `json:"eliminated_count"`
+
+	// Error explains why the outputs of a job of another pipeline could not
+	// be used for the input, e.g. because it does not share its resource's
+	// type and source.
+	Error string `json:"error,omitempty"`
+}
+
+// PassedConflict is a job which several inputs must have passed together,
+// but for which no single build has a candidate version of each of them.
+type PassedConflict struct {
+	Pipeline string   `json:"pipeline,omitempty"`
+	Job      string   `json:"job"`
+	Inputs   []string `json:"inputs"`
+}
+
+type JobInputsExplanation struct {
+	Inputs      []BuildInput      `json:"inputs"`
+	Explanation InputsExplanation `json:"explanation"`
+}
@@ -16,10 +16,10 @@ type Job struct {
 }
 
 type JobInput struct {
-	Name     string   `json:"name"`
-	Resource string   `json:"resource"`
-	Passed   []string `json:"passed,omitempty"`
-	Trigger  bool     `json:"trigger"`
+	Name     string        `json:"name"`
+	Resource string        `json:"resource"`
+	Passed   PassedConfigs `json:"passed,omitempty"`
+	Trigger  bool          `json:"trigger"`
 }
 
 type JobOutput struct {
@@ -0,0 +1,97 @@
+// This file was generated by counterfeiter
+package metricfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/metric"
+)
+
+type FakeEmitter struct {
+	EmitStub        func(lager.Logger, metric.Event) error
+	emitMutex       sync.RWMutex
+	emitArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 metric.Event
+	}
+	emitReturns struct {
+		result1 error
+	}
+	emitReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeEmitter) Emit(arg1 lager.Logger, arg2 metric.Event) error {
+	fake.emitMutex.Lock()
+	ret, specificReturn := fake.emitReturnsOnCall[len(fake.emitArgsForCall)]
+	fake.emitArgsForCall = append(fake.emitArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 metric.Event
+	}{arg1, arg2})
+	fake.recordInvocation("Emit", []interface{}{arg1, arg2})
+	fake.emitMutex.Unlock()
+	if fake.EmitStub != nil {
+		return fake.EmitStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.emitReturns.result1
+}
+
+func (fake *FakeEmitter) EmitCallCount() int {
+	fake.emitMutex.RLock()
+	defer fake.emitMutex.RUnlock()
+	return len(fake.emitArgsForCall)
+}
+
+func (fake *FakeEmitter) EmitArgsForCall(i int) (lager.Logger, metric.Event) {
+	fake.emitMutex.RLock()
+	defer fake.emitMutex.RUnlock()
+	return fake.emitArgsForCall[i].arg1, fake.emitArgsForCall[i].arg2
+}
+
+func (fake *FakeEmitter) EmitReturns(result1 error) {
+	fake.EmitStub = nil
+	fake.emitReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeEmitter) EmitReturnsOnCall(i int, result1 error) {
+	fake.EmitStub = nil
+	if fake.emitReturnsOnCall == nil {
+		fake.emitReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.emitReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeEmitter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.emitMutex.RLock()
+	defer fake.emitMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeEmitter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ metric.Emitter = new(FakeEmitter)
@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
-	"github.com/The-Cloud-Source/goryman"
 
 	"github.com/concourse/atc/db"
 )
@@ -13,20 +12,35 @@ import (
 var DatabaseQueries = Meter(0)
 var DatabaseConnections = &Gauge{}
 
+// The names of the events emitted by this package, for emitters which treat
+// them differently.
+const (
+	SchedulingFullDurationName         = "scheduling: full duration (ms)"
+	SchedulingLoadVersionsDurationName = "scheduling: loading versions duration (ms)"
+	SchedulingJobDurationName          = "scheduling: job duration (ms)"
+	WorkerContainersName               = "worker containers"
+	BuildStartedName                   = "build started"
+	BuildFinishedName                  = "build finished"
+	BuildQueueWaitTimeName             = "build queue: wait time (ms)"
+	HTTPResponseTimeName               = "http response time"
+	DatabaseQueriesName                = "database queries"
+	DatabaseConnectionsName            = "database connections"
+)
+
 type SchedulingFullDuration struct {
 	PipelineName string
 	Duration     time.Duration
 }
 
 func (event SchedulingFullDuration) Emit(logger lager.Logger) {
-	state := "ok"
+	state := EventStateOK
 
 	if event.Duration > time.Second {
-		state = "warning"
+		state = EventStateWarning
 	}
 
 	if event.Duration > 5*time.Second {
-		state = "critical"
+		state = EventStateCritical
 	}
 
 	emit(
@@ -35,10 +49,10 @@ func (event SchedulingFullDuration) Emit(logger lager.Logger) {
 			"duration": event.Duration.String(),
 		}),
 
-		goryman.Event{
-			Service: "scheduling: full duration (ms)",
-			Metric:  ms(event.Duration),
-			State:   state,
+		Event{
+			Name:  SchedulingFullDurationName,
+			Value: ms(event.Duration),
+			State: state,
 			Attributes: map[string]string{
 				"pipeline": event.PipelineName,
 			},
@@ -52,14 +66,14 @@ type SchedulingLoadVersionsDuration struct {
 }
 
 func (event SchedulingLoadVersionsDuration) Emit(logger lager.Logger) {
-	state := "ok"
+	state := EventStateOK
 
 	if event.Duration > time.Second {
-		state = "warning"
+		state = EventStateWarning
 	}
 
 	if event.Duration > 5*time.Second {
-		state = "critical"
+		state = EventStateCritical
 	}
 
 	emit(
@@ -67,10 +81,10 @@ func (event SchedulingLoadVersionsDuration) Emit(logger lager.Logger) {
 			"pipeline": event.PipelineName,
 			"duration": event.Duration.String(),
 		}),
-		goryman.Event{
-			Service: "scheduling: loading versions duration (ms)",
-			Metric:  ms(event.Duration),
-			State:   state,
+		Event{
+			Name:  SchedulingLoadVersionsDurationName,
+			Value: ms(event.Duration),
+			State: state,
 			Attributes: map[string]string{
 				"pipeline": event.PipelineName,
 			},
@@ -85,14 +99,14 @@ type SchedulingJobDuration struct {
 }
 
 func (event SchedulingJobDuration) Emit(logger lager.Logger) {
-	state := "ok"
+	state := EventStateOK
 
 	if event.Duration > time.Second {
-		state = "warning"
+		state = EventStateWarning
 	}
 
 	if event.Duration > 5*time.Second {
-		state = "critical"
+		state = EventStateCritical
 	}
 
 	emit(
@@ -101,10 +115,10 @@ func (event SchedulingJobDuration) Emit(logger lager.Logger) {
 			"job":      event.JobName,
 			"duration": event.Duration.String(),
 		}),
-		goryman.Event{
-			Service: "scheduling: job duration (ms)",
-			Metric:  ms(event.Duration),
-			State:   state,
+		Event{
+			Name:  SchedulingJobDurationName,
+			Value: ms(event.Duration),
+			State: state,
 			Attributes: map[string]string{
 				"pipeline": event.PipelineName,
 				"job":      event.JobName,
@@ -124,10 +138,10 @@ func (event WorkerContainers) Emit(logger lager.Logger) {
 			"worker":     event.WorkerName,
 			"containers": event.Containers,
 		}),
-		goryman.Event{
-			Service: "worker containers",
-			Metric:  event.Containers,
-			State:   "ok",
+		Event{
+			Name:  WorkerContainersName,
+			Value: event.Containers,
+			State: EventStateOK,
 			Attributes: map[string]string{
 				"worker": event.WorkerName,
 			},
@@ -150,10 +164,10 @@ func (event BuildStarted) Emit(logger lager.Logger) {
 			"build-name": event.BuildName,
 			"build-id":   event.BuildID,
 		}),
-		goryman.Event{
-			Service: "build started",
-			Metric:  event.BuildID,
-			State:   "ok",
+		Event{
+			Name:  BuildStartedName,
+			Value: event.BuildID,
+			State: EventStateOK,
 			Attributes: map[string]string{
 				"pipeline":   event.PipelineName,
 				"job":        event.JobName,
@@ -182,10 +196,10 @@ func (event BuildFinished) Emit(logger lager.Logger) {
 			"build-id":     event.BuildID,
 			"build-status": event.BuildStatus,
 		}),
-		goryman.Event{
-			Service: "build finished",
-			Metric:  ms(event.BuildDuration),
-			State:   "ok",
+		Event{
+			Name:  BuildFinishedName,
+			Value: ms(event.BuildDuration),
+			State: EventStateOK,
 			Attributes: map[string]string{
 				"pipeline":     event.PipelineName,
 				"job":          event.JobName,
@@ -197,6 +211,43 @@ func (event BuildFinished) Emit(logger lager.Logger) {
 	)
 }
 
+type BuildQueueWaitTime struct {
+	TeamName     string
+	PipelineName string
+	JobName      string
+	BuildName    string
+	BuildID      int
+	Priority     int
+	Duration     time.Duration
+}
+
+func (event BuildQueueWaitTime) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("build-queue-wait-time", lager.Data{
+			"team":       event.TeamName,
+			"pipeline":   event.PipelineName,
+			"job":        event.JobName,
+			"build-name": event.BuildName,
+			"build-id":   event.BuildID,
+			"priority":   event.Priority,
+			"duration":   event.Duration.String(),
+		}),
+		Event{
+			Name:  BuildQueueWaitTimeName,
+			Value: ms(event.Duration),
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"team":       event.TeamName,
+				"pipeline":   event.PipelineName,
+				"job":        event.JobName,
+				"build_name": event.BuildName,
+				"build_id":   strconv.Itoa(event.BuildID),
+				"priority":   strconv.Itoa(event.Priority),
+			},
+		},
+	)
+}
+
 func ms(duration time.Duration) float64 {
 	return float64(duration) / 1000000
 }
@@ -208,14 +259,14 @@ type HTTPResponseTime struct {
 }
 
 func (event HTTPResponseTime) Emit(logger lager.Logger) {
-	state := "ok"
+	state := EventStateOK
 
 	if event.Duration > 100*time.Millisecond {
-		state = "warning"
+		state = EventStateWarning
 	}
 
 	if event.Duration > 1*time.Second {
-		state = "critical"
+		state = EventStateCritical
 	}
 
 	emit(
@@ -224,10 +275,10 @@ func (event HTTPResponseTime) Emit(logger lager.Logger) {
 			"path":     event.Path,
 			"duration": event.Duration.String(),
 		}),
-		goryman.Event{
-			Service: "http response time",
-			Metric:  ms(event.Duration),
-			State:   state,
+		Event{
+			Name:  HTTPResponseTimeName,
+			Value: ms(event.Duration),
+			State: state,
 			Attributes: map[string]string{
 				"route": event.Route,
 				"path":  event.Path,
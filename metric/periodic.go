@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
-	"github.com/The-Cloud-Source/goryman"
 )
 
 func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
@@ -22,10 +21,10 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 			tLog.Session("database-queries", lager.Data{
 				"count": databaseQueries,
 			}),
-			goryman.Event{
-				Service: "database queries",
-				Metric:  databaseQueries,
-				State:   "ok",
+			Event{
+				Name:  DatabaseQueriesName,
+				Value: databaseQueries,
+				State: EventStateOK,
 			},
 		)
 
@@ -33,10 +32,10 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 			tLog.Session("database-connections", lager.Data{
 				"count": databaseConnections,
 			}),
-			goryman.Event{
-				Service: "database connections",
-				Metric:  databaseConnections,
-				State:   "ok",
+			Event{
+				Name:  DatabaseConnectionsName,
+				Value: databaseConnections,
+				State: EventStateOK,
 			},
 		)
 
@@ -47,10 +46,10 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 			tLog.Session("gc-pause-total-duration", lager.Data{
 				"ns": memStats.PauseTotalNs,
 			}),
-			goryman.Event{
-				Service: "gc pause total duration",
-				Metric:  int(memStats.PauseTotalNs),
-				State:   "ok",
+			Event{
+				Name:  "gc pause total duration",
+				Value: int(memStats.PauseTotalNs),
+				State: EventStateOK,
 			},
 		)
 
@@ -58,10 +57,10 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 			tLog.Session("mallocs", lager.Data{
 				"count": memStats.Mallocs,
 			}),
-			goryman.Event{
-				Service: "mallocs",
-				Metric:  int(memStats.Mallocs),
-				State:   "ok",
+			Event{
+				Name:  "mallocs",
+				Value: int(memStats.Mallocs),
+				State: EventStateOK,
 			},
 		)
 
@@ -69,10 +68,10 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 			tLog.Session("frees", lager.Data{
 				"count": memStats.Frees,
 			}),
-			goryman.Event{
-				Service: "frees",
-				Metric:  int(memStats.Frees),
-				State:   "ok",
+			Event{
+				Name:  "frees",
+				Value: int(memStats.Frees),
+				State: EventStateOK,
 			},
 		)
 
@@ -80,10 +79,10 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 			tLog.Session("goroutines", lager.Data{
 				"count": runtime.NumGoroutine(),
 			}),
-			goryman.Event{
-				Service: "goroutines",
-				Metric:  int(runtime.NumGoroutine()),
-				State:   "ok",
+			Event{
+				Name:  "goroutines",
+				Value: int(runtime.NumGoroutine()),
+				State: EventStateOK,
 			},
 		)
 	}
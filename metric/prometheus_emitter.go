@@ -0,0 +1,199 @@
+package metric
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusEmitter translates emitted events into Prometheus collectors, to
+// be scraped from the handler returned by promhttp for the registry they were
+// registered with.
+//
+// Request paths and build names are deliberately not used as labels, as their
+// cardinality is unbounded.
+type PrometheusEmitter struct {
+	schedulingFullDuration         *prometheus.HistogramVec
+	schedulingLoadVersionsDuration *prometheus.HistogramVec
+	schedulingJobDuration          *prometheus.HistogramVec
+
+	workerContainers *prometheus.GaugeVec
+
+	buildsStarted  *prometheus.CounterVec
+	buildsFinished *prometheus.CounterVec
+	buildDuration  *prometheus.HistogramVec
+
+	buildQueueWaitTime *prometheus.HistogramVec
+
+	httpResponseDuration *prometheus.HistogramVec
+
+	databaseQueries     prometheus.Counter
+	databaseConnections prometheus.Gauge
+}
+
+func NewPrometheusEmitter(registerer prometheus.Registerer) (*PrometheusEmitter, error) {
+	emitter := &PrometheusEmitter{
+		schedulingFullDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "scheduling",
+			Name:      "full_duration_seconds",
+			Help:      "Time taken to schedule an entire pipeline.",
+		}, []string{"pipeline"}),
+
+		schedulingLoadVersionsDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "scheduling",
+			Name:      "loading_versions_duration_seconds",
+			Help:      "Time taken to load version information from the database.",
+		}, []string{"pipeline"}),
+
+		schedulingJobDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "scheduling",
+			Name:      "job_duration_seconds",
+			Help:      "Time taken to schedule a single job.",
+		}, []string{"pipeline", "job"}),
+
+		workerContainers: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "workers",
+			Name:      "containers",
+			Help:      "Number of containers per worker.",
+		}, []string{"worker"}),
+
+		buildsStarted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "builds",
+			Name:      "started_total",
+			Help:      "Total number of builds started.",
+		}, []string{"pipeline", "job"}),
+
+		buildsFinished: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "builds",
+			Name:      "finished_total",
+			Help:      "Total number of builds finished, by status.",
+		}, []string{"pipeline", "job", "status"}),
+
+		buildDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "builds",
+			Name:      "duration_seconds",
+			Help:      "Duration of finished builds.",
+			Buckets:   []float64{1, 10, 30, 60, 120, 300, 600, 1800, 3600, 7200},
+		}, []string{"pipeline", "job", "status"}),
+
+		buildQueueWaitTime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "builds",
+			Name:      "queue_wait_seconds",
+			Help:      "Time builds spent waiting in the build queue before starting, by team.",
+			Buckets:   []float64{1, 10, 30, 60, 120, 300, 600, 1800, 3600},
+		}, []string{"team"}),
+
+		httpResponseDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "http_responses",
+			Name:      "duration_seconds",
+			Help:      "Time taken to respond to HTTP requests, by route.",
+		}, []string{"route"}),
+
+		databaseQueries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "db",
+			Name:      "queries_total",
+			Help:      "Total number of database queries.",
+		}),
+
+		databaseConnections: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "db",
+			Name:      "connections",
+			Help:      "Peak number of open database connections since the last emission.",
+		}),
+	}
+
+	collectors := []prometheus.Collector{
+		emitter.schedulingFullDuration,
+		emitter.schedulingLoadVersionsDuration,
+		emitter.schedulingJobDuration,
+		emitter.workerContainers,
+		emitter.buildsStarted,
+		emitter.buildsFinished,
+		emitter.buildDuration,
+		emitter.buildQueueWaitTime,
+		emitter.httpResponseDuration,
+		emitter.databaseQueries,
+		emitter.databaseConnections,
+	}
+
+	for _, collector := range collectors {
+		err := registerer.Register(collector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return emitter, nil
+}
+
+func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event Event) error {
+	value, err := floatValue(event.Value)
+	if err != nil {
+		return err
+	}
+
+	attrs := event.Attributes
+
+	switch event.Name {
+	case SchedulingFullDurationName:
+		emitter.schedulingFullDuration.WithLabelValues(attrs["pipeline"]).Observe(value / 1000)
+
+	case SchedulingLoadVersionsDurationName:
+		emitter.schedulingLoadVersionsDuration.WithLabelValues(attrs["pipeline"]).Observe(value / 1000)
+
+	case SchedulingJobDurationName:
+		emitter.schedulingJobDuration.WithLabelValues(attrs["pipeline"], attrs["job"]).Observe(value / 1000)
+
+	case WorkerContainersName:
+		emitter.workerContainers.WithLabelValues(attrs["worker"]).Set(value)
+
+	case BuildStartedName:
+		emitter.buildsStarted.WithLabelValues(attrs["pipeline"], attrs["job"]).Inc()
+
+	case BuildFinishedName:
+		emitter.buildsFinished.WithLabelValues(attrs["pipeline"], attrs["job"], attrs["build_status"]).Inc()
+		emitter.buildDuration.WithLabelValues(attrs["pipeline"], attrs["job"], attrs["build_status"]).Observe(value / 1000)
+
+	case BuildQueueWaitTimeName:
+		emitter.buildQueueWaitTime.WithLabelValues(attrs["team"]).Observe(value / 1000)
+
+	case HTTPResponseTimeName:
+		emitter.httpResponseDuration.WithLabelValues(attrs["route"]).Observe(value / 1000)
+
+	case DatabaseQueriesName:
+		emitter.databaseQueries.Add(value)
+
+	case DatabaseConnectionsName:
+		emitter.databaseConnections.Set(value)
+
+	default:
+		// runtime statistics are covered by Prometheus's own Go collector
+	}
+
+	return nil
+}
+
+func floatValue(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	default:
+		return 0, fmt.Errorf("unsupported metric value type: %T", value)
+	}
+}
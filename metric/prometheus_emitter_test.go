@@ -0,0 +1,129 @@
+package metric_test
+
+import (
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/metric"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrometheusEmitter", func() {
+	var (
+		registry *prometheus.Registry
+		emitter  *metric.PrometheusEmitter
+		logger   *lagertest.TestLogger
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		registry = prometheus.NewRegistry()
+		emitter, err = metric.NewPrometheusEmitter(registry)
+		Expect(err).NotTo(HaveOccurred())
+
+		logger = lagertest.NewTestLogger("test")
+	})
+
+	family := func(name string) *dto.MetricFamily {
+		families, err := registry.Gather()
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, family := range families {
+			if family.GetName() == name {
+				return family
+			}
+		}
+
+		return nil
+	}
+
+	It("observes scheduling durations in seconds", func() {
+		err := emitter.Emit(logger, metric.Event{
+			Name:       metric.SchedulingFullDurationName,
+			Value:      1500.0,
+			State:      metric.EventStateWarning,
+			Attributes: map[string]string{"pipeline": "some-pipeline"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		durations := family("concourse_scheduling_full_duration_seconds")
+		Expect(durations).NotTo(BeNil())
+		Expect(durations.Metric).To(HaveLen(1))
+		Expect(durations.Metric[0].GetLabel()[0].GetValue()).To(Equal("some-pipeline"))
+		Expect(durations.Metric[0].GetHistogram().GetSampleCount()).To(Equal(uint64(1)))
+		Expect(durations.Metric[0].GetHistogram().GetSampleSum()).To(Equal(1.5))
+	})
+
+	It("counts finished builds by status", func() {
+		for i := 0; i < 2; i++ {
+			err := emitter.Emit(logger, metric.Event{
+				Name:  metric.BuildFinishedName,
+				Value: 60000.0,
+				Attributes: map[string]string{
+					"pipeline":     "some-pipeline",
+					"job":          "some-job",
+					"build_status": "succeeded",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		finished := family("concourse_builds_finished_total")
+		Expect(finished).NotTo(BeNil())
+		Expect(finished.Metric).To(HaveLen(1))
+		Expect(finished.Metric[0].GetCounter().GetValue()).To(Equal(2.0))
+
+		durations := family("concourse_builds_duration_seconds")
+		Expect(durations).NotTo(BeNil())
+		Expect(durations.Metric[0].GetHistogram().GetSampleSum()).To(Equal(120.0))
+	})
+
+	It("observes build queue wait times by team", func() {
+		err := emitter.Emit(logger, metric.Event{
+			Name:  metric.BuildQueueWaitTimeName,
+			Value: 30000.0,
+			Attributes: map[string]string{
+				"team":     "some-team",
+				"pipeline": "some-pipeline",
+				"job":      "some-job",
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		waits := family("concourse_builds_queue_wait_seconds")
+		Expect(waits).NotTo(BeNil())
+		Expect(waits.Metric).To(HaveLen(1))
+		Expect(waits.Metric[0].GetLabel()[0].GetValue()).To(Equal("some-team"))
+		Expect(waits.Metric[0].GetHistogram().GetSampleSum()).To(Equal(30.0))
+	})
+
+	It("accumulates database queries and tracks connections", func() {
+		Expect(emitter.Emit(logger, metric.Event{Name: metric.DatabaseQueriesName, Value: 3})).To(Succeed())
+		Expect(emitter.Emit(logger, metric.Event{Name: metric.DatabaseQueriesName, Value: 4})).To(Succeed())
+		Expect(emitter.Emit(logger, metric.Event{Name: metric.DatabaseConnectionsName, Value: 5})).To(Succeed())
+
+		Expect(family("concourse_db_queries_total").Metric[0].GetCounter().GetValue()).To(Equal(7.0))
+		Expect(family("concourse_db_connections").Metric[0].GetGauge().GetValue()).To(Equal(5.0))
+	})
+
+	It("ignores events it does not export", func() {
+		Expect(emitter.Emit(logger, metric.Event{Name: "goroutines", Value: 42})).To(Succeed())
+	})
+
+	Context("when the value is not numeric", func() {
+		It("returns an error", func() {
+			err := emitter.Emit(logger, metric.Event{Name: metric.DatabaseQueriesName, Value: "lots"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("when registered twice with the same registry", func() {
+		It("returns an error", func() {
+			_, err := metric.NewPrometheusEmitter(registry)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
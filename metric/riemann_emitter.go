@@ -0,0 +1,54 @@
+package metric
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/The-Cloud-Source/goryman"
+)
+
+type RiemannEmitter struct {
+	client        *goryman.GorymanClient
+	servicePrefix string
+
+	connected bool
+}
+
+func NewRiemannEmitter(addr string, servicePrefix string) *RiemannEmitter {
+	return &RiemannEmitter{
+		client:        goryman.NewGorymanClient(addr),
+		servicePrefix: servicePrefix,
+	}
+}
+
+// Emit is only ever called from the emit loop, so the connection state does
+// not need to be synchronized.
+func (emitter *RiemannEmitter) Emit(logger lager.Logger, event Event) error {
+	if !emitter.connected {
+		err := emitter.client.Connect()
+		if err != nil {
+			return err
+		}
+
+		emitter.connected = true
+	}
+
+	err := emitter.client.SendEvent(&goryman.Event{
+		Service:    emitter.servicePrefix + event.Name,
+		Metric:     event.Value,
+		State:      string(event.State),
+		Host:       event.Host,
+		Time:       event.Time.Unix(),
+		Tags:       event.Tags,
+		Attributes: event.Attributes,
+	})
+	if err != nil {
+		if err := emitter.client.Close(); err != nil {
+			logger.Error("failed-to-close", err)
+		}
+
+		emitter.connected = false
+
+		return err
+	}
+
+	return nil
+}
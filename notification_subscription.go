@@ -0,0 +1,46 @@
+package atc
+
+// NotificationSubscription configures a webhook to which a team's build
+// status changes are posted as a BuildNotification.
+type NotificationSubscription struct {
+	ID  int    `json:"id,omitempty"`
+	URL string `json:"url"`
+
+	// Events lists the build statuses to notify of. Only started and the
+	// finished statuses are valid.
+	Events []BuildStatus `json:"events"`
+
+	// PipelineName and JobName restrict the subscription to builds of the
+	// given pipeline and job. Empty values match any.
+	PipelineName string `json:"pipeline_name,omitempty"`
+	JobName      string `json:"job_name,omitempty"`
+
+	// Secret signs each notification's body, sent as the
+	// X-Concourse-Signature header. It is only returned when the
+	// subscription is created.
+	Secret string `json:"secret,omitempty"`
+}
+
+// BuildNotification is the body of a notification's POST request.
+type BuildNotification struct {
+	Status BuildStatus `json:"status"`
+	Build  Build       `json:"build"`
+
+	// URL is the absolute URL of the build in the web UI.
+	URL string `json:"url"`
+}
+
+// IsValidNotificationEvent returns whether subscriptions may be notified of
+// builds changing to the given status.
+func IsValidNotificationEvent(status BuildStatus) bool {
+	switch status {
+	case StatusStarted,
+		StatusSucceeded,
+		StatusFailed,
+		StatusErrored,
+		StatusAborted:
+		return true
+	default:
+		return false
+	}
+}
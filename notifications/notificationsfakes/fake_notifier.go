@@ -0,0 +1,71 @@
+// This file was generated by counterfeiter
+package notificationsfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/notifications"
+)
+
+type FakeNotifier struct {
+	BuildStatusChangedStub        func(logger lager.Logger, build db.Build, status atc.BuildStatus)
+	buildStatusChangedMutex       sync.RWMutex
+	buildStatusChangedArgsForCall []struct {
+		logger lager.Logger
+		build  db.Build
+		status atc.BuildStatus
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeNotifier) BuildStatusChanged(logger lager.Logger, build db.Build, status atc.BuildStatus) {
+	fake.buildStatusChangedMutex.Lock()
+	fake.buildStatusChangedArgsForCall = append(fake.buildStatusChangedArgsForCall, struct {
+		logger lager.Logger
+		build  db.Build
+		status atc.BuildStatus
+	}{logger, build, status})
+	fake.recordInvocation("BuildStatusChanged", []interface{}{logger, build, status})
+	fake.buildStatusChangedMutex.Unlock()
+	if fake.BuildStatusChangedStub != nil {
+		fake.BuildStatusChangedStub(logger, build, status)
+	}
+}
+
+func (fake *FakeNotifier) BuildStatusChangedCallCount() int {
+	fake.buildStatusChangedMutex.RLock()
+	defer fake.buildStatusChangedMutex.RUnlock()
+	return len(fake.buildStatusChangedArgsForCall)
+}
+
+func (fake *FakeNotifier) BuildStatusChangedArgsForCall(i int) (lager.Logger, db.Build, atc.BuildStatus) {
+	fake.buildStatusChangedMutex.RLock()
+	defer fake.buildStatusChangedMutex.RUnlock()
+	return fake.buildStatusChangedArgsForCall[i].logger, fake.buildStatusChangedArgsForCall[i].build, fake.buildStatusChangedArgsForCall[i].status
+}
+
+func (fake *FakeNotifier) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.buildStatusChangedMutex.RLock()
+	defer fake.buildStatusChangedMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeNotifier) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ notifications.Notifier = new(FakeNotifier)
@@ -0,0 +1,168 @@
+package notifications
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+const (
+	SignatureHeader = "X-Concourse-Signature"
+	EventHeader     = "X-Concourse-Event"
+)
+
+const (
+	maxDeliveryAttempts = 5
+	initialBackoff      = time.Second
+)
+
+//go:generate counterfeiter . Notifier
+
+// Notifier posts build status changes to the webhooks to which the build's
+// team has subscribed. Notifications are delivered in the background, and
+// failing to deliver one does not affect the build.
+type Notifier interface {
+	BuildStatusChanged(logger lager.Logger, build db.Build, status atc.BuildStatus)
+}
+
+func NewNotifier(
+	teamDBFactory db.TeamDBFactory,
+	httpClient *http.Client,
+	clock clock.Clock,
+	externalURL string,
+) Notifier {
+	return &notifier{
+		teamDBFactory: teamDBFactory,
+		httpClient:    httpClient,
+		clock:         clock,
+		externalURL:   externalURL,
+	}
+}
+
+type notifier struct {
+	teamDBFactory db.TeamDBFactory
+	httpClient    *http.Client
+	clock         clock.Clock
+	externalURL   string
+}
+
+func (notifier *notifier) BuildStatusChanged(logger lager.Logger, build db.Build, status atc.BuildStatus) {
+	if !atc.IsValidNotificationEvent(status) {
+		return
+	}
+
+	logger = logger.Session("notify", lager.Data{
+		"build":  build.ID(),
+		"status": status,
+	})
+
+	// present the build before returning, as the caller may go on to modify it
+	presentedBuild := present.Build(build)
+	presentedBuild.Status = string(status)
+
+	notification := atc.BuildNotification{
+		Status: status,
+		Build:  presentedBuild,
+		URL:    notifier.externalURL + presentedBuild.URL,
+	}
+
+	go notifier.notify(logger, build.TeamName(), notification)
+}
+
+func (notifier *notifier) notify(logger lager.Logger, teamName string, notification atc.BuildNotification) {
+	subscriptions, err := notifier.teamDBFactory.GetTeamDB(teamName).GetNotificationSubscriptions()
+	if err != nil {
+		logger.Error("failed-to-get-subscriptions", err)
+		return
+	}
+
+	body, err := json.Marshal(notification)
+	if err != nil {
+		logger.Error("failed-to-marshal-notification", err)
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		if !subscription.Matches(notification.Status, notification.Build.PipelineName, notification.Build.JobName) {
+			continue
+		}
+
+		go notifier.deliver(
+			logger.Session("deliver", lager.Data{"subscription": subscription.ID}),
+			subscription,
+			notification.Status,
+			body,
+		)
+	}
+}
+
+func (notifier *notifier) deliver(logger lager.Logger, subscription db.SavedNotificationSubscription, status atc.BuildStatus, body []byte) {
+	backoff := initialBackoff
+
+	for attempt := 1; ; attempt++ {
+		retry, err := notifier.post(subscription, status, body)
+		if err == nil {
+			logger.Debug("delivered", lager.Data{"attempt": attempt})
+			return
+		}
+
+		if !retry || attempt == maxDeliveryAttempts {
+			logger.Error("failed-to-deliver", err, lager.Data{"attempt": attempt})
+			return
+		}
+
+		logger.Info("retrying", lager.Data{"attempt": attempt, "error": err.Error(), "backoff": backoff.String()})
+
+		notifier.clock.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// post returns whether a failed delivery is worth retrying; errors reaching
+// the endpoint, server errors, and rate limiting may be transient.
+func (notifier *notifier) post(subscription db.SavedNotificationSubscription, status atc.BuildStatus, body []byte) (bool, error) {
+	request, err := http.NewRequest("POST", subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(EventHeader, string(status))
+	request.Header.Set(SignatureHeader, Sign(subscription.Secret, body))
+
+	response, err := notifier.httpClient.Do(request)
+	if err != nil {
+		return true, err
+	}
+
+	response.Body.Close()
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return false, nil
+	}
+
+	err = fmt.Errorf("unexpected response: %s", response.Status)
+
+	retry := response.StatusCode >= 500 || response.StatusCode == http.StatusTooManyRequests
+
+	return retry, err
+}
+
+// Sign returns the value of the signature header for the given body, by
+// which a subscriber can verify that a notification came from the ATC.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
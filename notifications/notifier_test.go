@@ -0,0 +1,211 @@
+package notifications_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/notifications"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Notifier", func() {
+	var (
+		fakeTeamDBFactory *dbfakes.FakeTeamDBFactory
+		fakeTeamDB        *dbfakes.FakeTeamDB
+		fakeClock         *fakeclock.FakeClock
+		server            *ghttp.Server
+
+		fakeBuild *dbfakes.FakeBuild
+
+		notifier Notifier
+	)
+
+	BeforeEach(func() {
+		fakeTeamDBFactory = new(dbfakes.FakeTeamDBFactory)
+		fakeTeamDB = new(dbfakes.FakeTeamDB)
+		fakeTeamDBFactory.GetTeamDBReturns(fakeTeamDB)
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+		server = ghttp.NewServer()
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.NameReturns("7")
+		fakeBuild.TeamNameReturns("some-team")
+		fakeBuild.PipelineNameReturns("some-pipeline")
+		fakeBuild.JobNameReturns("some-job")
+		fakeBuild.StatusReturns(db.StatusStarted)
+
+		fakeTeamDB.GetNotificationSubscriptionsReturns([]db.SavedNotificationSubscription{
+			{
+				ID: 1,
+				NotificationSubscription: db.NotificationSubscription{
+					URL:    server.URL() + "/hook",
+					Secret: "some-secret",
+					Events: []atc.BuildStatus{atc.StatusFailed},
+				},
+			},
+		}, nil)
+
+		notifier = NewNotifier(fakeTeamDBFactory, http.DefaultClient, fakeClock, "https://example.com")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when a subscription matches the status", func() {
+		var body []byte
+
+		BeforeEach(func() {
+			server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				Expect(r.Method).To(Equal("POST"))
+				Expect(r.URL.Path).To(Equal("/hook"))
+				Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+				Expect(r.Header.Get("X-Concourse-Event")).To(Equal("failed"))
+
+				var err error
+				body, err = ioutil.ReadAll(r.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(r.Header.Get("X-Concourse-Signature")).To(Equal(Sign("some-secret", body)))
+			})
+		})
+
+		It("posts the signed notification for the team's subscriptions", func() {
+			notifier.BuildStatusChanged(lagertest.NewTestLogger("test"), fakeBuild, atc.StatusFailed)
+
+			Eventually(server.ReceivedRequests).Should(HaveLen(1))
+
+			Expect(fakeTeamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("some-team"))
+
+			var notification atc.BuildNotification
+			Expect(json.Unmarshal(body, &notification)).To(Succeed())
+			Expect(notification.Status).To(Equal(atc.StatusFailed))
+			Expect(notification.Build.ID).To(Equal(42))
+			Expect(notification.Build.Status).To(Equal("failed"))
+			Expect(notification.URL).To(Equal("https://example.com/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/7"))
+		})
+	})
+
+	Context("when no subscription matches the status", func() {
+		It("does not post anything", func() {
+			notifier.BuildStatusChanged(lagertest.NewTestLogger("test"), fakeBuild, atc.StatusSucceeded)
+
+			Eventually(fakeTeamDB.GetNotificationSubscriptionsCallCount).Should(Equal(1))
+			Consistently(server.ReceivedRequests).Should(BeEmpty())
+		})
+	})
+
+	Context("when the subscription is restricted to another job", func() {
+		BeforeEach(func() {
+			fakeTeamDB.GetNotificationSubscriptionsReturns([]db.SavedNotificationSubscription{
+				{
+					ID: 1,
+					NotificationSubscription: db.NotificationSubscription{
+						URL:     server.URL() + "/hook",
+						Events:  []atc.BuildStatus{atc.StatusFailed},
+						JobName: "other-job",
+					},
+				},
+			}, nil)
+		})
+
+		It("does not post anything", func() {
+			notifier.BuildStatusChanged(lagertest.NewTestLogger("test"), fakeBuild, atc.StatusFailed)
+
+			Eventually(fakeTeamDB.GetNotificationSubscriptionsCallCount).Should(Equal(1))
+			Consistently(server.ReceivedRequests).Should(BeEmpty())
+		})
+	})
+
+	Context("when the status is not a notification event", func() {
+		It("does not look up subscriptions", func() {
+			notifier.BuildStatusChanged(lagertest.NewTestLogger("test"), fakeBuild, atc.StatusPending)
+
+			Consistently(fakeTeamDB.GetNotificationSubscriptionsCallCount).Should(BeZero())
+		})
+	})
+
+	Context("when the endpoint fails with a server error", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusInternalServerError, ""),
+				ghttp.RespondWith(http.StatusServiceUnavailable, ""),
+				ghttp.RespondWith(http.StatusOK, ""),
+			)
+		})
+
+		It("retries with exponential backoff", func() {
+			notifier.BuildStatusChanged(lagertest.NewTestLogger("test"), fakeBuild, atc.StatusFailed)
+
+			Eventually(server.ReceivedRequests).Should(HaveLen(1))
+
+			fakeClock.WaitForWatcherAndIncrement(time.Second)
+			Eventually(server.ReceivedRequests).Should(HaveLen(2))
+
+			fakeClock.WaitForWatcherAndIncrement(time.Second)
+			Consistently(server.ReceivedRequests).Should(HaveLen(2))
+
+			fakeClock.Increment(time.Second)
+			Eventually(server.ReceivedRequests).Should(HaveLen(3))
+		})
+	})
+
+	Context("when the endpoint keeps failing", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("POST", "/hook", ghttp.RespondWith(http.StatusBadGateway, ""))
+		})
+
+		It("gives up after 5 attempts", func() {
+			notifier.BuildStatusChanged(lagertest.NewTestLogger("test"), fakeBuild, atc.StatusFailed)
+
+			for i := 1; i < 5; i++ {
+				Eventually(server.ReceivedRequests).Should(HaveLen(i))
+				fakeClock.WaitForWatcherAndIncrement(time.Duration(1<<uint(i-1)) * time.Second)
+			}
+
+			Eventually(server.ReceivedRequests).Should(HaveLen(5))
+			Consistently(fakeClock.WatcherCount).Should(BeZero())
+			Expect(server.ReceivedRequests()).To(HaveLen(5))
+		})
+	})
+
+	Context("when the endpoint rejects the notification", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("POST", "/hook", ghttp.RespondWith(http.StatusBadRequest, ""))
+		})
+
+		It("does not retry", func() {
+			notifier.BuildStatusChanged(lagertest.NewTestLogger("test"), fakeBuild, atc.StatusFailed)
+
+			Eventually(server.ReceivedRequests).Should(HaveLen(1))
+			Consistently(fakeClock.WatcherCount).Should(BeZero())
+		})
+	})
+
+	Context("when getting the subscriptions fails", func() {
+		BeforeEach(func() {
+			fakeTeamDB.GetNotificationSubscriptionsReturns(nil, errors.New("nope"))
+		})
+
+		It("does not post anything", func() {
+			notifier.BuildStatusChanged(lagertest.NewTestLogger("test"), fakeBuild, atc.StatusFailed)
+
+			Eventually(fakeTeamDB.GetNotificationSubscriptionsCallCount).Should(Equal(1))
+			Consistently(server.ReceivedRequests).Should(BeEmpty())
+		})
+	})
+})
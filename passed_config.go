@@ -0,0 +1,115 @@
+package atc
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+// PassedConfig is a job through which versions of an input must have passed.
+// The job is in the same pipeline unless Pipeline names another pipeline of
+// the same team.
+//
+// Jobs in the same pipeline may be configured as just their name, e.g.
+// 'passed: [unit, {pipeline: build, job: package}]'.
+type PassedConfig struct {
+	Pipeline string `yaml:"pipeline,omitempty" json:"pipeline,omitempty" mapstructure:"pipeline"`
+	Job      string `yaml:"job" json:"job" mapstructure:"job"`
+}
+
+func (c PassedConfig) IsOtherPipeline() bool {
+	return c.Pipeline != ""
+}
+
+func (c PassedConfig) String() string {
+	if c.IsOtherPipeline() {
+		return c.Pipeline + "/" + c.Job
+	}
+
+	return c.Job
+}
+
+type passedConfigObject PassedConfig
+
+func (c *PassedConfig) UnmarshalJSON(data []byte) error {
+	var job string
+	if err := json.Unmarshal(data, &job); err == nil {
+		*c = PassedConfig{Job: job}
+		return nil
+	}
+
+	var object passedConfigObject
+	if err := json.Unmarshal(data, &object); err != nil {
+		return errors.New("unknown type for passed")
+	}
+
+	*c = PassedConfig(object)
+
+	return nil
+}
+
+func (c *PassedConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var job string
+	if err := unmarshal(&job); err == nil {
+		*c = PassedConfig{Job: job}
+		return nil
+	}
+
+	var object passedConfigObject
+	if err := unmarshal(&object); err != nil {
+		return errors.New("unknown type for passed")
+	}
+
+	*c = PassedConfig(object)
+
+	return nil
+}
+
+// jobs in the same pipeline are marshaled as just their name, so that
+// existing configs round-trip unchanged
+
+func (c PassedConfig) MarshalJSON() ([]byte, error) {
+	if !c.IsOtherPipeline() {
+		return json.Marshal(c.Job)
+	}
+
+	return json.Marshal(passedConfigObject(c))
+}
+
+func (c PassedConfig) MarshalYAML() (interface{}, error) {
+	if !c.IsOtherPipeline() {
+		return c.Job, nil
+	}
+
+	return passedConfigObject(c), nil
+}
+
+type PassedConfigs []PassedConfig
+
+// Jobs returns the names of the jobs in the same pipeline.
+func (configs PassedConfigs) Jobs() []string {
+	jobs := []string{}
+	for _, config := range configs {
+		if !config.IsOtherPipeline() {
+			jobs = append(jobs, config.Job)
+		}
+	}
+
+	return jobs
+}
+
+var PassedConfigDecodeHook = func(
+	srcType reflect.Type,
+	dstType reflect.Type,
+	data interface{},
+) (interface{}, error) {
+	if dstType != reflect.TypeOf(PassedConfig{}) {
+		return data, nil
+	}
+
+	if s, ok := data.(string); ok {
+		return PassedConfig{Job: s}, nil
+	}
+
+	return data, nil
+}
@@ -0,0 +1,67 @@
+package atc_test
+
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v2"
+
+	. "github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PassedConfigs", func() {
+	Describe("unmarshaling", func() {
+		It("accepts job names and jobs of other pipelines from YAML", func() {
+			var configs PassedConfigs
+			err := yaml.Unmarshal([]byte(`[some-job, {pipeline: some-pipeline, job: some-other-job}]`), &configs)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(configs).To(Equal(PassedConfigs{
+				{Job: "some-job"},
+				{Pipeline: "some-pipeline", Job: "some-other-job"},
+			}))
+		})
+
+		It("accepts job names and jobs of other pipelines from JSON", func() {
+			var configs PassedConfigs
+			err := json.Unmarshal([]byte(`["some-job", {"pipeline": "some-pipeline", "job": "some-other-job"}]`), &configs)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(configs).To(Equal(PassedConfigs{
+				{Job: "some-job"},
+				{Pipeline: "some-pipeline", Job: "some-other-job"},
+			}))
+		})
+
+		It("errors on anything else", func() {
+			var configs PassedConfigs
+			err := json.Unmarshal([]byte(`[42]`), &configs)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("marshaling", func() {
+		It("marshals jobs of the same pipeline as just their name", func() {
+			payload, err := json.Marshal(PassedConfigs{
+				{Job: "some-job"},
+				{Pipeline: "some-pipeline", Job: "some-other-job"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(payload).To(MatchJSON(`["some-job", {"pipeline": "some-pipeline", "job": "some-other-job"}]`))
+		})
+	})
+
+	Describe("Jobs", func() {
+		It("returns the jobs of the same pipeline", func() {
+			configs := PassedConfigs{
+				{Job: "some-job"},
+				{Pipeline: "some-pipeline", Job: "some-other-job"},
+			}
+
+			Expect(configs.Jobs()).To(Equal([]string{"some-job"}))
+		})
+	})
+})
@@ -7,4 +7,7 @@ type Pipeline struct {
 	Public   bool         `json:"public"`
 	Groups   GroupConfigs `json:"groups,omitempty"`
 	TeamName string       `json:"team_name"`
+
+	TemplateName string       `json:"template_name,omitempty"`
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
 }
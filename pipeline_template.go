@@ -0,0 +1,87 @@
+package atc
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// InstanceVars are the values an instance of a pipeline template fills in for
+// its ((var)) placeholders.
+type InstanceVars map[string]interface{}
+
+type PipelineTemplate struct {
+	Name     string      `json:"name"`
+	TeamName string      `json:"team_name"`
+	Version  int         `json:"version"`
+	Config   interface{} `json:"config"`
+}
+
+var templateVarRegexp = regexp.MustCompile(`\(\(([-\w]+)\)\)`)
+
+// SanitizeTemplate converts a template decoded from YAML into one which may
+// be encoded as JSON.
+func SanitizeTemplate(template interface{}) (interface{}, error) {
+	return sanitize(template)
+}
+
+// RenderTemplate fills in a template's ((var)) placeholders and decodes the
+// result as a pipeline config. A placeholder which makes up an entire value
+// is replaced with the var as-is, so vars may be of any type; placeholders
+// within a string are replaced with the var formatted as a string.
+//
+// Placeholders for vars which the instance does not define are left in
+// place, so that they may be filled in from the credential manager when the
+// pipeline's builds run.
+func RenderTemplate(template interface{}, vars InstanceVars) (Config, error) {
+	sanitized, err := sanitize(template)
+	if err != nil {
+		return Config{}, err
+	}
+
+	return DecodeConfig(renderTemplateValue(sanitized, vars))
+}
+
+func renderTemplateValue(value interface{}, vars InstanceVars) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		rendered := map[string]interface{}{}
+		for key, val := range v {
+			rendered[key] = renderTemplateValue(val, vars)
+		}
+
+		return rendered
+
+	case []interface{}:
+		rendered := make([]interface{}, len(v))
+		for i, val := range v {
+			rendered[i] = renderTemplateValue(val, vars)
+		}
+
+		return rendered
+
+	case string:
+		match := templateVarRegexp.FindStringSubmatch(v)
+		if match != nil && match[0] == v {
+			val, found := vars[match[1]]
+			if !found {
+				return v
+			}
+
+			return val
+		}
+
+		return templateVarRegexp.ReplaceAllStringFunc(v, func(placeholder string) string {
+			name := templateVarRegexp.FindStringSubmatch(placeholder)[1]
+
+			val, found := vars[name]
+			if !found {
+				return placeholder
+			}
+
+			return fmt.Sprintf("%v", val)
+		})
+
+	default:
+		return value
+	}
+}
@@ -0,0 +1,105 @@
+package atc_test
+
+import (
+	. "github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RenderTemplate", func() {
+	var template interface{}
+	var vars InstanceVars
+
+	var config Config
+	var renderErr error
+
+	BeforeEach(func() {
+		template = map[string]interface{}{
+			"resources": []interface{}{
+				map[string]interface{}{
+					"name": "repo",
+					"type": "git",
+					"source": map[string]interface{}{
+						"uri":    "((uri))",
+						"branch": "release/((branch))",
+					},
+				},
+			},
+			"jobs": []interface{}{
+				map[string]interface{}{
+					"name":   "test-((branch))",
+					"public": "((public))",
+					"plan": []interface{}{
+						map[string]interface{}{"get": "repo"},
+					},
+				},
+			},
+		}
+
+		vars = InstanceVars{
+			"uri":    map[string]interface{}{"host": "example.com"},
+			"branch": 1.2,
+			"public": true,
+		}
+	})
+
+	JustBeforeEach(func() {
+		config, renderErr = RenderTemplate(template, vars)
+	})
+
+	It("fills in the vars", func() {
+		Expect(renderErr).NotTo(HaveOccurred())
+
+		Expect(config.Resources[0].Source).To(Equal(Source{
+			"uri":    map[string]interface{}{"host": "example.com"},
+			"branch": "release/1.2",
+		}))
+
+		Expect(config.Jobs[0].Name).To(Equal("test-1.2"))
+		Expect(config.Jobs[0].Public).To(BeTrue())
+	})
+
+	Context("when the template is YAML", func() {
+		BeforeEach(func() {
+			template = map[interface{}]interface{}{
+				"jobs": []interface{}{
+					map[interface{}]interface{}{"name": "test-((branch))"},
+				},
+			}
+		})
+
+		It("fills in the vars", func() {
+			Expect(renderErr).NotTo(HaveOccurred())
+			Expect(config.Jobs[0].Name).To(Equal("test-1.2"))
+		})
+	})
+
+	Context("when vars are missing", func() {
+		BeforeEach(func() {
+			delete(vars, "uri")
+			delete(vars, "branch")
+		})
+
+		It("leaves their placeholders for the credential manager", func() {
+			Expect(renderErr).NotTo(HaveOccurred())
+
+			Expect(config.Resources[0].Source).To(Equal(Source{
+				"uri":    "((uri))",
+				"branch": "release/((branch))",
+			}))
+
+			Expect(config.Jobs[0].Name).To(Equal("test-((branch))"))
+		})
+	})
+
+	Context("when the rendered config has extra keys", func() {
+		BeforeEach(func() {
+			template.(map[string]interface{})["bogus"] = "((branch))"
+		})
+
+		It("returns an error", func() {
+			Expect(renderErr).To(Equal(ExtraKeysError{ExtraKeys: []string{"bogus"}}))
+		})
+	})
+})
@@ -5,12 +5,14 @@ import (
 
 	"code.cloudfoundry.org/clock"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/radar"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/scheduler"
+	"github.com/concourse/atc/scheduler/buildqueue"
 	"github.com/concourse/atc/scheduler/factory"
 	"github.com/concourse/atc/scheduler/inputmapper"
 	"github.com/concourse/atc/scheduler/inputmapper/inputconfig"
@@ -25,28 +27,36 @@ type RadarSchedulerFactory interface {
 }
 
 type radarSchedulerFactory struct {
-	resourceFactory resource.ResourceFactory
-	interval        time.Duration
-	engine          engine.Engine
+	resourceFactory   resource.ResourceFactory
+	interval          time.Duration
+	engine            engine.Engine
+	credentialManager creds.CredentialManager
+	buildQueue        buildqueue.Queue
 }
 
 func NewRadarSchedulerFactory(
 	resourceFactory resource.ResourceFactory,
 	interval time.Duration,
 	engine engine.Engine,
+	credentialManager creds.CredentialManager,
+	buildQueue buildqueue.Queue,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
-		resourceFactory: resourceFactory,
-		interval:        interval,
-		engine:          engine,
+		resourceFactory:   resourceFactory,
+		interval:          interval,
+		engine:            engine,
+		credentialManager: credentialManager,
+		buildQueue:        buildQueue,
 	}
 }
 
 func (rsf *radarSchedulerFactory) BuildScanRunnerFactory(pipelineDB db.PipelineDB, dbPipeline dbng.Pipeline, externalURL string) radar.ScanRunnerFactory {
-	return radar.NewScanRunnerFactory(rsf.resourceFactory, rsf.interval, pipelineDB, dbPipeline, clock.NewClock(), externalURL)
+	variables := creds.NewVariables(rsf.credentialManager, pipelineDB.TeamName(), pipelineDB.GetPipelineName())
+	return radar.NewScanRunnerFactory(rsf.resourceFactory, rsf.interval, pipelineDB, dbPipeline, clock.NewClock(), externalURL, variables)
 }
 
 func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, dbPipeline dbng.Pipeline, externalURL string) scheduler.BuildScheduler {
+	variables := creds.NewVariables(rsf.credentialManager, pipelineDB.TeamName(), pipelineDB.GetPipelineName())
 	scanner := radar.NewResourceScanner(
 		clock.NewClock(),
 		rsf.resourceFactory,
@@ -54,6 +64,7 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, dbPip
 		pipelineDB,
 		dbPipeline,
 		externalURL,
+		variables,
 	)
 	inputMapper := inputmapper.NewInputMapper(
 		pipelineDB,
@@ -72,6 +83,8 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, dbPip
 			scanner,
 			inputMapper,
 			rsf.engine,
+			clock.NewClock(),
+			rsf.buildQueue,
 		),
 		Scanner: scanner,
 	}
@@ -4,6 +4,7 @@ import (
 	"os"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/dbng"
 	"github.com/tedsuo/ifrit"
@@ -15,6 +16,10 @@ type SyncherDB interface {
 	GetAllPipelines() ([]db.SavedPipeline, error)
 }
 
+// templateAuthor is recorded as the author of configs saved by re-rendering
+// a template's instances.
+const templateAuthor = "template"
+
 type PipelineRunnerFactory func(db.PipelineDB, dbng.Pipeline) ifrit.Runner
 
 type Syncer struct {
@@ -23,6 +28,7 @@ type Syncer struct {
 	syncherDB             SyncherDB
 	pipelineDBFactory     db.PipelineDBFactory
 	pipelineFactory       dbng.PipelineFactory
+	teamFactory           dbng.TeamFactory
 	pipelineRunnerFactory PipelineRunnerFactory
 
 	runningPipelines map[int]runningPipeline
@@ -41,6 +47,7 @@ func NewSyncer(
 	syncherDB SyncherDB,
 	pipelineDBFactory db.PipelineDBFactory,
 	pipelineFactory dbng.PipelineFactory,
+	teamFactory dbng.TeamFactory,
 	pipelineRunnerFactory PipelineRunnerFactory,
 ) *Syncer {
 	return &Syncer{
@@ -48,6 +55,7 @@ func NewSyncer(
 		syncherDB:             syncherDB,
 		pipelineDBFactory:     pipelineDBFactory,
 		pipelineFactory:       pipelineFactory,
+		teamFactory:           teamFactory,
 		pipelineRunnerFactory: pipelineRunnerFactory,
 
 		runningPipelines: map[int]runningPipeline{},
@@ -61,6 +69,8 @@ func (syncer *Syncer) Sync() {
 		return
 	}
 
+	syncer.renderInstances(pipelines)
+
 	for id, runningPipeline := range syncer.runningPipelines {
 		select {
 		case <-runningPipeline.Exited:
@@ -108,6 +118,82 @@ func (syncer *Syncer) Sync() {
 	}
 }
 
+type templateKey struct {
+	teamID int
+	name   string
+}
+
+type loadedTemplate struct {
+	template dbng.PipelineTemplate
+	found    bool
+	err      error
+}
+
+// renderInstances re-renders each instance of a template which has been
+// saved since the instance was last rendered. Each template is only loaded
+// once, however many instances it has.
+func (syncer *Syncer) renderInstances(pipelines []db.SavedPipeline) {
+	templates := map[templateKey]loadedTemplate{}
+
+	for _, pipeline := range pipelines {
+		if pipeline.TemplateName == "" {
+			continue
+		}
+
+		logger := syncer.logger.Session("render-instance", lager.Data{
+			"pipeline": pipeline.Name,
+			"template": pipeline.TemplateName,
+		})
+
+		team := syncer.teamFactory.GetByID(pipeline.TeamID)
+
+		key := templateKey{teamID: pipeline.TeamID, name: pipeline.TemplateName}
+
+		loaded, cached := templates[key]
+		if !cached {
+			loaded.template, loaded.found, loaded.err = team.FindPipelineTemplate(pipeline.TemplateName)
+			templates[key] = loaded
+		}
+
+		if loaded.err != nil {
+			logger.Error("failed-to-find-template", loaded.err)
+			continue
+		}
+
+		template := loaded.template
+		if !loaded.found || template.Version <= pipeline.TemplateVersion {
+			continue
+		}
+
+		config, err := atc.RenderTemplate(template.Config, pipeline.InstanceVars)
+		if err != nil {
+			logger.Error("failed-to-render-template", err)
+			continue
+		}
+
+		_, errorMessages := config.ValidateForPipeline(pipeline.Name)
+		if len(errorMessages) > 0 {
+			logger.Info("rendered-invalid-config", lager.Data{"errors": errorMessages})
+			continue
+		}
+
+		_, _, err = team.SavePipelineInstance(
+			pipeline.Name,
+			template,
+			pipeline.InstanceVars,
+			config,
+			dbng.ConfigVersion(pipeline.Version),
+			templateAuthor,
+		)
+		if err != nil {
+			logger.Error("failed-to-save-instance", err)
+			continue
+		}
+
+		logger.Info("rendered", lager.Data{"template-version": template.Version})
+	}
+}
+
 func (syncer *Syncer) removePipeline(pipelineID int) {
 	delete(syncer.runningPipelines, pipelineID)
 }
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/dbng/dbngfakes"
 	. "github.com/concourse/atc/pipelines"
@@ -24,6 +25,8 @@ var _ = Describe("Pipelines Syncer", func() {
 		pipelineDB            *dbfakes.FakePipelineDB
 		otherPipelineDB       *dbfakes.FakePipelineDB
 		pipelineDBFactory     *dbfakes.FakePipelineDBFactory
+		teamFactory           *dbngfakes.FakeTeamFactory
+		team                  *dbngfakes.FakeTeam
 		pipelineRunnerFactory PipelineRunnerFactory
 
 		fakeRunner         *fake_runner.FakeRunner
@@ -43,6 +46,10 @@ var _ = Describe("Pipelines Syncer", func() {
 		pipelineDBFactory = new(dbfakes.FakePipelineDBFactory)
 		dbPipelineFactory := new(dbngfakes.FakePipelineFactory)
 
+		teamFactory = new(dbngfakes.FakeTeamFactory)
+		team = new(dbngfakes.FakeTeam)
+		teamFactory.GetByIDReturns(team)
+
 		fakeRunner = new(fake_runner.FakeRunner)
 		otherFakeRunner = new(fake_runner.FakeRunner)
 
@@ -100,6 +107,7 @@ var _ = Describe("Pipelines Syncer", func() {
 			syncherDB,
 			pipelineDBFactory,
 			dbPipelineFactory,
+			teamFactory,
 			pipelineRunnerFactory,
 		)
 	})
@@ -249,6 +257,120 @@ var _ = Describe("Pipelines Syncer", func() {
 		})
 	})
 
+	Context("when a pipeline is an instance of a template", func() {
+		var template dbng.PipelineTemplate
+
+		BeforeEach(func() {
+			syncherDB.GetAllPipelinesReturns([]db.SavedPipeline{
+				{
+					ID:              1,
+					TeamID:          7,
+					TemplateName:    "some-template",
+					TemplateVersion: 2,
+					InstanceVars:    atc.InstanceVars{"branch": "master"},
+					Pipeline: db.Pipeline{
+						Name:    "pipeline",
+						Version: 42,
+					},
+				},
+			}, nil)
+
+			template = dbng.PipelineTemplate{
+				ID:   3,
+				Name: "some-template",
+				Config: map[string]interface{}{
+					"jobs": []interface{}{
+						map[string]interface{}{"name": "build-((branch))"},
+					},
+				},
+			}
+		})
+
+		Context("when the template has been saved since the instance was rendered", func() {
+			BeforeEach(func() {
+				template.Version = 3
+				team.FindPipelineTemplateReturns(template, true, nil)
+			})
+
+			It("re-renders the instance", func() {
+				Expect(teamFactory.GetByIDArgsForCall(0)).To(Equal(7))
+				Expect(team.FindPipelineTemplateArgsForCall(0)).To(Equal("some-template"))
+
+				Expect(team.SavePipelineInstanceCallCount()).To(Equal(1))
+				name, savedTemplate, vars, config, from, author := team.SavePipelineInstanceArgsForCall(0)
+				Expect(name).To(Equal("pipeline"))
+				Expect(savedTemplate).To(Equal(template))
+				Expect(vars).To(Equal(atc.InstanceVars{"branch": "master"}))
+				Expect(config.Jobs).To(Equal(atc.JobConfigs{{Name: "build-master"}}))
+				Expect(from).To(Equal(dbng.ConfigVersion(42)))
+				Expect(author).To(Equal("template"))
+			})
+
+			It("still runs the pipeline", func() {
+				Eventually(fakeRunner.RunCallCount).Should(Equal(1))
+			})
+
+			Context("when the instance no longer renders", func() {
+				BeforeEach(func() {
+					template.Config = map[string]interface{}{
+						"jobs": []interface{}{
+							map[string]interface{}{"name": "build-((branch))"},
+						},
+						"bogus": true,
+					}
+
+					team.FindPipelineTemplateReturns(template, true, nil)
+				})
+
+				It("leaves it alone", func() {
+					Expect(team.SavePipelineInstanceCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the template has several instances", func() {
+			BeforeEach(func() {
+				syncherDB.GetAllPipelinesReturns([]db.SavedPipeline{
+					{
+						ID:              1,
+						TeamID:          7,
+						TemplateName:    "some-template",
+						TemplateVersion: 2,
+						InstanceVars:    atc.InstanceVars{"branch": "master"},
+						Pipeline:        db.Pipeline{Name: "pipeline"},
+					},
+					{
+						ID:              2,
+						TeamID:          7,
+						TemplateName:    "some-template",
+						TemplateVersion: 2,
+						InstanceVars:    atc.InstanceVars{"branch": "develop"},
+						Pipeline:        db.Pipeline{Name: "other-pipeline"},
+					},
+				}, nil)
+
+				template.Version = 3
+				team.FindPipelineTemplateReturns(template, true, nil)
+			})
+
+			It("loads the template once", func() {
+				Expect(team.FindPipelineTemplateCallCount()).To(Equal(1))
+				Expect(team.SavePipelineInstanceCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when the instance is up to date", func() {
+			BeforeEach(func() {
+				template.Version = 2
+				team.FindPipelineTemplateReturns(template, true, nil)
+			})
+
+			It("does not re-render it", func() {
+				Expect(team.SavePipelineInstanceCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Context("when the call to lookup pipelines errors", func() {
 		It("does not spawn any processes", func() {
 		})
@@ -4,6 +4,10 @@ type Plan struct {
 	ID       PlanID `json:"id"`
 	Attempts []int  `json:"attempts,omitempty"`
 
+	AcrossValues map[string]interface{} `json:"across_values,omitempty"`
+
+	RetryPolicy *RetryPolicy `json:"retry_policy,omitempty"`
+
 	Aggregate    *AggregatePlan    `json:"aggregate,omitempty"`
 	Do           *DoPlan           `json:"do,omitempty"`
 	Get          *GetPlan          `json:"get,omitempty"`
@@ -15,7 +19,9 @@ type Plan struct {
 	Try          *TryPlan          `json:"try,omitempty"`
 	DependentGet *DependentGetPlan `json:"dependent_get,omitempty"`
 	Timeout      *TimeoutPlan      `json:"timeout,omitempty"`
+	Breakpoint   *BreakpointPlan   `json:"breakpoint,omitempty"`
 	Retry        *RetryPlan        `json:"retry,omitempty"`
+	SetPipeline  *SetPipelinePlan  `json:"set_pipeline,omitempty"`
 }
 
 type PlanID string
@@ -68,6 +74,13 @@ type TimeoutPlan struct {
 	Duration string `json:"duration"`
 }
 
+// BreakpointPlan pauses the build before running Step, or after Step fails,
+// depending on When.
+type BreakpointPlan struct {
+	Step Plan   `json:"step"`
+	When string `json:"when"`
+}
+
 type TryPlan struct {
 	Step Plan `json:"step"`
 }
@@ -121,6 +134,22 @@ type TaskPlan struct {
 	PipelineID int    `json:"pipeline_id"`
 
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
+
+	// The values of the `across` vars the task is run with, for evaluating a
+	// config loaded from a file.
+	AcrossValues map[string]interface{} `json:"across_values,omitempty"`
+}
+
+type SetPipelinePlan struct {
+	Name string `json:"name"`
+	File string `json:"file"`
 }
 
 type RetryPlan []Plan
+
+// RetryPolicy configures how the attempts of a RetryPlan are retried. An empty
+// On retries attempts which either failed or errored.
+type RetryPolicy struct {
+	Backoff *RetryBackoffConfig `json:"backoff,omitempty"`
+	On      []BuildStatus       `json:"on,omitempty"`
+}
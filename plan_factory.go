@@ -47,8 +47,12 @@ func (factory PlanFactory) NewPlan(step Step) Plan {
 		plan.DependentGet = &t
 	case TimeoutPlan:
 		plan.Timeout = &t
+	case BreakpointPlan:
+		plan.Breakpoint = &t
 	case RetryPlan:
 		plan.Retry = &t
+	case SetPipelinePlan:
+		plan.SetPipeline = &t
 	default:
 		panic(fmt.Sprintf("don't know how to construct plan from %T", step))
 	}
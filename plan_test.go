@@ -197,7 +197,8 @@ var _ = Describe("Plan", func() {
 					ID: "20",
 					Do: &atc.DoPlan{
 						atc.Plan{
-							ID: "21",
+							ID:           "21",
+							AcrossValues: map[string]interface{}{"go": "1.8"},
 							Task: &atc.TaskPlan{
 								Name:       "name",
 								ConfigPath: "some/config/path.yml",
@@ -211,6 +212,13 @@ var _ = Describe("Plan", func() {
 
 				atc.Plan{
 					ID: "22",
+					RetryPolicy: &atc.RetryPolicy{
+						Backoff: &atc.RetryBackoffConfig{
+							Strategy: "exponential",
+							Delay:    "10s",
+						},
+						On: []atc.BuildStatus{atc.StatusErrored},
+					},
 					Retry: &atc.RetryPlan{
 						atc.Plan{
 							ID: "23",
@@ -244,6 +252,14 @@ var _ = Describe("Plan", func() {
 						},
 					},
 				},
+
+				atc.Plan{
+					ID: "26",
+					SetPipeline: &atc.SetPipelinePlan{
+						Name: "some-pipeline",
+						File: "some/pipeline.yml",
+					},
+				},
 			},
 		}
 
@@ -385,6 +401,7 @@ var _ = Describe("Plan", func() {
       "do": [
         {
           "id": "21",
+          "across_values": {"go": "1.8"},
           "task": {
             "name": "name",
             "privileged": false
@@ -394,6 +411,10 @@ var _ = Describe("Plan", func() {
     },
     {
       "id": "22",
+      "retry_policy": {
+        "backoff": {"strategy": "exponential", "delay": "10s"},
+        "on": ["errored"]
+      },
       "retry": [
         {
           "id": "23",
@@ -417,6 +438,12 @@ var _ = Describe("Plan", func() {
           }
         }
       ]
+    },
+    {
+      "id": "26",
+      "set_pipeline": {
+        "name": "some-pipeline"
+      }
     }
   ]
 }
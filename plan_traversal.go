@@ -40,6 +40,9 @@ func (pt *planTraversal) Traverse(plan *Plan) error {
 	case plan.Timeout != nil:
 		return pt.Traverse(&plan.Timeout.Step)
 
+	case plan.Breakpoint != nil:
+		return pt.Traverse(&plan.Breakpoint.Step)
+
 	case plan.Try != nil:
 		return pt.Traverse(&plan.Try.Step)
 
@@ -6,6 +6,10 @@ func (plan Plan) Public() *json.RawMessage {
 	var public struct {
 		ID PlanID `json:"id"`
 
+		AcrossValues map[string]interface{} `json:"across_values,omitempty"`
+
+		RetryPolicy *RetryPolicy `json:"retry_policy,omitempty"`
+
 		Aggregate    *json.RawMessage `json:"aggregate,omitempty"`
 		Do           *json.RawMessage `json:"do,omitempty"`
 		Get          *json.RawMessage `json:"get,omitempty"`
@@ -17,10 +21,14 @@ func (plan Plan) Public() *json.RawMessage {
 		Try          *json.RawMessage `json:"try,omitempty"`
 		DependentGet *json.RawMessage `json:"dependent_get,omitempty"`
 		Timeout      *json.RawMessage `json:"timeout,omitempty"`
+		Breakpoint   *json.RawMessage `json:"breakpoint,omitempty"`
 		Retry        *json.RawMessage `json:"retry,omitempty"`
+		SetPipeline  *json.RawMessage `json:"set_pipeline,omitempty"`
 	}
 
 	public.ID = plan.ID
+	public.AcrossValues = plan.AcrossValues
+	public.RetryPolicy = plan.RetryPolicy
 
 	if plan.Aggregate != nil {
 		public.Aggregate = plan.Aggregate.Public()
@@ -66,10 +74,18 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Timeout = plan.Timeout.Public()
 	}
 
+	if plan.Breakpoint != nil {
+		public.Breakpoint = plan.Breakpoint.Public()
+	}
+
 	if plan.Retry != nil {
 		public.Retry = plan.Retry.Public()
 	}
 
+	if plan.SetPipeline != nil {
+		public.SetPipeline = plan.SetPipeline.Public()
+	}
+
 	return enc(public)
 }
 
@@ -181,6 +197,16 @@ func (plan TimeoutPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan BreakpointPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Step *json.RawMessage `json:"step"`
+		When string           `json:"when"`
+	}{
+		Step: plan.Step.Public(),
+		When: plan.When,
+	})
+}
+
 func (plan TryPlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step *json.RawMessage `json:"step"`
@@ -199,6 +225,14 @@ func (plan RetryPlan) Public() *json.RawMessage {
 	return enc(public)
 }
 
+func (plan SetPipelinePlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name string `json:"name"`
+	}{
+		Name: plan.Name,
+	})
+}
+
 func enc(public interface{}) *json.RawMessage {
 	enc, _ := json.Marshal(public)
 	return (*json.RawMessage)(&enc)
@@ -12,6 +12,8 @@ import (
 //go:generate counterfeiter . RadarDB
 
 type RadarDB interface {
+	GetPipelineName() string
+	TeamName() string
 	ScopedName(string) string
 
 	IsPaused() (bool, error)
@@ -13,6 +13,24 @@ import (
 )
 
 type FakeRadarDB struct {
+	GetPipelineNameStub        func() string
+	getPipelineNameMutex       sync.RWMutex
+	getPipelineNameArgsForCall []struct{}
+	getPipelineNameReturns     struct {
+		result1 string
+	}
+	getPipelineNameReturnsOnCall map[int]struct {
+		result1 string
+	}
+	TeamNameStub        func() string
+	teamNameMutex       sync.RWMutex
+	teamNameArgsForCall []struct{}
+	teamNameReturns     struct {
+		result1 string
+	}
+	teamNameReturnsOnCall map[int]struct {
+		result1 string
+	}
 	ScopedNameStub        func(string) string
 	scopedNameMutex       sync.RWMutex
 	scopedNameArgsForCall []struct {
@@ -171,6 +189,86 @@ type FakeRadarDB struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeRadarDB) GetPipelineName() string {
+	fake.getPipelineNameMutex.Lock()
+	ret, specificReturn := fake.getPipelineNameReturnsOnCall[len(fake.getPipelineNameArgsForCall)]
+	fake.getPipelineNameArgsForCall = append(fake.getPipelineNameArgsForCall, struct{}{})
+	fake.recordInvocation("GetPipelineName", []interface{}{})
+	fake.getPipelineNameMutex.Unlock()
+	if fake.GetPipelineNameStub != nil {
+		return fake.GetPipelineNameStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.getPipelineNameReturns.result1
+}
+
+func (fake *FakeRadarDB) GetPipelineNameCallCount() int {
+	fake.getPipelineNameMutex.RLock()
+	defer fake.getPipelineNameMutex.RUnlock()
+	return len(fake.getPipelineNameArgsForCall)
+}
+
+func (fake *FakeRadarDB) GetPipelineNameReturns(result1 string) {
+	fake.GetPipelineNameStub = nil
+	fake.getPipelineNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRadarDB) GetPipelineNameReturnsOnCall(i int, result1 string) {
+	fake.GetPipelineNameStub = nil
+	if fake.getPipelineNameReturnsOnCall == nil {
+		fake.getPipelineNameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.getPipelineNameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRadarDB) TeamName() string {
+	fake.teamNameMutex.Lock()
+	ret, specificReturn := fake.teamNameReturnsOnCall[len(fake.teamNameArgsForCall)]
+	fake.teamNameArgsForCall = append(fake.teamNameArgsForCall, struct{}{})
+	fake.recordInvocation("TeamName", []interface{}{})
+	fake.teamNameMutex.Unlock()
+	if fake.TeamNameStub != nil {
+		return fake.TeamNameStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.teamNameReturns.result1
+}
+
+func (fake *FakeRadarDB) TeamNameCallCount() int {
+	fake.teamNameMutex.RLock()
+	defer fake.teamNameMutex.RUnlock()
+	return len(fake.teamNameArgsForCall)
+}
+
+func (fake *FakeRadarDB) TeamNameReturns(result1 string) {
+	fake.TeamNameStub = nil
+	fake.teamNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRadarDB) TeamNameReturnsOnCall(i int, result1 string) {
+	fake.TeamNameStub = nil
+	if fake.teamNameReturnsOnCall == nil {
+		fake.teamNameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.teamNameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeRadarDB) ScopedName(arg1 string) string {
 	fake.scopedNameMutex.Lock()
 	ret, specificReturn := fake.scopedNameReturnsOnCall[len(fake.scopedNameArgsForCall)]
@@ -775,6 +873,10 @@ func (fake *FakeRadarDB) AcquireResourceTypeCheckingLockReturnsOnCall(i int, res
 func (fake *FakeRadarDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.getPipelineNameMutex.RLock()
+	defer fake.getPipelineNameMutex.RUnlock()
+	fake.teamNameMutex.RLock()
+	defer fake.teamNameMutex.RUnlock()
 	fake.scopedNameMutex.RLock()
 	defer fake.scopedNameMutex.RUnlock()
 	fake.isPausedMutex.RLock()
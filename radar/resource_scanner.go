@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/resource"
@@ -21,6 +22,7 @@ type resourceScanner struct {
 	db              RadarDB
 	dbPipeline      dbng.Pipeline
 	externalURL     string
+	variables       creds.Variables
 }
 
 func NewResourceScanner(
@@ -30,6 +32,7 @@ func NewResourceScanner(
 	db RadarDB,
 	dbPipeline dbng.Pipeline,
 	externalURL string,
+	variables creds.Variables,
 ) Scanner {
 	return &resourceScanner{
 		clock:           clock,
@@ -38,6 +41,7 @@ func NewResourceScanner(
 		db:              db,
 		dbPipeline:      dbPipeline,
 		externalURL:     externalURL,
+		variables:       variables,
 	}
 }
 
@@ -73,7 +77,23 @@ func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (t
 		return 0, err
 	}
 
-	versionedResourceTypes := deserializeVersionedResourceTypes(resourceTypes)
+	versionedResourceTypes, err := scanner.variables.EvaluateResourceTypes(
+		deserializeVersionedResourceTypes(resourceTypes),
+	)
+	if err != nil {
+		logger.Error("failed-to-evaluate-resource-types", err)
+		return 0, err
+	}
+
+	source, err := scanner.variables.EvaluateSource(savedResource.Config.Source)
+	if err != nil {
+		setErr := scanner.db.SetResourceCheckError(savedResource, err)
+		if setErr != nil {
+			logger.Error("failed-to-set-check-error", err)
+		}
+
+		return 0, err
+	}
 
 	lock, acquired, err := scanner.dbPipeline.AcquireResourceCheckingLock(
 		logger,
@@ -81,7 +101,7 @@ func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (t
 			ID:     savedResource.ID,
 			Name:   savedResource.Name,
 			Type:   savedResource.Config.Type,
-			Source: savedResource.Config.Source,
+			Source: source,
 		},
 		versionedResourceTypes,
 		interval,
@@ -112,6 +132,7 @@ func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (t
 		scanner.scan(
 			logger.Session("tick"),
 			savedResource,
+			source,
 			atc.Version(vr.Version),
 			versionedResourceTypes,
 		),
@@ -156,7 +177,23 @@ func (scanner *resourceScanner) ScanFromVersion(logger lager.Logger, resourceNam
 		return err
 	}
 
-	versionedResourceTypes := deserializeVersionedResourceTypes(resourceTypes)
+	versionedResourceTypes, err := scanner.variables.EvaluateResourceTypes(
+		deserializeVersionedResourceTypes(resourceTypes),
+	)
+	if err != nil {
+		logger.Error("failed-to-evaluate-resource-types", err)
+		return err
+	}
+
+	source, err := scanner.variables.EvaluateSource(savedResource.Config.Source)
+	if err != nil {
+		setErr := scanner.db.SetResourceCheckError(savedResource, err)
+		if setErr != nil {
+			logger.Error("failed-to-set-check-error", err)
+		}
+
+		return err
+	}
 
 	for {
 		lock, acquired, err := scanner.dbPipeline.AcquireResourceCheckingLock(
@@ -165,7 +202,7 @@ func (scanner *resourceScanner) ScanFromVersion(logger lager.Logger, resourceNam
 				ID:     savedResource.ID,
 				Name:   savedResource.Name,
 				Type:   savedResource.Config.Type,
-				Source: savedResource.Config.Source,
+				Source: source,
 			},
 			versionedResourceTypes,
 			interval,
@@ -190,7 +227,7 @@ func (scanner *resourceScanner) ScanFromVersion(logger lager.Logger, resourceNam
 		break
 	}
 
-	return scanner.scan(logger, savedResource, fromVersion, versionedResourceTypes)
+	return scanner.scan(logger, savedResource, source, fromVersion, versionedResourceTypes)
 }
 
 func (scanner *resourceScanner) Scan(logger lager.Logger, resourceName string) error {
@@ -208,6 +245,7 @@ func (scanner *resourceScanner) Scan(logger lager.Logger, resourceName string) e
 func (scanner *resourceScanner) scan(
 	logger lager.Logger,
 	savedResource db.SavedResource,
+	source atc.Source,
 	fromVersion atc.Version,
 	resourceTypes atc.VersionedResourceTypes,
 ) error {
@@ -271,7 +309,13 @@ func (scanner *resourceScanner) scan(
 		containerSpec,
 		resourceTypes,
 		worker.NoopImageFetchingDelegate{},
-		savedResource.Config,
+		atc.ResourceConfig{
+			Name:       savedResource.Config.Name,
+			Type:       savedResource.Config.Type,
+			Source:     source,
+			CheckEvery: savedResource.Config.CheckEvery,
+			Tags:       savedResource.Config.Tags,
+		},
 	)
 	if err != nil {
 		logger.Error("failed-to-initialize-new-container", err)
@@ -282,7 +326,7 @@ func (scanner *resourceScanner) scan(
 		"from": fromVersion,
 	})
 
-	newVersions, err := res.Check(savedResource.Config.Source, fromVersion)
+	newVersions, err := res.Check(source, fromVersion)
 
 	setErr := scanner.db.SetResourceCheckError(savedResource, err)
 	if setErr != nil {
@@ -8,6 +8,8 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/db/lock/lockfakes"
@@ -27,11 +29,12 @@ var _ = Describe("ResourceScanner", func() {
 	var (
 		epoch time.Time
 
-		fakeResourceFactory *rfakes.FakeResourceFactory
-		fakeRadarDB         *radarfakes.FakeRadarDB
-		fakeDBPipeline      *dbngfakes.FakePipeline
-		fakeClock           *fakeclock.FakeClock
-		interval            time.Duration
+		fakeResourceFactory   *rfakes.FakeResourceFactory
+		fakeRadarDB           *radarfakes.FakeRadarDB
+		fakeDBPipeline        *dbngfakes.FakePipeline
+		fakeCredentialManager *credsfakes.FakeCredentialManager
+		fakeClock             *fakeclock.FakeClock
+		interval              time.Duration
 
 		fakeResourceType      *dbngfakes.FakeResourceType
 		versionedResourceType atc.VersionedResourceType
@@ -52,6 +55,7 @@ var _ = Describe("ResourceScanner", func() {
 		fakeDBPipeline = new(dbngfakes.FakePipeline)
 		fakeDBPipeline.IDReturns(42)
 		fakeDBPipeline.TeamIDReturns(teamID)
+		fakeCredentialManager = new(credsfakes.FakeCredentialManager)
 		fakeClock = fakeclock.NewFakeClock(epoch)
 		interval = 1 * time.Minute
 
@@ -62,6 +66,7 @@ var _ = Describe("ResourceScanner", func() {
 			fakeRadarDB,
 			fakeDBPipeline,
 			"https://www.example.com",
+			creds.NewVariables(fakeCredentialManager, "some-team", "some-pipeline"),
 		)
 
 		resourceConfig = atc.ResourceConfig{
@@ -183,6 +188,47 @@ var _ = Describe("ResourceScanner", func() {
 				}))
 			})
 
+			Context("when the resource source contains credential placeholders", func() {
+				BeforeEach(func() {
+					savedResource.Config.Source = atc.Source{"uri": "((git-uri))"}
+					fakeRadarDB.GetResourceReturns(savedResource, true, nil)
+
+					fakeCredentialManager.GetReturns("http://secret.example.com", true, nil)
+				})
+
+				It("looks up the credentials for the pipeline", func() {
+					Expect(fakeCredentialManager.GetCallCount()).To(Equal(1))
+
+					teamName, pipelineName, varName := fakeCredentialManager.GetArgsForCall(0)
+					Expect(teamName).To(Equal("some-team"))
+					Expect(pipelineName).To(Equal("some-pipeline"))
+					Expect(varName).To(Equal("git-uri"))
+				})
+
+				It("checks with the evaluated source", func() {
+					source, _ := fakeResource.CheckArgsForCall(0)
+					Expect(source).To(Equal(atc.Source{"uri": "http://secret.example.com"}))
+				})
+
+				Context("when the credential is not defined", func() {
+					BeforeEach(func() {
+						fakeCredentialManager.GetReturns(nil, false, nil)
+					})
+
+					It("sets the check error", func() {
+						Expect(fakeRadarDB.SetResourceCheckErrorCallCount()).To(Equal(1))
+
+						_, resourceErr := fakeRadarDB.SetResourceCheckErrorArgsForCall(0)
+						Expect(resourceErr).To(Equal(creds.UndefinedVariableError{Name: "git-uri"}))
+					})
+
+					It("does not check", func() {
+						Expect(runErr).To(HaveOccurred())
+						Expect(fakeResource.CheckCallCount()).To(BeZero())
+					})
+				})
+			})
+
 			Context("when the resource config has a specified check interval", func() {
 				BeforeEach(func() {
 					savedResource.Config.CheckEvery = "10ms"
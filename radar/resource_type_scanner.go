@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/resource"
@@ -17,6 +18,7 @@ type resourceTypeScanner struct {
 	db              RadarDB
 	dbPipeline      dbng.Pipeline
 	externalURL     string
+	variables       creds.Variables
 }
 
 func NewResourceTypeScanner(
@@ -25,6 +27,7 @@ func NewResourceTypeScanner(
 	db RadarDB,
 	dbPipeline dbng.Pipeline,
 	externalURL string,
+	variables creds.Variables,
 ) Scanner {
 	return &resourceTypeScanner{
 		resourceFactory: resourceFactory,
@@ -32,6 +35,7 @@ func NewResourceTypeScanner(
 		db:              db,
 		dbPipeline:      dbPipeline,
 		externalURL:     externalURL,
+		variables:       variables,
 	}
 }
 
@@ -99,7 +103,19 @@ func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, savedR
 		return err
 	}
 
-	versionedResourceTypes := deserializeVersionedResourceTypes(resourceTypes)
+	versionedResourceTypes, err := scanner.variables.EvaluateResourceTypes(
+		deserializeVersionedResourceTypes(resourceTypes),
+	)
+	if err != nil {
+		logger.Error("failed-to-evaluate-resource-types", err)
+		return err
+	}
+
+	source, err := scanner.variables.EvaluateSource(savedResourceType.Config.Source)
+	if err != nil {
+		logger.Error("failed-to-evaluate-resource-type-source", err)
+		return err
+	}
 
 	resourceSpec := worker.ContainerSpec{
 		ImageSpec: worker.ImageSpec{
@@ -119,7 +135,7 @@ func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, savedR
 		worker.Identifier{
 			Stage:               db.ContainerStageCheck,
 			ImageResourceType:   savedResourceType.Config.Type,
-			ImageResourceSource: savedResourceType.Config.Source,
+			ImageResourceSource: source,
 		},
 		worker.Metadata{
 			Type:       db.ContainerTypeCheck,
@@ -130,7 +146,7 @@ func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, savedR
 		worker.NoopImageFetchingDelegate{},
 		atc.ResourceConfig{
 			Type:   savedResourceType.Config.Type, // XXX think deeply why this used to be .Name
-			Source: savedResourceType.Config.Source,
+			Source: source,
 		},
 	)
 	if err != nil {
@@ -138,7 +154,7 @@ func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, savedR
 		return err
 	}
 
-	newVersions, err := res.Check(savedResourceType.Config.Source, atc.Version(savedResourceType.Version))
+	newVersions, err := res.Check(source, atc.Version(savedResourceType.Version))
 	if err != nil {
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
 			logger.Info("check-failed", lager.Data{"exit-status": rErr.ExitStatus})
@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/lock/lockfakes"
 	"github.com/concourse/atc/dbng"
@@ -50,6 +51,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 			fakeRadarDB,
 			fakeDBPipeline,
 			"https://www.example.com",
+			creds.NewVariables(nil, "some-team", "some-pipeline"),
 		)
 
 		fakeRadarDB.ScopedNameStub = func(thing string) string {
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/resource"
 	"github.com/tedsuo/ifrit"
@@ -38,6 +39,7 @@ func NewScanRunnerFactory(
 	dbPipeline dbng.Pipeline,
 	clock clock.Clock,
 	externalURL string,
+	variables creds.Variables,
 ) ScanRunnerFactory {
 	resourceScanner := NewResourceScanner(
 		clock,
@@ -46,6 +48,7 @@ func NewScanRunnerFactory(
 		db,
 		dbPipeline,
 		externalURL,
+		variables,
 	)
 	resourceTypeScanner := NewResourceTypeScanner(
 		resourceFactory,
@@ -53,6 +56,7 @@ func NewScanRunnerFactory(
 		db,
 		dbPipeline,
 		externalURL,
+		variables,
 	)
 
 	return &scanRunnerFactory{
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/clock"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/resource"
 )
@@ -13,23 +14,27 @@ type ScannerFactory interface {
 }
 
 type scannerFactory struct {
-	resourceFactory resource.ResourceFactory
-	defaultInterval time.Duration
-	externalURL     string
+	resourceFactory   resource.ResourceFactory
+	defaultInterval   time.Duration
+	externalURL       string
+	credentialManager creds.CredentialManager
 }
 
 func NewScannerFactory(
 	resourceFactory resource.ResourceFactory,
 	defaultInterval time.Duration,
 	externalURL string,
+	credentialManager creds.CredentialManager,
 ) ScannerFactory {
 	return &scannerFactory{
-		resourceFactory: resourceFactory,
-		defaultInterval: defaultInterval,
-		externalURL:     externalURL,
+		resourceFactory:   resourceFactory,
+		defaultInterval:   defaultInterval,
+		externalURL:       externalURL,
+		credentialManager: credentialManager,
 	}
 }
 
 func (f *scannerFactory) NewResourceScanner(db RadarDB, dbPipeline dbng.Pipeline) Scanner {
-	return NewResourceScanner(clock.NewClock(), f.resourceFactory, f.defaultInterval, db, dbPipeline, f.externalURL)
+	variables := creds.NewVariables(f.credentialManager, db.TeamName(), db.GetPipelineName())
+	return NewResourceScanner(clock.NewClock(), f.resourceFactory, f.defaultInterval, db, dbPipeline, f.externalURL, variables)
 }
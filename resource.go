@@ -10,4 +10,11 @@ type Resource struct {
 
 	FailingToCheck bool   `json:"failing_to_check,omitempty"`
 	CheckError     string `json:"check_error,omitempty"`
+
+	PinnedVersion Version `json:"pinned_version,omitempty"`
+	PinComment    string  `json:"pin_comment,omitempty"`
+}
+
+type PinVersionRequestBody struct {
+	Comment string `json:"comment,omitempty"`
 }
@@ -73,40 +73,32 @@ func (f *resourceFactory) NewBuildResource(
 	inputSources []InputSource,
 	outputPaths map[string]string,
 ) (Resource, []InputSource, error) {
-	compatibleWorkers, err := f.workerClient.AllSatisfying(containerSpec.WorkerSpec(), resourceTypes)
+	sources := make([]worker.ArtifactSource, len(inputSources))
+	for i, inputSource := range inputSources {
+		sources[i] = inputSource.Source()
+	}
+
+	chosenWorker, err := f.workerClient.SatisfyingWithInputs(containerSpec.WorkerSpec(), resourceTypes, sources)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// find the worker with the most volumes
 	mounts := []worker.VolumeMount{}
 	missingSources := []InputSource{}
-	var chosenWorker worker.Worker
-
-	for _, w := range compatibleWorkers {
-		candidateMounts := []worker.VolumeMount{}
-		missing := []InputSource{}
-
-		for _, inputSource := range inputSources {
-			ourVolume, found, err := inputSource.Source().VolumeOn(w)
-			if err != nil {
-				return nil, nil, err
-			}
-
-			if found {
-				candidateMounts = append(candidateMounts, worker.VolumeMount{
-					Volume:    ourVolume,
-					MountPath: inputSource.MountPath(),
-				})
-			} else {
-				missing = append(missing, inputSource)
-			}
+
+	for _, inputSource := range inputSources {
+		ourVolume, found, err := inputSource.Source().VolumeOn(chosenWorker)
+		if err != nil {
+			return nil, nil, err
 		}
 
-		if len(candidateMounts) >= len(mounts) {
-			mounts = candidateMounts
-			missingSources = missing
-			chosenWorker = w
+		if found {
+			mounts = append(mounts, worker.VolumeMount{
+				Volume:    ourVolume,
+				MountPath: inputSource.MountPath(),
+			})
+		} else {
+			missingSources = append(missingSources, inputSource)
 		}
 	}
 
@@ -3,8 +3,16 @@ package atc
 import "github.com/tedsuo/rata"
 
 const (
-	SaveConfig = "SaveConfig"
-	GetConfig  = "GetConfig"
+	SaveConfig         = "SaveConfig"
+	GetConfig          = "GetConfig"
+	ListConfigVersions = "ListConfigVersions"
+	GetConfigVersion   = "GetConfigVersion"
+	DiffConfigVersions = "DiffConfigVersions"
+	RollbackConfig     = "RollbackConfig"
+
+	SavePipelineTemplate = "SavePipelineTemplate"
+	GetPipelineTemplate  = "GetPipelineTemplate"
+	SavePipelineInstance = "SavePipelineInstance"
 
 	GetBuild            = "GetBuild"
 	GetBuildPlan        = "GetBuildPlan"
@@ -13,6 +21,7 @@ const (
 	BuildEvents         = "BuildEvents"
 	BuildResources      = "BuildResources"
 	AbortBuild          = "AbortBuild"
+	ResumeBuild         = "ResumeBuild"
 	GetBuildPreparation = "GetBuildPreparation"
 
 	GetJob         = "GetJob"
@@ -21,6 +30,7 @@ const (
 	ListJobBuilds  = "ListJobBuilds"
 	ListJobInputs  = "ListJobInputs"
 	GetJobBuild    = "GetJobBuild"
+	RerunBuild     = "RerunBuild"
 	PauseJob       = "PauseJob"
 	UnpauseJob     = "UnpauseJob"
 	GetVersionsDB  = "GetVersionsDB"
@@ -33,9 +43,13 @@ const (
 	UnpauseResource = "UnpauseResource"
 	CheckResource   = "CheckResource"
 
+	CheckResourceWebHook = "CheckResourceWebHook"
+
 	ListResourceVersions          = "ListResourceVersions"
 	EnableResourceVersion         = "EnableResourceVersion"
 	DisableResourceVersion        = "DisableResourceVersion"
+	PinResourceVersion            = "PinResourceVersion"
+	UnpinResourceVersion          = "UnpinResourceVersion"
 	ListBuildsWithVersionAsInput  = "ListBuildsWithVersionAsInput"
 	ListBuildsWithVersionAsOutput = "ListBuildsWithVersionAsOutput"
 
@@ -81,11 +95,29 @@ const (
 	ListTeams   = "ListTeams"
 	SetTeam     = "SetTeam"
 	DestroyTeam = "DestroyTeam"
+
+	ListAPITokens  = "ListAPITokens"
+	CreateAPIToken = "CreateAPIToken"
+	RevokeAPIToken = "RevokeAPIToken"
+
+	ListNotificationSubscriptions  = "ListNotificationSubscriptions"
+	CreateNotificationSubscription = "CreateNotificationSubscription"
+	DeleteNotificationSubscription = "DeleteNotificationSubscription"
+
+	ListAuditEvents = "ListAuditEvents"
 )
 
 var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "PUT", Name: SaveConfig},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "GET", Name: GetConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions", Method: "GET", Name: ListConfigVersions},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions/:config_version", Method: "GET", Name: GetConfigVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions/:config_version/rollback", Method: "PUT", Name: RollbackConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/diff", Method: "GET", Name: DiffConfigVersions},
+
+	{Path: "/api/v1/teams/:team_name/pipeline-templates/:template_name", Method: "PUT", Name: SavePipelineTemplate},
+	{Path: "/api/v1/teams/:team_name/pipeline-templates/:template_name", Method: "GET", Name: GetPipelineTemplate},
+	{Path: "/api/v1/teams/:team_name/pipeline-templates/:template_name/instances/:pipeline_name", Method: "PUT", Name: SavePipelineInstance},
 
 	{Path: "/api/v1/builds", Method: "POST", Name: CreateBuild},
 	{Path: "/api/v1/builds", Method: "GET", Name: ListBuilds},
@@ -94,6 +126,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds/:build_id/events", Method: "GET", Name: BuildEvents},
 	{Path: "/api/v1/builds/:build_id/resources", Method: "GET", Name: BuildResources},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "POST", Name: AbortBuild},
+	{Path: "/api/v1/builds/:build_id/resume", Method: "POST", Name: ResumeBuild},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
@@ -102,6 +135,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "POST", Name: CreateJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", Method: "GET", Name: ListJobInputs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "GET", Name: GetJobBuild},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "POST", Name: RerunBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause", Method: "PUT", Name: PauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: JobBadge},
@@ -124,10 +158,13 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pause", Method: "PUT", Name: PauseResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpause", Method: "PUT", Name: UnpauseResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", Method: "POST", Name: CheckResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", Method: "POST", Name: CheckResourceWebHook},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "GET", Name: ListResourceVersions},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/disable", Method: "PUT", Name: DisableResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", Method: "PUT", Name: PinResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin", Method: "PUT", Name: UnpinResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/input_to", Method: "GET", Name: ListBuildsWithVersionAsInput},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/output_of", Method: "GET", Name: ListBuildsWithVersionAsOutput},
 
@@ -162,4 +199,14 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams", Method: "GET", Name: ListTeams},
 	{Path: "/api/v1/teams/:team_name", Method: "PUT", Name: SetTeam},
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
+
+	{Path: "/api/v1/teams/:team_name/api-tokens", Method: "GET", Name: ListAPITokens},
+	{Path: "/api/v1/teams/:team_name/api-tokens", Method: "POST", Name: CreateAPIToken},
+	{Path: "/api/v1/teams/:team_name/api-tokens/:api_token_name", Method: "DELETE", Name: RevokeAPIToken},
+
+	{Path: "/api/v1/teams/:team_name/notifications", Method: "GET", Name: ListNotificationSubscriptions},
+	{Path: "/api/v1/teams/:team_name/notifications", Method: "POST", Name: CreateNotificationSubscription},
+	{Path: "/api/v1/teams/:team_name/notifications/:subscription_id", Method: "DELETE", Name: DeleteNotificationSubscription},
+
+	{Path: "/api/v1/audit-events", Method: "GET", Name: ListAuditEvents},
 })
@@ -0,0 +1,13 @@
+package buildqueue_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestBuildQueue(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Build Queue Suite")
+}
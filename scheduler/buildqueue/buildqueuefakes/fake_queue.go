@@ -0,0 +1,107 @@
+// This file was generated by counterfeiter
+package buildqueuefakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/scheduler/buildqueue"
+)
+
+type FakeQueue struct {
+	StartStub        func(logger lager.Logger, build db.Build, priority int, start func() (bool, error)) (bool, error)
+	startMutex       sync.RWMutex
+	startArgsForCall []struct {
+		logger   lager.Logger
+		build    db.Build
+		priority int
+		start    func() (bool, error)
+	}
+	startReturns struct {
+		result1 bool
+		result2 error
+	}
+	startReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeQueue) Start(logger lager.Logger, build db.Build, priority int, start func() (bool, error)) (bool, error) {
+	fake.startMutex.Lock()
+	ret, specificReturn := fake.startReturnsOnCall[len(fake.startArgsForCall)]
+	fake.startArgsForCall = append(fake.startArgsForCall, struct {
+		logger   lager.Logger
+		build    db.Build
+		priority int
+		start    func() (bool, error)
+	}{logger, build, priority, start})
+	fake.recordInvocation("Start", []interface{}{logger, build, priority, start})
+	fake.startMutex.Unlock()
+	if fake.StartStub != nil {
+		return fake.StartStub(logger, build, priority, start)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.startReturns.result1, fake.startReturns.result2
+}
+
+func (fake *FakeQueue) StartCallCount() int {
+	fake.startMutex.RLock()
+	defer fake.startMutex.RUnlock()
+	return len(fake.startArgsForCall)
+}
+
+func (fake *FakeQueue) StartArgsForCall(i int) (lager.Logger, db.Build, int, func() (bool, error)) {
+	fake.startMutex.RLock()
+	defer fake.startMutex.RUnlock()
+	return fake.startArgsForCall[i].logger, fake.startArgsForCall[i].build, fake.startArgsForCall[i].priority, fake.startArgsForCall[i].start
+}
+
+func (fake *FakeQueue) StartReturns(result1 bool, result2 error) {
+	fake.StartStub = nil
+	fake.startReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQueue) StartReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.StartStub = nil
+	if fake.startReturnsOnCall == nil {
+		fake.startReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.startReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQueue) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.startMutex.RLock()
+	defer fake.startMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeQueue) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ buildqueue.Queue = new(FakeQueue)
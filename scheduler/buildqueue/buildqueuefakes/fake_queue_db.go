@@ -0,0 +1,417 @@
+// This file was generated by counterfeiter
+package buildqueuefakes
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/scheduler/buildqueue"
+)
+
+type FakeQueueDB struct {
+	AcquireBuildStartingLockStub        func(logger lager.Logger) (lock.Lock, bool, error)
+	acquireBuildStartingLockMutex       sync.RWMutex
+	acquireBuildStartingLockArgsForCall []struct {
+		logger lager.Logger
+	}
+	acquireBuildStartingLockReturns struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}
+	acquireBuildStartingLockReturnsOnCall map[int]struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}
+	SaveQueuedBuildStub        func(buildID int, priority int, offeredAt time.Time) (db.QueuedBuild, error)
+	saveQueuedBuildMutex       sync.RWMutex
+	saveQueuedBuildArgsForCall []struct {
+		buildID   int
+		priority  int
+		offeredAt time.Time
+	}
+	saveQueuedBuildReturns struct {
+		result1 db.QueuedBuild
+		result2 error
+	}
+	saveQueuedBuildReturnsOnCall map[int]struct {
+		result1 db.QueuedBuild
+		result2 error
+	}
+	GetQueuedBuildsStub        func() ([]db.QueuedBuild, error)
+	getQueuedBuildsMutex       sync.RWMutex
+	getQueuedBuildsArgsForCall []struct{}
+	getQueuedBuildsReturns     struct {
+		result1 []db.QueuedBuild
+		result2 error
+	}
+	getQueuedBuildsReturnsOnCall map[int]struct {
+		result1 []db.QueuedBuild
+		result2 error
+	}
+	DeleteQueuedBuildsOfferedBeforeStub        func(offeredBefore time.Time) error
+	deleteQueuedBuildsOfferedBeforeMutex       sync.RWMutex
+	deleteQueuedBuildsOfferedBeforeArgsForCall []struct {
+		offeredBefore time.Time
+	}
+	deleteQueuedBuildsOfferedBeforeReturns struct {
+		result1 error
+	}
+	deleteQueuedBuildsOfferedBeforeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ScheduleQueuedBuildStub        func(buildID int) (bool, error)
+	scheduleQueuedBuildMutex       sync.RWMutex
+	scheduleQueuedBuildArgsForCall []struct {
+		buildID int
+	}
+	scheduleQueuedBuildReturns struct {
+		result1 bool
+		result2 error
+	}
+	scheduleQueuedBuildReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	GetActiveBuildsCountByTeamStub        func() (map[string]int, error)
+	getActiveBuildsCountByTeamMutex       sync.RWMutex
+	getActiveBuildsCountByTeamArgsForCall []struct{}
+	getActiveBuildsCountByTeamReturns     struct {
+		result1 map[string]int
+		result2 error
+	}
+	getActiveBuildsCountByTeamReturnsOnCall map[int]struct {
+		result1 map[string]int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeQueueDB) AcquireBuildStartingLock(logger lager.Logger) (lock.Lock, bool, error) {
+	fake.acquireBuildStartingLockMutex.Lock()
+	ret, specificReturn := fake.acquireBuildStartingLockReturnsOnCall[len(fake.acquireBuildStartingLockArgsForCall)]
+	fake.acquireBuildStartingLockArgsForCall = append(fake.acquireBuildStartingLockArgsForCall, struct {
+		logger lager.Logger
+	}{logger})
+	fake.recordInvocation("AcquireBuildStartingLock", []interface{}{logger})
+	fake.acquireBuildStartingLockMutex.Unlock()
+	if fake.AcquireBuildStartingLockStub != nil {
+		return fake.AcquireBuildStartingLockStub(logger)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.acquireBuildStartingLockReturns.result1, fake.acquireBuildStartingLockReturns.result2, fake.acquireBuildStartingLockReturns.result3
+}
+
+func (fake *FakeQueueDB) AcquireBuildStartingLockCallCount() int {
+	fake.acquireBuildStartingLockMutex.RLock()
+	defer fake.acquireBuildStartingLockMutex.RUnlock()
+	return len(fake.acquireBuildStartingLockArgsForCall)
+}
+
+func (fake *FakeQueueDB) AcquireBuildStartingLockArgsForCall(i int) lager.Logger {
+	fake.acquireBuildStartingLockMutex.RLock()
+	defer fake.acquireBuildStartingLockMutex.RUnlock()
+	return fake.acquireBuildStartingLockArgsForCall[i].logger
+}
+
+func (fake *FakeQueueDB) AcquireBuildStartingLockReturns(result1 lock.Lock, result2 bool, result3 error) {
+	fake.AcquireBuildStartingLockStub = nil
+	fake.acquireBuildStartingLockReturns = struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeQueueDB) AcquireBuildStartingLockReturnsOnCall(i int, result1 lock.Lock, result2 bool, result3 error) {
+	fake.AcquireBuildStartingLockStub = nil
+	if fake.acquireBuildStartingLockReturnsOnCall == nil {
+		fake.acquireBuildStartingLockReturnsOnCall = make(map[int]struct {
+			result1 lock.Lock
+			result2 bool
+			result3 error
+		})
+	}
+	fake.acquireBuildStartingLockReturnsOnCall[i] = struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeQueueDB) SaveQueuedBuild(buildID int, priority int, offeredAt time.Time) (db.QueuedBuild, error) {
+	fake.saveQueuedBuildMutex.Lock()
+	ret, specificReturn := fake.saveQueuedBuildReturnsOnCall[len(fake.saveQueuedBuildArgsForCall)]
+	fake.saveQueuedBuildArgsForCall = append(fake.saveQueuedBuildArgsForCall, struct {
+		buildID   int
+		priority  int
+		offeredAt time.Time
+	}{buildID, priority, offeredAt})
+	fake.recordInvocation("SaveQueuedBuild", []interface{}{buildID, priority, offeredAt})
+	fake.saveQueuedBuildMutex.Unlock()
+	if fake.SaveQueuedBuildStub != nil {
+		return fake.SaveQueuedBuildStub(buildID, priority, offeredAt)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.saveQueuedBuildReturns.result1, fake.saveQueuedBuildReturns.result2
+}
+
+func (fake *FakeQueueDB) SaveQueuedBuildCallCount() int {
+	fake.saveQueuedBuildMutex.RLock()
+	defer fake.saveQueuedBuildMutex.RUnlock()
+	return len(fake.saveQueuedBuildArgsForCall)
+}
+
+func (fake *FakeQueueDB) SaveQueuedBuildArgsForCall(i int) (int, int, time.Time) {
+	fake.saveQueuedBuildMutex.RLock()
+	defer fake.saveQueuedBuildMutex.RUnlock()
+	return fake.saveQueuedBuildArgsForCall[i].buildID, fake.saveQueuedBuildArgsForCall[i].priority, fake.saveQueuedBuildArgsForCall[i].offeredAt
+}
+
+func (fake *FakeQueueDB) SaveQueuedBuildReturns(result1 db.QueuedBuild, result2 error) {
+	fake.SaveQueuedBuildStub = nil
+	fake.saveQueuedBuildReturns = struct {
+		result1 db.QueuedBuild
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQueueDB) SaveQueuedBuildReturnsOnCall(i int, result1 db.QueuedBuild, result2 error) {
+	fake.SaveQueuedBuildStub = nil
+	if fake.saveQueuedBuildReturnsOnCall == nil {
+		fake.saveQueuedBuildReturnsOnCall = make(map[int]struct {
+			result1 db.QueuedBuild
+			result2 error
+		})
+	}
+	fake.saveQueuedBuildReturnsOnCall[i] = struct {
+		result1 db.QueuedBuild
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQueueDB) GetQueuedBuilds() ([]db.QueuedBuild, error) {
+	fake.getQueuedBuildsMutex.Lock()
+	ret, specificReturn := fake.getQueuedBuildsReturnsOnCall[len(fake.getQueuedBuildsArgsForCall)]
+	fake.getQueuedBuildsArgsForCall = append(fake.getQueuedBuildsArgsForCall, struct{}{})
+	fake.recordInvocation("GetQueuedBuilds", []interface{}{})
+	fake.getQueuedBuildsMutex.Unlock()
+	if fake.GetQueuedBuildsStub != nil {
+		return fake.GetQueuedBuildsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getQueuedBuildsReturns.result1, fake.getQueuedBuildsReturns.result2
+}
+
+func (fake *FakeQueueDB) GetQueuedBuildsCallCount() int {
+	fake.getQueuedBuildsMutex.RLock()
+	defer fake.getQueuedBuildsMutex.RUnlock()
+	return len(fake.getQueuedBuildsArgsForCall)
+}
+
+func (fake *FakeQueueDB) GetQueuedBuildsReturns(result1 []db.QueuedBuild, result2 error) {
+	fake.GetQueuedBuildsStub = nil
+	fake.getQueuedBuildsReturns = struct {
+		result1 []db.QueuedBuild
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQueueDB) GetQueuedBuildsReturnsOnCall(i int, result1 []db.QueuedBuild, result2 error) {
+	fake.GetQueuedBuildsStub = nil
+	if fake.getQueuedBuildsReturnsOnCall == nil {
+		fake.getQueuedBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.QueuedBuild
+			result2 error
+		})
+	}
+	fake.getQueuedBuildsReturnsOnCall[i] = struct {
+		result1 []db.QueuedBuild
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQueueDB) DeleteQueuedBuildsOfferedBefore(offeredBefore time.Time) error {
+	fake.deleteQueuedBuildsOfferedBeforeMutex.Lock()
+	ret, specificReturn := fake.deleteQueuedBuildsOfferedBeforeReturnsOnCall[len(fake.deleteQueuedBuildsOfferedBeforeArgsForCall)]
+	fake.deleteQueuedBuildsOfferedBeforeArgsForCall = append(fake.deleteQueuedBuildsOfferedBeforeArgsForCall, struct {
+		offeredBefore time.Time
+	}{offeredBefore})
+	fake.recordInvocation("DeleteQueuedBuildsOfferedBefore", []interface{}{offeredBefore})
+	fake.deleteQueuedBuildsOfferedBeforeMutex.Unlock()
+	if fake.DeleteQueuedBuildsOfferedBeforeStub != nil {
+		return fake.DeleteQueuedBuildsOfferedBeforeStub(offeredBefore)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.deleteQueuedBuildsOfferedBeforeReturns.result1
+}
+
+func (fake *FakeQueueDB) DeleteQueuedBuildsOfferedBeforeCallCount() int {
+	fake.deleteQueuedBuildsOfferedBeforeMutex.RLock()
+	defer fake.deleteQueuedBuildsOfferedBeforeMutex.RUnlock()
+	return len(fake.deleteQueuedBuildsOfferedBeforeArgsForCall)
+}
+
+func (fake *FakeQueueDB) DeleteQueuedBuildsOfferedBeforeArgsForCall(i int) time.Time {
+	fake.deleteQueuedBuildsOfferedBeforeMutex.RLock()
+	defer fake.deleteQueuedBuildsOfferedBeforeMutex.RUnlock()
+	return fake.deleteQueuedBuildsOfferedBeforeArgsForCall[i].offeredBefore
+}
+
+func (fake *FakeQueueDB) DeleteQueuedBuildsOfferedBeforeReturns(result1 error) {
+	fake.DeleteQueuedBuildsOfferedBeforeStub = nil
+	fake.deleteQueuedBuildsOfferedBeforeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQueueDB) DeleteQueuedBuildsOfferedBeforeReturnsOnCall(i int, result1 error) {
+	fake.DeleteQueuedBuildsOfferedBeforeStub = nil
+	if fake.deleteQueuedBuildsOfferedBeforeReturnsOnCall == nil {
+		fake.deleteQueuedBuildsOfferedBeforeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteQueuedBuildsOfferedBeforeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQueueDB) ScheduleQueuedBuild(buildID int) (bool, error) {
+	fake.scheduleQueuedBuildMutex.Lock()
+	ret, specificReturn := fake.scheduleQueuedBuildReturnsOnCall[len(fake.scheduleQueuedBuildArgsForCall)]
+	fake.scheduleQueuedBuildArgsForCall = append(fake.scheduleQueuedBuildArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.recordInvocation("ScheduleQueuedBuild", []interface{}{buildID})
+	fake.scheduleQueuedBuildMutex.Unlock()
+	if fake.ScheduleQueuedBuildStub != nil {
+		return fake.ScheduleQueuedBuildStub(buildID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.scheduleQueuedBuildReturns.result1, fake.scheduleQueuedBuildReturns.result2
+}
+
+func (fake *FakeQueueDB) ScheduleQueuedBuildCallCount() int {
+	fake.scheduleQueuedBuildMutex.RLock()
+	defer fake.scheduleQueuedBuildMutex.RUnlock()
+	return len(fake.scheduleQueuedBuildArgsForCall)
+}
+
+func (fake *FakeQueueDB) ScheduleQueuedBuildArgsForCall(i int) int {
+	fake.scheduleQueuedBuildMutex.RLock()
+	defer fake.scheduleQueuedBuildMutex.RUnlock()
+	return fake.scheduleQueuedBuildArgsForCall[i].buildID
+}
+
+func (fake *FakeQueueDB) ScheduleQueuedBuildReturns(result1 bool, result2 error) {
+	fake.ScheduleQueuedBuildStub = nil
+	fake.scheduleQueuedBuildReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQueueDB) ScheduleQueuedBuildReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.ScheduleQueuedBuildStub = nil
+	if fake.scheduleQueuedBuildReturnsOnCall == nil {
+		fake.scheduleQueuedBuildReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.scheduleQueuedBuildReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQueueDB) GetActiveBuildsCountByTeam() (map[string]int, error) {
+	fake.getActiveBuildsCountByTeamMutex.Lock()
+	ret, specificReturn := fake.getActiveBuildsCountByTeamReturnsOnCall[len(fake.getActiveBuildsCountByTeamArgsForCall)]
+	fake.getActiveBuildsCountByTeamArgsForCall = append(fake.getActiveBuildsCountByTeamArgsForCall, struct{}{})
+	fake.recordInvocation("GetActiveBuildsCountByTeam", []interface{}{})
+	fake.getActiveBuildsCountByTeamMutex.Unlock()
+	if fake.GetActiveBuildsCountByTeamStub != nil {
+		return fake.GetActiveBuildsCountByTeamStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getActiveBuildsCountByTeamReturns.result1, fake.getActiveBuildsCountByTeamReturns.result2
+}
+
+func (fake *FakeQueueDB) GetActiveBuildsCountByTeamCallCount() int {
+	fake.getActiveBuildsCountByTeamMutex.RLock()
+	defer fake.getActiveBuildsCountByTeamMutex.RUnlock()
+	return len(fake.getActiveBuildsCountByTeamArgsForCall)
+}
+
+func (fake *FakeQueueDB) GetActiveBuildsCountByTeamReturns(result1 map[string]int, result2 error) {
+	fake.GetActiveBuildsCountByTeamStub = nil
+	fake.getActiveBuildsCountByTeamReturns = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQueueDB) GetActiveBuildsCountByTeamReturnsOnCall(i int, result1 map[string]int, result2 error) {
+	fake.GetActiveBuildsCountByTeamStub = nil
+	if fake.getActiveBuildsCountByTeamReturnsOnCall == nil {
+		fake.getActiveBuildsCountByTeamReturnsOnCall = make(map[int]struct {
+			result1 map[string]int
+			result2 error
+		})
+	}
+	fake.getActiveBuildsCountByTeamReturnsOnCall[i] = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQueueDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.acquireBuildStartingLockMutex.RLock()
+	defer fake.acquireBuildStartingLockMutex.RUnlock()
+	fake.saveQueuedBuildMutex.RLock()
+	defer fake.saveQueuedBuildMutex.RUnlock()
+	fake.getQueuedBuildsMutex.RLock()
+	defer fake.getQueuedBuildsMutex.RUnlock()
+	fake.deleteQueuedBuildsOfferedBeforeMutex.RLock()
+	defer fake.deleteQueuedBuildsOfferedBeforeMutex.RUnlock()
+	fake.scheduleQueuedBuildMutex.RLock()
+	defer fake.scheduleQueuedBuildMutex.RUnlock()
+	fake.getActiveBuildsCountByTeamMutex.RLock()
+	defer fake.getActiveBuildsCountByTeamMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeQueueDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ buildqueue.QueueDB = new(FakeQueueDB)
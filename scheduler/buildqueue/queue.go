@@ -0,0 +1,211 @@
+package buildqueue
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/metric"
+)
+
+// builds which have not been offered to the queue for this long are assumed to
+// no longer be ready to start, e.g. because their job has been paused
+const waitingBuildExpiry = time.Minute
+
+// the build starting lock is only held while picking builds, so it is retried
+// soon rather than waiting for the next scheduling tick
+const buildStartingLockRetryDelay = 100 * time.Millisecond
+
+//go:generate counterfeiter . Queue
+
+// Queue is shared by the build starters of every pipeline, and decides which
+// of the builds ready to start may do so.
+type Queue interface {
+	// Start marks the build as scheduled if it may start now, then calls start
+	// and returns its result. If the build has to wait, it returns false and
+	// expects to be offered again on the next scheduling tick.
+	Start(logger lager.Logger, build db.Build, priority int, start func() (bool, error)) (bool, error)
+}
+
+//go:generate counterfeiter . QueueDB
+
+type QueueDB interface {
+	AcquireBuildStartingLock(logger lager.Logger) (lock.Lock, bool, error)
+
+	SaveQueuedBuild(buildID int, priority int, offeredAt time.Time) (db.QueuedBuild, error)
+	GetQueuedBuilds() ([]db.QueuedBuild, error)
+	DeleteQueuedBuildsOfferedBefore(offeredBefore time.Time) error
+	ScheduleQueuedBuild(buildID int) (bool, error)
+
+	GetActiveBuildsCountByTeam() (map[string]int, error)
+}
+
+// NewQueue constructs a Queue which allows up to maxStartedBuilds builds to be
+// running across the cluster. The builds waiting to start are kept in the
+// database and picked while holding a lock there, so that the limit and the
+// order hold across every ATC. Once the limit is reached, builds are started
+// as others finish, highest priority first, then favouring the teams with the
+// fewest running builds, then oldest first. If maxStartedBuilds is 0 builds
+// are started immediately.
+func NewQueue(db QueueDB, maxStartedBuilds int, clock clock.Clock) Queue {
+	return &queue{
+		db:               db,
+		maxStartedBuilds: maxStartedBuilds,
+		clock:            clock,
+	}
+}
+
+type queue struct {
+	db               QueueDB
+	maxStartedBuilds int
+	clock            clock.Clock
+}
+
+func (q *queue) Start(logger lager.Logger, build db.Build, priority int, start func() (bool, error)) (bool, error) {
+	logger = logger.Session("build-queue")
+
+	if q.maxStartedBuilds == 0 {
+		scheduled, err := q.db.ScheduleQueuedBuild(build.ID())
+		if err != nil {
+			logger.Error("failed-to-schedule-build", err)
+			return false, err
+		}
+
+		if !scheduled {
+			logger.Debug("build-not-found")
+			return false, nil
+		}
+
+		return start()
+	}
+
+	queuedBuild, scheduled, err := q.schedule(logger, build, priority)
+	if err != nil {
+		return false, err
+	}
+
+	if !scheduled {
+		return false, nil
+	}
+
+	started, err := start()
+	if err != nil {
+		return false, err
+	}
+
+	if started {
+		metric.BuildQueueWaitTime{
+			TeamName:     build.TeamName(),
+			PipelineName: build.PipelineName(),
+			JobName:      build.JobName(),
+			BuildName:    build.Name(),
+			BuildID:      build.ID(),
+			Priority:     priority,
+			Duration:     q.clock.Now().Sub(queuedBuild.FirstOffered),
+		}.Emit(logger)
+	}
+
+	return started, nil
+}
+
+// schedule queues the build and marks it as scheduled if there is room for it
+// ahead of the other queued builds. The build starting lock is only held while
+// deciding, so that starting the build does not hold up the other ATCs.
+func (q *queue) schedule(logger lager.Logger, build db.Build, priority int) (db.QueuedBuild, bool, error) {
+	startingLock, err := q.acquireStartingLock(logger)
+	if err != nil {
+		return db.QueuedBuild{}, false, err
+	}
+
+	defer startingLock.Release()
+
+	now := q.clock.Now()
+
+	queuedBuild, err := q.db.SaveQueuedBuild(build.ID(), priority, now)
+	if err != nil {
+		logger.Error("failed-to-save-queued-build", err)
+		return db.QueuedBuild{}, false, err
+	}
+
+	err = q.db.DeleteQueuedBuildsOfferedBefore(now.Add(-waitingBuildExpiry))
+	if err != nil {
+		logger.Error("failed-to-delete-expired-queued-builds", err)
+		return db.QueuedBuild{}, false, err
+	}
+
+	activeBuilds, err := q.db.GetActiveBuildsCountByTeam()
+	if err != nil {
+		logger.Error("failed-to-get-active-builds-count", err)
+		return db.QueuedBuild{}, false, err
+	}
+
+	queuedBuilds, err := q.db.GetQueuedBuilds()
+	if err != nil {
+		logger.Error("failed-to-get-queued-builds", err)
+		return db.QueuedBuild{}, false, err
+	}
+
+	free := q.maxStartedBuilds
+	for _, count := range activeBuilds {
+		free -= count
+	}
+
+	if buildsAhead(queuedBuild, queuedBuilds, activeBuilds) >= free {
+		logger.Debug("waiting", lager.Data{"build-id": build.ID(), "free": free})
+		return db.QueuedBuild{}, false, nil
+	}
+
+	scheduled, err := q.db.ScheduleQueuedBuild(build.ID())
+	if err != nil {
+		logger.Error("failed-to-schedule-build", err)
+		return db.QueuedBuild{}, false, err
+	}
+
+	return queuedBuild, scheduled, nil
+}
+
+func (q *queue) acquireStartingLock(logger lager.Logger) (lock.Lock, error) {
+	for {
+		startingLock, acquired, err := q.db.AcquireBuildStartingLock(logger)
+		if err != nil {
+			logger.Error("failed-to-acquire-build-starting-lock", err)
+			return nil, err
+		}
+
+		if acquired {
+			return startingLock, nil
+		}
+
+		q.clock.Sleep(buildStartingLockRetryDelay)
+	}
+}
+
+func buildsAhead(build db.QueuedBuild, queuedBuilds []db.QueuedBuild, activeBuilds map[string]int) int {
+	ahead := 0
+
+	for _, other := range queuedBuilds {
+		if other.BuildID != build.BuildID && before(other, build, activeBuilds) {
+			ahead++
+		}
+	}
+
+	return ahead
+}
+
+func before(a db.QueuedBuild, b db.QueuedBuild, activeBuilds map[string]int) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+
+	if activeBuilds[a.TeamName] != activeBuilds[b.TeamName] {
+		return activeBuilds[a.TeamName] < activeBuilds[b.TeamName]
+	}
+
+	if !a.FirstOffered.Equal(b.FirstOffered) {
+		return a.FirstOffered.Before(b.FirstOffered)
+	}
+
+	return a.BuildID < b.BuildID
+}
@@ -0,0 +1,299 @@
+package buildqueue_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/db/lock/lockfakes"
+	"github.com/concourse/atc/scheduler/buildqueue"
+	"github.com/concourse/atc/scheduler/buildqueue/buildqueuefakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Queue", func() {
+	var (
+		fakeDB           *buildqueuefakes.FakeQueueDB
+		fakeLock         *lockfakes.FakeLock
+		fakeClock        *fakeclock.FakeClock
+		maxStartedBuilds int
+		logger           *lagertest.TestLogger
+
+		queue buildqueue.Queue
+
+		queuedBuilds  []db.QueuedBuild
+		startedBuilds []int
+	)
+
+	build := func(id int, teamName string) *dbfakes.FakeBuild {
+		build := new(dbfakes.FakeBuild)
+		build.IDReturns(id)
+		build.TeamNameReturns(teamName)
+		return build
+	}
+
+	queued := func(id int, teamName string, priority int, firstOffered time.Time) db.QueuedBuild {
+		return db.QueuedBuild{
+			BuildID:      id,
+			TeamName:     teamName,
+			Priority:     priority,
+			FirstOffered: firstOffered,
+			LastOffered:  firstOffered,
+		}
+	}
+
+	offer := func(build *dbfakes.FakeBuild, priority int) bool {
+		started, err := queue.Start(logger, build, priority, func() (bool, error) {
+			startedBuilds = append(startedBuilds, build.ID())
+			return true, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return started
+	}
+
+	BeforeEach(func() {
+		fakeDB = new(buildqueuefakes.FakeQueueDB)
+		fakeLock = new(lockfakes.FakeLock)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 0))
+		maxStartedBuilds = 2
+		logger = lagertest.NewTestLogger("test")
+
+		queuedBuilds = nil
+		startedBuilds = nil
+
+		fakeDB.AcquireBuildStartingLockReturns(fakeLock, true, nil)
+		fakeDB.ScheduleQueuedBuildReturns(true, nil)
+
+		fakeDB.SaveQueuedBuildStub = func(buildID int, priority int, offeredAt time.Time) (db.QueuedBuild, error) {
+			for _, queuedBuild := range queuedBuilds {
+				if queuedBuild.BuildID == buildID {
+					return queuedBuild, nil
+				}
+			}
+
+			return queued(buildID, "some-team", priority, offeredAt), nil
+		}
+
+		fakeDB.GetQueuedBuildsStub = func() ([]db.QueuedBuild, error) {
+			return queuedBuilds, nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		queue = buildqueue.NewQueue(fakeDB, maxStartedBuilds, fakeClock)
+	})
+
+	Context("when there is no maximum", func() {
+		BeforeEach(func() {
+			maxStartedBuilds = 0
+		})
+
+		It("schedules and starts builds immediately", func() {
+			Expect(offer(build(1, "some-team"), 0)).To(BeTrue())
+			Expect(fakeDB.ScheduleQueuedBuildCallCount()).To(Equal(1))
+			Expect(fakeDB.ScheduleQueuedBuildArgsForCall(0)).To(Equal(1))
+
+			Expect(fakeDB.AcquireBuildStartingLockCallCount()).To(BeZero())
+			Expect(fakeDB.GetActiveBuildsCountByTeamCallCount()).To(BeZero())
+		})
+
+		Context("when the build does not exist", func() {
+			BeforeEach(func() {
+				fakeDB.ScheduleQueuedBuildReturns(false, nil)
+			})
+
+			It("does not start it", func() {
+				Expect(offer(build(1, "some-team"), 0)).To(BeFalse())
+				Expect(startedBuilds).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("when there is room for more builds", func() {
+		BeforeEach(func() {
+			fakeDB.GetActiveBuildsCountByTeamReturns(map[string]int{"some-team": 1}, nil)
+		})
+
+		It("queues the build with its priority, then schedules and starts it", func() {
+			Expect(offer(build(1, "some-team"), 5)).To(BeTrue())
+
+			Expect(fakeDB.SaveQueuedBuildCallCount()).To(Equal(1))
+			buildID, priority, offeredAt := fakeDB.SaveQueuedBuildArgsForCall(0)
+			Expect(buildID).To(Equal(1))
+			Expect(priority).To(Equal(5))
+			Expect(offeredAt).To(Equal(fakeClock.Now()))
+
+			Expect(fakeDB.ScheduleQueuedBuildCallCount()).To(Equal(1))
+			Expect(fakeDB.ScheduleQueuedBuildArgsForCall(0)).To(Equal(1))
+
+			Expect(startedBuilds).To(Equal([]int{1}))
+		})
+
+		It("forgets builds which are no longer offered", func() {
+			offer(build(1, "some-team"), 0)
+
+			Expect(fakeDB.DeleteQueuedBuildsOfferedBeforeCallCount()).To(Equal(1))
+			Expect(fakeDB.DeleteQueuedBuildsOfferedBeforeArgsForCall(0)).To(Equal(fakeClock.Now().Add(-time.Minute)))
+		})
+
+		It("decides while holding the build starting lock, and starts the build after releasing it", func() {
+			fakeDB.ScheduleQueuedBuildStub = func(int) (bool, error) {
+				Expect(fakeLock.ReleaseCallCount()).To(BeZero())
+				return true, nil
+			}
+
+			_, err := queue.Start(logger, build(1, "some-team"), 0, func() (bool, error) {
+				Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+				return true, nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeDB.AcquireBuildStartingLockCallCount()).To(Equal(1))
+		})
+
+		Context("when another ATC holds the build starting lock", func() {
+			BeforeEach(func() {
+				fakeDB.AcquireBuildStartingLockReturnsOnCall(0, nil, false, nil)
+				fakeDB.AcquireBuildStartingLockReturnsOnCall(1, fakeLock, true, nil)
+			})
+
+			It("tries again shortly rather than waiting for the next tick", func() {
+				started := make(chan bool)
+				go func() {
+					defer GinkgoRecover()
+					started <- offer(build(1, "some-team"), 0)
+				}()
+
+				fakeClock.WaitForWatcherAndIncrement(100 * time.Millisecond)
+
+				Eventually(started).Should(Receive(BeTrue()))
+				Expect(fakeDB.AcquireBuildStartingLockCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when a build ahead of it is waiting", func() {
+			BeforeEach(func() {
+				queuedBuilds = []db.QueuedBuild{
+					queued(1, "some-team", 10, fakeClock.Now()),
+				}
+
+				fakeDB.GetActiveBuildsCountByTeamReturns(map[string]int{"some-team": 1}, nil)
+			})
+
+			It("leaves room for the build ahead of it, even if that build is waiting on another ATC", func() {
+				Expect(offer(build(2, "some-team"), 0)).To(BeFalse())
+				Expect(fakeDB.ScheduleQueuedBuildCallCount()).To(BeZero())
+
+				Expect(offer(build(1, "some-team"), 10)).To(BeTrue())
+				Expect(startedBuilds).To(Equal([]int{1}))
+			})
+		})
+
+		Context("when starting the build fails", func() {
+			disaster := errors.New("nope")
+
+			It("returns the error", func() {
+				_, err := queue.Start(logger, build(1, "some-team"), 0, func() (bool, error) {
+					return false, disaster
+				})
+				Expect(err).To(Equal(disaster))
+			})
+		})
+	})
+
+	Context("when the maximum is reached", func() {
+		BeforeEach(func() {
+			fakeDB.GetActiveBuildsCountByTeamReturns(map[string]int{"some-team": 1, "some-other-team": 1}, nil)
+		})
+
+		It("does not schedule or start the build", func() {
+			Expect(offer(build(1, "some-team"), 0)).To(BeFalse())
+			Expect(fakeDB.ScheduleQueuedBuildCallCount()).To(BeZero())
+			Expect(startedBuilds).To(BeEmpty())
+		})
+
+		Context("once builds finish", func() {
+			BeforeEach(func() {
+				firstOffered := fakeClock.Now()
+
+				queuedBuilds = []db.QueuedBuild{
+					queued(4, "greedy-team", 5, firstOffered.Add(3*time.Second)),
+					queued(1, "greedy-team", 0, firstOffered),
+					queued(2, "greedy-team", 0, firstOffered.Add(time.Second)),
+					queued(3, "modest-team", 0, firstOffered.Add(2*time.Second)),
+				}
+
+				fakeDB.ScheduleQueuedBuildStub = func(buildID int) (bool, error) {
+					remaining := []db.QueuedBuild{}
+					for _, queuedBuild := range queuedBuilds {
+						if queuedBuild.BuildID != buildID {
+							remaining = append(remaining, queuedBuild)
+						}
+					}
+
+					queuedBuilds = remaining
+
+					return true, nil
+				}
+			})
+
+			It("starts builds by priority, then favouring teams with fewer running builds, then by age", func() {
+				fakeDB.GetActiveBuildsCountByTeamReturns(map[string]int{"greedy-team": 1}, nil)
+
+				Expect(offer(build(1, "greedy-team"), 0)).To(BeFalse())
+				Expect(offer(build(3, "modest-team"), 0)).To(BeFalse())
+				Expect(offer(build(4, "greedy-team"), 5)).To(BeTrue())
+
+				fakeDB.GetActiveBuildsCountByTeamReturns(map[string]int{"greedy-team": 1}, nil)
+
+				Expect(offer(build(1, "greedy-team"), 0)).To(BeFalse())
+				Expect(offer(build(3, "modest-team"), 0)).To(BeTrue())
+
+				fakeDB.GetActiveBuildsCountByTeamReturns(map[string]int{"modest-team": 1}, nil)
+
+				Expect(offer(build(2, "greedy-team"), 0)).To(BeFalse())
+				Expect(offer(build(1, "greedy-team"), 0)).To(BeTrue())
+
+				Expect(startedBuilds).To(Equal([]int{4, 3, 1}))
+			})
+		})
+	})
+
+	Context("when acquiring the build starting lock fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeDB.AcquireBuildStartingLockReturns(nil, false, disaster)
+		})
+
+		It("returns the error without starting the build", func() {
+			_, err := queue.Start(logger, build(1, "some-team"), 0, func() (bool, error) {
+				return true, nil
+			})
+			Expect(err).To(Equal(disaster))
+			Expect(fakeDB.GetActiveBuildsCountByTeamCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when getting the active builds fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeDB.GetActiveBuildsCountByTeamReturns(nil, disaster)
+		})
+
+		It("returns the error without starting the build", func() {
+			_, err := queue.Start(logger, build(1, "some-team"), 0, func() (bool, error) {
+				return true, nil
+			})
+			Expect(err).To(Equal(disaster))
+			Expect(fakeDB.ScheduleQueuedBuildCallCount()).To(BeZero())
+			Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+		})
+	})
+})
@@ -1,12 +1,14 @@
 package scheduler
 
 import (
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/engine"
+	"github.com/concourse/atc/scheduler/buildqueue"
 	"github.com/concourse/atc/scheduler/inputmapper"
 	"github.com/concourse/atc/scheduler/maxinflight"
 )
@@ -29,9 +31,9 @@ type BuildStarterDB interface {
 	GetNextBuildInputs(jobName string) ([]db.BuildInput, bool, error)
 	IsPaused() (bool, error)
 	GetJob(job string) (db.SavedJob, bool, error)
-	UpdateBuildToScheduled(int) (bool, error)
 	UseInputsForBuild(buildID int, inputs []db.BuildInput) error
 	LoadVersionsDB() (*algorithm.VersionsDB, error)
+	SetOutsideTriggerWindow(jobName string, outside bool) error
 }
 
 //go:generate counterfeiter . BuildStarterBuildsDB
@@ -53,6 +55,8 @@ func NewBuildStarter(
 	scanner Scanner,
 	inputMapper inputmapper.InputMapper,
 	execEngine engine.Engine,
+	clock clock.Clock,
+	queue buildqueue.Queue,
 ) BuildStarter {
 	return &buildStarter{
 		db:                 db,
@@ -61,6 +65,8 @@ func NewBuildStarter(
 		scanner:            scanner,
 		inputMapper:        inputMapper,
 		execEngine:         execEngine,
+		clock:              clock,
+		queue:              queue,
 	}
 }
 
@@ -71,6 +77,8 @@ type buildStarter struct {
 	execEngine         engine.Engine
 	scanner            Scanner
 	inputMapper        inputmapper.InputMapper
+	clock              clock.Clock
+	queue              buildqueue.Queue
 }
 
 func (s *buildStarter) TryStartPendingBuildsForJob(
@@ -114,35 +122,8 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
-	if nextPendingBuild.IsManuallyTriggered() {
-		jobBuildInputs := config.JobInputs(jobConfig)
-		for _, input := range jobBuildInputs {
-			scanLog := logger.Session("scan", lager.Data{
-				"input":    input.Name,
-				"resource": input.Resource,
-			})
-
-			err := s.scanner.Scan(scanLog, input.Resource)
-			if err != nil {
-				return false, err
-			}
-		}
-
-		versions, err := s.db.LoadVersionsDB()
-		if err != nil {
-			logger.Error("failed-to-load-versions-db", err)
-			return false, err
-		}
-
-		_, err = s.inputMapper.SaveNextInputMapping(logger, versions, jobConfig)
-		if err != nil {
-			return false, err
-		}
-	}
-
-	buildInputs, found, err := s.db.GetNextBuildInputs(nextPendingBuild.JobName())
+	buildInputs, found, err := s.getBuildInputs(logger, nextPendingBuild, jobConfig)
 	if err != nil {
-		logger.Error("failed-to-get-next-build-inputs", err)
 		return false, err
 	}
 	if !found {
@@ -171,18 +152,107 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
-	updated, err := s.db.UpdateBuildToScheduled(nextPendingBuild.ID())
+	if !nextPendingBuild.IsManuallyTriggered() {
+		outside, err := s.isOutsideTriggerWindow(jobConfig)
+		if err != nil {
+			logger.Error("failed-to-check-trigger-window", err)
+			return false, err
+		}
+
+		err = s.db.SetOutsideTriggerWindow(jobConfig.Name, outside)
+		if err != nil {
+			logger.Error("failed-to-set-outside-trigger-window", err)
+			return false, err
+		}
+
+		if outside {
+			logger.Debug("outside-trigger-window")
+			return false, nil
+		}
+	}
+
+	return s.queue.Start(logger, nextPendingBuild, jobConfig.Priority, func() (bool, error) {
+		return s.startBuild(logger, nextPendingBuild, buildInputs, jobConfig, resourceConfigs, resourceTypes)
+	})
+}
+
+// isOutsideTriggerWindow returns true if the job has a trigger window and it
+// is currently closed.
+func (s *buildStarter) isOutsideTriggerWindow(jobConfig atc.JobConfig) (bool, error) {
+	if jobConfig.TriggerWindow == nil {
+		return false, nil
+	}
+
+	open, err := jobConfig.TriggerWindow.Contains(s.clock.Now())
 	if err != nil {
-		logger.Error("failed-to-update-build-to-scheduled", err)
 		return false, err
 	}
 
-	if !updated {
-		logger.Debug("build-already-scheduled")
-		return false, nil
+	return !open, nil
+}
+
+// getBuildInputs returns the inputs with which to start the build. Reruns
+// use the inputs copied from the original build when they were created;
+// other builds use the next inputs determined by the scheduler.
+func (s *buildStarter) getBuildInputs(
+	logger lager.Logger,
+	nextPendingBuild db.Build,
+	jobConfig atc.JobConfig,
+) ([]db.BuildInput, bool, error) {
+	if nextPendingBuild.RerunOf() != 0 {
+		buildInputs, _, err := nextPendingBuild.GetResources()
+		if err != nil {
+			logger.Error("failed-to-get-rerun-build-inputs", err)
+			return nil, false, err
+		}
+
+		return buildInputs, true, nil
+	}
+
+	if nextPendingBuild.IsManuallyTriggered() {
+		jobBuildInputs := config.JobInputs(jobConfig)
+		for _, input := range jobBuildInputs {
+			scanLog := logger.Session("scan", lager.Data{
+				"input":    input.Name,
+				"resource": input.Resource,
+			})
+
+			err := s.scanner.Scan(scanLog, input.Resource)
+			if err != nil {
+				return nil, false, err
+			}
+		}
+
+		versions, err := s.db.LoadVersionsDB()
+		if err != nil {
+			logger.Error("failed-to-load-versions-db", err)
+			return nil, false, err
+		}
+
+		_, err = s.inputMapper.SaveNextInputMapping(logger, versions, jobConfig)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	buildInputs, found, err := s.db.GetNextBuildInputs(nextPendingBuild.JobName())
+	if err != nil {
+		logger.Error("failed-to-get-next-build-inputs", err)
+		return nil, false, err
 	}
 
-	err = s.db.UseInputsForBuild(nextPendingBuild.ID(), buildInputs)
+	return buildInputs, found, nil
+}
+
+func (s *buildStarter) startBuild(
+	logger lager.Logger,
+	nextPendingBuild db.Build,
+	buildInputs []db.BuildInput,
+	jobConfig atc.JobConfig,
+	resourceConfigs atc.ResourceConfigs,
+	resourceTypes atc.VersionedResourceTypes,
+) (bool, error) {
+	err := s.db.UseInputsForBuild(nextPendingBuild.ID(), buildInputs)
 	if err != nil {
 		return false, err
 	}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
@@ -13,6 +14,7 @@ import (
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/engine/enginefakes"
 	"github.com/concourse/atc/scheduler"
+	"github.com/concourse/atc/scheduler/buildqueue/buildqueuefakes"
 	"github.com/concourse/atc/scheduler/inputmapper/inputmapperfakes"
 	"github.com/concourse/atc/scheduler/maxinflight/maxinflightfakes"
 	"github.com/concourse/atc/scheduler/schedulerfakes"
@@ -31,6 +33,8 @@ var _ = Describe("I'm a BuildStarter", func() {
 		fakeScanner      *schedulerfakes.FakeScanner
 		fakeInputMapper  *inputmapperfakes.FakeInputMapper
 		fakeBuildStarter *schedulerfakes.FakeBuildStarter
+		fakeClock        *fakeclock.FakeClock
+		fakeQueue        *buildqueuefakes.FakeQueue
 
 		buildStarter scheduler.BuildStarter
 
@@ -46,7 +50,14 @@ var _ = Describe("I'm a BuildStarter", func() {
 		fakeInputMapper = new(inputmapperfakes.FakeInputMapper)
 		fakeBuildStarter = new(schedulerfakes.FakeBuildStarter)
 
-		buildStarter = scheduler.NewBuildStarter(fakeDB, fakeUpdater, fakeFactory, fakeScanner, fakeInputMapper, fakeEngine)
+		fakeClock = fakeclock.NewFakeClock(time.Date(2017, 6, 5, 12, 0, 0, 0, time.UTC))
+
+		fakeQueue = new(buildqueuefakes.FakeQueue)
+		fakeQueue.StartStub = func(_ lager.Logger, _ db.Build, _ int, start func() (bool, error)) (bool, error) {
+			return start()
+		}
+
+		buildStarter = scheduler.NewBuildStarter(fakeDB, fakeUpdater, fakeFactory, fakeScanner, fakeInputMapper, fakeEngine, fakeClock, fakeQueue)
 
 		disaster = errors.New("bad thing")
 	})
@@ -258,8 +269,8 @@ var _ = Describe("I'm a BuildStarter", func() {
 					Expect(tryStartErr).NotTo(HaveOccurred())
 				})
 
-				It("doesn't try to mark the build as scheduled", func() {
-					Expect(fakeDB.UpdateBuildToScheduledCallCount()).To(BeZero())
+				It("doesn't offer the build to the queue to be scheduled", func() {
+					Expect(fakeQueue.StartCallCount()).To(BeZero())
 				})
 			}
 
@@ -308,24 +319,25 @@ var _ = Describe("I'm a BuildStarter", func() {
 						pendingBuilds = []db.Build{pendingBuild1, pendingBuild2, pendingBuild3}
 					})
 
-					Context("when marking the build as scheduled fails", func() {
+					Context("when the queue fails to schedule the build", func() {
 						BeforeEach(func() {
-							fakeDB.UpdateBuildToScheduledReturns(false, disaster)
+							fakeQueue.StartReturns(false, disaster)
 						})
 
 						It("returns the error", func() {
 							Expect(tryStartErr).To(Equal(disaster))
 						})
 
-						It("marked the right build as scheduled", func() {
-							Expect(fakeDB.UpdateBuildToScheduledCallCount()).To(Equal(1))
-							Expect(fakeDB.UpdateBuildToScheduledArgsForCall(0)).To(Equal(99))
+						It("offered the right build to the queue", func() {
+							Expect(fakeQueue.StartCallCount()).To(Equal(1))
+							_, actualBuild, _, _ := fakeQueue.StartArgsForCall(0)
+							Expect(actualBuild.ID()).To(Equal(99))
 						})
 					})
 
-					Context("when someone else already scheduled the build", func() {
+					Context("when the queue does not schedule the build", func() {
 						BeforeEach(func() {
-							fakeDB.UpdateBuildToScheduledReturns(false, nil)
+							fakeQueue.StartReturns(false, nil)
 						})
 
 						It("doesn't return an error", func() {
@@ -337,11 +349,7 @@ var _ = Describe("I'm a BuildStarter", func() {
 						})
 					})
 
-					Context("when marking the build as scheduled succeeds", func() {
-						BeforeEach(func() {
-							fakeDB.UpdateBuildToScheduledReturns(true, nil)
-						})
-
+					Context("when the queue schedules the build", func() {
 						Context("when using inputs for build fails", func() {
 							BeforeEach(func() {
 								fakeDB.UseInputsForBuildReturns(disaster)
@@ -571,6 +579,231 @@ var _ = Describe("I'm a BuildStarter", func() {
 				})
 			})
 		})
+
+		Context("when the job has a trigger window", func() {
+			var pendingBuild *dbfakes.FakeBuild
+
+			BeforeEach(func() {
+				jobConfig = atc.JobConfig{
+					Name: "some-job",
+					TriggerWindow: &atc.TriggerWindowConfig{
+						Start: "09:00",
+						Stop:  "17:00",
+					},
+				}
+
+				pendingBuild = new(dbfakes.FakeBuild)
+				pendingBuild.IDReturns(99)
+				pendingBuild.JobNameReturns("some-job")
+				pendingBuilds = []db.Build{pendingBuild}
+
+				fakeUpdater.UpdateMaxInFlightReachedReturns(false, nil)
+				fakeDB.GetNextBuildInputsReturns([]db.BuildInput{{Name: "some-input"}}, true, nil)
+				fakeDB.IsPausedReturns(false, nil)
+				fakeDB.GetJobReturns(db.SavedJob{Paused: false}, true, nil)
+				fakeEngine.CreateBuildReturns(new(enginefakes.FakeBuild), nil)
+			})
+
+			JustBeforeEach(func() {
+				tryStartErr = buildStarter.TryStartPendingBuildsForJob(
+					lagertest.NewTestLogger("test"),
+					jobConfig,
+					atc.ResourceConfigs{{Name: "some-resource"}},
+					versionedResourceTypes,
+					pendingBuilds,
+				)
+			})
+
+			Context("when the window is open", func() {
+				It("starts the build", func() {
+					Expect(tryStartErr).NotTo(HaveOccurred())
+					Expect(fakeQueue.StartCallCount()).To(Equal(1))
+					Expect(fakeEngine.CreateBuildCallCount()).To(Equal(1))
+				})
+
+				It("records that the job is not outside its trigger window", func() {
+					Expect(fakeDB.SetOutsideTriggerWindowCallCount()).To(Equal(1))
+					jobName, outside := fakeDB.SetOutsideTriggerWindowArgsForCall(0)
+					Expect(jobName).To(Equal("some-job"))
+					Expect(outside).To(BeFalse())
+				})
+			})
+
+			Context("when the window is closed", func() {
+				BeforeEach(func() {
+					fakeClock.IncrementBySeconds(6 * 60 * 60)
+				})
+
+				It("leaves the build pending", func() {
+					Expect(tryStartErr).NotTo(HaveOccurred())
+					Expect(fakeQueue.StartCallCount()).To(BeZero())
+				})
+
+				It("records that the job is outside its trigger window", func() {
+					Expect(fakeDB.SetOutsideTriggerWindowCallCount()).To(Equal(1))
+					jobName, outside := fakeDB.SetOutsideTriggerWindowArgsForCall(0)
+					Expect(jobName).To(Equal("some-job"))
+					Expect(outside).To(BeTrue())
+				})
+
+				Context("when recording it fails", func() {
+					BeforeEach(func() {
+						fakeDB.SetOutsideTriggerWindowReturns(disaster)
+					})
+
+					It("returns the error without starting the build", func() {
+						Expect(tryStartErr).To(Equal(disaster))
+						Expect(fakeQueue.StartCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the build was manually triggered", func() {
+					BeforeEach(func() {
+						pendingBuild.IsManuallyTriggeredReturns(true)
+						fakeDB.LoadVersionsDBReturns(&algorithm.VersionsDB{}, nil)
+					})
+
+					It("starts the build anyway", func() {
+						Expect(tryStartErr).NotTo(HaveOccurred())
+						Expect(fakeQueue.StartCallCount()).To(Equal(1))
+					})
+
+					It("does not record the trigger window", func() {
+						Expect(fakeDB.SetOutsideTriggerWindowCallCount()).To(BeZero())
+					})
+				})
+			})
+		})
+
+		Context("when the build is ready to start", func() {
+			var pendingBuild *dbfakes.FakeBuild
+
+			BeforeEach(func() {
+				jobConfig = atc.JobConfig{Name: "some-job", Priority: 5}
+
+				pendingBuild = new(dbfakes.FakeBuild)
+				pendingBuild.IDReturns(99)
+				pendingBuild.JobNameReturns("some-job")
+				pendingBuilds = []db.Build{pendingBuild}
+
+				fakeUpdater.UpdateMaxInFlightReachedReturns(false, nil)
+				fakeDB.GetNextBuildInputsReturns([]db.BuildInput{{Name: "some-input"}}, true, nil)
+				fakeDB.IsPausedReturns(false, nil)
+				fakeDB.GetJobReturns(db.SavedJob{Paused: false}, true, nil)
+				fakeEngine.CreateBuildReturns(new(enginefakes.FakeBuild), nil)
+			})
+
+			JustBeforeEach(func() {
+				tryStartErr = buildStarter.TryStartPendingBuildsForJob(
+					lagertest.NewTestLogger("test"),
+					jobConfig,
+					atc.ResourceConfigs{{Name: "some-resource"}},
+					versionedResourceTypes,
+					pendingBuilds,
+				)
+			})
+
+			It("starts it through the queue with the job's priority", func() {
+				Expect(fakeQueue.StartCallCount()).To(Equal(1))
+				_, actualBuild, actualPriority, _ := fakeQueue.StartArgsForCall(0)
+				Expect(actualBuild).To(Equal(pendingBuild))
+				Expect(actualPriority).To(Equal(5))
+
+				Expect(fakeEngine.CreateBuildCallCount()).To(Equal(1))
+			})
+
+			Context("when the queue holds the build back", func() {
+				BeforeEach(func() {
+					fakeQueue.StartReturns(false, nil)
+				})
+
+				It("leaves the build pending", func() {
+					Expect(tryStartErr).NotTo(HaveOccurred())
+					Expect(fakeEngine.CreateBuildCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the queue fails", func() {
+				BeforeEach(func() {
+					fakeQueue.StartReturns(false, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(tryStartErr).To(Equal(disaster))
+				})
+			})
+		})
+
+		Context("when the build is a rerun", func() {
+			var rerunBuild *dbfakes.FakeBuild
+			var originalInputs []db.BuildInput
+
+			BeforeEach(func() {
+				jobConfig = atc.JobConfig{Name: "some-job", Plan: atc.PlanSequence{{Get: "some-input"}}}
+
+				originalInputs = []db.BuildInput{
+					{
+						Name: "some-input",
+						VersionedResource: db.VersionedResource{
+							Resource: "some-resource",
+							Version:  db.Version{"ref": "original"},
+						},
+					},
+				}
+
+				rerunBuild = new(dbfakes.FakeBuild)
+				rerunBuild.IDReturns(100)
+				rerunBuild.JobNameReturns("some-job")
+				rerunBuild.IsManuallyTriggeredReturns(true)
+				rerunBuild.RerunOfReturns(99)
+				rerunBuild.GetResourcesReturns(originalInputs, nil, nil)
+				pendingBuilds = []db.Build{rerunBuild}
+
+				fakeUpdater.UpdateMaxInFlightReachedReturns(false, nil)
+				fakeDB.IsPausedReturns(false, nil)
+				fakeDB.GetJobReturns(db.SavedJob{Paused: false}, true, nil)
+				fakeEngine.CreateBuildReturns(new(enginefakes.FakeBuild), nil)
+			})
+
+			JustBeforeEach(func() {
+				tryStartErr = buildStarter.TryStartPendingBuildsForJob(
+					lagertest.NewTestLogger("test"),
+					jobConfig,
+					atc.ResourceConfigs{{Name: "some-resource"}},
+					versionedResourceTypes,
+					pendingBuilds,
+				)
+			})
+
+			It("does not resolve new inputs", func() {
+				Expect(fakeScanner.ScanCallCount()).To(BeZero())
+				Expect(fakeInputMapper.SaveNextInputMappingCallCount()).To(BeZero())
+				Expect(fakeDB.GetNextBuildInputsCallCount()).To(BeZero())
+			})
+
+			It("starts the build with the inputs copied from the original build", func() {
+				Expect(tryStartErr).NotTo(HaveOccurred())
+
+				Expect(fakeDB.UseInputsForBuildCallCount()).To(Equal(1))
+				buildID, inputs := fakeDB.UseInputsForBuildArgsForCall(0)
+				Expect(buildID).To(Equal(100))
+				Expect(inputs).To(Equal(originalInputs))
+
+				Expect(fakeFactory.CreateCallCount()).To(Equal(1))
+				_, _, _, planInputs := fakeFactory.CreateArgsForCall(0)
+				Expect(planInputs).To(Equal(originalInputs))
+			})
+
+			Context("when getting the inputs fails", func() {
+				BeforeEach(func() {
+					rerunBuild.GetResourcesReturns(nil, nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(tryStartErr).To(Equal(disaster))
+				})
+			})
+		})
 	})
 
 })
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 )
 
@@ -18,6 +19,12 @@ type BuildFactory interface {
 type buildFactory struct {
 	PipelineID  int
 	planFactory atc.PlanFactory
+
+	// variables only ever hold the values of `across` vars. Credentials are
+	// left as ((var)) placeholders, to be resolved when each step runs, so
+	// that they're never persisted with the build's plan.
+	variables    creds.Variables
+	acrossValues map[string]interface{}
 }
 
 func NewBuildFactory(pipelineID int, planFactory atc.PlanFactory) BuildFactory {
@@ -99,6 +106,17 @@ func (factory *buildFactory) constructPlanFromConfig(
 	resourceTypes atc.VersionedResourceTypes,
 	inputs []db.BuildInput,
 ) (atc.Plan, error) {
+	if len(planConfig.Across) > 0 {
+		return factory.across(
+			planConfig,
+			planConfig.Across,
+			map[string]interface{}{},
+			resources,
+			resourceTypes,
+			inputs,
+		)
+	}
+
 	var plan atc.Plan
 	var err error
 
@@ -120,6 +138,13 @@ func (factory *buildFactory) constructPlanFromConfig(
 		}
 
 		plan = factory.planFactory.NewPlan(retryStep)
+
+		if planConfig.RetryBackoff != nil || len(planConfig.RetryOn) > 0 {
+			plan.RetryPolicy = &atc.RetryPolicy{
+				Backoff: planConfig.RetryBackoff,
+				On:      planConfig.RetryOn,
+			}
+		}
 	}
 
 	return factory.applyHooks(constructionParams{
@@ -131,6 +156,83 @@ func (factory *buildFactory) constructPlanFromConfig(
 	})
 }
 
+// across constructs the step once for each combination of the vars' values,
+// with the values available as ((var)) placeholders. The combinations for
+// each var run in parallel, in batches of at most max_in_flight.
+func (factory *buildFactory) across(
+	planConfig atc.PlanConfig,
+	vars []atc.AcrossVarConfig,
+	values map[string]interface{},
+	resources atc.ResourceConfigs,
+	resourceTypes atc.VersionedResourceTypes,
+	inputs []db.BuildInput,
+) (atc.Plan, error) {
+	if len(vars) == 0 {
+		scopedFactory := *factory
+		scopedFactory.variables = factory.variables.WithValues(values)
+		scopedFactory.acrossValues = values
+
+		planConfig.Across = nil
+
+		plan, err := scopedFactory.constructPlanFromConfig(
+			planConfig,
+			resources,
+			resourceTypes,
+			inputs,
+		)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		plan.AcrossValues = values
+
+		return plan, nil
+	}
+
+	acrossVar := vars[0]
+
+	steps := []atc.Plan{}
+	for _, value := range acrossVar.Values {
+		scopedValues := map[string]interface{}{}
+		for name, val := range values {
+			scopedValues[name] = val
+		}
+
+		scopedValues[acrossVar.Var] = value
+
+		step, err := factory.across(
+			planConfig,
+			vars[1:],
+			scopedValues,
+			resources,
+			resourceTypes,
+			inputs,
+		)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		steps = append(steps, step)
+	}
+
+	if acrossVar.MaxInFlight == 0 || acrossVar.MaxInFlight >= len(steps) {
+		return factory.planFactory.NewPlan(atc.AggregatePlan(steps)), nil
+	}
+
+	batches := atc.DoPlan{}
+	for len(steps) > 0 {
+		size := acrossVar.MaxInFlight
+		if size > len(steps) {
+			size = len(steps)
+		}
+
+		batches = append(batches, factory.planFactory.NewPlan(atc.AggregatePlan(steps[:size])))
+		steps = steps[size:]
+	}
+
+	return factory.planFactory.NewPlan(batches), nil
+}
+
 func (factory *buildFactory) constructUnhookedPlan(
 	planConfig atc.PlanConfig,
 	resources atc.ResourceConfigs,
@@ -165,13 +267,28 @@ func (factory *buildFactory) constructUnhookedPlan(
 			return atc.Plan{}, ErrResourceNotFound
 		}
 
+		source, err := factory.variables.EvaluateSource(resource.Source)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		params, err := factory.variables.EvaluateParams(planConfig.Params)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		getParams, err := factory.variables.EvaluateParams(planConfig.GetParams)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
 		putPlan := atc.PutPlan{
 			Type:       resource.Type,
 			Name:       logicalName,
 			PipelineID: factory.PipelineID,
 			Resource:   resourceName,
-			Source:     resource.Source,
-			Params:     planConfig.Params,
+			Source:     source,
+			Params:     params,
 			Tags:       planConfig.Tags,
 
 			VersionedResourceTypes: resourceTypes,
@@ -182,9 +299,9 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Name:       logicalName,
 			PipelineID: factory.PipelineID,
 			Resource:   resourceName,
-			Params:     planConfig.GetParams,
+			Params:     getParams,
 			Tags:       planConfig.Tags,
-			Source:     resource.Source,
+			Source:     source,
 
 			VersionedResourceTypes: resourceTypes,
 		}
@@ -205,6 +322,16 @@ func (factory *buildFactory) constructUnhookedPlan(
 			return atc.Plan{}, ErrResourceNotFound
 		}
 
+		source, err := factory.variables.EvaluateSource(resource.Source)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		params, err := factory.variables.EvaluateParams(planConfig.Params)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
 		name := planConfig.Get
 		var version db.Version
 		for _, input := range inputs {
@@ -219,8 +346,8 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Name:       name,
 			PipelineID: factory.PipelineID,
 			Resource:   resourceName,
-			Source:     resource.Source,
-			Params:     planConfig.Params,
+			Source:     source,
+			Params:     params,
 			Version:    atc.Version(version),
 			Tags:       planConfig.Tags,
 
@@ -228,20 +355,44 @@ func (factory *buildFactory) constructUnhookedPlan(
 		})
 
 	case planConfig.Task != "":
+		taskConfig := planConfig.TaskConfig
+		if taskConfig != nil {
+			evaluated, err := factory.variables.EvaluateTaskConfig(*taskConfig)
+			if err != nil {
+				return atc.Plan{}, err
+			}
+
+			taskConfig = &evaluated
+		}
+
+		params, err := factory.variables.EvaluateParams(planConfig.Params)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
 		plan = factory.planFactory.NewPlan(atc.TaskPlan{
 			Name:              planConfig.Task,
 			PipelineID:        factory.PipelineID,
 			Privileged:        planConfig.Privileged,
-			Config:            planConfig.TaskConfig,
+			Config:            taskConfig,
 			ConfigPath:        planConfig.TaskConfigPath,
 			Tags:              planConfig.Tags,
-			Params:            planConfig.Params,
+			Params:            params,
 			InputMapping:      planConfig.InputMapping,
 			OutputMapping:     planConfig.OutputMapping,
 			ImageArtifactName: planConfig.ImageArtifactName,
 
 			VersionedResourceTypes: resourceTypes,
+
+			AcrossValues: factory.acrossValues,
 		})
+
+	case planConfig.SetPipeline != "":
+		plan = factory.planFactory.NewPlan(atc.SetPipelinePlan{
+			Name: planConfig.SetPipeline,
+			File: planConfig.TaskConfigPath,
+		})
+
 	case planConfig.Try != nil:
 		nextStep, err := factory.constructPlanFromConfig(
 			*planConfig.Try,
@@ -284,6 +435,15 @@ func (factory *buildFactory) constructUnhookedPlan(
 		})
 	}
 
+	// the breakpoint wraps the timeout so that time spent paused doesn't count
+	// towards it
+	if planConfig.Breakpoint != "" {
+		plan = factory.planFactory.NewPlan(atc.BreakpointPlan{
+			When: planConfig.Breakpoint,
+			Step: plan,
+		})
+	}
+
 	return plan, nil
 }
 
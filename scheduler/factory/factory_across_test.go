@@ -0,0 +1,164 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	"github.com/concourse/atc/testhelpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Across Step", func() {
+	var (
+		buildFactory        factory.BuildFactory
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+	})
+
+	Context("when a task runs across a var", func() {
+		It("builds an aggregate with the value substituted into each task's params, and passes the values on for its config file", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:           "unit",
+						TaskConfigPath: "some/config/path.yml",
+						Params:         atc.Params{"GO_VERSION": "((go))"},
+						Across: []atc.AcrossVarConfig{
+							{Var: "go", Values: []interface{}{"1.7", "1.8"}},
+						},
+					},
+				},
+			}, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			goSeven := expectedPlanFactory.NewPlan(atc.TaskPlan{
+				Name:       "unit",
+				PipelineID: 42,
+				ConfigPath: "some/config/path.yml",
+				Params:     atc.Params{"GO_VERSION": "1.7"},
+
+				AcrossValues: map[string]interface{}{"go": "1.7"},
+			})
+			goSeven.AcrossValues = map[string]interface{}{"go": "1.7"}
+
+			goEight := expectedPlanFactory.NewPlan(atc.TaskPlan{
+				Name:       "unit",
+				PipelineID: 42,
+				ConfigPath: "some/config/path.yml",
+				Params:     atc.Params{"GO_VERSION": "1.8"},
+
+				AcrossValues: map[string]interface{}{"go": "1.8"},
+			})
+			goEight.AcrossValues = map[string]interface{}{"go": "1.8"}
+
+			expected := expectedPlanFactory.NewPlan(atc.AggregatePlan{goSeven, goEight})
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+
+	Context("when a task runs across multiple vars with a max in flight", func() {
+		It("builds batches of every combination", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:           "unit",
+						TaskConfigPath: "some/config/path.yml",
+						Params:         atc.Params{"GO_VERSION": "((go))", "PLATFORM": "((platform))"},
+						Across: []atc.AcrossVarConfig{
+							{Var: "go", Values: []interface{}{"1.7", "1.8", "1.9"}, MaxInFlight: 2},
+							{Var: "platform", Values: []interface{}{"linux", "darwin"}},
+						},
+					},
+				},
+			}, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			combination := func(goVersion string, platform string) atc.Plan {
+				plan := expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:       "unit",
+					PipelineID: 42,
+					ConfigPath: "some/config/path.yml",
+					Params:     atc.Params{"GO_VERSION": goVersion, "PLATFORM": platform},
+
+					AcrossValues: map[string]interface{}{"go": goVersion, "platform": platform},
+				})
+				plan.AcrossValues = map[string]interface{}{"go": goVersion, "platform": platform}
+				return plan
+			}
+
+			goVersion := func(version string) atc.Plan {
+				linux := combination(version, "linux")
+				darwin := combination(version, "darwin")
+				return expectedPlanFactory.NewPlan(atc.AggregatePlan{linux, darwin})
+			}
+
+			goSeven := goVersion("1.7")
+			goEight := goVersion("1.8")
+			goNine := goVersion("1.9")
+
+			firstBatch := expectedPlanFactory.NewPlan(atc.AggregatePlan{goSeven, goEight})
+			secondBatch := expectedPlanFactory.NewPlan(atc.AggregatePlan{goNine})
+
+			expected := expectedPlanFactory.NewPlan(atc.DoPlan{firstBatch, secondBatch})
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+
+	Context("when the step has hooks", func() {
+		It("runs the hooks for each value", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:           "unit",
+						TaskConfigPath: "some/config/path.yml",
+						Across: []atc.AcrossVarConfig{
+							{Var: "go", Values: []interface{}{"1.8"}},
+						},
+						Failure: &atc.PlanConfig{
+							Task:           "alert",
+							TaskConfigPath: "some/alert.yml",
+							Params:         atc.Params{"GO_VERSION": "((go))"},
+						},
+					},
+				},
+			}, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			step := expectedPlanFactory.NewPlan(atc.TaskPlan{
+				Name:       "unit",
+				PipelineID: 42,
+				ConfigPath: "some/config/path.yml",
+
+				AcrossValues: map[string]interface{}{"go": "1.8"},
+			})
+
+			hook := expectedPlanFactory.NewPlan(atc.TaskPlan{
+				Name:       "alert",
+				PipelineID: 42,
+				ConfigPath: "some/alert.yml",
+				Params:     atc.Params{"GO_VERSION": "1.8"},
+
+				AcrossValues: map[string]interface{}{"go": "1.8"},
+			})
+
+			onFailure := expectedPlanFactory.NewPlan(atc.OnFailurePlan{
+				Step: step,
+				Next: hook,
+			})
+			onFailure.AcrossValues = map[string]interface{}{"go": "1.8"}
+
+			expected := expectedPlanFactory.NewPlan(atc.AggregatePlan{onFailure})
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+})
@@ -0,0 +1,127 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	"github.com/concourse/atc/testhelpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Credentials", func() {
+	var (
+		buildFactory factory.BuildFactory
+
+		resources           atc.ResourceConfigs
+		resourceTypes       atc.VersionedResourceTypes
+		input               atc.JobConfig
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+		resources = atc.ResourceConfigs{
+			{
+				Name:   "some-resource",
+				Type:   "git",
+				Source: atc.Source{"uri": "git://some-resource", "private_key": "((git-key))"},
+			},
+		}
+
+		resourceTypes = atc.VersionedResourceTypes{
+			{
+				ResourceType: atc.ResourceType{
+					Name:   "some-custom-resource",
+					Type:   "docker-image",
+					Source: atc.Source{"password": "((registry-password))"},
+				},
+				Version: atc.Version{"some": "version"},
+			},
+		}
+	})
+
+	Context("with a get and a task", func() {
+		BeforeEach(func() {
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Get: "some-resource",
+					},
+					{
+						Task: "some-task",
+						TaskConfig: &atc.TaskConfig{
+							Platform: "linux",
+							Params:   map[string]string{"TOKEN": "((api-token))"},
+							Run:      atc.TaskRunConfig{Path: "true"},
+						},
+						Params: atc.Params{"OTHER_TOKEN": "((api-token))"},
+					},
+				},
+			}
+		})
+
+		It("leaves credentials to be resolved when the steps run", func() {
+			actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.DoPlan{
+				expectedPlanFactory.NewPlan(atc.GetPlan{
+					Type:                   "git",
+					Name:                   "some-resource",
+					Resource:               "some-resource",
+					Source:                 atc.Source{"uri": "git://some-resource", "private_key": "((git-key))"},
+					PipelineID:             42,
+					VersionedResourceTypes: resourceTypes,
+				}),
+				expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:       "some-task",
+					PipelineID: 42,
+					Config: &atc.TaskConfig{
+						Platform: "linux",
+						Params:   map[string]string{"TOKEN": "((api-token))"},
+						Run:      atc.TaskRunConfig{Path: "true"},
+					},
+					Params:                 atc.Params{"OTHER_TOKEN": "((api-token))"},
+					VersionedResourceTypes: resourceTypes,
+				}),
+			})
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+
+	Context("with a step using across", func() {
+		BeforeEach(func() {
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:           "deploy",
+						TaskConfigPath: "some/config/path.yml",
+						Across: []atc.AcrossVarConfig{
+							{Var: "env", Values: []interface{}{"staging"}},
+						},
+						Params: atc.Params{
+							"env":   "((env))",
+							"token": "((api-token))",
+						},
+					},
+				},
+			}
+		})
+
+		It("only interpolates the across vars", func() {
+			actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			task := (*actual.Aggregate)[0].Task
+			Expect(task.Params).To(Equal(atc.Params{
+				"env":   "staging",
+				"token": "((api-token))",
+			}))
+			Expect(task.AcrossValues).To(Equal(map[string]interface{}{"env": "staging"}))
+		})
+	})
+})
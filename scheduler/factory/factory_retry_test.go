@@ -69,6 +69,48 @@ var _ = Describe("Factory Retry Step", func() {
 		})
 	})
 
+	Context("when there is a task annotated with 'attempts', 'retry_backoff', and 'retry_on'", func() {
+		It("builds the retry plan with a retry policy", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:     "second task",
+						Attempts: 2,
+						RetryBackoff: &atc.RetryBackoffConfig{
+							Strategy: atc.RetryBackoffExponential,
+							Delay:    "10s",
+						},
+						RetryOn: []atc.BuildStatus{atc.StatusErrored},
+					},
+				},
+			}, nil, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.RetryPlan{
+				expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:                   "second task",
+					PipelineID:             42,
+					VersionedResourceTypes: resourceTypes,
+				}),
+				expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:                   "second task",
+					PipelineID:             42,
+					VersionedResourceTypes: resourceTypes,
+				}),
+			})
+
+			expected.RetryPolicy = &atc.RetryPolicy{
+				Backoff: &atc.RetryBackoffConfig{
+					Strategy: atc.RetryBackoffExponential,
+					Delay:    "10s",
+				},
+				On: []atc.BuildStatus{atc.StatusErrored},
+			}
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+
 	Context("when there is a task annotated with 'attempts' and 'on_success'", func() {
 		It("builds correctly", func() {
 			actual, err := buildFactory.Create(atc.JobConfig{
@@ -0,0 +1,63 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	"github.com/concourse/atc/testhelpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory SetPipeline Step", func() {
+	var (
+		buildFactory        factory.BuildFactory
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+	})
+
+	Context("when there is a set_pipeline step", func() {
+		It("builds correctly", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Get: "some-resource",
+					},
+					{
+						SetPipeline:    "some-pipeline",
+						TaskConfigPath: "some-resource/pipeline.yml",
+					},
+				},
+			}, atc.ResourceConfigs{
+				{
+					Name:   "some-resource",
+					Type:   "git",
+					Source: atc.Source{"uri": "git://some-resource"},
+				},
+			}, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.DoPlan{
+				expectedPlanFactory.NewPlan(atc.GetPlan{
+					Type:       "git",
+					Name:       "some-resource",
+					Resource:   "some-resource",
+					Source:     atc.Source{"uri": "git://some-resource"},
+					PipelineID: 42,
+				}),
+				expectedPlanFactory.NewPlan(atc.SetPipelinePlan{
+					Name: "some-pipeline",
+					File: "some-resource/pipeline.yml",
+				}),
+			})
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+})
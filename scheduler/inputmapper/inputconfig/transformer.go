@@ -49,16 +49,27 @@ func (i *transformer) TransformInputConfigs(db *algorithm.VersionsDB, jobName st
 			pinnedVersionID = savedVersion.ID
 		}
 
+		resourceID := db.ResourceIDs[input.Resource]
+
+		// a version pinned through the API applies unless the config pins one
+		if pinnedVersionID == 0 {
+			pinnedVersionID = db.PinnedVersionIDs[resourceID]
+		}
+
 		jobs := algorithm.JobSet{}
-		for _, passedJobName := range input.Passed {
-			jobs[db.JobIDs[passedJobName]] = struct{}{}
+		for _, passed := range input.Passed {
+			if passed.IsOtherPipeline() {
+				jobs[db.OtherPipelineJobIDs[passed.Pipeline][passed.Job]] = struct{}{}
+			} else {
+				jobs[db.JobIDs[passed.Job]] = struct{}{}
+			}
 		}
 
 		inputConfigs = append(inputConfigs, algorithm.InputConfig{
 			Name:            input.Name,
 			UseEveryVersion: input.Version.Every,
 			PinnedVersionID: pinnedVersionID,
-			ResourceID:      db.ResourceIDs[input.Resource],
+			ResourceID:      resourceID,
 			Passed:          jobs,
 			JobID:           db.JobIDs[jobName],
 		})
@@ -28,17 +28,22 @@ var _ = Describe("Transformer", func() {
 	Describe("TransformInputConfigs", func() {
 		Context("when the job name exists in the versionsDB", func() {
 			var (
+				versionsDB      *algorithm.VersionsDB
 				jobInputs       []config.JobInput
 				algorithmInputs algorithm.InputConfigs
 				tranformErr     error
 			)
 
+			BeforeEach(func() {
+				versionsDB = &algorithm.VersionsDB{
+					JobIDs:      map[string]int{"j1": 1, "j2": 2},
+					ResourceIDs: map[string]int{"r1": 11, "r2": 12},
+				}
+			})
+
 			JustBeforeEach(func() {
 				algorithmInputs, tranformErr = transformer.TransformInputConfigs(
-					&algorithm.VersionsDB{
-						JobIDs:      map[string]int{"j1": 1, "j2": 2},
-						ResourceIDs: map[string]int{"r1": 11, "r2": 12},
-					},
+					versionsDB,
 					"j1",
 					jobInputs,
 				)
@@ -71,7 +76,7 @@ var _ = Describe("Transformer", func() {
 						Name:     "job-input-1",
 						Resource: "r1",
 						Version:  &atc.VersionConfig{Latest: true},
-						Passed:   []string{"j1", "j2"},
+						Passed:   atc.PassedConfigs{{Job: "j1"}, {Job: "j2"}},
 					}}
 				})
 
@@ -87,6 +92,32 @@ var _ = Describe("Transformer", func() {
 				})
 			})
 
+			Context("when an input has passed constraints on jobs of another pipeline", func() {
+				BeforeEach(func() {
+					versionsDB.OtherPipelineJobIDs = map[string]map[string]int{
+						"other-pipeline": {"j1": 21},
+					}
+
+					jobInputs = []config.JobInput{{
+						Name:     "job-input-1",
+						Resource: "r1",
+						Version:  &atc.VersionConfig{Latest: true},
+						Passed:   atc.PassedConfigs{{Job: "j2"}, {Pipeline: "other-pipeline", Job: "j1"}},
+					}}
+				})
+
+				It("uses the other pipeline's job IDs", func() {
+					Expect(algorithmInputs).To(ConsistOf(algorithm.InputConfig{
+						Name:            "job-input-1",
+						UseEveryVersion: false,
+						PinnedVersionID: 0,
+						ResourceID:      11,
+						Passed:          algorithm.JobSet{2: struct{}{}, 21: struct{}{}},
+						JobID:           1,
+					}))
+				})
+			})
+
 			Context("when an input has version: every", func() {
 				BeforeEach(func() {
 					jobInputs = []config.JobInput{{
@@ -108,6 +139,50 @@ var _ = Describe("Transformer", func() {
 				})
 			})
 
+			Context("when an input's resource is pinned through the API", func() {
+				BeforeEach(func() {
+					versionsDB.PinnedVersionIDs = map[int]int{11: 42}
+
+					jobInputs = []config.JobInput{
+						{
+							Name:     "job-input-1",
+							Resource: "r1",
+							Version:  &atc.VersionConfig{Latest: true},
+						},
+						{
+							Name:     "job-input-2",
+							Resource: "r2",
+							Version:  &atc.VersionConfig{Latest: true},
+						},
+					}
+				})
+
+				It("sets the pinned version ID", func() {
+					Expect(algorithmInputs).To(ConsistOf(
+						algorithm.InputConfig{
+							Name:            "job-input-1",
+							UseEveryVersion: false,
+							PinnedVersionID: 42,
+							ResourceID:      11,
+							Passed:          algorithm.JobSet{},
+							JobID:           1,
+						},
+						algorithm.InputConfig{
+							Name:            "job-input-2",
+							UseEveryVersion: false,
+							PinnedVersionID: 0,
+							ResourceID:      12,
+							Passed:          algorithm.JobSet{},
+							JobID:           1,
+						},
+					))
+				})
+
+				It("does not look up a version", func() {
+					Expect(fakeDB.GetVersionedResourceByVersionCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when an input has a pinned version", func() {
 				BeforeEach(func() {
 					jobInputs = []config.JobInput{
@@ -166,6 +241,23 @@ var _ = Describe("Transformer", func() {
 						fakeDB.GetVersionedResourceByVersionReturns(db.SavedVersionedResource{ID: 99}, true, nil)
 					})
 
+					Context("when the resource is also pinned through the API", func() {
+						BeforeEach(func() {
+							versionsDB.PinnedVersionIDs = map[int]int{11: 42}
+						})
+
+						It("uses the version pinned by the config", func() {
+							Expect(algorithmInputs).To(ContainElement(algorithm.InputConfig{
+								Name:            "job-input-1",
+								UseEveryVersion: false,
+								PinnedVersionID: 99,
+								ResourceID:      11,
+								Passed:          algorithm.JobSet{},
+								JobID:           1,
+							}))
+						})
+					})
+
 					It("sets the pinned version ID", func() {
 						Expect(algorithmInputs).To(ConsistOf(
 							algorithm.InputConfig{
@@ -199,7 +291,7 @@ var _ = Describe("Transformer", func() {
 						Name:     "job-input-1",
 						Resource: "nah",
 						Version:  &atc.VersionConfig{},
-						Passed:   []string{"nope", "gone"},
+						Passed:   atc.PassedConfigs{{Job: "nope"}, {Job: "gone"}},
 					}},
 				)
 				Expect(transformErr).NotTo(HaveOccurred())
@@ -202,8 +202,8 @@ var _ = Describe("Inputmapper", func() {
 				jobConfig = atc.JobConfig{
 					Name: "some-job",
 					Plan: atc.PlanSequence{
-						{Get: "a", Version: &atc.VersionConfig{Latest: true}, Passed: []string{"upstream"}},
-						{Get: "b", Version: &atc.VersionConfig{Latest: true}, Passed: []string{"upstream"}},
+						{Get: "a", Version: &atc.VersionConfig{Latest: true}, Passed: atc.PassedConfigs{{Job: "upstream"}}},
+						{Get: "b", Version: &atc.VersionConfig{Latest: true}, Passed: atc.PassedConfigs{{Job: "upstream"}}},
 					},
 				}
 
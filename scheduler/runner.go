@@ -31,6 +31,14 @@ type BuildScheduler interface {
 		resourceTypes atc.VersionedResourceTypes,
 	) (db.Build, Waiter, error)
 
+	RerunBuild(
+		logger lager.Logger,
+		originalBuild db.Build,
+		jobConfig atc.JobConfig,
+		resourceConfigs atc.ResourceConfigs,
+		resourceTypes atc.VersionedResourceTypes,
+	) (db.Build, Waiter, error)
+
 	SaveNextInputMapping(logger lager.Logger, job atc.JobConfig) error
 }
 
@@ -112,6 +120,15 @@ func (runner *Runner) tick(logger lager.Logger) error {
 		return err
 	}
 
+	for _, passedErr := range versions.OtherPipelinePassedErrors {
+		logger.Info("other-pipeline-passed-constraint-unresolved", lager.Data{
+			"resource": passedErr.Resource,
+			"pipeline": passedErr.Pipeline,
+			"job":      passedErr.Job,
+			"error":    passedErr.Message,
+		})
+	}
+
 	metric.SchedulingLoadVersionsDuration{
 		PipelineName: runner.DB.GetPipelineName(),
 		Duration:     time.Since(start),
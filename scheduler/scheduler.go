@@ -29,6 +29,7 @@ type SchedulerDB interface {
 	Reload() (bool, error)
 	Config() atc.Config
 	CreateJobBuild(job string) (db.Build, error)
+	CreateRerunBuild(build db.Build) (db.Build, error)
 	EnsurePendingBuildExists(jobName string) error
 	GetAllPendingBuilds() (map[string][]db.Build, error)
 	GetPendingBuildsForJob(jobName string) ([]db.Build, error)
@@ -128,6 +129,39 @@ func (s *Scheduler) TriggerImmediately(
 		logger.Error("failed-to-create-job-build", err)
 		return nil, nil, err
 	}
+
+	return build, s.startPendingBuilds(logger, jobConfig, resourceConfigs, resourceTypes), nil
+}
+
+// RerunBuild creates a build of the original build's job with the same
+// inputs, and starts it along with any other pending builds of the job.
+func (s *Scheduler) RerunBuild(
+	logger lager.Logger,
+	originalBuild db.Build,
+	jobConfig atc.JobConfig,
+	resourceConfigs atc.ResourceConfigs,
+	resourceTypes atc.VersionedResourceTypes,
+) (db.Build, Waiter, error) {
+	logger = logger.Session("rerun-build", lager.Data{
+		"job_name": jobConfig.Name,
+		"build_id": originalBuild.ID(),
+	})
+
+	build, err := s.DB.CreateRerunBuild(originalBuild)
+	if err != nil {
+		logger.Error("failed-to-create-rerun-build", err)
+		return nil, nil, err
+	}
+
+	return build, s.startPendingBuilds(logger, jobConfig, resourceConfigs, resourceTypes), nil
+}
+
+func (s *Scheduler) startPendingBuilds(
+	logger lager.Logger,
+	jobConfig atc.JobConfig,
+	resourceConfigs atc.ResourceConfigs,
+	resourceTypes atc.VersionedResourceTypes,
+) Waiter {
 	wg := new(sync.WaitGroup)
 	wg.Add(1)
 
@@ -147,7 +181,7 @@ func (s *Scheduler) TriggerImmediately(
 		}
 	}()
 
-	return build, wg, nil
+	return wg
 }
 
 func (s *Scheduler) SaveNextInputMapping(logger lager.Logger, job atc.JobConfig) error {
@@ -330,6 +330,82 @@ var _ = Describe("Scheduler", func() {
 		})
 	})
 
+	Describe("RerunBuild", func() {
+		var (
+			jobConfig     atc.JobConfig
+			originalBuild *dbfakes.FakeBuild
+			rerunBuild    db.Build
+			rerunErr      error
+		)
+
+		BeforeEach(func() {
+			originalBuild = new(dbfakes.FakeBuild)
+			originalBuild.IDReturns(42)
+		})
+
+		JustBeforeEach(func() {
+			jobConfig = atc.JobConfig{Name: "some-job", Plan: atc.PlanSequence{{Get: "input-1"}}}
+
+			var waiter Waiter
+			rerunBuild, waiter, rerunErr = scheduler.RerunBuild(
+				lagertest.NewTestLogger("test"),
+				originalBuild,
+				jobConfig,
+				atc.ResourceConfigs{{Name: "some-resource"}},
+				atc.VersionedResourceTypes{},
+			)
+			if waiter != nil {
+				waiter.Wait()
+			}
+		})
+
+		Context("when creating the rerun build fails", func() {
+			BeforeEach(func() {
+				fakeDB.CreateRerunBuildReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(rerunErr).To(Equal(disaster))
+			})
+
+			It("does not try to start pending builds", func() {
+				Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when creating the rerun build succeeds", func() {
+			var createdBuild *dbfakes.FakeBuild
+			var nextPendingBuilds []db.Build
+
+			BeforeEach(func() {
+				createdBuild = new(dbfakes.FakeBuild)
+				fakeDB.CreateRerunBuildReturns(createdBuild, nil)
+
+				nextPendingBuilds = []db.Build{createdBuild}
+				fakeDB.GetPendingBuildsForJobReturns(nextPendingBuilds, nil)
+			})
+
+			It("creates the rerun from the original build", func() {
+				Expect(fakeDB.CreateRerunBuildCallCount()).To(Equal(1))
+				Expect(fakeDB.CreateRerunBuildArgsForCall(0)).To(Equal(originalBuild))
+			})
+
+			It("returns the rerun build", func() {
+				Expect(rerunErr).NotTo(HaveOccurred())
+				Expect(rerunBuild).To(Equal(createdBuild))
+			})
+
+			It("tries to start the job's pending builds", func() {
+				Expect(fakeDB.GetPendingBuildsForJobArgsForCall(0)).To(Equal("some-job"))
+
+				Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
+				_, actualJobConfig, _, _, builds := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
+				Expect(actualJobConfig).To(Equal(jobConfig))
+				Expect(builds).To(Equal(nextPendingBuilds))
+			})
+		})
+	})
+
 	Describe("SaveNextInputMapping", func() {
 		var saveErr error
 
@@ -48,6 +48,25 @@ type FakeBuildScheduler struct {
 		result2 scheduler.Waiter
 		result3 error
 	}
+	RerunBuildStub        func(logger lager.Logger, originalBuild db.Build, jobConfig atc.JobConfig, resourceConfigs atc.ResourceConfigs, resourceTypes atc.VersionedResourceTypes) (db.Build, scheduler.Waiter, error)
+	rerunBuildMutex       sync.RWMutex
+	rerunBuildArgsForCall []struct {
+		logger          lager.Logger
+		originalBuild   db.Build
+		jobConfig       atc.JobConfig
+		resourceConfigs atc.ResourceConfigs
+		resourceTypes   atc.VersionedResourceTypes
+	}
+	rerunBuildReturns struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}
+	rerunBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}
 	SaveNextInputMappingStub        func(logger lager.Logger, job atc.JobConfig) error
 	saveNextInputMappingMutex       sync.RWMutex
 	saveNextInputMappingArgsForCall []struct {
@@ -176,6 +195,64 @@ func (fake *FakeBuildScheduler) TriggerImmediatelyReturnsOnCall(i int, result1 d
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuildScheduler) RerunBuild(logger lager.Logger, originalBuild db.Build, jobConfig atc.JobConfig, resourceConfigs atc.ResourceConfigs, resourceTypes atc.VersionedResourceTypes) (db.Build, scheduler.Waiter, error) {
+	fake.rerunBuildMutex.Lock()
+	ret, specificReturn := fake.rerunBuildReturnsOnCall[len(fake.rerunBuildArgsForCall)]
+	fake.rerunBuildArgsForCall = append(fake.rerunBuildArgsForCall, struct {
+		logger          lager.Logger
+		originalBuild   db.Build
+		jobConfig       atc.JobConfig
+		resourceConfigs atc.ResourceConfigs
+		resourceTypes   atc.VersionedResourceTypes
+	}{logger, originalBuild, jobConfig, resourceConfigs, resourceTypes})
+	fake.recordInvocation("RerunBuild", []interface{}{logger, originalBuild, jobConfig, resourceConfigs, resourceTypes})
+	fake.rerunBuildMutex.Unlock()
+	if fake.RerunBuildStub != nil {
+		return fake.RerunBuildStub(logger, originalBuild, jobConfig, resourceConfigs, resourceTypes)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.rerunBuildReturns.result1, fake.rerunBuildReturns.result2, fake.rerunBuildReturns.result3
+}
+
+func (fake *FakeBuildScheduler) RerunBuildCallCount() int {
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
+	return len(fake.rerunBuildArgsForCall)
+}
+
+func (fake *FakeBuildScheduler) RerunBuildArgsForCall(i int) (lager.Logger, db.Build, atc.JobConfig, atc.ResourceConfigs, atc.VersionedResourceTypes) {
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
+	return fake.rerunBuildArgsForCall[i].logger, fake.rerunBuildArgsForCall[i].originalBuild, fake.rerunBuildArgsForCall[i].jobConfig, fake.rerunBuildArgsForCall[i].resourceConfigs, fake.rerunBuildArgsForCall[i].resourceTypes
+}
+
+func (fake *FakeBuildScheduler) RerunBuildReturns(result1 db.Build, result2 scheduler.Waiter, result3 error) {
+	fake.RerunBuildStub = nil
+	fake.rerunBuildReturns = struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuildScheduler) RerunBuildReturnsOnCall(i int, result1 db.Build, result2 scheduler.Waiter, result3 error) {
+	fake.RerunBuildStub = nil
+	if fake.rerunBuildReturnsOnCall == nil {
+		fake.rerunBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 scheduler.Waiter
+			result3 error
+		})
+	}
+	fake.rerunBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuildScheduler) SaveNextInputMapping(logger lager.Logger, job atc.JobConfig) error {
 	fake.saveNextInputMappingMutex.Lock()
 	ret, specificReturn := fake.saveNextInputMappingReturnsOnCall[len(fake.saveNextInputMappingArgsForCall)]
@@ -232,6 +309,8 @@ func (fake *FakeBuildScheduler) Invocations() map[string][][]interface{} {
 	defer fake.scheduleMutex.RUnlock()
 	fake.triggerImmediatelyMutex.RLock()
 	defer fake.triggerImmediatelyMutex.RUnlock()
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
 	return fake.invocations
@@ -51,19 +51,6 @@ type FakeBuildStarterDB struct {
 		result2 bool
 		result3 error
 	}
-	UpdateBuildToScheduledStub        func(int) (bool, error)
-	updateBuildToScheduledMutex       sync.RWMutex
-	updateBuildToScheduledArgsForCall []struct {
-		arg1 int
-	}
-	updateBuildToScheduledReturns struct {
-		result1 bool
-		result2 error
-	}
-	updateBuildToScheduledReturnsOnCall map[int]struct {
-		result1 bool
-		result2 error
-	}
 	UseInputsForBuildStub        func(buildID int, inputs []db.BuildInput) error
 	useInputsForBuildMutex       sync.RWMutex
 	useInputsForBuildArgsForCall []struct {
@@ -87,6 +74,18 @@ type FakeBuildStarterDB struct {
 		result1 *algorithm.VersionsDB
 		result2 error
 	}
+	SetOutsideTriggerWindowStub        func(jobName string, outside bool) error
+	setOutsideTriggerWindowMutex       sync.RWMutex
+	setOutsideTriggerWindowArgsForCall []struct {
+		jobName string
+		outside bool
+	}
+	setOutsideTriggerWindowReturns struct {
+		result1 error
+	}
+	setOutsideTriggerWindowReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -242,57 +241,6 @@ func (fake *FakeBuildStarterDB) GetJobReturnsOnCall(i int, result1 db.SavedJob,
 	}{result1, result2, result3}
 }
 
-func (fake *FakeBuildStarterDB) UpdateBuildToScheduled(arg1 int) (bool, error) {
-	fake.updateBuildToScheduledMutex.Lock()
-	ret, specificReturn := fake.updateBuildToScheduledReturnsOnCall[len(fake.updateBuildToScheduledArgsForCall)]
-	fake.updateBuildToScheduledArgsForCall = append(fake.updateBuildToScheduledArgsForCall, struct {
-		arg1 int
-	}{arg1})
-	fake.recordInvocation("UpdateBuildToScheduled", []interface{}{arg1})
-	fake.updateBuildToScheduledMutex.Unlock()
-	if fake.UpdateBuildToScheduledStub != nil {
-		return fake.UpdateBuildToScheduledStub(arg1)
-	}
-	if specificReturn {
-		return ret.result1, ret.result2
-	}
-	return fake.updateBuildToScheduledReturns.result1, fake.updateBuildToScheduledReturns.result2
-}
-
-func (fake *FakeBuildStarterDB) UpdateBuildToScheduledCallCount() int {
-	fake.updateBuildToScheduledMutex.RLock()
-	defer fake.updateBuildToScheduledMutex.RUnlock()
-	return len(fake.updateBuildToScheduledArgsForCall)
-}
-
-func (fake *FakeBuildStarterDB) UpdateBuildToScheduledArgsForCall(i int) int {
-	fake.updateBuildToScheduledMutex.RLock()
-	defer fake.updateBuildToScheduledMutex.RUnlock()
-	return fake.updateBuildToScheduledArgsForCall[i].arg1
-}
-
-func (fake *FakeBuildStarterDB) UpdateBuildToScheduledReturns(result1 bool, result2 error) {
-	fake.UpdateBuildToScheduledStub = nil
-	fake.updateBuildToScheduledReturns = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakeBuildStarterDB) UpdateBuildToScheduledReturnsOnCall(i int, result1 bool, result2 error) {
-	fake.UpdateBuildToScheduledStub = nil
-	if fake.updateBuildToScheduledReturnsOnCall == nil {
-		fake.updateBuildToScheduledReturnsOnCall = make(map[int]struct {
-			result1 bool
-			result2 error
-		})
-	}
-	fake.updateBuildToScheduledReturnsOnCall[i] = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
-}
-
 func (fake *FakeBuildStarterDB) UseInputsForBuild(buildID int, inputs []db.BuildInput) error {
 	var inputsCopy []db.BuildInput
 	if inputs != nil {
@@ -390,6 +338,55 @@ func (fake *FakeBuildStarterDB) LoadVersionsDBReturnsOnCall(i int, result1 *algo
 	}{result1, result2}
 }
 
+func (fake *FakeBuildStarterDB) SetOutsideTriggerWindow(jobName string, outside bool) error {
+	fake.setOutsideTriggerWindowMutex.Lock()
+	ret, specificReturn := fake.setOutsideTriggerWindowReturnsOnCall[len(fake.setOutsideTriggerWindowArgsForCall)]
+	fake.setOutsideTriggerWindowArgsForCall = append(fake.setOutsideTriggerWindowArgsForCall, struct {
+		jobName string
+		outside bool
+	}{jobName, outside})
+	fake.recordInvocation("SetOutsideTriggerWindow", []interface{}{jobName, outside})
+	fake.setOutsideTriggerWindowMutex.Unlock()
+	if fake.SetOutsideTriggerWindowStub != nil {
+		return fake.SetOutsideTriggerWindowStub(jobName, outside)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.setOutsideTriggerWindowReturns.result1
+}
+
+func (fake *FakeBuildStarterDB) SetOutsideTriggerWindowCallCount() int {
+	fake.setOutsideTriggerWindowMutex.RLock()
+	defer fake.setOutsideTriggerWindowMutex.RUnlock()
+	return len(fake.setOutsideTriggerWindowArgsForCall)
+}
+
+func (fake *FakeBuildStarterDB) SetOutsideTriggerWindowArgsForCall(i int) (string, bool) {
+	fake.setOutsideTriggerWindowMutex.RLock()
+	defer fake.setOutsideTriggerWindowMutex.RUnlock()
+	return fake.setOutsideTriggerWindowArgsForCall[i].jobName, fake.setOutsideTriggerWindowArgsForCall[i].outside
+}
+
+func (fake *FakeBuildStarterDB) SetOutsideTriggerWindowReturns(result1 error) {
+	fake.SetOutsideTriggerWindowStub = nil
+	fake.setOutsideTriggerWindowReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildStarterDB) SetOutsideTriggerWindowReturnsOnCall(i int, result1 error) {
+	fake.SetOutsideTriggerWindowStub = nil
+	if fake.setOutsideTriggerWindowReturnsOnCall == nil {
+		fake.setOutsideTriggerWindowReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setOutsideTriggerWindowReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuildStarterDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -399,12 +396,12 @@ func (fake *FakeBuildStarterDB) Invocations() map[string][][]interface{} {
 	defer fake.isPausedMutex.RUnlock()
 	fake.getJobMutex.RLock()
 	defer fake.getJobMutex.RUnlock()
-	fake.updateBuildToScheduledMutex.RLock()
-	defer fake.updateBuildToScheduledMutex.RUnlock()
 	fake.useInputsForBuildMutex.RLock()
 	defer fake.useInputsForBuildMutex.RUnlock()
 	fake.loadVersionsDBMutex.RLock()
 	defer fake.loadVersionsDBMutex.RUnlock()
+	fake.setOutsideTriggerWindowMutex.RLock()
+	defer fake.setOutsideTriggerWindowMutex.RUnlock()
 	return fake.invocations
 }
 
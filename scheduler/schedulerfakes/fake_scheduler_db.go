@@ -83,6 +83,19 @@ type FakeSchedulerDB struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateRerunBuildStub        func(build db.Build) (db.Build, error)
+	createRerunBuildMutex       sync.RWMutex
+	createRerunBuildArgsForCall []struct {
+		build db.Build
+	}
+	createRerunBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createRerunBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	EnsurePendingBuildExistsStub        func(jobName string) error
 	ensurePendingBuildExistsMutex       sync.RWMutex
 	ensurePendingBuildExistsArgsForCall []struct {
@@ -394,6 +407,57 @@ func (fake *FakeSchedulerDB) CreateJobBuildReturnsOnCall(i int, result1 db.Build
 	}{result1, result2}
 }
 
+func (fake *FakeSchedulerDB) CreateRerunBuild(build db.Build) (db.Build, error) {
+	fake.createRerunBuildMutex.Lock()
+	ret, specificReturn := fake.createRerunBuildReturnsOnCall[len(fake.createRerunBuildArgsForCall)]
+	fake.createRerunBuildArgsForCall = append(fake.createRerunBuildArgsForCall, struct {
+		build db.Build
+	}{build})
+	fake.recordInvocation("CreateRerunBuild", []interface{}{build})
+	fake.createRerunBuildMutex.Unlock()
+	if fake.CreateRerunBuildStub != nil {
+		return fake.CreateRerunBuildStub(build)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createRerunBuildReturns.result1, fake.createRerunBuildReturns.result2
+}
+
+func (fake *FakeSchedulerDB) CreateRerunBuildCallCount() int {
+	fake.createRerunBuildMutex.RLock()
+	defer fake.createRerunBuildMutex.RUnlock()
+	return len(fake.createRerunBuildArgsForCall)
+}
+
+func (fake *FakeSchedulerDB) CreateRerunBuildArgsForCall(i int) db.Build {
+	fake.createRerunBuildMutex.RLock()
+	defer fake.createRerunBuildMutex.RUnlock()
+	return fake.createRerunBuildArgsForCall[i].build
+}
+
+func (fake *FakeSchedulerDB) CreateRerunBuildReturns(result1 db.Build, result2 error) {
+	fake.CreateRerunBuildStub = nil
+	fake.createRerunBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSchedulerDB) CreateRerunBuildReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.CreateRerunBuildStub = nil
+	if fake.createRerunBuildReturnsOnCall == nil {
+		fake.createRerunBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createRerunBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSchedulerDB) EnsurePendingBuildExists(jobName string) error {
 	fake.ensurePendingBuildExistsMutex.Lock()
 	ret, specificReturn := fake.ensurePendingBuildExistsReturnsOnCall[len(fake.ensurePendingBuildExistsArgsForCall)]
@@ -551,6 +615,8 @@ func (fake *FakeSchedulerDB) Invocations() map[string][][]interface{} {
 	defer fake.configMutex.RUnlock()
 	fake.createJobBuildMutex.RLock()
 	defer fake.createJobBuildMutex.RUnlock()
+	fake.createRerunBuildMutex.RLock()
+	defer fake.createRerunBuildMutex.RUnlock()
 	fake.ensurePendingBuildExistsMutex.RLock()
 	defer fake.ensurePendingBuildExistsMutex.RUnlock()
 	fake.getAllPendingBuildsMutex.RLock()
@@ -31,6 +31,35 @@ type TaskConfig struct {
 
 	// The set of (logical, name-only) outputs provided by the task.
 	Outputs []TaskOutputConfig `json:"outputs,omitempty" yaml:"outputs,omitempty" mapstructure:"outputs"`
+
+	// Directories persisted between builds of the same job on a worker, given
+	// relative to the task's working directory.
+	Caches []CacheConfig `json:"caches,omitempty" yaml:"caches,omitempty" mapstructure:"caches"`
+
+	// Resource limits for the task's container. Unset limits fall back to the
+	// cluster-wide defaults.
+	Limits *ContainerLimits `json:"container_limits,omitempty" yaml:"container_limits,omitempty" mapstructure:"container_limits"`
+}
+
+type ContainerLimits struct {
+	// Relative weight of the container's CPU usage, in shares.
+	CPU uint64 `json:"cpu,omitempty" yaml:"cpu,omitempty" mapstructure:"cpu"`
+
+	// Maximum memory usage of the container, in bytes.
+	Memory uint64 `json:"memory,omitempty" yaml:"memory,omitempty" mapstructure:"memory"`
+}
+
+// WithDefaults fills in any unset limits from the given defaults.
+func (limits ContainerLimits) WithDefaults(defaults ContainerLimits) ContainerLimits {
+	if limits.CPU == 0 {
+		limits.CPU = defaults.CPU
+	}
+
+	if limits.Memory == 0 {
+		limits.Memory = defaults.Memory
+	}
+
+	return limits
 }
 
 type ImageResource struct {
@@ -110,6 +139,15 @@ func (config TaskConfig) Merge(other TaskConfig) TaskConfig {
 		config.Run = other.Run
 	}
 
+	if other.Limits != nil {
+		limits := *other.Limits
+		if config.Limits != nil {
+			limits = limits.WithDefaults(*config.Limits)
+		}
+
+		config.Limits = &limits
+	}
+
 	return config
 }
 
@@ -125,6 +163,7 @@ func (config TaskConfig) Validate() error {
 	}
 
 	messages = append(messages, config.validateInputsAndOutputs()...)
+	messages = append(messages, config.validateCacheContainsPaths()...)
 
 	if len(messages) > 0 {
 		return fmt.Errorf("invalid task configuration:\n%s", strings.Join(messages, "\n"))
@@ -327,6 +366,33 @@ func (config TaskConfig) validateInputContainsNames() []string {
 	return messages
 }
 
+func (config TaskConfig) validateCacheContainsPaths() []string {
+	messages := []string{}
+
+	for i, cache := range config.Caches {
+		if cache.Path == "" {
+			messages = append(messages, fmt.Sprintf("  cache in position %d is missing a path", i))
+			continue
+		}
+
+		if filepath.IsAbs(cache.Path) || strings.HasPrefix(cache.Path, "~") || escapesWorkingDir(cache.Path) {
+			messages = append(messages, fmt.Sprintf("  cache in position %d has an invalid path ('%s'); it must be relative to the working directory and stay within it", i, cache.Path))
+		}
+	}
+
+	return messages
+}
+
+func escapesWorkingDir(path string) bool {
+	for _, segment := range strings.Split(filepath.ToSlash(path), "/") {
+		if segment == ".." {
+			return true
+		}
+	}
+
+	return false
+}
+
 type TaskRunConfig struct {
 	Path string   `json:"path" yaml:"path"`
 	Args []string `json:"args,omitempty" yaml:"args"`
@@ -360,6 +426,10 @@ func (output TaskOutputConfig) resolvePath() string {
 	return output.Name
 }
 
+type CacheConfig struct {
+	Path string `json:"path,omitempty" yaml:"path"`
+}
+
 type MetadataField struct {
 	Name  string `json:"name"`
 	Value string `json:"value"`
@@ -43,6 +43,22 @@ run: {path: a/file}
 					Expect(task.Run.Path).To(Equal("a/file"))
 				})
 
+				It("decodes container limits", func() {
+					data := []byte(`
+platform: beos
+
+container_limits: {cpu: 512, memory: 1073741824}
+
+run: {path: a/file}
+`)
+					task, err := LoadTaskConfig(data)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(task.Limits).To(Equal(&ContainerLimits{
+						CPU:    512,
+						Memory: 1073741824,
+					}))
+				})
+
 				It("converts yaml booleans to strings in params", func() {
 					data := []byte(`
 platform: beos
@@ -234,6 +250,66 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when the task has caches", func() {
+			BeforeEach(func() {
+				validConfig.Caches = append(validConfig.Caches, CacheConfig{Path: "node_modules"})
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when cache.path is missing", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = append(invalidConfig.Caches, CacheConfig{Path: "node_modules"}, CacheConfig{Path: ""})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  cache in position 1 is missing a path")))
+				})
+			})
+
+			Context("when cache.path is nested within the working directory", func() {
+				BeforeEach(func() {
+					validConfig.Caches = append(validConfig.Caches, CacheConfig{Path: "./vendor/cache"})
+				})
+
+				It("is valid", func() {
+					Expect(validConfig.Validate()).ToNot(HaveOccurred())
+				})
+			})
+
+			Context("when cache.path is absolute", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = append(invalidConfig.Caches, CacheConfig{Path: "/root/.m2"})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  cache in position 0 has an invalid path ('/root/.m2'); it must be relative to the working directory and stay within it")))
+				})
+			})
+
+			Context("when cache.path is in the home directory", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = append(invalidConfig.Caches, CacheConfig{Path: "~/.m2"})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  cache in position 0 has an invalid path ('~/.m2')")))
+				})
+			})
+
+			Context("when cache.path leaves the working directory", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = append(invalidConfig.Caches, CacheConfig{Path: "node_modules/../../.."})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  cache in position 0 has an invalid path ('node_modules/../../..')")))
+				})
+			})
+		})
+
 		Context("when run is missing", func() {
 			BeforeEach(func() {
 				invalidConfig.Run.Path = ""
@@ -723,5 +799,23 @@ run: {path: a/file}
 				}))
 
 		})
+
+		It("overrides only the container limits which are set", func() {
+			Expect(TaskConfig{
+				Limits: &ContainerLimits{CPU: 512, Memory: 1024},
+			}.Merge(TaskConfig{
+				Limits: &ContainerLimits{Memory: 2048},
+			})).To(Equal(TaskConfig{
+				Limits: &ContainerLimits{CPU: 512, Memory: 2048},
+			}))
+		})
+
+		It("keeps the container limits when the other config has none", func() {
+			Expect(TaskConfig{
+				Limits: &ContainerLimits{CPU: 512},
+			}.Merge(TaskConfig{})).To(Equal(TaskConfig{
+				Limits: &ContainerLimits{CPU: 512},
+			}))
+		})
 	})
 })
@@ -12,6 +12,12 @@ type Team struct {
 	GitHubAuth   *GitHubAuth   `json:"github_auth,omitempty"`
 	UAAAuth      *UAAAuth      `json:"uaa_auth,omitempty"`
 	GenericOAuth *GenericOAuth `json:"genericoauth_auth,omitempty"`
+	LDAPAuth     *LDAPAuth     `json:"ldap_auth,omitempty"`
+
+	// Roles maps the name of an auth provider (e.g. "basic" or "ldap") to
+	// the role granted to users who log in with it. Users logging in with a
+	// provider which is not mapped are owners.
+	Roles map[string]TeamRole `json:"roles,omitempty"`
 }
 
 type BasicAuth struct {
@@ -54,3 +60,31 @@ type GenericOAuth struct {
 	AuthURLParams map[string]string `json:"auth_url_params,omitempty"`
 	Scope         string            `json:"scope,omitempty"`
 }
+
+type LDAPAuth struct {
+	// Address of the server, as host:port.
+	Host string `json:"host,omitempty"`
+
+	// Optional credentials to bind with when searching for users and groups.
+	BindDN       string `json:"bind_dn,omitempty"`
+	BindPassword string `json:"bind_password,omitempty"`
+
+	// Users are found by substituting the username for %s in the filter, e.g.
+	// "(uid=%s)".
+	UserSearchBaseDN string `json:"user_search_base_dn,omitempty"`
+	UserSearchFilter string `json:"user_search_filter,omitempty"`
+
+	// If Groups is not empty, users must belong to one of them. Groups are
+	// found by substituting the user's DN for %s in the filter, e.g.
+	// "(member=%s)", and matched by their GroupNameAttribute.
+	GroupSearchBaseDN  string   `json:"group_search_base_dn,omitempty"`
+	GroupSearchFilter  string   `json:"group_search_filter,omitempty"`
+	GroupNameAttribute string   `json:"group_name_attribute,omitempty"`
+	Groups             []string `json:"groups,omitempty"`
+
+	// Connect with TLS (ldaps), or upgrade a plain connection with StartTLS.
+	UseTLS             bool   `json:"use_tls,omitempty"`
+	StartTLS           bool   `json:"start_tls,omitempty"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty"`
+	CACert             string `json:"ca_cert,omitempty"`
+}
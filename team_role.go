@@ -0,0 +1,52 @@
+package atc
+
+// TeamRole determines what a user authenticated for a team may do within it.
+type TeamRole string
+
+const (
+	// TeamRoleOwner may do anything within the team, including changing its
+	// auth configuration and managing its workers.
+	TeamRoleOwner TeamRole = "owner"
+
+	// TeamRoleMember may do anything with the team's pipelines and builds,
+	// including configuring pipelines and running one-off builds.
+	TeamRoleMember TeamRole = "member"
+
+	// TeamRolePipelineOperator may operate the team's existing pipelines,
+	// e.g. triggering and aborting builds and pausing jobs, but not change
+	// their configuration.
+	TeamRolePipelineOperator TeamRole = "pipeline-operator"
+
+	// TeamRoleViewer may only view the team's pipelines and builds.
+	TeamRoleViewer TeamRole = "viewer"
+)
+
+// TeamRoles lists the roles from most to least privileged.
+var TeamRoles = []TeamRole{
+	TeamRoleOwner,
+	TeamRoleMember,
+	TeamRolePipelineOperator,
+	TeamRoleViewer,
+}
+
+// IsValid returns whether the role is one of TeamRoles.
+func (role TeamRole) IsValid() bool {
+	return role.rank() != -1
+}
+
+// Permits returns whether the role grants at least the privileges of the
+// given role.
+func (role TeamRole) Permits(required TeamRole) bool {
+	rank := role.rank()
+	return rank != -1 && rank <= required.rank()
+}
+
+func (role TeamRole) rank() int {
+	for i, r := range TeamRoles {
+		if r == role {
+			return i
+		}
+	}
+
+	return -1
+}
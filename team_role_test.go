@@ -0,0 +1,42 @@
+package atc_test
+
+import (
+	. "github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TeamRole", func() {
+	Describe("Permits", func() {
+		It("permits roles which are no more privileged", func() {
+			Expect(TeamRoleOwner.Permits(TeamRoleOwner)).To(BeTrue())
+			Expect(TeamRoleOwner.Permits(TeamRoleViewer)).To(BeTrue())
+			Expect(TeamRoleMember.Permits(TeamRolePipelineOperator)).To(BeTrue())
+			Expect(TeamRolePipelineOperator.Permits(TeamRoleViewer)).To(BeTrue())
+		})
+
+		It("does not permit more privileged roles", func() {
+			Expect(TeamRoleMember.Permits(TeamRoleOwner)).To(BeFalse())
+			Expect(TeamRolePipelineOperator.Permits(TeamRoleMember)).To(BeFalse())
+			Expect(TeamRoleViewer.Permits(TeamRolePipelineOperator)).To(BeFalse())
+		})
+
+		It("does not permit anything for unknown roles", func() {
+			Expect(TeamRole("bogus").Permits(TeamRoleViewer)).To(BeFalse())
+			Expect(TeamRoleOwner.Permits(TeamRole("bogus"))).To(BeFalse())
+		})
+	})
+
+	Describe("IsValid", func() {
+		It("is true for each of the roles", func() {
+			for _, role := range TeamRoles {
+				Expect(role.IsValid()).To(BeTrue())
+			}
+		})
+
+		It("is false for anything else", func() {
+			Expect(TeamRole("admin").IsValid()).To(BeFalse())
+		})
+	})
+})
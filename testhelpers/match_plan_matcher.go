@@ -133,5 +133,10 @@ func stripIDs(plan atc.Plan) (atc.Plan, []string) {
 		ids = append(ids, subIDs...)
 	}
 
+	if plan.Breakpoint != nil {
+		plan.Breakpoint.Step, subIDs = stripIDs(plan.Breakpoint.Step)
+		ids = append(ids, subIDs...)
+	}
+
 	return plan, ids
 }
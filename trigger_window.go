@@ -0,0 +1,128 @@
+package atc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const triggerWindowTimeFormat = "15:04"
+
+// TriggerWindowConfig restricts when a job's automatically triggered builds
+// may start. Builds remain pending outside of the window. Start and Stop are
+// times of day (e.g. "09:00") in Location, which defaults to UTC. If Stop is
+// before Start the window spans midnight, and belongs to the day on which it
+// starts. If Days is empty the window applies to every day of the week.
+type TriggerWindowConfig struct {
+	Start    string   `yaml:"start" json:"start" mapstructure:"start"`
+	Stop     string   `yaml:"stop" json:"stop" mapstructure:"stop"`
+	Location string   `yaml:"location,omitempty" json:"location,omitempty" mapstructure:"location"`
+	Days     []string `yaml:"days,omitempty" json:"days,omitempty" mapstructure:"days"`
+}
+
+// Contains returns whether the window is open at the given time.
+func (window TriggerWindowConfig) Contains(t time.Time) (bool, error) {
+	start, stop, location, err := window.parse()
+	if err != nil {
+		return false, err
+	}
+
+	local := t.In(location)
+	now := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute
+	day := local.Weekday()
+
+	var open bool
+	if start <= stop {
+		open = now >= start && now < stop
+	} else if now >= start {
+		open = true
+	} else if now < stop {
+		// still within the window which opened the day before
+		open = true
+		day = (day + 6) % 7
+	}
+
+	if !open {
+		return false, nil
+	}
+
+	if len(window.Days) == 0 {
+		return true, nil
+	}
+
+	for _, d := range window.Days {
+		if strings.EqualFold(d, day.String()) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Validate returns an error for each field which cannot be understood. The
+// errors are phrased to follow the window's identifier in a config error.
+func (window TriggerWindowConfig) Validate() []error {
+	errs := []error{}
+
+	if _, err := parseTimeOfDay(window.Start); err != nil {
+		errs = append(errs, fmt.Errorf("has an invalid start '%s' (expected a time of day such as 09:00)", window.Start))
+	}
+
+	if _, err := parseTimeOfDay(window.Stop); err != nil {
+		errs = append(errs, fmt.Errorf("has an invalid stop '%s' (expected a time of day such as 17:00)", window.Stop))
+	}
+
+	if window.Start != "" && window.Start == window.Stop {
+		errs = append(errs, fmt.Errorf("has the same start and stop ('%s')", window.Start))
+	}
+
+	if _, err := time.LoadLocation(window.Location); err != nil {
+		errs = append(errs, fmt.Errorf("has an invalid location '%s'", window.Location))
+	}
+
+	for _, d := range window.Days {
+		if !isWeekday(d) {
+			errs = append(errs, fmt.Errorf("has an invalid day '%s'", d))
+		}
+	}
+
+	return errs
+}
+
+func (window TriggerWindowConfig) parse() (time.Duration, time.Duration, *time.Location, error) {
+	start, err := parseTimeOfDay(window.Start)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	stop, err := parseTimeOfDay(window.Stop)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	location, err := time.LoadLocation(window.Location)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	return start, stop, location, nil
+}
+
+func parseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse(triggerWindowTimeFormat, value)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func isWeekday(name string) bool {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(name, d.String()) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,89 @@
+package atc_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TriggerWindowConfig", func() {
+	var window atc.TriggerWindowConfig
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		panic(err)
+	}
+
+	contains := func(t time.Time) bool {
+		open, err := window.Contains(t)
+		Expect(err).NotTo(HaveOccurred())
+		return open
+	}
+
+	Context("with a window during the day", func() {
+		BeforeEach(func() {
+			window = atc.TriggerWindowConfig{
+				Start:    "09:00",
+				Stop:     "17:00",
+				Location: "Europe/Berlin",
+				Days:     []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+			}
+		})
+
+		It("is open between start and stop in the window's location", func() {
+			Expect(contains(time.Date(2017, 6, 5, 9, 0, 0, 0, berlin))).To(BeTrue())
+			Expect(contains(time.Date(2017, 6, 5, 16, 59, 0, 0, berlin))).To(BeTrue())
+			Expect(contains(time.Date(2017, 6, 5, 7, 30, 0, 0, time.UTC))).To(BeTrue())
+		})
+
+		It("is closed outside of start and stop", func() {
+			Expect(contains(time.Date(2017, 6, 5, 8, 59, 0, 0, berlin))).To(BeFalse())
+			Expect(contains(time.Date(2017, 6, 5, 17, 0, 0, 0, berlin))).To(BeFalse())
+			Expect(contains(time.Date(2017, 6, 5, 16, 0, 0, 0, time.UTC))).To(BeFalse())
+		})
+
+		It("is closed on other days", func() {
+			Expect(contains(time.Date(2017, 6, 4, 12, 0, 0, 0, berlin))).To(BeFalse())
+		})
+	})
+
+	Context("with a window spanning midnight", func() {
+		BeforeEach(func() {
+			window = atc.TriggerWindowConfig{
+				Start: "22:00",
+				Stop:  "02:00",
+				Days:  []string{"friday"},
+			}
+		})
+
+		It("is open until stop on the following day", func() {
+			Expect(contains(time.Date(2017, 6, 9, 23, 0, 0, 0, time.UTC))).To(BeTrue())
+			Expect(contains(time.Date(2017, 6, 10, 1, 0, 0, 0, time.UTC))).To(BeTrue())
+		})
+
+		It("is closed on the window's day before it opens", func() {
+			Expect(contains(time.Date(2017, 6, 9, 1, 0, 0, 0, time.UTC))).To(BeFalse())
+		})
+
+		It("is closed on the following day after it opens again", func() {
+			Expect(contains(time.Date(2017, 6, 10, 23, 0, 0, 0, time.UTC))).To(BeFalse())
+		})
+	})
+
+	Context("when the window is invalid", func() {
+		BeforeEach(func() {
+			window = atc.TriggerWindowConfig{
+				Start: "nine",
+				Stop:  "17:00",
+			}
+		})
+
+		It("returns an error", func() {
+			_, err := window.Contains(time.Now())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
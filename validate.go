@@ -32,6 +32,17 @@ func newDeprecationWarning(message string) Warning {
 }
 
 func (c Config) Validate() ([]Warning, []string) {
+	return c.validate("")
+}
+
+// ValidateForPipeline validates the config as the config of the named
+// pipeline, which additionally rejects passed constraints that refer to the
+// pipeline's own jobs as though they were in another pipeline.
+func (c Config) ValidateForPipeline(pipelineName string) ([]Warning, []string) {
+	return c.validate(pipelineName)
+}
+
+func (c Config) validate(pipelineName string) ([]Warning, []string) {
 	warnings := []Warning{}
 	errorMessages := []string{}
 
@@ -50,7 +61,7 @@ func (c Config) Validate() ([]Warning, []string) {
 		errorMessages = append(errorMessages, formatErr("resource types", resourceTypesErr))
 	}
 
-	jobWarnings, jobsErr := validateJobs(c)
+	jobWarnings, jobsErr := validateJobs(c, pipelineName)
 	if jobsErr != nil {
 		errorMessages = append(errorMessages, formatErr("jobs", jobsErr))
 	}
@@ -182,7 +193,7 @@ func usedResources(c Config) map[string]bool {
 	return usedResources
 }
 
-func validateJobs(c Config) ([]Warning, error) {
+func validateJobs(c Config, pipelineName string) ([]Warning, error) {
 	errorMessages := []string{}
 	warnings := []Warning{}
 
@@ -216,7 +227,17 @@ func validateJobs(c Config) ([]Warning, error) {
 			)
 		}
 
-		planWarnings, planErrMessages := validatePlan(c, identifier+".plan", PlanConfig{Do: &job.Plan})
+		if job.BuildLogRetention != nil {
+			errorMessages = append(errorMessages, validateBuildLogRetention(identifier, job)...)
+		}
+
+		if job.TriggerWindow != nil {
+			for _, err := range job.TriggerWindow.Validate() {
+				errorMessages = append(errorMessages, identifier+".trigger_window "+err.Error())
+			}
+		}
+
+		planWarnings, planErrMessages := validatePlan(c, pipelineName, identifier+".plan", PlanConfig{Do: &job.Plan})
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
 
@@ -265,7 +286,7 @@ func (ft foundTypes) IsValid() (bool, string) {
 	return true, ""
 }
 
-func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []string) {
+func validatePlan(c Config, pipelineName string, identifier string, plan PlanConfig) ([]Warning, []string) {
 	foundTypes := foundTypes{
 		identifier: identifier,
 		found:      make(map[string]bool),
@@ -283,6 +304,10 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		foundTypes.Find("task")
 	}
 
+	if plan.SetPipeline != "" {
+		foundTypes.Find("set_pipeline")
+	}
+
 	if plan.Do != nil {
 		foundTypes.Find("do")
 	}
@@ -306,7 +331,7 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 	case plan.Do != nil:
 		for i, plan := range *plan.Do {
 			subIdentifier := fmt.Sprintf("%s[%d]", identifier, i)
-			planWarnings, planErrMessages := validatePlan(c, subIdentifier, plan)
+			planWarnings, planErrMessages := validatePlan(c, pipelineName, subIdentifier, plan)
 			warnings = append(warnings, planWarnings...)
 			errorMessages = append(errorMessages, planErrMessages...)
 		}
@@ -314,9 +339,13 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 	case plan.Aggregate != nil:
 		for i, plan := range *plan.Aggregate {
 			subIdentifier := fmt.Sprintf("%s.aggregate[%d]", identifier, i)
-			planWarnings, planErrMessages := validatePlan(c, subIdentifier, plan)
+			planWarnings, planErrMessages := validatePlan(c, pipelineName, subIdentifier, plan)
 			warnings = append(warnings, planWarnings...)
 			errorMessages = append(errorMessages, planErrMessages...)
+
+			if hasBreakpoint(plan) {
+				errorMessages = append(errorMessages, subIdentifier+" has a breakpoint, which cannot be used within aggregate")
+			}
 		}
 
 	case plan.Get != "":
@@ -352,7 +381,60 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 			}
 		}
 
-		for _, job := range plan.Passed {
+		for _, passed := range plan.Passed {
+			if passed.Job == "" {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf(
+						"%s.passed has a constraint with no job",
+						identifier,
+					),
+				)
+
+				continue
+			}
+
+			if passed.IsOtherPipeline() {
+				if strings.Contains(passed.Pipeline, "/") || strings.Contains(passed.Job, "/") {
+					errorMessages = append(
+						errorMessages,
+						fmt.Sprintf(
+							"%s.passed has an invalid constraint ('%s'); pipeline and job names cannot contain '/'",
+							identifier,
+							passed,
+						),
+					)
+				} else if passed.Pipeline == pipelineName {
+					errorMessages = append(
+						errorMessages,
+						fmt.Sprintf(
+							"%s.passed refers to its own pipeline ('%s'); refer to the job by name instead",
+							identifier,
+							passed,
+						),
+					)
+				}
+
+				// the jobs of other pipelines can only be found when resolving inputs,
+				// as they may be configured later
+				continue
+			}
+
+			if strings.Contains(passed.Job, "/") {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf(
+						"%s.passed references an unknown job ('%s'); jobs of other pipelines must be given as {pipeline: PIPELINE, job: JOB}",
+						identifier,
+						passed.Job,
+					),
+				)
+
+				continue
+			}
+
+			job := passed.Job
+
 			jobConfig, found := c.Jobs.Lookup(job)
 			if !found {
 				errorMessages = append(
@@ -450,30 +532,42 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 			plan, identifier)...,
 		)
 
+	case plan.SetPipeline != "":
+		identifier = fmt.Sprintf("%s.set_pipeline.%s", identifier, plan.SetPipeline)
+
+		if plan.TaskConfigPath == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify a config file")
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config"},
+			plan, identifier)...,
+		)
+
 	case plan.Try != nil:
 		subIdentifier := fmt.Sprintf("%s.try", identifier)
-		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Try)
+		planWarnings, planErrMessages := validatePlan(c, pipelineName, subIdentifier, *plan.Try)
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
 	}
 
 	if plan.Ensure != nil {
 		subIdentifier := fmt.Sprintf("%s.ensure", identifier)
-		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Ensure)
+		planWarnings, planErrMessages := validatePlan(c, pipelineName, subIdentifier, *plan.Ensure)
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
 	}
 
 	if plan.Success != nil {
 		subIdentifier := fmt.Sprintf("%s.success", identifier)
-		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Success)
+		planWarnings, planErrMessages := validatePlan(c, pipelineName, subIdentifier, *plan.Success)
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
 	}
 
 	if plan.Failure != nil {
 		subIdentifier := fmt.Sprintf("%s.failure", identifier)
-		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Failure)
+		planWarnings, planErrMessages := validatePlan(c, pipelineName, subIdentifier, *plan.Failure)
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
 	}
@@ -491,9 +585,159 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid number of attempts (%d)", plan.Attempts))
 	}
 
+	errorMessages = append(errorMessages, validateRetry(identifier, plan)...)
+
+	switch plan.Breakpoint {
+	case "", BreakpointBefore, BreakpointOnFailure:
+	default:
+		subIdentifier := fmt.Sprintf("%s.breakpoint", identifier)
+		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid value ('%s'); must be before or on_failure", plan.Breakpoint))
+	}
+
+	errorMessages = append(errorMessages, validateAcross(identifier, plan.Across)...)
+
+	if len(plan.Across) > 0 && (plan.Get != "" || plan.Put != "") {
+		errorMessages = append(errorMessages, identifier+" runs across vars, which cannot be used with get or put as every combination would save the same artifact")
+	}
+
+	if len(plan.Across) > 0 && hasBreakpoint(plan) {
+		errorMessages = append(errorMessages, identifier+" has a breakpoint, which cannot be used with across")
+	}
+
 	return warnings, errorMessages
 }
 
+// hasBreakpoint returns whether the plan or any of its nested plans has a
+// breakpoint. A build can only be paused at one breakpoint at a time, so
+// breakpoints may not be run in parallel.
+func hasBreakpoint(plan PlanConfig) bool {
+	if plan.Breakpoint != "" {
+		return true
+	}
+
+	for _, nested := range []*PlanConfig{plan.Try, plan.Ensure, plan.Success, plan.Failure} {
+		if nested != nil && hasBreakpoint(*nested) {
+			return true
+		}
+	}
+
+	for _, sequence := range []*PlanSequence{plan.Do, plan.Aggregate} {
+		if sequence == nil {
+			continue
+		}
+
+		for _, nested := range *sequence {
+			if hasBreakpoint(nested) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func validateBuildLogRetention(identifier string, job JobConfig) []string {
+	errorMessages := []string{}
+
+	retention := job.BuildLogRetention
+	subIdentifier := identifier + ".build_log_retention"
+
+	if job.BuildLogsToRetain != 0 {
+		errorMessages = append(errorMessages, identifier+" specifies both build_logs_to_retain and build_log_retention")
+	}
+
+	if retention.Builds < 0 {
+		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has negative builds: %d", retention.Builds))
+	}
+
+	if retention.Days < 0 {
+		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has negative days: %d", retention.Days))
+	}
+
+	if retention.MinimumSucceededBuilds < 0 {
+		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has negative minimum_succeeded_builds: %d", retention.MinimumSucceededBuilds))
+	}
+
+	if retention.Builds > 0 && retention.MinimumSucceededBuilds > retention.Builds {
+		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has minimum_succeeded_builds (%d) greater than builds (%d)", retention.MinimumSucceededBuilds, retention.Builds))
+	}
+
+	return errorMessages
+}
+
+func validateRetry(identifier string, plan PlanConfig) []string {
+	errorMessages := []string{}
+
+	if plan.RetryBackoff != nil {
+		subIdentifier := fmt.Sprintf("%s.retry_backoff", identifier)
+
+		if plan.Attempts == 0 {
+			errorMessages = append(errorMessages, subIdentifier+" is specified without attempts")
+		}
+
+		switch plan.RetryBackoff.Strategy {
+		case "", RetryBackoffFixed, RetryBackoffExponential:
+		default:
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an unknown strategy ('%s')", plan.RetryBackoff.Strategy))
+		}
+
+		if plan.RetryBackoff.Delay == "" {
+			errorMessages = append(errorMessages, subIdentifier+" has no delay specified")
+		} else if _, err := time.ParseDuration(plan.RetryBackoff.Delay); err != nil {
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has a delay that could not be parsed ('%s')", plan.RetryBackoff.Delay))
+		}
+
+		if plan.RetryBackoff.MaxDelay != "" {
+			if _, err := time.ParseDuration(plan.RetryBackoff.MaxDelay); err != nil {
+				errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has a max_delay that could not be parsed ('%s')", plan.RetryBackoff.MaxDelay))
+			}
+		}
+	}
+
+	if len(plan.RetryOn) > 0 {
+		subIdentifier := fmt.Sprintf("%s.retry_on", identifier)
+
+		if plan.Attempts == 0 {
+			errorMessages = append(errorMessages, subIdentifier+" is specified without attempts")
+		}
+
+		for _, status := range plan.RetryOn {
+			if status != StatusErrored && status != StatusFailed {
+				errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid status ('%s'); must be errored or failed", status))
+			}
+		}
+	}
+
+	return errorMessages
+}
+
+func validateAcross(identifier string, across []AcrossVarConfig) []string {
+	errorMessages := []string{}
+
+	seen := map[string]bool{}
+	for i, acrossVar := range across {
+		subIdentifier := fmt.Sprintf("%s.across[%d]", identifier, i)
+
+		if acrossVar.Var == "" {
+			errorMessages = append(errorMessages, subIdentifier+" has no var specified")
+		} else if seen[acrossVar.Var] {
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" repeats the var '%s'", acrossVar.Var))
+		}
+
+		seen[acrossVar.Var] = true
+
+		if len(acrossVar.Values) == 0 {
+			errorMessages = append(errorMessages, subIdentifier+" has no values specified")
+		}
+
+		if acrossVar.MaxInFlight < 0 {
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid max_in_flight (%d)", acrossVar.MaxInFlight))
+		}
+	}
+
+	return errorMessages
+}
+
 func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, identifier string) []string {
 	errorMessages := []string{}
 	foundInapplicableFields := []string{}
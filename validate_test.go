@@ -425,6 +425,46 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has an invalid build_log_retention", func() {
+			BeforeEach(func() {
+				job.BuildLogsToRetain = 5
+				job.BuildLogRetention = &BuildLogRetention{
+					Builds:                 2,
+					Days:                   -1,
+					MinimumSucceededBuilds: 3,
+				}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job specifies both build_logs_to_retain and build_log_retention"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.build_log_retention has negative days: -1"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.build_log_retention has minimum_succeeded_builds (3) greater than builds (2)"))
+			})
+		})
+
+		Context("when a job has an invalid trigger_window", func() {
+			BeforeEach(func() {
+				job.TriggerWindow = &TriggerWindowConfig{
+					Start:    "9am",
+					Stop:     "17:00",
+					Location: "Mars/Olympus_Mons",
+					Days:     []string{"Monday", "Funday"},
+				}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.trigger_window has an invalid start '9am'"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.trigger_window has an invalid location 'Mars/Olympus_Mons'"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.trigger_window has an invalid day 'Funday'"))
+			})
+		})
+
 		Context("when a job has duplicate inputs", func() {
 			BeforeEach(func() {
 				job.Plan = append(job.Plan, PlanConfig{
@@ -587,7 +627,7 @@ var _ = Describe("ValidateConfig", func() {
 					job.Plan = append(job.Plan, PlanConfig{
 						Task:     "lol",
 						Resource: "some-resource",
-						Passed:   []string{"hi"},
+						Passed:   PassedConfigs{{Job: "hi"}},
 						Trigger:  true,
 					})
 
@@ -617,6 +657,22 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a set_pipeline plan has no file set", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						SetPipeline: "lol",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.lol does not specify a config file"))
+				})
+			})
+
 			Context("when a task plan has config path and config specified", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -644,7 +700,7 @@ var _ = Describe("ValidateConfig", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
 						Put:            "lol",
-						Passed:         []string{"get", "only"},
+						Passed:         PassedConfigs{{Job: "get"}, {Job: "only"}},
 						Trigger:        true,
 						Privileged:     true,
 						TaskConfigPath: "btaskyml",
@@ -776,7 +832,7 @@ var _ = Describe("ValidateConfig", func() {
 
 					job2.Plan = append(job2.Plan, PlanConfig{
 						Get:    "some-resource",
-						Passed: []string{"job-one"},
+						Passed: PassedConfigs{{Job: "job-one"}},
 					})
 					config.Jobs = append(config.Jobs, job1, job2)
 				})
@@ -809,7 +865,7 @@ var _ = Describe("ValidateConfig", func() {
 
 					job2.Plan = append(job2.Plan, PlanConfig{
 						Get:    "some-resource",
-						Passed: []string{"job-one"},
+						Passed: PassedConfigs{{Job: "job-one"}},
 					})
 					config.Jobs = append(config.Jobs, job1, job2)
 				})
@@ -841,7 +897,7 @@ var _ = Describe("ValidateConfig", func() {
 
 					job2.Plan = append(job2.Plan, PlanConfig{
 						Get:    "some-resource",
-						Passed: []string{"job-one"},
+						Passed: PassedConfigs{{Job: "job-one"}},
 					})
 					config.Jobs = append(config.Jobs, job1, job2)
 
@@ -874,7 +930,7 @@ var _ = Describe("ValidateConfig", func() {
 
 					job2.Plan = append(job2.Plan, PlanConfig{
 						Get:    "some-resource",
-						Passed: []string{"job-one"},
+						Passed: PassedConfigs{{Job: "job-one"}},
 					})
 					config.Jobs = append(config.Jobs, job1, job2)
 
@@ -992,6 +1048,200 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a retry plan has a valid backoff and retry conditions", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:      "some-resource",
+						Attempts: 3,
+						RetryBackoff: &RetryBackoffConfig{
+							Strategy: "exponential",
+							Delay:    "10s",
+							MaxDelay: "1m",
+						},
+						RetryOn: []BuildStatus{StatusErrored},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a retry plan has an invalid backoff", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:      "some-resource",
+						Attempts: 3,
+						RetryBackoff: &RetryBackoffConfig{
+							Strategy: "linear",
+							Delay:    "nope",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.retry_backoff has an unknown strategy ('linear')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.retry_backoff has a delay that could not be parsed ('nope')"))
+				})
+			})
+
+			Context("when a retry plan retries on an invalid status", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:      "some-resource",
+						Attempts: 3,
+						RetryOn:  []BuildStatus{StatusSucceeded},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.retry_on has an invalid status ('succeeded'); must be errored or failed"))
+				})
+			})
+
+			Context("when a plan has a backoff without attempts", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:          "some-resource",
+						RetryBackoff: &RetryBackoffConfig{Delay: "10s"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.retry_backoff is specified without attempts"))
+				})
+			})
+
+			Context("when a plan has an invalid breakpoint", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:        "some-resource",
+						Breakpoint: "after",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.breakpoint has an invalid value ('after'); must be before or on_failure"))
+				})
+			})
+
+			Context("when an aggregate step has a breakpoint", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Aggregate: &PlanSequence{
+							{Put: "some-resource"},
+							{
+								Do: &PlanSequence{
+									{Put: "some-resource", Breakpoint: BreakpointOnFailure},
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].aggregate[1] has a breakpoint, which cannot be used within aggregate"))
+				})
+			})
+
+			Context("when a step run across vars has a breakpoint", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:           "some-task",
+						TaskConfigPath: "some/config/path.yml",
+						Breakpoint:     BreakpointBefore,
+						Across: []AcrossVarConfig{
+							{Var: "go", Values: []interface{}{"1.7", "1.8"}},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task has a breakpoint, which cannot be used with across"))
+				})
+			})
+
+			Context("when a get runs across vars", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get: "some-resource",
+						Across: []AcrossVarConfig{
+							{Var: "branch", Values: []interface{}{"master", "develop"}},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource runs across vars, which cannot be used with get or put as every combination would save the same artifact"))
+				})
+			})
+
+			Context("when a put runs across vars", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put: "some-resource",
+						Across: []AcrossVarConfig{
+							{Var: "env", Values: []interface{}{"staging", "production"}},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource runs across vars, which cannot be used with get or put as every combination would save the same artifact"))
+				})
+			})
+
+			Context("when a plan has invalid across vars", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:           "some-task",
+						TaskConfigPath: "some/config/path.yml",
+						Across: []AcrossVarConfig{
+							{Var: "go", Values: []interface{}{"1.7", "1.8"}},
+							{Var: "go", Values: []interface{}{"1.9"}},
+							{Var: "", Values: []interface{}{"linux"}},
+							{Var: "platform", MaxInFlight: -1},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.across[1] repeats the var 'go'"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.across[2] has no var specified"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.across[3] has no values specified"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.across[3] has an invalid max_in_flight (-1)"))
+				})
+			})
+
 			Context("when a put plan has a custom name but refers to a resource that does not exist", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -1012,7 +1262,7 @@ var _ = Describe("ValidateConfig", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
 						Get:    "lol",
-						Passed: []string{"bogus-job"},
+						Passed: PassedConfigs{{Job: "bogus-job"}},
 					})
 
 					config.Jobs = append(config.Jobs, job)
@@ -1024,6 +1274,84 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a job's input's passed constraints reference a job of another pipeline", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:    "some-resource",
+						Passed: PassedConfigs{{Pipeline: "some-other-pipeline", Job: "some-other-pipeline-job"}},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+
+				Context("when validated as the config of that pipeline", func() {
+					It("returns an error", func() {
+						_, errorMessages := config.ValidateForPipeline("some-other-pipeline")
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed refers to its own pipeline ('some-other-pipeline/some-other-pipeline-job'); refer to the job by name instead"))
+					})
+				})
+
+				Context("when validated as the config of another pipeline", func() {
+					It("does not return an error", func() {
+						_, errorMessages := config.ValidateForPipeline("some-pipeline")
+						Expect(errorMessages).To(HaveLen(0))
+					})
+				})
+			})
+
+			Context("when a job's input's passed constraints reference a job of another pipeline with a '/' in its name", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:    "some-resource",
+						Passed: PassedConfigs{{Pipeline: "some/other-pipeline", Job: "some-other-pipeline-job"}},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed has an invalid constraint ('some/other-pipeline/some-other-pipeline-job'); pipeline and job names cannot contain '/'"))
+				})
+			})
+
+			Context("when a job's input's passed constraints reference a job of another pipeline by its name", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:    "some-resource",
+						Passed: PassedConfigs{{Job: "some-other-pipeline/some-other-pipeline-job"}},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references an unknown job ('some-other-pipeline/some-other-pipeline-job'); jobs of other pipelines must be given as {pipeline: PIPELINE, job: JOB}"))
+				})
+			})
+
+			Context("when a job's input's passed constraints have no job", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:    "some-resource",
+						Passed: PassedConfigs{{Pipeline: "some-other-pipeline"}},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed has a constraint with no job"))
+				})
+			})
+
 			Context("when a job's input's passed constraints references a valid job that has the resource as an output", func() {
 				BeforeEach(func() {
 					config.Jobs[0].Plan = append(config.Jobs[0].Plan, PlanConfig{
@@ -1033,7 +1361,7 @@ var _ = Describe("ValidateConfig", func() {
 
 					job.Plan = append(job.Plan, PlanConfig{
 						Get:    "some-resource",
-						Passed: []string{"some-job"},
+						Passed: PassedConfigs{{Job: "some-job"}},
 					})
 
 					config.Jobs = append(config.Jobs, job)
@@ -1048,7 +1376,7 @@ var _ = Describe("ValidateConfig", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
 						Get:    "some-resource",
-						Passed: []string{"some-job"},
+						Passed: PassedConfigs{{Job: "some-job"}},
 					})
 
 					config.Jobs = append(config.Jobs, job)
@@ -1064,7 +1392,7 @@ var _ = Describe("ValidateConfig", func() {
 					job.Plan = append(job.Plan, PlanConfig{
 						Get:      "custom-name",
 						Resource: "some-resource",
-						Passed:   []string{"some-job"},
+						Passed:   PassedConfigs{{Job: "some-job"}},
 					})
 
 					config.Jobs = append(config.Jobs, job)
@@ -1079,7 +1407,7 @@ var _ = Describe("ValidateConfig", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
 						Get:    "some-resource",
-						Passed: []string{"some-empty-job"},
+						Passed: PassedConfigs{{Job: "some-empty-job"}},
 					})
 
 					config.Jobs = append(config.Jobs, job)
@@ -92,6 +92,10 @@ authMethodFromTuple tuple =
         ( "basic", _, _ ) ->
             Ok AuthMethodBasic
 
+        -- ldap logs in with a username and password, just like basic auth
+        ( "ldap", _, _ ) ->
+            Ok AuthMethodBasic
+
         ( "oauth", Just displayName, Just authUrl ) ->
             Ok (AuthMethodOAuth { displayName = displayName, authUrl = authUrl })
 
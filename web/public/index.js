@@ -369,10 +369,11 @@ function createGraph(svg, jobs, resources) {
 
       if (input.passed && input.passed.length > 0) {
         for (var p in input.passed) {
-          var sourceJobNode = jobNode(input.passed[p]);
+          var passedJob = passedJobName(input.passed[p]);
+          var sourceJobNode = jobNode(passedJob);
 
-          var sourceOutputNode = outputNode(input.passed[p], input.resource);
-          var sourceInputNode = inputNode(input.passed[p], input.resource);
+          var sourceOutputNode = outputNode(passedJob, input.resource);
+          var sourceInputNode = inputNode(passedJob, input.resource);
 
           var sourceNode;
           if (graph.node(sourceOutputNode)) {
@@ -493,6 +494,14 @@ function jobNode(name) {
   return "job-"+name;
 }
 
+function passedJobName(passed) {
+  if (typeof passed === "string") {
+    return passed;
+  }
+
+  return passed.pipeline+"/"+passed.job;
+}
+
 function gatewayNode(jobNames) {
   return "gateway-"+jobNames.sort().join("-");
 }
@@ -72,6 +72,7 @@ type Client interface {
 	LookupVolume(lager.Logger, string) (Volume, bool, error)
 
 	Satisfying(WorkerSpec, atc.VersionedResourceTypes) (Worker, error)
+	SatisfyingWithInputs(WorkerSpec, atc.VersionedResourceTypes, []ArtifactSource) (Worker, error)
 	AllSatisfying(WorkerSpec, atc.VersionedResourceTypes) ([]Worker, error)
 	RunningWorkers() ([]Worker, error)
 	GetWorker(workerName string) (Worker, error)
@@ -113,6 +114,12 @@ func (OutputStrategy) baggageclaimStrategy() baggageclaim.Strategy {
 	return baggageclaim.EmptyStrategy{}
 }
 
+type TaskCacheStrategy struct{}
+
+func (TaskCacheStrategy) baggageclaimStrategy() baggageclaim.Strategy {
+	return baggageclaim.EmptyStrategy{}
+}
+
 type ImageArtifactReplicationStrategy struct {
 	Name string
 }
@@ -578,6 +578,24 @@ func (p *containerProvider) createGardenContainer(
 		})
 	}
 
+	for _, cachePath := range spec.Caches {
+		cacheVolume, volumeErr := p.findOrCreateCacheVolume(
+			logger,
+			creatingContainer,
+			metadata,
+			spec,
+			cachePath,
+		)
+		if volumeErr != nil {
+			return nil, volumeErr
+		}
+
+		volumeMounts = append(volumeMounts, VolumeMount{
+			Volume:    cacheVolume,
+			MountPath: cachePath,
+		})
+	}
+
 	for _, mount := range spec.Mounts {
 		volumeMounts = append(volumeMounts, mount)
 	}
@@ -647,11 +665,51 @@ func (p *containerProvider) createGardenContainer(
 		RootFSPath: imageURL,
 		Env:        env,
 		Handle:     creatingContainer.Handle(),
+		Limits: garden.Limits{
+			CPU:    garden.CPULimits{LimitInShares: spec.Limits.CPU},
+			Memory: garden.MemoryLimits{LimitInBytes: spec.Limits.Memory},
+		},
 	}
 
 	return p.gardenClient.Create(gardenSpec)
 }
 
+func (p *containerProvider) findOrCreateCacheVolume(
+	logger lager.Logger,
+	creatingContainer dbng.CreatingContainer,
+	metadata Metadata,
+	spec ContainerSpec,
+	cachePath string,
+) (Volume, error) {
+	volumeSpec := VolumeSpec{
+		Strategy:   TaskCacheStrategy{},
+		Privileged: spec.ImageSpec.Privileged,
+	}
+
+	if metadata.JobName == "" {
+		// one-off builds have no job to persist the cache for
+		return p.volumeClient.FindOrCreateVolumeForContainer(
+			logger,
+			volumeSpec,
+			creatingContainer,
+			spec.TeamID,
+			cachePath,
+		)
+	}
+
+	return p.volumeClient.FindOrCreateVolumeForTaskCache(
+		logger,
+		volumeSpec,
+		spec.TeamID,
+		dbng.TaskCache{
+			PipelineID: metadata.PipelineID,
+			JobName:    metadata.JobName,
+			StepName:   metadata.StepName,
+			Path:       cachePath,
+		},
+	)
+}
+
 func (p *containerProvider) maxContainerLifetime(metadata Metadata) time.Duration {
 	if metadata.Type == db.ContainerTypeCheck {
 		uptime := p.worker.Uptime()
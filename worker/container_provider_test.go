@@ -259,7 +259,17 @@ var _ = Describe("ContainerProvider", func() {
 	}
 
 	Describe("FindOrCreateBuildContainer", func() {
+		var (
+			metadata Metadata
+			caches   []string
+			limits   atc.ContainerLimits
+		)
+
 		BeforeEach(func() {
+			metadata = Metadata{}
+			caches = nil
+			limits = atc.ContainerLimits{}
+
 			fakeDBTeam.CreateBuildContainerReturns(fakeCreatingContainer, nil)
 			fakeGardenWorkerDB.AcquireContainerCreatingLockReturns(new(lockfakes.FakeLock), true, nil)
 		})
@@ -269,10 +279,12 @@ var _ = Describe("ContainerProvider", func() {
 				logger, nil,
 				fakeImageFetchingDelegate,
 				Identifier{},
-				Metadata{},
+				metadata,
 				ContainerSpec{
 					ImageSpec: ImageSpec{},
 					Inputs:    inputs,
+					Caches:    caches,
+					Limits:    limits,
 				},
 				atc.VersionedResourceTypes{},
 				outputPaths,
@@ -303,6 +315,92 @@ var _ = Describe("ContainerProvider", func() {
 			ItHandlesNonExistentContainer(func() int {
 				return fakeDBTeam.CreateBuildContainerCallCount()
 			})
+
+			Context("when the spec has caches", func() {
+				var fakeCacheVolume *wfakes.FakeVolume
+
+				BeforeEach(func() {
+					caches = []string{"/tmp/build/some-dir/some-cache"}
+
+					fakeCacheVolume = new(wfakes.FakeVolume)
+					fakeCacheVolume.HandleReturns("some-cache-handle")
+					fakeCacheVolume.PathReturns("/some/cache/volume/path")
+				})
+
+				Context("when the build belongs to a job", func() {
+					BeforeEach(func() {
+						metadata = Metadata{
+							PipelineID: 42,
+							JobName:    "some-job",
+							StepName:   "some-task",
+						}
+
+						fakeVolumeClient.FindOrCreateVolumeForTaskCacheReturns(fakeCacheVolume, nil)
+					})
+
+					It("finds or creates a task cache volume keyed by the job and step", func() {
+						Expect(fakeVolumeClient.FindOrCreateVolumeForTaskCacheCallCount()).To(Equal(1))
+						_, volumeSpec, _, taskCache := fakeVolumeClient.FindOrCreateVolumeForTaskCacheArgsForCall(0)
+						Expect(volumeSpec.Strategy).To(Equal(TaskCacheStrategy{}))
+						Expect(taskCache).To(Equal(dbng.TaskCache{
+							PipelineID: 42,
+							JobName:    "some-job",
+							StepName:   "some-task",
+							Path:       "/tmp/build/some-dir/some-cache",
+						}))
+					})
+
+					It("mounts the cache volume into the container", func() {
+						Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+						gardenSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(gardenSpec.BindMounts).To(ContainElement(garden.BindMount{
+							SrcPath: "/some/cache/volume/path",
+							DstPath: "/tmp/build/some-dir/some-cache",
+							Mode:    garden.BindMountModeRW,
+						}))
+					})
+
+					Context("when finding or creating the cache volume fails", func() {
+						BeforeEach(func() {
+							fakeVolumeClient.FindOrCreateVolumeForTaskCacheReturns(nil, disasterErr)
+						})
+
+						It("returns an error", func() {
+							Expect(findOrCreateErr).To(Equal(disasterErr))
+						})
+
+						It("does not create container in garden", func() {
+							Expect(fakeGardenClient.CreateCallCount()).To(Equal(0))
+						})
+					})
+				})
+
+				Context("when the build is one-off", func() {
+					BeforeEach(func() {
+						fakeVolumeClient.FindOrCreateVolumeForContainerReturns(fakeCacheVolume, nil)
+					})
+
+					It("creates a volume for the container instead", func() {
+						Expect(fakeVolumeClient.FindOrCreateVolumeForTaskCacheCallCount()).To(Equal(0))
+						Expect(fakeVolumeClient.FindOrCreateVolumeForContainerCallCount()).To(Equal(1))
+						_, _, _, _, mountPath := fakeVolumeClient.FindOrCreateVolumeForContainerArgsForCall(0)
+						Expect(mountPath).To(Equal("/tmp/build/some-dir/some-cache"))
+					})
+				})
+			})
+
+			Context("when the spec has limits", func() {
+				BeforeEach(func() {
+					limits = atc.ContainerLimits{CPU: 512, Memory: 1024}
+				})
+
+				It("creates the container in garden with the limits", func() {
+					Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+					gardenSpec := fakeGardenClient.CreateArgsForCall(0)
+					Expect(gardenSpec.Limits.CPU).To(Equal(garden.CPULimits{LimitInShares: 512}))
+					Expect(gardenSpec.Limits.Memory).To(Equal(garden.MemoryLimits{LimitInBytes: 1024}))
+				})
+			})
 		})
 	})
 
@@ -30,8 +30,15 @@ type ContainerSpec struct {
 	// volumes that need to be mounted to container
 	Mounts []VolumeMount
 
+	// Paths at which to mount task caches, which persist between builds of the
+	// same job step on the worker.
+	Caches []string
+
 	// Optional user to run processes as. Overwrites the one specified in the docker image.
 	User string
+
+	// Resource limits to apply to the container. Zero values are unlimited.
+	Limits atc.ContainerLimits
 }
 
 type ImageSpec struct {
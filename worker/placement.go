@@ -0,0 +1,110 @@
+package worker
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+//go:generate counterfeiter . ContainerPlacementStrategy
+
+// ContainerPlacementStrategy decides which of the workers compatible with a
+// container should run it.
+type ContainerPlacementStrategy interface {
+	// Choose picks one of the given workers, which is never empty, for a
+	// container that will use the given inputs.
+	Choose(workers []Worker, inputs []ArtifactSource) (Worker, error)
+}
+
+const (
+	RandomPlacementStrategy                = "random"
+	FewestBuildContainersPlacementStrategy = "fewest-build-containers"
+	VolumeLocalityPlacementStrategy        = "volume-locality"
+)
+
+// NewContainerPlacementStrategy constructs the strategy with the given name.
+func NewContainerPlacementStrategy(name string) (ContainerPlacementStrategy, error) {
+	switch name {
+	case RandomPlacementStrategy:
+		return NewRandomPlacementStrategy(), nil
+	case FewestBuildContainersPlacementStrategy:
+		return NewFewestBuildContainersPlacementStrategy(), nil
+	case VolumeLocalityPlacementStrategy:
+		return NewVolumeLocalityPlacementStrategy(), nil
+	default:
+		return nil, fmt.Errorf("unknown container placement strategy: %s", name)
+	}
+}
+
+type randomPlacementStrategy struct {
+	rand *rand.Rand
+}
+
+// NewRandomPlacementStrategy constructs a strategy which picks any of the
+// workers at random.
+func NewRandomPlacementStrategy() ContainerPlacementStrategy {
+	return &randomPlacementStrategy{
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (strategy *randomPlacementStrategy) Choose(workers []Worker, inputs []ArtifactSource) (Worker, error) {
+	return workers[strategy.rand.Intn(len(workers))], nil
+}
+
+type fewestBuildContainersPlacementStrategy struct{}
+
+// NewFewestBuildContainersPlacementStrategy constructs a strategy which picks
+// the worker with the fewest active containers. Ties go to whichever worker
+// comes first.
+func NewFewestBuildContainersPlacementStrategy() ContainerPlacementStrategy {
+	return fewestBuildContainersPlacementStrategy{}
+}
+
+func (fewestBuildContainersPlacementStrategy) Choose(workers []Worker, inputs []ArtifactSource) (Worker, error) {
+	chosenWorker := workers[0]
+
+	for _, w := range workers[1:] {
+		if w.ActiveContainers() < chosenWorker.ActiveContainers() {
+			chosenWorker = w
+		}
+	}
+
+	return chosenWorker, nil
+}
+
+type volumeLocalityPlacementStrategy struct{}
+
+// NewVolumeLocalityPlacementStrategy constructs a strategy which picks the
+// worker already holding volumes for the most inputs, so that the fewest have
+// to be streamed to it. Ties go to whichever worker comes first.
+func NewVolumeLocalityPlacementStrategy() ContainerPlacementStrategy {
+	return volumeLocalityPlacementStrategy{}
+}
+
+func (volumeLocalityPlacementStrategy) Choose(workers []Worker, inputs []ArtifactSource) (Worker, error) {
+	var chosenWorker Worker
+	mostVolumes := -1
+
+	for _, w := range workers {
+		volumes := 0
+
+		for _, input := range inputs {
+			_, found, err := input.VolumeOn(w)
+			if err != nil {
+				return nil, err
+			}
+
+			if found {
+				volumes++
+			}
+		}
+
+		if volumes > mostVolumes {
+			chosenWorker = w
+			mostVolumes = volumes
+		}
+	}
+
+	return chosenWorker, nil
+}
@@ -0,0 +1,140 @@
+package worker_test
+
+import (
+	"errors"
+
+	. "github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContainerPlacementStrategy", func() {
+	var (
+		workerA *workerfakes.FakeWorker
+		workerB *workerfakes.FakeWorker
+		workerC *workerfakes.FakeWorker
+
+		workers []Worker
+		inputs  []ArtifactSource
+
+		strategy ContainerPlacementStrategy
+
+		chosenWorker Worker
+		chooseErr    error
+	)
+
+	BeforeEach(func() {
+		workerA = new(workerfakes.FakeWorker)
+		workerB = new(workerfakes.FakeWorker)
+		workerC = new(workerfakes.FakeWorker)
+
+		workers = []Worker{workerA, workerB, workerC}
+		inputs = nil
+	})
+
+	JustBeforeEach(func() {
+		chosenWorker, chooseErr = strategy.Choose(workers, inputs)
+	})
+
+	Describe("NewContainerPlacementStrategy", func() {
+		It("constructs each of the known strategies", func() {
+			for _, name := range []string{"random", "fewest-build-containers", "volume-locality"} {
+				_, err := NewContainerPlacementStrategy(name)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("rejects unknown strategies", func() {
+			_, err := NewContainerPlacementStrategy("bogus")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("random", func() {
+		BeforeEach(func() {
+			strategy = NewRandomPlacementStrategy()
+		})
+
+		It("chooses each worker some of the time", func() {
+			chosenCount := map[Worker]int{}
+			for i := 0; i < 300; i++ {
+				chosenWorker, chooseErr = strategy.Choose(workers, inputs)
+				Expect(chooseErr).NotTo(HaveOccurred())
+				chosenCount[chosenWorker]++
+			}
+
+			Expect(chosenCount[workerA]).NotTo(BeZero())
+			Expect(chosenCount[workerB]).NotTo(BeZero())
+			Expect(chosenCount[workerC]).NotTo(BeZero())
+		})
+	})
+
+	Describe("fewest-build-containers", func() {
+		BeforeEach(func() {
+			strategy = NewFewestBuildContainersPlacementStrategy()
+
+			workerA.ActiveContainersReturns(5)
+			workerB.ActiveContainersReturns(2)
+			workerC.ActiveContainersReturns(2)
+		})
+
+		It("chooses the first worker with the fewest active containers", func() {
+			Expect(chooseErr).NotTo(HaveOccurred())
+			Expect(chosenWorker).To(Equal(workerB))
+		})
+	})
+
+	Describe("volume-locality", func() {
+		var (
+			inputA *workerfakes.FakeArtifactSource
+			inputB *workerfakes.FakeArtifactSource
+		)
+
+		BeforeEach(func() {
+			strategy = NewVolumeLocalityPlacementStrategy()
+
+			inputA = new(workerfakes.FakeArtifactSource)
+			inputB = new(workerfakes.FakeArtifactSource)
+			inputs = []ArtifactSource{inputA, inputB}
+
+			inputA.VolumeOnStub = func(w Worker) (Volume, bool, error) {
+				return new(workerfakes.FakeVolume), w == workerB || w == workerC, nil
+			}
+
+			inputB.VolumeOnStub = func(w Worker) (Volume, bool, error) {
+				return new(workerfakes.FakeVolume), w == workerC, nil
+			}
+		})
+
+		It("chooses the worker holding the most input volumes", func() {
+			Expect(chooseErr).NotTo(HaveOccurred())
+			Expect(chosenWorker).To(Equal(workerC))
+		})
+
+		Context("when no worker holds any of the inputs", func() {
+			BeforeEach(func() {
+				inputs = []ArtifactSource{new(workerfakes.FakeArtifactSource)}
+			})
+
+			It("chooses the first worker", func() {
+				Expect(chooseErr).NotTo(HaveOccurred())
+				Expect(chosenWorker).To(Equal(workerA))
+			})
+		})
+
+		Context("when locating a volume fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				inputB.VolumeOnReturns(nil, false, disaster)
+				inputB.VolumeOnStub = nil
+			})
+
+			It("returns the error", func() {
+				Expect(chooseErr).To(Equal(disaster))
+			})
+		})
+	})
+})
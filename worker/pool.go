@@ -6,7 +6,6 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
-	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
@@ -59,14 +58,13 @@ func (err NoCompatibleWorkersError) Error() string {
 
 type pool struct {
 	provider WorkerProvider
-
-	rand *rand.Rand
+	strategy ContainerPlacementStrategy
 }
 
-func NewPool(provider WorkerProvider) Client {
+func NewPool(provider WorkerProvider, strategy ContainerPlacementStrategy) Client {
 	return &pool{
 		provider: provider,
-		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		strategy: strategy,
 	}
 }
 
@@ -134,12 +132,16 @@ func (pool *pool) AllSatisfying(spec WorkerSpec, resourceTypes atc.VersionedReso
 }
 
 func (pool *pool) Satisfying(spec WorkerSpec, resourceTypes atc.VersionedResourceTypes) (Worker, error) {
+	return pool.SatisfyingWithInputs(spec, resourceTypes, nil)
+}
+
+func (pool *pool) SatisfyingWithInputs(spec WorkerSpec, resourceTypes atc.VersionedResourceTypes, inputs []ArtifactSource) (Worker, error) {
 	compatibleWorkers, err := pool.AllSatisfying(spec, resourceTypes)
 	if err != nil {
 		return nil, err
 	}
-	randomWorker := compatibleWorkers[pool.rand.Intn(len(compatibleWorkers))]
-	return randomWorker, nil
+
+	return pool.strategy.Choose(compatibleWorkers, inputs)
 }
 
 func (pool *pool) FindOrCreateBuildContainer(
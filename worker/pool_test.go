@@ -27,7 +27,7 @@ var _ = Describe("Pool", func() {
 		logger = lagertest.NewTestLogger("test")
 		fakeProvider = new(workerfakes.FakeWorkerProvider)
 
-		pool = NewPool(fakeProvider)
+		pool = NewPool(fakeProvider, NewRandomPlacementStrategy())
 	})
 
 	Describe("GetWorker", func() {
@@ -195,6 +195,82 @@ var _ = Describe("Pool", func() {
 		})
 	})
 
+	Describe("SatisfyingWithInputs", func() {
+		var (
+			fakeStrategy *workerfakes.FakeContainerPlacementStrategy
+
+			spec   WorkerSpec
+			inputs []ArtifactSource
+
+			workerA *workerfakes.FakeWorker
+			workerB *workerfakes.FakeWorker
+
+			satisfyingErr    error
+			satisfyingWorker Worker
+		)
+
+		BeforeEach(func() {
+			fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
+			pool = NewPool(fakeProvider, fakeStrategy)
+
+			spec = WorkerSpec{Platform: "some-platform"}
+			inputs = []ArtifactSource{new(workerfakes.FakeArtifactSource)}
+
+			workerA = new(workerfakes.FakeWorker)
+			workerB = new(workerfakes.FakeWorker)
+
+			workerA.SatisfyingReturns(workerA, nil)
+			workerB.SatisfyingReturns(nil, errors.New("nope"))
+
+			fakeProvider.RunningWorkersReturns([]Worker{workerA, workerB}, nil)
+		})
+
+		JustBeforeEach(func() {
+			satisfyingWorker, satisfyingErr = pool.SatisfyingWithInputs(spec, nil, inputs)
+		})
+
+		Context("when the placement strategy chooses a worker", func() {
+			BeforeEach(func() {
+				fakeStrategy.ChooseReturns(workerA, nil)
+			})
+
+			It("chooses among the satisfying workers with the inputs", func() {
+				Expect(fakeStrategy.ChooseCallCount()).To(Equal(1))
+				workers, actualInputs := fakeStrategy.ChooseArgsForCall(0)
+				Expect(workers).To(Equal([]Worker{workerA}))
+				Expect(actualInputs).To(Equal(inputs))
+			})
+
+			It("returns the chosen worker", func() {
+				Expect(satisfyingErr).NotTo(HaveOccurred())
+				Expect(satisfyingWorker).To(Equal(workerA))
+			})
+		})
+
+		Context("when the placement strategy fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeStrategy.ChooseReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(satisfyingErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when no workers satisfy the spec", func() {
+			BeforeEach(func() {
+				workerA.SatisfyingReturns(nil, errors.New("nope"))
+			})
+
+			It("does not consult the placement strategy", func() {
+				Expect(satisfyingErr).To(HaveOccurred())
+				Expect(fakeStrategy.ChooseCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("AllSatisfying", func() {
 		var (
 			spec WorkerSpec
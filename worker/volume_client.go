@@ -33,6 +33,12 @@ type VolumeClient interface {
 		int,
 		string,
 	) (Volume, error)
+	FindOrCreateVolumeForTaskCache(
+		lager.Logger,
+		VolumeSpec,
+		int,
+		dbng.TaskCache,
+	) (Volume, error)
 	FindInitializedVolumeForResourceCache(
 		lager.Logger,
 		*dbng.UsedResourceCache,
@@ -135,6 +141,30 @@ func (c *volumeClient) FindOrCreateVolumeForBaseResourceType(
 	)
 }
 
+func (c *volumeClient) FindOrCreateVolumeForTaskCache(
+	logger lager.Logger,
+	volumeSpec VolumeSpec,
+	teamID int,
+	taskCache dbng.TaskCache,
+) (Volume, error) {
+	return c.findOrCreateVolume(
+		logger,
+		volumeSpec,
+		func() (dbng.CreatingVolume, dbng.CreatedVolume, error) {
+			return c.dbVolumeFactory.FindTaskCacheVolume(teamID, c.dbWorker, taskCache)
+		},
+		func() (dbng.CreatingVolume, error) {
+			v, err := c.dbVolumeFactory.CreateTaskCacheVolume(teamID, c.dbWorker, taskCache)
+			if err != nil {
+				return nil, err
+			}
+
+			logger.Debug("created-volume-for-task-cache", lager.Data{"handle": v.Handle()})
+			return v, nil
+		},
+	)
+}
+
 func (c *volumeClient) CreateVolumeForResourceCache(
 	logger lager.Logger,
 	volumeSpec VolumeSpec,
@@ -299,6 +299,10 @@ func (worker *gardenWorker) Satisfying(spec WorkerSpec, resourceTypes atc.Versio
 	return worker, nil
 }
 
+func (worker *gardenWorker) SatisfyingWithInputs(spec WorkerSpec, resourceTypes atc.VersionedResourceTypes, inputs []ArtifactSource) (Worker, error) {
+	return worker.Satisfying(spec, resourceTypes)
+}
+
 func determineUnderlyingTypeName(typeName string, resourceTypes atc.VersionedResourceTypes) string {
 	resourceTypesMap := make(map[string]atc.VersionedResourceType)
 	for _, resourceType := range resourceTypes {
@@ -186,6 +186,21 @@ type FakeClient struct {
 		result1 worker.Worker
 		result2 error
 	}
+	SatisfyingWithInputsStub        func(worker.WorkerSpec, atc.VersionedResourceTypes, []worker.ArtifactSource) (worker.Worker, error)
+	satisfyingWithInputsMutex       sync.RWMutex
+	satisfyingWithInputsArgsForCall []struct {
+		arg1 worker.WorkerSpec
+		arg2 atc.VersionedResourceTypes
+		arg3 []worker.ArtifactSource
+	}
+	satisfyingWithInputsReturns struct {
+		result1 worker.Worker
+		result2 error
+	}
+	satisfyingWithInputsReturnsOnCall map[int]struct {
+		result1 worker.Worker
+		result2 error
+	}
 	AllSatisfyingStub        func(worker.WorkerSpec, atc.VersionedResourceTypes) ([]worker.Worker, error)
 	allSatisfyingMutex       sync.RWMutex
 	allSatisfyingArgsForCall []struct {
@@ -786,6 +801,64 @@ func (fake *FakeClient) SatisfyingReturnsOnCall(i int, result1 worker.Worker, re
 	}{result1, result2}
 }
 
+func (fake *FakeClient) SatisfyingWithInputs(arg1 worker.WorkerSpec, arg2 atc.VersionedResourceTypes, arg3 []worker.ArtifactSource) (worker.Worker, error) {
+	var arg3Copy []worker.ArtifactSource
+	if arg3 != nil {
+		arg3Copy = make([]worker.ArtifactSource, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.satisfyingWithInputsMutex.Lock()
+	ret, specificReturn := fake.satisfyingWithInputsReturnsOnCall[len(fake.satisfyingWithInputsArgsForCall)]
+	fake.satisfyingWithInputsArgsForCall = append(fake.satisfyingWithInputsArgsForCall, struct {
+		arg1 worker.WorkerSpec
+		arg2 atc.VersionedResourceTypes
+		arg3 []worker.ArtifactSource
+	}{arg1, arg2, arg3Copy})
+	fake.recordInvocation("SatisfyingWithInputs", []interface{}{arg1, arg2, arg3Copy})
+	fake.satisfyingWithInputsMutex.Unlock()
+	if fake.SatisfyingWithInputsStub != nil {
+		return fake.SatisfyingWithInputsStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.satisfyingWithInputsReturns.result1, fake.satisfyingWithInputsReturns.result2
+}
+
+func (fake *FakeClient) SatisfyingWithInputsCallCount() int {
+	fake.satisfyingWithInputsMutex.RLock()
+	defer fake.satisfyingWithInputsMutex.RUnlock()
+	return len(fake.satisfyingWithInputsArgsForCall)
+}
+
+func (fake *FakeClient) SatisfyingWithInputsArgsForCall(i int) (worker.WorkerSpec, atc.VersionedResourceTypes, []worker.ArtifactSource) {
+	fake.satisfyingWithInputsMutex.RLock()
+	defer fake.satisfyingWithInputsMutex.RUnlock()
+	return fake.satisfyingWithInputsArgsForCall[i].arg1, fake.satisfyingWithInputsArgsForCall[i].arg2, fake.satisfyingWithInputsArgsForCall[i].arg3
+}
+
+func (fake *FakeClient) SatisfyingWithInputsReturns(result1 worker.Worker, result2 error) {
+	fake.SatisfyingWithInputsStub = nil
+	fake.satisfyingWithInputsReturns = struct {
+		result1 worker.Worker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) SatisfyingWithInputsReturnsOnCall(i int, result1 worker.Worker, result2 error) {
+	fake.SatisfyingWithInputsStub = nil
+	if fake.satisfyingWithInputsReturnsOnCall == nil {
+		fake.satisfyingWithInputsReturnsOnCall = make(map[int]struct {
+			result1 worker.Worker
+			result2 error
+		})
+	}
+	fake.satisfyingWithInputsReturnsOnCall[i] = struct {
+		result1 worker.Worker
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) AllSatisfying(arg1 worker.WorkerSpec, arg2 atc.VersionedResourceTypes) ([]worker.Worker, error) {
 	fake.allSatisfyingMutex.Lock()
 	ret, specificReturn := fake.allSatisfyingReturnsOnCall[len(fake.allSatisfyingArgsForCall)]
@@ -955,6 +1028,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.lookupVolumeMutex.RUnlock()
 	fake.satisfyingMutex.RLock()
 	defer fake.satisfyingMutex.RUnlock()
+	fake.satisfyingWithInputsMutex.RLock()
+	defer fake.satisfyingWithInputsMutex.RUnlock()
 	fake.allSatisfyingMutex.RLock()
 	defer fake.allSatisfyingMutex.RUnlock()
 	fake.runningWorkersMutex.RLock()
@@ -0,0 +1,111 @@
+// This file was generated by counterfeiter
+package workerfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/worker"
+)
+
+type FakeContainerPlacementStrategy struct {
+	ChooseStub        func(workers []worker.Worker, inputs []worker.ArtifactSource) (worker.Worker, error)
+	chooseMutex       sync.RWMutex
+	chooseArgsForCall []struct {
+		workers []worker.Worker
+		inputs  []worker.ArtifactSource
+	}
+	chooseReturns struct {
+		result1 worker.Worker
+		result2 error
+	}
+	chooseReturnsOnCall map[int]struct {
+		result1 worker.Worker
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeContainerPlacementStrategy) Choose(workers []worker.Worker, inputs []worker.ArtifactSource) (worker.Worker, error) {
+	var workersCopy []worker.Worker
+	if workers != nil {
+		workersCopy = make([]worker.Worker, len(workers))
+		copy(workersCopy, workers)
+	}
+	var inputsCopy []worker.ArtifactSource
+	if inputs != nil {
+		inputsCopy = make([]worker.ArtifactSource, len(inputs))
+		copy(inputsCopy, inputs)
+	}
+	fake.chooseMutex.Lock()
+	ret, specificReturn := fake.chooseReturnsOnCall[len(fake.chooseArgsForCall)]
+	fake.chooseArgsForCall = append(fake.chooseArgsForCall, struct {
+		workers []worker.Worker
+		inputs  []worker.ArtifactSource
+	}{workersCopy, inputsCopy})
+	fake.recordInvocation("Choose", []interface{}{workersCopy, inputsCopy})
+	fake.chooseMutex.Unlock()
+	if fake.ChooseStub != nil {
+		return fake.ChooseStub(workers, inputs)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.chooseReturns.result1, fake.chooseReturns.result2
+}
+
+func (fake *FakeContainerPlacementStrategy) ChooseCallCount() int {
+	fake.chooseMutex.RLock()
+	defer fake.chooseMutex.RUnlock()
+	return len(fake.chooseArgsForCall)
+}
+
+func (fake *FakeContainerPlacementStrategy) ChooseArgsForCall(i int) ([]worker.Worker, []worker.ArtifactSource) {
+	fake.chooseMutex.RLock()
+	defer fake.chooseMutex.RUnlock()
+	return fake.chooseArgsForCall[i].workers, fake.chooseArgsForCall[i].inputs
+}
+
+func (fake *FakeContainerPlacementStrategy) ChooseReturns(result1 worker.Worker, result2 error) {
+	fake.ChooseStub = nil
+	fake.chooseReturns = struct {
+		result1 worker.Worker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContainerPlacementStrategy) ChooseReturnsOnCall(i int, result1 worker.Worker, result2 error) {
+	fake.ChooseStub = nil
+	if fake.chooseReturnsOnCall == nil {
+		fake.chooseReturnsOnCall = make(map[int]struct {
+			result1 worker.Worker
+			result2 error
+		})
+	}
+	fake.chooseReturnsOnCall[i] = struct {
+		result1 worker.Worker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContainerPlacementStrategy) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.chooseMutex.RLock()
+	defer fake.chooseMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeContainerPlacementStrategy) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ worker.ContainerPlacementStrategy = new(FakeContainerPlacementStrategy)
@@ -58,6 +58,22 @@ type FakeVolumeClient struct {
 		result1 worker.Volume
 		result2 error
 	}
+	FindOrCreateVolumeForTaskCacheStub        func(lager.Logger, worker.VolumeSpec, int, dbng.TaskCache) (worker.Volume, error)
+	findOrCreateVolumeForTaskCacheMutex       sync.RWMutex
+	findOrCreateVolumeForTaskCacheArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.VolumeSpec
+		arg3 int
+		arg4 dbng.TaskCache
+	}
+	findOrCreateVolumeForTaskCacheReturns struct {
+		result1 worker.Volume
+		result2 error
+	}
+	findOrCreateVolumeForTaskCacheReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 error
+	}
 	FindInitializedVolumeForResourceCacheStub        func(lager.Logger, *dbng.UsedResourceCache) (worker.Volume, bool, error)
 	findInitializedVolumeForResourceCacheMutex       sync.RWMutex
 	findInitializedVolumeForResourceCacheArgsForCall []struct {
@@ -256,6 +272,60 @@ func (fake *FakeVolumeClient) FindOrCreateVolumeForBaseResourceTypeReturnsOnCall
 	}{result1, result2}
 }
 
+func (fake *FakeVolumeClient) FindOrCreateVolumeForTaskCache(arg1 lager.Logger, arg2 worker.VolumeSpec, arg3 int, arg4 dbng.TaskCache) (worker.Volume, error) {
+	fake.findOrCreateVolumeForTaskCacheMutex.Lock()
+	ret, specificReturn := fake.findOrCreateVolumeForTaskCacheReturnsOnCall[len(fake.findOrCreateVolumeForTaskCacheArgsForCall)]
+	fake.findOrCreateVolumeForTaskCacheArgsForCall = append(fake.findOrCreateVolumeForTaskCacheArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.VolumeSpec
+		arg3 int
+		arg4 dbng.TaskCache
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("FindOrCreateVolumeForTaskCache", []interface{}{arg1, arg2, arg3, arg4})
+	fake.findOrCreateVolumeForTaskCacheMutex.Unlock()
+	if fake.FindOrCreateVolumeForTaskCacheStub != nil {
+		return fake.FindOrCreateVolumeForTaskCacheStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.findOrCreateVolumeForTaskCacheReturns.result1, fake.findOrCreateVolumeForTaskCacheReturns.result2
+}
+
+func (fake *FakeVolumeClient) FindOrCreateVolumeForTaskCacheCallCount() int {
+	fake.findOrCreateVolumeForTaskCacheMutex.RLock()
+	defer fake.findOrCreateVolumeForTaskCacheMutex.RUnlock()
+	return len(fake.findOrCreateVolumeForTaskCacheArgsForCall)
+}
+
+func (fake *FakeVolumeClient) FindOrCreateVolumeForTaskCacheArgsForCall(i int) (lager.Logger, worker.VolumeSpec, int, dbng.TaskCache) {
+	fake.findOrCreateVolumeForTaskCacheMutex.RLock()
+	defer fake.findOrCreateVolumeForTaskCacheMutex.RUnlock()
+	return fake.findOrCreateVolumeForTaskCacheArgsForCall[i].arg1, fake.findOrCreateVolumeForTaskCacheArgsForCall[i].arg2, fake.findOrCreateVolumeForTaskCacheArgsForCall[i].arg3, fake.findOrCreateVolumeForTaskCacheArgsForCall[i].arg4
+}
+
+func (fake *FakeVolumeClient) FindOrCreateVolumeForTaskCacheReturns(result1 worker.Volume, result2 error) {
+	fake.FindOrCreateVolumeForTaskCacheStub = nil
+	fake.findOrCreateVolumeForTaskCacheReturns = struct {
+		result1 worker.Volume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeClient) FindOrCreateVolumeForTaskCacheReturnsOnCall(i int, result1 worker.Volume, result2 error) {
+	fake.FindOrCreateVolumeForTaskCacheStub = nil
+	if fake.findOrCreateVolumeForTaskCacheReturnsOnCall == nil {
+		fake.findOrCreateVolumeForTaskCacheReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 error
+		})
+	}
+	fake.findOrCreateVolumeForTaskCacheReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolumeClient) FindInitializedVolumeForResourceCache(arg1 lager.Logger, arg2 *dbng.UsedResourceCache) (worker.Volume, bool, error) {
 	fake.findInitializedVolumeForResourceCacheMutex.Lock()
 	ret, specificReturn := fake.findInitializedVolumeForResourceCacheReturnsOnCall[len(fake.findInitializedVolumeForResourceCacheArgsForCall)]
@@ -375,6 +445,8 @@ func (fake *FakeVolumeClient) Invocations() map[string][][]interface{} {
 	defer fake.findOrCreateVolumeForContainerMutex.RUnlock()
 	fake.findOrCreateVolumeForBaseResourceTypeMutex.RLock()
 	defer fake.findOrCreateVolumeForBaseResourceTypeMutex.RUnlock()
+	fake.findOrCreateVolumeForTaskCacheMutex.RLock()
+	defer fake.findOrCreateVolumeForTaskCacheMutex.RUnlock()
 	fake.findInitializedVolumeForResourceCacheMutex.RLock()
 	defer fake.findInitializedVolumeForResourceCacheMutex.RUnlock()
 	fake.lookupVolumeMutex.RLock()
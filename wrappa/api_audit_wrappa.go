@@ -0,0 +1,181 @@
+package wrappa
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/audit"
+	"github.com/concourse/atc/auth"
+	"github.com/tedsuo/rata"
+)
+
+type APIAuditWrappa struct {
+	auditor           audit.Auditor
+	userContextReader auth.UserContextReader
+	apiTokenReader    auth.APITokenContextReader
+}
+
+// NewAPIAuditWrappa constructs a Wrappa which records an audit event for
+// every request to a route which may change something, whether or not the
+// request is allowed.
+func NewAPIAuditWrappa(
+	auditor audit.Auditor,
+	userContextReader auth.UserContextReader,
+	apiTokenReader auth.APITokenContextReader,
+) Wrappa {
+	return APIAuditWrappa{
+		auditor:           auditor,
+		userContextReader: userContextReader,
+		apiTokenReader:    apiTokenReader,
+	}
+}
+
+func (wrappa APIAuditWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
+	wrapped := rata.Handlers{}
+
+	for name, handler := range handlers {
+		route, found := findRoute(name)
+		if !found || !isAudited(route) {
+			wrapped[name] = handler
+			continue
+		}
+
+		wrapped[name] = auditHandler{
+			route:             route,
+			handler:           handler,
+			auditor:           wrappa.auditor,
+			userContextReader: wrappa.userContextReader,
+			apiTokenReader:    wrappa.apiTokenReader,
+		}
+	}
+
+	return wrapped
+}
+
+func findRoute(name string) (rata.Route, bool) {
+	for _, route := range atc.Routes {
+		if route.Name == name {
+			return route, true
+		}
+	}
+
+	return rata.Route{}, false
+}
+
+// hijacking a container runs arbitrary code, despite being a GET
+func isAudited(route rata.Route) bool {
+	return route.Method != "GET" || route.Name == atc.HijackContainer
+}
+
+type auditHandler struct {
+	route             rata.Route
+	handler           http.Handler
+	auditor           audit.Auditor
+	userContextReader auth.UserContextReader
+	apiTokenReader    auth.APITokenContextReader
+}
+
+func (h auditHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	event := atc.AuditEvent{
+		Time:       time.Now().Unix(),
+		Actor:      h.actor(r),
+		RemoteAddr: r.RemoteAddr,
+		Route:      h.route.Name,
+	}
+
+	if token, found := h.apiTokenReader.GetAPIToken(r); found {
+		event.APITokenName = token.Name
+		event.APITokenRole = token.Role
+	}
+
+	for _, segment := range strings.Split(h.route.Path, "/") {
+		if !strings.HasPrefix(segment, ":") {
+			continue
+		}
+
+		// route params are read from the query rather than with FormValue,
+		// which would consume the request body before the handler sees it
+		param := segment[1:]
+		value := r.URL.Query().Get(segment)
+
+		if param == "team_name" {
+			event.TeamName = value
+			continue
+		}
+
+		if event.Params == nil {
+			event.Params = map[string]string{}
+		}
+
+		event.Params[param] = value
+	}
+
+	recorder := &statusRecorder{
+		ResponseWriter: w,
+		status:         http.StatusOK,
+	}
+
+	h.handler.ServeHTTP(recorder, r)
+
+	event.Status = recorder.status
+
+	h.auditor.Record(event)
+}
+
+func (h auditHandler) actor(r *http.Request) string {
+	if isSystem, found := h.userContextReader.GetSystem(r); found && isSystem {
+		return "system"
+	}
+
+	if teamName, _, found := h.userContextReader.GetTeam(r); found {
+		return teamName
+	}
+
+	return ""
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+
+	status      int
+	wroteHeader bool
+}
+
+func (recorder *statusRecorder) WriteHeader(status int) {
+	if !recorder.wroteHeader {
+		recorder.status = status
+		recorder.wroteHeader = true
+	}
+
+	recorder.ResponseWriter.WriteHeader(status)
+}
+
+func (recorder *statusRecorder) Write(data []byte) (int, error) {
+	recorder.wroteHeader = true
+	return recorder.ResponseWriter.Write(data)
+}
+
+func (recorder *statusRecorder) Flush() {
+	if flusher, ok := recorder.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (recorder *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := recorder.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer cannot be hijacked")
+	}
+
+	if !recorder.wroteHeader {
+		recorder.status = http.StatusSwitchingProtocols
+		recorder.wroteHeader = true
+	}
+
+	return hijacker.Hijack()
+}
@@ -0,0 +1,220 @@
+package wrappa_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/audit/auditfakes"
+	"github.com/concourse/atc/auth/authfakes"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/wrappa"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type statusHandler int
+
+func (status statusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(int(status))
+}
+
+var _ = Describe("APIAuditWrappa", func() {
+	var (
+		fakeAuditor           *auditfakes.FakeAuditor
+		fakeUserContextReader *authfakes.FakeUserContextReader
+		fakeAPITokenReader    *authfakes.FakeAPITokenContextReader
+
+		inputHandlers rata.Handlers
+		wrapped       rata.Handlers
+		server        *httptest.Server
+	)
+
+	BeforeEach(func() {
+		fakeAuditor = new(auditfakes.FakeAuditor)
+		fakeUserContextReader = new(authfakes.FakeUserContextReader)
+		fakeAPITokenReader = new(authfakes.FakeAPITokenContextReader)
+
+		inputHandlers = rata.Handlers{}
+		for _, route := range atc.Routes {
+			inputHandlers[route.Name] = statusHandler(http.StatusOK)
+		}
+
+		inputHandlers[atc.PauseJob] = statusHandler(http.StatusForbidden)
+	})
+
+	JustBeforeEach(func() {
+		wrapped = wrappa.NewAPIAuditWrappa(fakeAuditor, fakeUserContextReader, fakeAPITokenReader).Wrap(inputHandlers)
+
+		router, err := rata.NewRouter(atc.Routes, wrapped)
+		Expect(err).NotTo(HaveOccurred())
+
+		server = httptest.NewServer(router)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	request := func(route string, params rata.Params) *http.Response {
+		req, err := rata.NewRequestGenerator(server.URL, atc.Routes).CreateRequest(route, params, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		response, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+
+		return response
+	}
+
+	It("does not wrap GET routes", func() {
+		Expect(wrapped[atc.GetPipeline]).To(Equal(inputHandlers[atc.GetPipeline]))
+		Expect(wrapped[atc.ListAuditEvents]).To(Equal(inputHandlers[atc.ListAuditEvents]))
+	})
+
+	It("wraps other routes", func() {
+		Expect(wrapped[atc.PausePipeline]).NotTo(Equal(inputHandlers[atc.PausePipeline]))
+		Expect(wrapped[atc.SetTeam]).NotTo(Equal(inputHandlers[atc.SetTeam]))
+	})
+
+	It("wraps HijackContainer", func() {
+		Expect(wrapped[atc.HijackContainer]).NotTo(Equal(inputHandlers[atc.HijackContainer]))
+	})
+
+	Context("when a team makes a request", func() {
+		BeforeEach(func() {
+			fakeUserContextReader.GetTeamReturns("some-team", false, true)
+		})
+
+		It("records the request and its outcome", func() {
+			response := request(atc.PausePipeline, rata.Params{
+				"team_name":     "some-team",
+				"pipeline_name": "some-pipeline",
+			})
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			Expect(fakeAuditor.RecordCallCount()).To(Equal(1))
+
+			event := fakeAuditor.RecordArgsForCall(0)
+			Expect(event.Time).To(BeNumerically("~", time.Now().Unix(), 60))
+			Expect(event.Actor).To(Equal("some-team"))
+			Expect(event.RemoteAddr).NotTo(BeEmpty())
+			Expect(event.Route).To(Equal(atc.PausePipeline))
+			Expect(event.TeamName).To(Equal("some-team"))
+			Expect(event.Params).To(Equal(map[string]string{"pipeline_name": "some-pipeline"}))
+			Expect(event.Status).To(Equal(http.StatusOK))
+			Expect(event.APITokenName).To(BeEmpty())
+			Expect(event.APITokenRole).To(BeEmpty())
+		})
+
+		Context("with an API token", func() {
+			BeforeEach(func() {
+				fakeAPITokenReader.GetAPITokenReturns(db.SavedAPIToken{
+					APIToken: db.APIToken{
+						Name: "some-token",
+						Role: atc.TeamRolePipelineOperator,
+					},
+					ID:       42,
+					TeamName: "some-team",
+				}, true)
+			})
+
+			It("records the token's name and role", func() {
+				request(atc.PausePipeline, rata.Params{
+					"team_name":     "some-team",
+					"pipeline_name": "some-pipeline",
+				})
+
+				event := fakeAuditor.RecordArgsForCall(0)
+				Expect(event.Actor).To(Equal("some-team"))
+				Expect(event.APITokenName).To(Equal("some-token"))
+				Expect(event.APITokenRole).To(Equal(atc.TeamRolePipelineOperator))
+			})
+		})
+
+		It("records requests which are not allowed", func() {
+			response := request(atc.PauseJob, rata.Params{
+				"team_name":     "some-team",
+				"pipeline_name": "some-pipeline",
+				"job_name":      "some-job",
+			})
+			Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+
+			event := fakeAuditor.RecordArgsForCall(0)
+			Expect(event.Status).To(Equal(http.StatusForbidden))
+			Expect(event.Params).To(Equal(map[string]string{
+				"pipeline_name": "some-pipeline",
+				"job_name":      "some-job",
+			}))
+		})
+
+		Context("when the handler reads the request body", func() {
+			var receivedBody []byte
+
+			BeforeEach(func() {
+				inputHandlers[atc.SaveConfig] = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					defer GinkgoRecover()
+
+					var err error
+					receivedBody, err = ioutil.ReadAll(r.Body)
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			It("does not consume it", func() {
+				req, err := rata.NewRequestGenerator(server.URL, atc.Routes).CreateRequest(atc.SaveConfig, rata.Params{
+					"team_name":     "some-team",
+					"pipeline_name": "some-pipeline",
+				}, strings.NewReader("paused=true"))
+				Expect(err).NotTo(HaveOccurred())
+
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+				_, err = http.DefaultClient.Do(req)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(string(receivedBody)).To(Equal("paused=true"))
+
+				event := fakeAuditor.RecordArgsForCall(0)
+				Expect(event.Params).To(Equal(map[string]string{"pipeline_name": "some-pipeline"}))
+			})
+		})
+
+		It("does not record GET requests", func() {
+			request(atc.GetPipeline, rata.Params{
+				"team_name":     "some-team",
+				"pipeline_name": "some-pipeline",
+			})
+
+			Expect(fakeAuditor.RecordCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the system makes a request", func() {
+		BeforeEach(func() {
+			fakeUserContextReader.GetSystemReturns(true, true)
+		})
+
+		It("records the system as the actor", func() {
+			request(atc.RegisterWorker, nil)
+
+			event := fakeAuditor.RecordArgsForCall(0)
+			Expect(event.Actor).To(Equal("system"))
+			Expect(event.TeamName).To(BeEmpty())
+			Expect(event.Params).To(BeNil())
+		})
+	})
+
+	Context("when the requester is not authenticated", func() {
+		It("records no actor", func() {
+			request(atc.SetLogLevel, nil)
+
+			event := fakeAuditor.RecordArgsForCall(0)
+			Expect(event.Actor).To(BeEmpty())
+		})
+	})
+})
@@ -53,7 +53,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListAllPipelines,
 			atc.ListPipelines,
 			atc.ListBuilds,
-			atc.MainJobBadge:
+			atc.MainJobBadge,
+			atc.CheckResourceWebHook:
 
 		// pipeline is public or authorized
 		case atc.GetBuild,
@@ -67,7 +68,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 		// resource belongs to authorized team
-		case atc.AbortBuild:
+		case atc.AbortBuild,
+			atc.ResumeBuild:
 			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// requester is system, admin team, or worker owning team
@@ -110,16 +112,24 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			newHandler = auth.CheckAuthenticationHandler(handler, rejector)
 
 		case atc.GetLogLevel,
-			atc.SetLogLevel:
+			atc.SetLogLevel,
+			atc.ListAuditEvents:
 			newHandler = auth.CheckAdminHandler(handler, rejector)
 
 		// authorized (requested team matches resource team)
 		case atc.CheckResource,
 			atc.CreateJobBuild,
+			atc.RerunBuild,
 			atc.DeletePipeline,
 			atc.DisableResourceVersion,
 			atc.EnableResourceVersion,
+			atc.PinResourceVersion,
+			atc.UnpinResourceVersion,
 			atc.GetConfig,
+			atc.ListConfigVersions,
+			atc.GetConfigVersion,
+			atc.DiffConfigVersions,
+			atc.GetPipelineTemplate,
 			atc.GetVersionsDB,
 			atc.ListJobInputs,
 			atc.OrderPipelines,
@@ -132,7 +142,16 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.UnpauseResource,
 			atc.ExposePipeline,
 			atc.HidePipeline,
-			atc.SaveConfig:
+			atc.SaveConfig,
+			atc.RollbackConfig,
+			atc.SavePipelineTemplate,
+			atc.SavePipelineInstance,
+			atc.ListAPITokens,
+			atc.CreateAPIToken,
+			atc.RevokeAPIToken,
+			atc.ListNotificationSubscriptions,
+			atc.CreateNotificationSubscription,
+			atc.DeleteNotificationSubscription:
 			newHandler = auth.CheckAuthorizationHandler(handler, rejector)
 
 		// think about it!
@@ -140,6 +159,10 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			panic("you missed a spot")
 		}
 
+		if role := requiredRole(name); role != atc.TeamRoleViewer {
+			newHandler = auth.CheckRoleHandler(newHandler, role, rejector)
+		}
+
 		if name == atc.GetAuthToken {
 			newHandler = auth.WrapHandler(newHandler, wrappa.getTokenValidator, wrappa.userContextReader)
 		} else {
@@ -150,3 +173,77 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 
 	return wrapped
 }
+
+// requiredRole returns the least privileged team role which may use the
+// route. Viewers may use any route which does not change anything.
+func requiredRole(name string) atc.TeamRole {
+	switch name {
+	// changes the team, its workers, or who may access it
+	case atc.SetTeam,
+		atc.DestroyTeam,
+		atc.ListAPITokens,
+		atc.CreateAPIToken,
+		atc.RevokeAPIToken,
+		atc.ListNotificationSubscriptions,
+		atc.CreateNotificationSubscription,
+		atc.DeleteNotificationSubscription,
+		atc.RegisterWorker,
+		atc.HeartbeatWorker,
+		atc.DeleteWorker,
+		atc.PruneWorker,
+		atc.LandWorker,
+		atc.RetireWorker:
+		return atc.TeamRoleOwner
+
+	// administers the ATC itself
+	case atc.SetLogLevel,
+		atc.ListAuditEvents:
+		return atc.TeamRoleOwner
+
+	// changes pipeline configuration or runs arbitrary code
+	case atc.SaveConfig,
+		atc.RollbackConfig,
+		atc.SavePipelineTemplate,
+		atc.SavePipelineInstance,
+		atc.DeletePipeline,
+		atc.RenamePipeline,
+		atc.OrderPipelines,
+		atc.ExposePipeline,
+		atc.HidePipeline,
+		atc.CreateBuild,
+		atc.CreatePipe,
+		atc.ReadPipe,
+		atc.WritePipe,
+		atc.HijackContainer:
+		return atc.TeamRoleMember
+
+	// operates existing pipelines
+	case atc.AbortBuild,
+		atc.ResumeBuild,
+		atc.CheckResource,
+		atc.CreateJobBuild,
+		atc.RerunBuild,
+		atc.DisableResourceVersion,
+		atc.EnableResourceVersion,
+		atc.PinResourceVersion,
+		atc.UnpinResourceVersion,
+		atc.PauseJob,
+		atc.UnpauseJob,
+		atc.PausePipeline,
+		atc.UnpausePipeline,
+		atc.PauseResource,
+		atc.UnpauseResource:
+		return atc.TeamRolePipelineOperator
+
+	// exposes pipeline configuration, which contains secrets such as resource
+	// webhook tokens
+	case atc.GetConfig,
+		atc.GetConfigVersion,
+		atc.DiffConfigVersions,
+		atc.GetPipelineTemplate:
+		return atc.TeamRolePipelineOperator
+
+	default:
+		return atc.TeamRoleViewer
+	}
+}
@@ -74,6 +74,21 @@ var _ = Describe("APIAuthWrappa", func() {
 		)
 	}
 
+	authenticatedAndAdminWithRole := func(role atc.TeamRole, handler http.Handler) http.Handler {
+		return auth.WrapHandler(
+			auth.CheckRoleHandler(
+				auth.CheckAdminHandler(
+					handler,
+					auth.UnauthorizedRejector{},
+				),
+				role,
+				auth.UnauthorizedRejector{},
+			),
+			fakeAuthValidator,
+			fakeUserContextReader,
+		)
+	}
+
 	authenticatedWithGetTokenValidator := func(handler http.Handler) http.Handler {
 		return auth.WrapHandler(
 			auth.CheckAuthenticationHandler(
@@ -131,8 +146,12 @@ var _ = Describe("APIAuthWrappa", func() {
 
 	checkWritePermissionForBuild := func(handler http.Handler) http.Handler {
 		return auth.WrapHandler(
-			fakeCheckBuildWriteAccessHandlerFactory.HandlerFor(
-				handler,
+			auth.CheckRoleHandler(
+				fakeCheckBuildWriteAccessHandlerFactory.HandlerFor(
+					handler,
+					auth.UnauthorizedRejector{},
+				),
+				atc.TeamRolePipelineOperator,
 				auth.UnauthorizedRejector{},
 			),
 			fakeAuthValidator,
@@ -142,8 +161,42 @@ var _ = Describe("APIAuthWrappa", func() {
 
 	checkTeamAccessForWorker := func(handler http.Handler) http.Handler {
 		return auth.WrapHandler(
-			fakeCheckWorkerTeamAccessHandlerFactory.HandlerFor(
-				handler,
+			auth.CheckRoleHandler(
+				fakeCheckWorkerTeamAccessHandlerFactory.HandlerFor(
+					handler,
+					auth.UnauthorizedRejector{},
+				),
+				atc.TeamRoleOwner,
+				auth.UnauthorizedRejector{},
+			),
+			fakeAuthValidator,
+			fakeUserContextReader,
+		)
+	}
+
+	authenticatedWithRole := func(role atc.TeamRole, handler http.Handler) http.Handler {
+		return auth.WrapHandler(
+			auth.CheckRoleHandler(
+				auth.CheckAuthenticationHandler(
+					handler,
+					auth.UnauthorizedRejector{},
+				),
+				role,
+				auth.UnauthorizedRejector{},
+			),
+			fakeAuthValidator,
+			fakeUserContextReader,
+		)
+	}
+
+	authorizedWithRole := func(role atc.TeamRole, handler http.Handler) http.Handler {
+		return auth.WrapHandler(
+			auth.CheckRoleHandler(
+				auth.CheckAuthorizationHandler(
+					handler,
+					auth.UnauthorizedRejector{},
+				),
+				role,
 				auth.UnauthorizedRejector{},
 			),
 			fakeAuthValidator,
@@ -177,6 +230,9 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ListTeams:        unauthenticated(inputHandlers[atc.ListTeams]),
 				atc.MainJobBadge:     unauthenticated(inputHandlers[atc.MainJobBadge]),
 
+				// unauthenticated; the handler validates the resource's webhook token
+				atc.CheckResourceWebHook: unauthenticated(inputHandlers[atc.CheckResourceWebHook]),
+
 				// authorized or public pipeline
 				atc.GetBuild:       doesNotCheckIfPrivateJob(inputHandlers[atc.GetBuild]),
 				atc.BuildResources: doesNotCheckIfPrivateJob(inputHandlers[atc.BuildResources]),
@@ -187,7 +243,8 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.GetBuildPreparation: checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
 
 				// resource belongs to authorized team
-				atc.AbortBuild: checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.AbortBuild:  checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.ResumeBuild: checkWritePermissionForBuild(inputHandlers[atc.ResumeBuild]),
 
 				// resource belongs to authorized team
 				atc.PruneWorker:  checkTeamAccessForWorker(inputHandlers[atc.PruneWorker]),
@@ -208,48 +265,67 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ListResourceVersions:          openForPublicPipelineOrAuthorized(inputHandlers[atc.ListResourceVersions]),
 
 				// authenticated
-				atc.CreateBuild:     authenticated(inputHandlers[atc.CreateBuild]),
-				atc.CreatePipe:      authenticated(inputHandlers[atc.CreatePipe]),
+				atc.CreateBuild:     authenticatedWithRole(atc.TeamRoleMember, inputHandlers[atc.CreateBuild]),
+				atc.CreatePipe:      authenticatedWithRole(atc.TeamRoleMember, inputHandlers[atc.CreatePipe]),
 				atc.GetAuthToken:    authenticatedWithGetTokenValidator(inputHandlers[atc.GetAuthToken]),
 				atc.GetContainer:    authenticated(inputHandlers[atc.GetContainer]),
-				atc.HijackContainer: authenticated(inputHandlers[atc.HijackContainer]),
+				atc.HijackContainer: authenticatedWithRole(atc.TeamRoleMember, inputHandlers[atc.HijackContainer]),
 				atc.ListContainers:  authenticated(inputHandlers[atc.ListContainers]),
 				atc.ListVolumes:     authenticated(inputHandlers[atc.ListVolumes]),
 				atc.ListWorkers:     authenticated(inputHandlers[atc.ListWorkers]),
-				atc.ReadPipe:        authenticated(inputHandlers[atc.ReadPipe]),
-				atc.RegisterWorker:  authenticated(inputHandlers[atc.RegisterWorker]),
-				atc.HeartbeatWorker: authenticated(inputHandlers[atc.HeartbeatWorker]),
-				atc.DeleteWorker:    authenticated(inputHandlers[atc.DeleteWorker]),
-
-				atc.SetTeam:     authenticated(inputHandlers[atc.SetTeam]),
-				atc.DestroyTeam: authenticated(inputHandlers[atc.DestroyTeam]),
-				atc.WritePipe:   authenticated(inputHandlers[atc.WritePipe]),
+				atc.ReadPipe:        authenticatedWithRole(atc.TeamRoleMember, inputHandlers[atc.ReadPipe]),
+				atc.RegisterWorker:  authenticatedWithRole(atc.TeamRoleOwner, inputHandlers[atc.RegisterWorker]),
+				atc.HeartbeatWorker: authenticatedWithRole(atc.TeamRoleOwner, inputHandlers[atc.HeartbeatWorker]),
+				atc.DeleteWorker:    authenticatedWithRole(atc.TeamRoleOwner, inputHandlers[atc.DeleteWorker]),
+
+				atc.SetTeam:     authenticatedWithRole(atc.TeamRoleOwner, inputHandlers[atc.SetTeam]),
+				atc.DestroyTeam: authenticatedWithRole(atc.TeamRoleOwner, inputHandlers[atc.DestroyTeam]),
+				atc.WritePipe:   authenticatedWithRole(atc.TeamRoleMember, inputHandlers[atc.WritePipe]),
 				atc.GetUser:     authenticated(inputHandlers[atc.GetUser]),
 
 				// authenticated and is admin
 				atc.GetLogLevel: authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
-				atc.SetLogLevel: authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
+				atc.SetLogLevel: authenticatedAndAdminWithRole(atc.TeamRoleOwner, inputHandlers[atc.SetLogLevel]),
+
+				atc.ListAuditEvents: authenticatedAndAdminWithRole(atc.TeamRoleOwner, inputHandlers[atc.ListAuditEvents]),
 
 				// authorized (requested team matches resource team)
-				atc.CheckResource:          authorized(inputHandlers[atc.CheckResource]),
-				atc.CreateJobBuild:         authorized(inputHandlers[atc.CreateJobBuild]),
-				atc.DeletePipeline:         authorized(inputHandlers[atc.DeletePipeline]),
-				atc.DisableResourceVersion: authorized(inputHandlers[atc.DisableResourceVersion]),
-				atc.EnableResourceVersion:  authorized(inputHandlers[atc.EnableResourceVersion]),
-				atc.GetConfig:              authorized(inputHandlers[atc.GetConfig]),
+				atc.CheckResource:          authorizedWithRole(atc.TeamRolePipelineOperator, inputHandlers[atc.CheckResource]),
+				atc.CreateJobBuild:         authorizedWithRole(atc.TeamRolePipelineOperator, inputHandlers[atc.CreateJobBuild]),
+				atc.RerunBuild:             authorizedWithRole(atc.TeamRolePipelineOperator, inputHandlers[atc.RerunBuild]),
+				atc.DeletePipeline:         authorizedWithRole(atc.TeamRoleMember, inputHandlers[atc.DeletePipeline]),
+				atc.DisableResourceVersion: authorizedWithRole(atc.TeamRolePipelineOperator, inputHandlers[atc.DisableResourceVersion]),
+				atc.EnableResourceVersion:  authorizedWithRole(atc.TeamRolePipelineOperator, inputHandlers[atc.EnableResourceVersion]),
+				atc.PinResourceVersion:     authorizedWithRole(atc.TeamRolePipelineOperator, inputHandlers[atc.PinResourceVersion]),
+				atc.UnpinResourceVersion:   authorizedWithRole(atc.TeamRolePipelineOperator, inputHandlers[atc.UnpinResourceVersion]),
+				atc.GetConfig:              authorizedWithRole(atc.TeamRolePipelineOperator, inputHandlers[atc.GetConfig]),
+				atc.ListConfigVersions:     authorized(inputHandlers[atc.ListConfigVersions]),
+				atc.GetConfigVersion:       authorizedWithRole(atc.TeamRolePipelineOperator, inputHandlers[atc.GetConfigVersion]),
+				atc.DiffConfigVersions:     authorizedWithRole(atc.TeamRolePipelineOperator, inputHandlers[atc.DiffConfigVersions]),
+				atc.GetPipelineTemplate:    authorizedWithRole(atc.TeamRolePipelineOperator, inputHandlers[atc.GetPipelineTemplate]),
 				atc.GetVersionsDB:          authorized(inputHandlers[atc.GetVersionsDB]),
 				atc.ListJobInputs:          authorized(inputHandlers[atc.ListJobInputs]),
-				atc.OrderPipelines:         authorized(inputHandlers[atc.OrderPipelines]),
-				atc.PauseJob:               authorized(inputHandlers[atc.PauseJob]),
-				atc.PausePipeline:          authorized(inputHandlers[atc.PausePipeline]),
-				atc.PauseResource:          authorized(inputHandlers[atc.PauseResource]),
-				atc.RenamePipeline:         authorized(inputHandlers[atc.RenamePipeline]),
-				atc.SaveConfig:             authorized(inputHandlers[atc.SaveConfig]),
-				atc.UnpauseJob:             authorized(inputHandlers[atc.UnpauseJob]),
-				atc.UnpausePipeline:        authorized(inputHandlers[atc.UnpausePipeline]),
-				atc.UnpauseResource:        authorized(inputHandlers[atc.UnpauseResource]),
-				atc.ExposePipeline:         authorized(inputHandlers[atc.ExposePipeline]),
-				atc.HidePipeline:           authorized(inputHandlers[atc.HidePipeline]),
+				atc.OrderPipelines:         authorizedWithRole(atc.TeamRoleMember, inputHandlers[atc.OrderPipelines]),
+				atc.PauseJob:               authorizedWithRole(atc.TeamRolePipelineOperator, inputHandlers[atc.PauseJob]),
+				atc.PausePipeline:          authorizedWithRole(atc.TeamRolePipelineOperator, inputHandlers[atc.PausePipeline]),
+				atc.PauseResource:          authorizedWithRole(atc.TeamRolePipelineOperator, inputHandlers[atc.PauseResource]),
+				atc.RenamePipeline:         authorizedWithRole(atc.TeamRoleMember, inputHandlers[atc.RenamePipeline]),
+				atc.SaveConfig:             authorizedWithRole(atc.TeamRoleMember, inputHandlers[atc.SaveConfig]),
+				atc.RollbackConfig:         authorizedWithRole(atc.TeamRoleMember, inputHandlers[atc.RollbackConfig]),
+				atc.SavePipelineTemplate:   authorizedWithRole(atc.TeamRoleMember, inputHandlers[atc.SavePipelineTemplate]),
+				atc.SavePipelineInstance:   authorizedWithRole(atc.TeamRoleMember, inputHandlers[atc.SavePipelineInstance]),
+				atc.UnpauseJob:             authorizedWithRole(atc.TeamRolePipelineOperator, inputHandlers[atc.UnpauseJob]),
+				atc.UnpausePipeline:        authorizedWithRole(atc.TeamRolePipelineOperator, inputHandlers[atc.UnpausePipeline]),
+				atc.UnpauseResource:        authorizedWithRole(atc.TeamRolePipelineOperator, inputHandlers[atc.UnpauseResource]),
+				atc.ExposePipeline:         authorizedWithRole(atc.TeamRoleMember, inputHandlers[atc.ExposePipeline]),
+				atc.HidePipeline:           authorizedWithRole(atc.TeamRoleMember, inputHandlers[atc.HidePipeline]),
+				atc.ListAPITokens:          authorizedWithRole(atc.TeamRoleOwner, inputHandlers[atc.ListAPITokens]),
+				atc.CreateAPIToken:         authorizedWithRole(atc.TeamRoleOwner, inputHandlers[atc.CreateAPIToken]),
+				atc.RevokeAPIToken:         authorizedWithRole(atc.TeamRoleOwner, inputHandlers[atc.RevokeAPIToken]),
+
+				atc.ListNotificationSubscriptions:  authorizedWithRole(atc.TeamRoleOwner, inputHandlers[atc.ListNotificationSubscriptions]),
+				atc.CreateNotificationSubscription: authorizedWithRole(atc.TeamRoleOwner, inputHandlers[atc.CreateNotificationSubscription]),
+				atc.DeleteNotificationSubscription: authorizedWithRole(atc.TeamRoleOwner, inputHandlers[atc.DeleteNotificationSubscription]),
 			}
 		})
 